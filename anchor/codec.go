@@ -0,0 +1,782 @@
+package anchor
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+)
+
+// EnumValue is a decoded enum. Fields is nil for a unit variant, a map[string]any for named fields
+// and a []any for tuple fields. Encoding also takes the variant name as a string for a unit variant
+// or a single entry map like {"Variant": fields}.
+type EnumValue struct {
+	Variant string
+	Fields  any
+}
+
+var (
+	bigIntType    = reflect.TypeOf(big.Int{})
+	publicKeyType = reflect.TypeOf(common.PublicKey{})
+)
+
+type encoder struct {
+	idl *Idl
+	buf []byte
+}
+
+// Encode borsh encodes a value of the idl type. maps, go structs and slices are accepted for compound types.
+func (idl *Idl) Encode(t IdlType, v any) ([]byte, error) {
+	e := encoder{idl: idl}
+	if err := e.encode(t, v); err != nil {
+		return nil, err
+	}
+	return e.buf, nil
+}
+
+func (e *encoder) encode(t IdlType, v any) error {
+	switch {
+	case t.Primitive != "":
+		return e.encodePrimitive(t.Primitive, v)
+	case t.Vec != nil:
+		rv, err := sliceValue(v)
+		if err != nil {
+			return err
+		}
+		e.buf = binary.LittleEndian.AppendUint32(e.buf, uint32(rv.Len()))
+		for i := 0; i < rv.Len(); i++ {
+			if err := e.encode(*t.Vec, rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	case t.Option != nil:
+		inner, ok := optionValue(v)
+		if !ok {
+			e.buf = append(e.buf, 0)
+			return nil
+		}
+		e.buf = append(e.buf, 1)
+		return e.encode(*t.Option, inner)
+	case t.COption != nil:
+		inner, ok := optionValue(v)
+		if !ok {
+			size, fixed := e.idl.typeSize(*t.COption)
+			if !fixed {
+				return fmt.Errorf("%w, coption of a variable size type", ErrUnsupportedType)
+			}
+			e.buf = binary.LittleEndian.AppendUint32(e.buf, 0)
+			e.buf = append(e.buf, make([]byte, size)...)
+			return nil
+		}
+		e.buf = binary.LittleEndian.AppendUint32(e.buf, 1)
+		return e.encode(*t.COption, inner)
+	case t.Array != nil:
+		rv, err := sliceValue(v)
+		if err != nil {
+			return err
+		}
+		if rv.Len() != t.Len {
+			return fmt.Errorf("%w, expected an array of %v, got %v", ErrInvalidValue, t.Len, rv.Len())
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if err := e.encode(*t.Array, rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	case t.Defined != "":
+		def, ok := e.idl.TypeDef(t.Defined)
+		if !ok {
+			return fmt.Errorf("%w, %v", ErrUnknownType, t.Defined)
+		}
+		return e.encodeDefined(def, v)
+	}
+	return fmt.Errorf("%w, empty type", ErrInvalidIdl)
+}
+
+func (e *encoder) encodeDefined(def IdlTypeDefTy, v any) error {
+	switch def.Kind {
+	case IdlTypeDefTyKindStruct:
+		return e.encodeFields(def.Fields, v)
+	case IdlTypeDefTyKindEnum:
+		name, fields, err := enumValue(v)
+		if err != nil {
+			return err
+		}
+		for i, variant := range def.Variants {
+			if variant.Name == name {
+				e.buf = append(e.buf, uint8(i))
+				return e.encodeFields(variant.Fields, fields)
+			}
+		}
+		return fmt.Errorf("%w, unknown variant %v", ErrInvalidValue, name)
+	case IdlTypeDefTyKindAlias:
+		if def.Alias == nil {
+			return fmt.Errorf("%w, alias without type", ErrInvalidIdl)
+		}
+		return e.encode(*def.Alias, v)
+	}
+	return fmt.Errorf("%w, kind: %v", ErrUnsupportedType, def.Kind)
+}
+
+func (e *encoder) encodeFields(fields IdlDefinedFields, v any) error {
+	if fields.Named != nil {
+		for _, field := range fields.Named {
+			fv, ok := fieldValue(v, field.Name)
+			if !ok {
+				return fmt.Errorf("%w, missing field %v", ErrInvalidValue, field.Name)
+			}
+			if err := e.encode(field.Type, fv); err != nil {
+				return fmt.Errorf("field %v: %w", field.Name, err)
+			}
+		}
+		return nil
+	}
+	if fields.Tuple != nil {
+		rv, err := sliceValue(v)
+		if err != nil {
+			return err
+		}
+		if rv.Len() != len(fields.Tuple) {
+			return fmt.Errorf("%w, expected %v tuple fields, got %v", ErrInvalidValue, len(fields.Tuple), rv.Len())
+		}
+		for i, t := range fields.Tuple {
+			if err := e.encode(t, rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *encoder) encodePrimitive(primitive string, v any) error {
+	switch primitive {
+	case "bool":
+		b, ok := v.(bool)
+		if !ok {
+			return invalidValue(primitive, v)
+		}
+		if b {
+			e.buf = append(e.buf, 1)
+		} else {
+			e.buf = append(e.buf, 0)
+		}
+	case "u8", "u16", "u32", "u64":
+		n, err := toUint64(v, primitiveBits(primitive))
+		if err != nil {
+			return err
+		}
+		e.buf = appendUint(e.buf, n, primitiveBits(primitive))
+	case "i8", "i16", "i32", "i64":
+		n, err := toInt64(v, primitiveBits(primitive))
+		if err != nil {
+			return err
+		}
+		e.buf = appendUint(e.buf, uint64(n), primitiveBits(primitive))
+	case "u128", "i128":
+		n, err := toBigInt(v)
+		if err != nil {
+			return err
+		}
+		b, err := bigIntToLE(n, primitive == "i128")
+		if err != nil {
+			return err
+		}
+		e.buf = append(e.buf, b...)
+	case "f32":
+		f, err := toFloat64(v)
+		if err != nil {
+			return err
+		}
+		e.buf = binary.LittleEndian.AppendUint32(e.buf, math.Float32bits(float32(f)))
+	case "f64":
+		f, err := toFloat64(v)
+		if err != nil {
+			return err
+		}
+		e.buf = binary.LittleEndian.AppendUint64(e.buf, math.Float64bits(f))
+	case "string":
+		s, ok := v.(string)
+		if !ok {
+			return invalidValue(primitive, v)
+		}
+		e.buf = binary.LittleEndian.AppendUint32(e.buf, uint32(len(s)))
+		e.buf = append(e.buf, s...)
+	case "bytes":
+		b, ok := v.([]byte)
+		if !ok {
+			return invalidValue(primitive, v)
+		}
+		e.buf = binary.LittleEndian.AppendUint32(e.buf, uint32(len(b)))
+		e.buf = append(e.buf, b...)
+	case "pubkey":
+		pubkey, err := toPublicKey(v)
+		if err != nil {
+			return err
+		}
+		e.buf = append(e.buf, pubkey.Bytes()...)
+	default:
+		return fmt.Errorf("%w, %v", ErrUnsupportedType, primitive)
+	}
+	return nil
+}
+
+type decoder struct {
+	idl  *Idl
+	data []byte
+	pos  int
+}
+
+// Decode borsh decodes a value of the idl type, see EnumValue for the go types compound types decode to.
+// it returns the number of bytes consumed.
+func (idl *Idl) Decode(t IdlType, data []byte) (any, int, error) {
+	d := decoder{idl: idl, data: data}
+	v, err := d.decode(t)
+	if err != nil {
+		return nil, 0, err
+	}
+	return v, d.pos, nil
+}
+
+func (d *decoder) read(n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.pos < n {
+		return nil, fmt.Errorf("%w, need %v bytes at offset %v, data size: %v", ErrInvalidAccountDataSize, n, d.pos, len(d.data))
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) readLen() (int, error) {
+	b, err := d.read(4)
+	if err != nil {
+		return 0, err
+	}
+	n := binary.LittleEndian.Uint32(b)
+	// every element takes at least one byte except zero sized ones which idls don't have
+	if int64(n) > int64(len(d.data)-d.pos) {
+		return 0, fmt.Errorf("%w, length %v exceeds the remaining %v bytes", ErrInvalidAccountDataSize, n, len(d.data)-d.pos)
+	}
+	return int(n), nil
+}
+
+func (d *decoder) decode(t IdlType) (any, error) {
+	switch {
+	case t.Primitive != "":
+		return d.decodePrimitive(t.Primitive)
+	case t.Vec != nil:
+		n, err := d.readLen()
+		if err != nil {
+			return nil, err
+		}
+		items := make([]any, 0, n)
+		for i := 0; i < n; i++ {
+			item, err := d.decode(*t.Vec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case t.Option != nil:
+		tag, err := d.read(1)
+		if err != nil {
+			return nil, err
+		}
+		switch tag[0] {
+		case 0:
+			return nil, nil
+		case 1:
+			return d.decode(*t.Option)
+		}
+		return nil, fmt.Errorf("%w, option tag: %v", ErrInvalidAccountData, tag[0])
+	case t.COption != nil:
+		tag, err := d.read(4)
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.decode(*t.COption)
+		if err != nil {
+			return nil, err
+		}
+		switch binary.LittleEndian.Uint32(tag) {
+		case 0:
+			return nil, nil
+		case 1:
+			return v, nil
+		}
+		return nil, fmt.Errorf("%w, coption tag: %v", ErrInvalidAccountData, tag)
+	case t.Array != nil:
+		items := make([]any, 0, t.Len)
+		for i := 0; i < t.Len; i++ {
+			item, err := d.decode(*t.Array)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case t.Defined != "":
+		def, ok := d.idl.TypeDef(t.Defined)
+		if !ok {
+			return nil, fmt.Errorf("%w, %v", ErrUnknownType, t.Defined)
+		}
+		return d.decodeDefined(def)
+	}
+	return nil, fmt.Errorf("%w, empty type", ErrInvalidIdl)
+}
+
+func (d *decoder) decodeDefined(def IdlTypeDefTy) (any, error) {
+	switch def.Kind {
+	case IdlTypeDefTyKindStruct:
+		return d.decodeFields(def.Fields)
+	case IdlTypeDefTyKindEnum:
+		tag, err := d.read(1)
+		if err != nil {
+			return nil, err
+		}
+		if int(tag[0]) >= len(def.Variants) {
+			return nil, fmt.Errorf("%w, enum variant: %v", ErrInvalidAccountData, tag[0])
+		}
+		variant := def.Variants[tag[0]]
+		fields, err := d.decodeFields(variant.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return EnumValue{Variant: variant.Name, Fields: fields}, nil
+	case IdlTypeDefTyKindAlias:
+		if def.Alias == nil {
+			return nil, fmt.Errorf("%w, alias without type", ErrInvalidIdl)
+		}
+		return d.decode(*def.Alias)
+	}
+	return nil, fmt.Errorf("%w, kind: %v", ErrUnsupportedType, def.Kind)
+}
+
+func (d *decoder) decodeFields(fields IdlDefinedFields) (any, error) {
+	if fields.Named != nil {
+		m := make(map[string]any, len(fields.Named))
+		for _, field := range fields.Named {
+			v, err := d.decode(field.Type)
+			if err != nil {
+				return nil, fmt.Errorf("field %v: %w", field.Name, err)
+			}
+			m[field.Name] = v
+		}
+		return m, nil
+	}
+	if fields.Tuple != nil {
+		items := make([]any, 0, len(fields.Tuple))
+		for _, t := range fields.Tuple {
+			v, err := d.decode(t)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		}
+		return items, nil
+	}
+	return nil, nil
+}
+
+func (d *decoder) decodePrimitive(primitive string) (any, error) {
+	switch primitive {
+	case "bool":
+		b, err := d.read(1)
+		if err != nil {
+			return nil, err
+		}
+		switch b[0] {
+		case 0:
+			return false, nil
+		case 1:
+			return true, nil
+		}
+		return nil, fmt.Errorf("%w, bool: %v", ErrInvalidAccountData, b[0])
+	case "u8", "i8", "u16", "i16", "u32", "i32", "u64", "i64":
+		bits := primitiveBits(primitive)
+		b, err := d.read(bits / 8)
+		if err != nil {
+			return nil, err
+		}
+		var n uint64
+		for i := len(b) - 1; i >= 0; i-- {
+			n = n<<8 | uint64(b[i])
+		}
+		switch primitive {
+		case "u8":
+			return uint8(n), nil
+		case "i8":
+			return int8(n), nil
+		case "u16":
+			return uint16(n), nil
+		case "i16":
+			return int16(n), nil
+		case "u32":
+			return uint32(n), nil
+		case "i32":
+			return int32(n), nil
+		case "u64":
+			return n, nil
+		}
+		return int64(n), nil
+	case "u128", "i128":
+		b, err := d.read(16)
+		if err != nil {
+			return nil, err
+		}
+		return leToBigInt(b, primitive == "i128"), nil
+	case "f32":
+		b, err := d.read(4)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float32frombits(binary.LittleEndian.Uint32(b)), nil
+	case "f64":
+		b, err := d.read(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case "string", "bytes":
+		n, err := d.readLen()
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.read(n)
+		if err != nil {
+			return nil, err
+		}
+		if primitive == "string" {
+			return string(b), nil
+		}
+		return append([]byte{}, b...), nil
+	case "pubkey":
+		b, err := d.read(32)
+		if err != nil {
+			return nil, err
+		}
+		return common.PublicKeyFromBytes(b), nil
+	}
+	return nil, fmt.Errorf("%w, %v", ErrUnsupportedType, primitive)
+}
+
+// typeSize returns the encoded size of a type if it doesn't vary
+func (idl *Idl) typeSize(t IdlType) (int, bool) {
+	switch {
+	case t.Primitive != "":
+		switch t.Primitive {
+		case "bool", "u8", "i8":
+			return 1, true
+		case "u16", "i16":
+			return 2, true
+		case "u32", "i32", "f32":
+			return 4, true
+		case "u64", "i64", "f64":
+			return 8, true
+		case "u128", "i128":
+			return 16, true
+		case "pubkey":
+			return 32, true
+		}
+		return 0, false
+	case t.COption != nil:
+		n, ok := idl.typeSize(*t.COption)
+		return 4 + n, ok
+	case t.Array != nil:
+		n, ok := idl.typeSize(*t.Array)
+		return n * t.Len, ok
+	case t.Defined != "":
+		def, ok := idl.TypeDef(t.Defined)
+		if !ok {
+			return 0, false
+		}
+		switch def.Kind {
+		case IdlTypeDefTyKindStruct:
+			size := 0
+			for _, field := range def.Fields.Named {
+				n, ok := idl.typeSize(field.Type)
+				if !ok {
+					return 0, false
+				}
+				size += n
+			}
+			for _, t := range def.Fields.Tuple {
+				n, ok := idl.typeSize(t)
+				if !ok {
+					return 0, false
+				}
+				size += n
+			}
+			return size, true
+		case IdlTypeDefTyKindAlias:
+			if def.Alias != nil {
+				return idl.typeSize(*def.Alias)
+			}
+		}
+	}
+	return 0, false
+}
+
+func primitiveBits(primitive string) int {
+	switch primitive {
+	case "u8", "i8":
+		return 8
+	case "u16", "i16":
+		return 16
+	case "u32", "i32":
+		return 32
+	}
+	return 64
+}
+
+func appendUint(b []byte, n uint64, bits int) []byte {
+	for i := 0; i < bits/8; i++ {
+		b = append(b, uint8(n>>(8*i)))
+	}
+	return b
+}
+
+func invalidValue(t string, v any) error {
+	return fmt.Errorf("%w, can't encode %T as %v", ErrInvalidValue, v, t)
+}
+
+func toUint64(v any, bits int) (uint64, error) {
+	var n uint64
+	switch x := v.(type) {
+	case json.Number:
+		u, err := x.Int64()
+		if err != nil || u < 0 {
+			return 0, invalidValue(fmt.Sprintf("u%v", bits), v)
+		}
+		n = uint64(u)
+	case float64:
+		if x < 0 || x != math.Trunc(x) || x > math.MaxUint64 {
+			return 0, invalidValue(fmt.Sprintf("u%v", bits), v)
+		}
+		n = uint64(x)
+	default:
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			n = rv.Uint()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if rv.Int() < 0 {
+				return 0, invalidValue(fmt.Sprintf("u%v", bits), v)
+			}
+			n = uint64(rv.Int())
+		default:
+			return 0, invalidValue(fmt.Sprintf("u%v", bits), v)
+		}
+	}
+	if bits < 64 && n >= 1<<bits {
+		return 0, fmt.Errorf("%w, %v overflows u%v", ErrInvalidValue, n, bits)
+	}
+	return n, nil
+}
+
+func toInt64(v any, bits int) (int64, error) {
+	var n int64
+	switch x := v.(type) {
+	case json.Number:
+		i, err := x.Int64()
+		if err != nil {
+			return 0, invalidValue(fmt.Sprintf("i%v", bits), v)
+		}
+		n = i
+	case float64:
+		if x != math.Trunc(x) || x > math.MaxInt64 || x < math.MinInt64 {
+			return 0, invalidValue(fmt.Sprintf("i%v", bits), v)
+		}
+		n = int64(x)
+	default:
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = rv.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if rv.Uint() > math.MaxInt64 {
+				return 0, invalidValue(fmt.Sprintf("i%v", bits), v)
+			}
+			n = int64(rv.Uint())
+		default:
+			return 0, invalidValue(fmt.Sprintf("i%v", bits), v)
+		}
+	}
+	if bits < 64 && (n >= 1<<(bits-1) || n < -(1<<(bits-1))) {
+		return 0, fmt.Errorf("%w, %v overflows i%v", ErrInvalidValue, n, bits)
+	}
+	return n, nil
+}
+
+func toBigInt(v any) (*big.Int, error) {
+	switch x := v.(type) {
+	case *big.Int:
+		return x, nil
+	case big.Int:
+		return &x, nil
+	case json.Number:
+		n, ok := new(big.Int).SetString(x.String(), 10)
+		if !ok {
+			return nil, invalidValue("128 bits integer", v)
+		}
+		return n, nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(rv.Uint()), nil
+	}
+	return nil, invalidValue("128 bits integer", v)
+}
+
+func bigIntToLE(n *big.Int, signed bool) ([]byte, error) {
+	min, max := new(big.Int), new(big.Int).Lsh(big.NewInt(1), 128)
+	if signed {
+		max.Rsh(max, 1)
+		min.Neg(max)
+	}
+	if n.Cmp(min) < 0 || n.Cmp(max) >= 0 {
+		return nil, fmt.Errorf("%w, %v overflows 128 bits", ErrInvalidValue, n)
+	}
+	u := new(big.Int).Set(n)
+	if u.Sign() < 0 {
+		// two's complement
+		u.Add(u, new(big.Int).Lsh(big.NewInt(1), 128))
+	}
+	be := u.FillBytes(make([]byte, 16))
+	le := make([]byte, 16)
+	for i := range be {
+		le[i] = be[15-i]
+	}
+	return le, nil
+}
+
+func leToBigInt(le []byte, signed bool) *big.Int {
+	be := make([]byte, len(le))
+	for i := range le {
+		be[i] = le[len(le)-1-i]
+	}
+	n := new(big.Int).SetBytes(be)
+	if signed && le[len(le)-1]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(8*len(le))))
+	}
+	return n
+}
+
+func toFloat64(v any) (float64, error) {
+	switch x := v.(type) {
+	case json.Number:
+		return x.Float64()
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	}
+	return 0, invalidValue("float", v)
+}
+
+func toPublicKey(v any) (common.PublicKey, error) {
+	switch x := v.(type) {
+	case common.PublicKey:
+		return x, nil
+	case *common.PublicKey:
+		if x != nil {
+			return *x, nil
+		}
+	case [32]byte:
+		return common.PublicKey(x), nil
+	case string:
+		pubkey := common.PublicKeyFromString(x)
+		if pubkey.ToBase58() == x {
+			return pubkey, nil
+		}
+	}
+	return common.PublicKey{}, invalidValue("pubkey", v)
+}
+
+func sliceValue(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return reflect.Value{}, invalidValue("sequence", v)
+	}
+	return rv, nil
+}
+
+func optionValue(v any) (any, bool) {
+	if v == nil {
+		return nil, false
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, false
+		}
+		// a pointer to a struct can be encoded as the struct, but other pointers are options
+		if rv.Type().Elem() != bigIntType && rv.Type().Elem() != publicKeyType {
+			return rv.Elem().Interface(), true
+		}
+	}
+	return v, true
+}
+
+func enumValue(v any) (string, any, error) {
+	switch x := v.(type) {
+	case EnumValue:
+		return x.Variant, x.Fields, nil
+	case *EnumValue:
+		if x != nil {
+			return x.Variant, x.Fields, nil
+		}
+	case string:
+		return x, nil, nil
+	case map[string]any:
+		if len(x) == 1 {
+			for k, fields := range x {
+				return k, fields, nil
+			}
+		}
+	}
+	return "", nil, invalidValue("enum", v)
+}
+
+// fieldValue gets a field of a map by its idl name, or a field of a go struct by its `anchor` tag or its camel case name
+func fieldValue(v any, name string) (any, bool) {
+	if m, ok := v.(map[string]any); ok {
+		fv, ok := m[name]
+		return fv, ok
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, false
+	}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		if rt.Field(i).IsExported() && rt.Field(i).Tag.Get("anchor") == name {
+			return rv.Field(i).Interface(), true
+		}
+	}
+	f := rv.FieldByName(toCamelCase(name))
+	if !f.IsValid() || !f.CanInterface() {
+		return nil, false
+	}
+	return f.Interface(), true
+}
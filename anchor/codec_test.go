@@ -0,0 +1,211 @@
+package anchor
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdl_Encode(t *testing.T) {
+	idl, err := ParseIdl([]byte(testLegacyIdl))
+	assert.Nil(t, err)
+
+	type config struct {
+		Step uint16
+		Note *string
+		Tags [2]uint8
+	}
+	note := "hi"
+
+	type args struct {
+		t IdlType
+		v any
+	}
+	tests := []struct {
+		name string
+		args args
+		want []byte
+		err  error
+	}{
+		{
+			name: "struct from map",
+			args: args{
+				t: IdlType{Defined: "Config"},
+				v: map[string]any{"step": 2, "note": "hi", "tags": []any{1, 2}},
+			},
+			want: []byte{2, 0, 1, 2, 0, 0, 0, 104, 105, 1, 2},
+		},
+		{
+			name: "struct from go struct",
+			args: args{
+				t: IdlType{Defined: "Config"},
+				v: config{Step: 2, Note: &note, Tags: [2]uint8{1, 2}},
+			},
+			want: []byte{2, 0, 1, 2, 0, 0, 0, 104, 105, 1, 2},
+		},
+		{
+			name: "none",
+			args: args{
+				t: IdlType{Defined: "Config"},
+				v: config{Step: 2},
+			},
+			want: []byte{2, 0, 0, 0, 0},
+		},
+		{
+			name: "unit variant",
+			args: args{t: IdlType{Defined: "Kind"}, v: "Simple"},
+			want: []byte{0},
+		},
+		{
+			name: "named variant",
+			args: args{t: IdlType{Defined: "Kind"}, v: map[string]any{"Weighted": map[string]any{"weight": uint8(3)}}},
+			want: []byte{1, 3},
+		},
+		{
+			name: "tuple variant",
+			args: args{t: IdlType{Defined: "Kind"}, v: EnumValue{Variant: "Pair", Fields: []any{1, 515}}},
+			want: []byte{2, 1, 3, 2},
+		},
+		{
+			name: "negative i128",
+			args: args{t: IdlType{Primitive: "i128"}, v: big.NewInt(-2)},
+			want: []byte{254, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255},
+		},
+		{
+			name: "pubkey from string",
+			args: args{t: IdlType{Primitive: "pubkey"}, v: "11111111111111111111111111111111"},
+			want: make([]byte, 32),
+		},
+		{
+			name: "coption none keeps the space",
+			args: args{t: IdlType{COption: &IdlType{Primitive: "u32"}}, v: nil},
+			want: []byte{0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name: "coption some",
+			args: args{t: IdlType{COption: &IdlType{Primitive: "u32"}}, v: uint32(7)},
+			want: []byte{1, 0, 0, 0, 7, 0, 0, 0},
+		},
+		{
+			name: "u8 overflow",
+			args: args{t: IdlType{Primitive: "u8"}, v: 256},
+			err:  ErrInvalidValue,
+		},
+		{
+			name: "negative unsigned",
+			args: args{t: IdlType{Primitive: "u64"}, v: -1},
+			err:  ErrInvalidValue,
+		},
+		{
+			name: "wrong array size",
+			args: args{t: IdlType{Array: &IdlType{Primitive: "u8"}, Len: 2}, v: []uint8{1}},
+			err:  ErrInvalidValue,
+		},
+		{
+			name: "unknown variant",
+			args: args{t: IdlType{Defined: "Kind"}, v: "Other"},
+			err:  ErrInvalidValue,
+		},
+		{
+			name: "unknown type",
+			args: args{t: IdlType{Defined: "Other"}, v: 1},
+			err:  ErrUnknownType,
+		},
+		{
+			name: "missing field",
+			args: args{t: IdlType{Defined: "Config"}, v: map[string]any{"step": 1}},
+			err:  ErrInvalidValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := idl.Encode(tt.args.t, tt.args.v)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIdl_Decode(t *testing.T) {
+	idl, err := ParseIdl([]byte(testLegacyIdl))
+	assert.Nil(t, err)
+
+	type args struct {
+		t    IdlType
+		data []byte
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  any
+		wantN int
+		err   error
+	}{
+		{
+			name:  "struct",
+			args:  args{t: IdlType{Defined: "Config"}, data: []byte{2, 0, 1, 2, 0, 0, 0, 104, 105, 1, 2, 99}},
+			want:  map[string]any{"step": uint16(2), "note": "hi", "tags": []any{uint8(1), uint8(2)}},
+			wantN: 11,
+		},
+		{
+			name:  "unit variant",
+			args:  args{t: IdlType{Defined: "Kind"}, data: []byte{0}},
+			want:  EnumValue{Variant: "Simple"},
+			wantN: 1,
+		},
+		{
+			name:  "tuple variant",
+			args:  args{t: IdlType{Defined: "Kind"}, data: []byte{2, 1, 3, 2}},
+			want:  EnumValue{Variant: "Pair", Fields: []any{uint8(1), uint16(515)}},
+			wantN: 4,
+		},
+		{
+			name:  "negative i128",
+			args:  args{t: IdlType{Primitive: "i128"}, data: []byte{254, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255}},
+			want:  big.NewInt(-2),
+			wantN: 16,
+		},
+		{
+			name:  "pubkey",
+			args:  args{t: IdlType{Primitive: "pubkey"}, data: make([]byte, 32)},
+			want:  common.SystemProgramID,
+			wantN: 32,
+		},
+		{
+			name:  "coption none",
+			args:  args{t: IdlType{COption: &IdlType{Primitive: "u32"}}, data: []byte{0, 0, 0, 0, 0, 0, 0, 0}},
+			want:  nil,
+			wantN: 8,
+		},
+		{
+			name: "short data",
+			args: args{t: IdlType{Primitive: "u64"}, data: []byte{1, 2}},
+			err:  ErrInvalidAccountDataSize,
+		},
+		{
+			name: "vec longer than data",
+			args: args{t: IdlType{Vec: &IdlType{Primitive: "u8"}}, data: []byte{255, 255, 255, 255, 1}},
+			err:  ErrInvalidAccountDataSize,
+		},
+		{
+			name: "bad variant",
+			args: args{t: IdlType{Defined: "Kind"}, data: []byte{3}},
+			err:  ErrInvalidAccountData,
+		},
+		{
+			name: "bad bool",
+			args: args{t: IdlType{Primitive: "bool"}, data: []byte{2}},
+			err:  ErrInvalidAccountData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, n, err := idl.Decode(tt.args.t, tt.args.data)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantN, n)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package anchor
+
+import (
+	"crypto/sha256"
+	"strings"
+	"unicode"
+)
+
+const DiscriminatorSize = 8
+
+// Sighash returns the first 8 bytes of sha256("<namespace>:<name>")
+func Sighash(namespace, name string) [DiscriminatorSize]byte {
+	var discriminator [DiscriminatorSize]byte
+	h := sha256.Sum256([]byte(namespace + ":" + name))
+	copy(discriminator[:], h[:DiscriminatorSize])
+	return discriminator
+}
+
+// InstructionDiscriminator returns the discriminator of an instruction, the legacy idl names
+// instructions in camel case but the discriminator uses the snake case rust name
+func InstructionDiscriminator(name string) [DiscriminatorSize]byte {
+	return Sighash("global", toSnakeCase(name))
+}
+
+// AccountDiscriminator returns the discriminator of an account type
+func AccountDiscriminator(name string) [DiscriminatorSize]byte {
+	return Sighash("account", name)
+}
+
+// EventDiscriminator returns the discriminator of an event type
+func EventDiscriminator(name string) [DiscriminatorSize]byte {
+	return Sighash("event", name)
+}
+
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func toCamelCase(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
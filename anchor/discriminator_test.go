@@ -0,0 +1,63 @@
+package anchor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstructionDiscriminator(t *testing.T) {
+	type args struct {
+		name string
+	}
+	tests := []struct {
+		name string
+		args args
+		want [DiscriminatorSize]byte
+	}{
+		{
+			args: args{name: "initialize"},
+			want: [DiscriminatorSize]byte{175, 175, 109, 31, 13, 152, 155, 237},
+		},
+		{
+			args: args{name: "incrementBy"},
+			want: [DiscriminatorSize]byte{103, 82, 124, 55, 231, 50, 146, 138},
+		},
+		{
+			args: args{name: "increment_by"},
+			want: [DiscriminatorSize]byte{103, 82, 124, 55, 231, 50, 146, 138},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.args.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, InstructionDiscriminator(tt.args.name))
+		})
+	}
+}
+
+func TestAccountDiscriminator(t *testing.T) {
+	assert.Equal(t, [DiscriminatorSize]byte{255, 176, 4, 245, 188, 253, 124, 25}, AccountDiscriminator("Counter"))
+}
+
+func TestEventDiscriminator(t *testing.T) {
+	assert.Equal(t, [DiscriminatorSize]byte{92, 207, 119, 204, 71, 205, 108, 15}, EventDiscriminator("Incremented"))
+}
+
+func Test_toSnakeCase(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "initialize", want: "initialize"},
+		{s: "incrementBy", want: "increment_by"},
+		{s: "initializeMint2", want: "initialize_mint2"},
+		{s: "setURI", want: "set_uri"},
+		{s: "parseHTTPRequest", want: "parse_http_request"},
+		{s: "already_snake", want: "already_snake"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			assert.Equal(t, tt.want, toSnakeCase(tt.s))
+		})
+	}
+}
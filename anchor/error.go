@@ -0,0 +1,18 @@
+package anchor
+
+import "errors"
+
+var (
+	ErrInvalidIdl             = errors.New("invalid idl")
+	ErrUnknownInstruction     = errors.New("unknown instruction")
+	ErrUnknownType            = errors.New("unknown type")
+	ErrUnsupportedType        = errors.New("unsupported type")
+	ErrInvalidValue           = errors.New("invalid value")
+	ErrMissingArg             = errors.New("missing arg")
+	ErrMissingAccount         = errors.New("missing account")
+	ErrUnresolvableSeed       = errors.New("unresolvable seed")
+	ErrUnknownDiscriminator   = errors.New("unknown discriminator")
+	ErrInvalidAccountDataSize = errors.New("invalid account data size")
+	ErrInvalidAccountData     = errors.New("invalid account data")
+	ErrNameConflict           = errors.New("name conflict")
+)
@@ -0,0 +1,208 @@
+package anchor
+
+const testProgramID = "CounterProgram11111111111111111111111111111"
+
+const testLegacyIdl = `{
+  "version": "0.1.0",
+  "name": "counter",
+  "instructions": [
+    {
+      "name": "initialize",
+      "accounts": [
+        {
+          "name": "counter",
+          "isMut": true,
+          "isSigner": false,
+          "pda": {
+            "seeds": [
+              {"kind": "const", "type": "string", "value": "counter"},
+              {"kind": "account", "type": "publicKey", "path": "authority"},
+              {"kind": "arg", "type": "string", "path": "name"}
+            ]
+          }
+        },
+        {"name": "authority", "isMut": true, "isSigner": true},
+        {"name": "systemProgram", "isMut": false, "isSigner": false}
+      ],
+      "args": [
+        {"name": "name", "type": "string"},
+        {"name": "kind", "type": {"defined": "Kind"}}
+      ]
+    },
+    {
+      "name": "incrementBy",
+      "accounts": [
+        {"name": "counter", "isMut": true, "isSigner": false},
+        {
+          "name": "auth",
+          "accounts": [
+            {"name": "authority", "isMut": false, "isSigner": true},
+            {"name": "delegate", "isMut": false, "isSigner": false, "isOptional": true}
+          ]
+        }
+      ],
+      "args": [
+        {"name": "amount", "type": "u64"},
+        {"name": "config", "type": {"defined": "Config"}}
+      ]
+    }
+  ],
+  "accounts": [
+    {
+      "name": "Counter",
+      "type": {
+        "kind": "struct",
+        "fields": [
+          {"name": "authority", "type": "publicKey"},
+          {"name": "name", "type": "string"},
+          {"name": "count", "type": "u64"},
+          {"name": "kind", "type": {"defined": "Kind"}},
+          {"name": "history", "type": {"vec": "i16"}},
+          {"name": "total", "type": "u128"}
+        ]
+      }
+    }
+  ],
+  "types": [
+    {
+      "name": "Config",
+      "type": {
+        "kind": "struct",
+        "fields": [
+          {"name": "step", "type": "u16"},
+          {"name": "note", "type": {"option": "string"}},
+          {"name": "tags", "type": {"array": ["u8", 2]}}
+        ]
+      }
+    },
+    {
+      "name": "Kind",
+      "type": {
+        "kind": "enum",
+        "variants": [
+          {"name": "Simple"},
+          {"name": "Weighted", "fields": [{"name": "weight", "type": "u8"}]},
+          {"name": "Pair", "fields": ["u8", "u16"]}
+        ]
+      }
+    }
+  ],
+  "events": [
+    {
+      "name": "Incremented",
+      "fields": [
+        {"name": "counter", "type": "publicKey", "index": false},
+        {"name": "count", "type": "u64", "index": false}
+      ]
+    }
+  ],
+  "errors": [
+    {"code": 6000, "name": "Overflow", "msg": "count overflows"}
+  ]
+}`
+
+// testIdl is testLegacyIdl in the 0.30 format
+const testIdl = `{
+  "address": "CounterProgram11111111111111111111111111111",
+  "metadata": {"name": "counter", "version": "0.1.0", "spec": "0.1.0"},
+  "instructions": [
+    {
+      "name": "initialize",
+      "discriminator": [175, 175, 109, 31, 13, 152, 155, 237],
+      "accounts": [
+        {
+          "name": "counter",
+          "writable": true,
+          "pda": {
+            "seeds": [
+              {"kind": "const", "value": [99, 111, 117, 110, 116, 101, 114]},
+              {"kind": "account", "path": "authority"},
+              {"kind": "arg", "path": "name"}
+            ]
+          }
+        },
+        {"name": "authority", "writable": true, "signer": true},
+        {"name": "system_program", "address": "11111111111111111111111111111111"}
+      ],
+      "args": [
+        {"name": "name", "type": "string"},
+        {"name": "kind", "type": {"defined": {"name": "Kind"}}}
+      ]
+    },
+    {
+      "name": "increment_by",
+      "discriminator": [103, 82, 124, 55, 231, 50, 146, 138],
+      "accounts": [
+        {"name": "counter", "writable": true},
+        {
+          "name": "auth",
+          "accounts": [
+            {"name": "authority", "signer": true},
+            {"name": "delegate", "optional": true}
+          ]
+        }
+      ],
+      "args": [
+        {"name": "amount", "type": "u64"},
+        {"name": "config", "type": {"defined": {"name": "Config"}}}
+      ]
+    }
+  ],
+  "accounts": [
+    {"name": "Counter", "discriminator": [255, 176, 4, 245, 188, 253, 124, 25]}
+  ],
+  "events": [
+    {"name": "Incremented", "discriminator": [92, 207, 119, 204, 71, 205, 108, 15]}
+  ],
+  "types": [
+    {
+      "name": "Config",
+      "type": {
+        "kind": "struct",
+        "fields": [
+          {"name": "step", "type": "u16"},
+          {"name": "note", "type": {"option": "string"}},
+          {"name": "tags", "type": {"array": ["u8", 2]}}
+        ]
+      }
+    },
+    {
+      "name": "Counter",
+      "type": {
+        "kind": "struct",
+        "fields": [
+          {"name": "authority", "type": "pubkey"},
+          {"name": "name", "type": "string"},
+          {"name": "count", "type": "u64"},
+          {"name": "kind", "type": {"defined": {"name": "Kind"}}},
+          {"name": "history", "type": {"vec": "i16"}},
+          {"name": "total", "type": "u128"}
+        ]
+      }
+    },
+    {
+      "name": "Incremented",
+      "type": {
+        "kind": "struct",
+        "fields": [
+          {"name": "counter", "type": "pubkey"},
+          {"name": "count", "type": "u64"}
+        ]
+      }
+    },
+    {
+      "name": "Kind",
+      "type": {
+        "kind": "enum",
+        "variants": [
+          {"name": "Simple"},
+          {"name": "Weighted", "fields": [{"name": "weight", "type": "u8"}]},
+          {"name": "Pair", "fields": ["u8", "u16"]}
+        ]
+      }
+    }
+  ],
+  "errors": [
+    {"code": 6000, "name": "Overflow", "msg": "count overflows"}
+  ]
+}`
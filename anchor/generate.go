@@ -0,0 +1,752 @@
+package anchor
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+)
+
+type GenerateConfig struct {
+	// Package is the name of the generated package
+	Package string
+	// ProgramID overrides the address in the idl, the legacy idl has no address
+	ProgramID string
+}
+
+// Generate generates a go package from an idl. the package follows the program packages of this module,
+// instruction.go has the param structs and the builders, state.go has the accounts and their decoders,
+// event.go has the events, error.go has the error codes and types.go has the other defined types.
+// it returns the formatted sources by file name.
+func Generate(idl Idl, config GenerateConfig) (map[string][]byte, error) {
+	programID := idl.Address
+	if config.ProgramID != "" {
+		programID = config.ProgramID
+	}
+	if programID == "" {
+		return nil, fmt.Errorf("%w, missing program id", ErrInvalidIdl)
+	}
+	if config.Package == "" {
+		return nil, fmt.Errorf("%w, missing package name", ErrInvalidIdl)
+	}
+
+	g := generator{
+		idl:       idl,
+		pkg:       config.Package,
+		programID: programID,
+		names:     map[string]bool{},
+		accounts:  map[string]bool{},
+		events:    map[string]bool{},
+	}
+	for _, account := range idl.Accounts {
+		g.accounts[account.Name] = true
+	}
+	for _, event := range idl.Events {
+		g.events[event.Name] = true
+	}
+
+	files := map[string][]byte{}
+	steps := []struct {
+		name string
+		fn   func(b *strings.Builder) error
+	}{
+		{"instruction.go", g.instructionFile},
+		{"state.go", g.stateFile},
+		{"event.go", g.eventFile},
+		{"types.go", g.typesFile},
+		{"error.go", g.errorFile},
+	}
+	for _, step := range steps {
+		g.imports = map[string]bool{}
+		var b strings.Builder
+		if err := step.fn(&b); err != nil {
+			return nil, err
+		}
+		if b.Len() == 0 {
+			continue
+		}
+		src, err := g.source(b.String())
+		if err != nil {
+			return nil, err
+		}
+		files[step.name] = src
+	}
+	return files, nil
+}
+
+var generatedImports = map[string]string{
+	"big":    "math/big",
+	"bytes":  "bytes",
+	"errors": "errors",
+	"fmt":    "fmt",
+	"borsh":  "github.com/near/borsh-go",
+	"common": "github.com/EntySquare/solana-go-sdk/common",
+	"types":  "github.com/EntySquare/solana-go-sdk/types",
+}
+
+// wellKnownAccountNames refers to the constants in the common package instead of the addresses
+var wellKnownAccountNames = map[common.PublicKey]string{
+	common.SystemProgramID:                    "common.SystemProgramID",
+	common.TokenProgramID:                     "common.TokenProgramID",
+	common.SPLAssociatedTokenAccountProgramID: "common.SPLAssociatedTokenAccountProgramID",
+	common.SysVarRentPubkey:                   "common.SysVarRentPubkey",
+	common.SysVarClockPubkey:                  "common.SysVarClockPubkey",
+}
+
+type generator struct {
+	idl       Idl
+	pkg       string
+	programID string
+	// names are the declared identifiers of the package
+	names    map[string]bool
+	accounts map[string]bool
+	events   map[string]bool
+	// imports are the imports of the current file
+	imports map[string]bool
+}
+
+func (g *generator) source(body string) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by anchorgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", g.pkg)
+
+	// the standard library goes first
+	var std, others []string
+	for name := range g.imports {
+		path := generatedImports[name]
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			others = append(others, path)
+			continue
+		}
+		std = append(std, path)
+	}
+	sort.Strings(std)
+	sort.Strings(others)
+	if len(std)+len(others) > 0 {
+		b.WriteString("import (\n")
+		for _, path := range std {
+			fmt.Fprintf(&b, "%q\n", path)
+		}
+		if len(std) > 0 && len(others) > 0 {
+			b.WriteString("\n")
+		}
+		for _, path := range others {
+			fmt.Fprintf(&b, "%q\n", path)
+		}
+		b.WriteString(")\n\n")
+	}
+	b.WriteString(body)
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format the generated code, err: %v", err)
+	}
+	return src, nil
+}
+
+func (g *generator) use(pkg string) {
+	g.imports[pkg] = true
+}
+
+func (g *generator) declare(names ...string) error {
+	for _, name := range names {
+		if g.names[name] {
+			return fmt.Errorf("%w, %v", ErrNameConflict, name)
+		}
+		g.names[name] = true
+	}
+	return nil
+}
+
+func (g *generator) instructionFile(b *strings.Builder) error {
+	g.use("common")
+	if err := g.declare("ProgramID", "Instruction"); err != nil {
+		return err
+	}
+	fmt.Fprintf(b, "var ProgramID = common.PublicKeyFromString(%q)\n\n", g.programID)
+
+	b.WriteString("// Instruction is the anchor discriminator of an instruction\n")
+	b.WriteString("type Instruction [8]uint8\n\n")
+	b.WriteString("var (\n")
+	for _, ix := range g.idl.Instructions {
+		discriminator := instructionDiscriminator(ix)
+		if len(discriminator) != DiscriminatorSize {
+			return fmt.Errorf("%w, discriminator size of %v: %v", ErrUnsupportedType, ix.Name, len(discriminator))
+		}
+		name := "Instruction" + exportName(ix.Name)
+		if err := g.declare(name); err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "%s = Instruction%s\n", name, byteList(discriminator))
+	}
+	b.WriteString(")\n")
+
+	hasOptional := false
+	for _, ix := range g.idl.Instructions {
+		optional, err := g.instruction(b, ix)
+		if err != nil {
+			return err
+		}
+		hasOptional = hasOptional || optional
+	}
+
+	if hasOptional {
+		if err := g.declare("optionalAccountMeta"); err != nil {
+			return err
+		}
+		b.WriteString(`
+func optionalAccountMeta(pubkey *common.PublicKey, isSigner, isWritable bool) types.AccountMeta {
+	if pubkey == nil {
+		return types.AccountMeta{
+			PubKey:     ProgramID,
+			IsSigner:   false,
+			IsWritable: false,
+		}
+	}
+	return types.AccountMeta{
+		PubKey:     *pubkey,
+		IsSigner:   isSigner,
+		IsWritable: isWritable,
+	}
+}
+`)
+	}
+	return nil
+}
+
+type generatedAccount struct {
+	flatAccount
+	// field is the field of the param struct, the account is fixed if it is empty
+	field string
+	// pubkey is the expression of a fixed account
+	pubkey string
+}
+
+func (g *generator) instruction(b *strings.Builder, ix IdlInstruction) (bool, error) {
+	g.use("types")
+	g.use("borsh")
+
+	fn := exportName(ix.Name)
+	param := fn + "Param"
+	if err := g.declare(fn, param); err != nil {
+		return false, err
+	}
+
+	// accounts of the nested groups are named by the path if the name is taken twice
+	flat := flattenAccounts("", ix.Accounts)
+	count := map[string]int{}
+	for _, account := range flat {
+		count[exportName(account.Name)]++
+	}
+	fields := map[string]bool{}
+	var accounts []generatedAccount
+	for _, account := range flat {
+		a := generatedAccount{flatAccount: account}
+		switch {
+		case account.Address != "":
+			a.pubkey = g.pubkeyExpr(common.PublicKeyFromString(account.Address))
+		case account.Pda == nil && isWellKnownAccount(account.Name):
+			a.pubkey = g.pubkeyExpr(wellKnownAccounts[account.Name])
+		default:
+			a.field = exportName(account.Name)
+			if count[a.field] > 1 {
+				a.field = exportName(strings.ReplaceAll(account.path, ".", "_"))
+			}
+			if fields[a.field] {
+				return false, fmt.Errorf("%w, account %v of %v", ErrNameConflict, a.field, ix.Name)
+			}
+			fields[a.field] = true
+		}
+		accounts = append(accounts, a)
+	}
+
+	// the discriminator is the Instruction field of the data
+	fields["Instruction"] = true
+	args := make([]string, 0, len(ix.Args))
+	for _, arg := range ix.Args {
+		name := exportName(arg.Name)
+		if fields[name] {
+			name += "Arg"
+		}
+		if fields[name] {
+			return false, fmt.Errorf("%w, arg %v of %v", ErrNameConflict, name, ix.Name)
+		}
+		fields[name] = true
+		args = append(args, name)
+	}
+
+	// param
+	fmt.Fprintf(b, "\ntype %s struct {\n", param)
+	optional := false
+	for _, a := range accounts {
+		if a.field == "" {
+			continue
+		}
+		writeDocs(b, a.Docs)
+		if a.Optional {
+			optional = true
+			fmt.Fprintf(b, "%s *common.PublicKey\n", a.field)
+			continue
+		}
+		fmt.Fprintf(b, "%s common.PublicKey\n", a.field)
+	}
+	for i, arg := range ix.Args {
+		typ, err := g.goType(arg.Type)
+		if err != nil {
+			return false, fmt.Errorf("%w, arg %v of %v", err, arg.Name, ix.Name)
+		}
+		writeDocs(b, arg.Docs)
+		fmt.Fprintf(b, "%s %s\n", args[i], typ)
+	}
+	b.WriteString("}\n\n")
+
+	// builder
+	if len(ix.Docs) > 0 {
+		writeDocs(b, ix.Docs)
+	} else {
+		fmt.Fprintf(b, "// %s builds the %s instruction\n", fn, toSnakeCase(ix.Name))
+	}
+	fmt.Fprintf(b, "func %s(param %s) types.Instruction {\n", fn, param)
+	b.WriteString("data, err := borsh.Serialize(struct {\nInstruction Instruction\n")
+	for i, arg := range ix.Args {
+		typ, _ := g.goType(arg.Type)
+		fmt.Fprintf(b, "%s %s\n", args[i], typ)
+	}
+	fmt.Fprintf(b, "}{\nInstruction: Instruction%s,\n", fn)
+	for _, arg := range args {
+		fmt.Fprintf(b, "%s: param.%s,\n", arg, arg)
+	}
+	b.WriteString("})\nif err != nil {\npanic(err)\n}\n\n")
+
+	b.WriteString("return types.Instruction{\nProgramID: ProgramID,\nAccounts: []types.AccountMeta{\n")
+	for _, a := range accounts {
+		if a.Optional && a.field != "" {
+			fmt.Fprintf(b, "optionalAccountMeta(param.%s, %v, %v),\n", a.field, a.Signer, a.Writable)
+			continue
+		}
+		pubkey := a.pubkey
+		if a.field != "" {
+			pubkey = "param." + a.field
+		}
+		fmt.Fprintf(b, "{\nPubKey: %s,\nIsSigner: %v,\nIsWritable: %v,\n},\n", pubkey, a.Signer, a.Writable)
+	}
+	b.WriteString("},\nData: data,\n}\n}\n")
+	return optional, nil
+}
+
+func isWellKnownAccount(name string) bool {
+	_, ok := wellKnownAccounts[name]
+	return ok
+}
+
+func (g *generator) pubkeyExpr(pubkey common.PublicKey) string {
+	g.use("common")
+	if name, ok := wellKnownAccountNames[pubkey]; ok {
+		return name
+	}
+	return fmt.Sprintf("common.PublicKeyFromString(%q)", pubkey.ToBase58())
+}
+
+func (g *generator) stateFile(b *strings.Builder) error {
+	if len(g.idl.Accounts) == 0 {
+		return nil
+	}
+	g.use("bytes")
+	g.use("fmt")
+	g.use("borsh")
+
+	for _, account := range g.idl.Accounts {
+		def, err := g.typeDef(account.Name)
+		if err != nil {
+			return err
+		}
+		if err := g.typeDecl(b, def); err != nil {
+			return err
+		}
+		if err := g.fromData(b, account.Name, accountDiscriminator(account), "ErrInvalidAccountDataSize", "ErrInvalidAccountData"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) eventFile(b *strings.Builder) error {
+	if len(g.idl.Events) == 0 {
+		return nil
+	}
+	g.use("bytes")
+	g.use("fmt")
+	g.use("borsh")
+
+	for _, event := range g.idl.Events {
+		def := IdlTypeDef{Name: event.Name, Type: IdlTypeDefTy{Kind: IdlTypeDefTyKindStruct, Fields: IdlDefinedFields{Named: event.Fields}}}
+		if len(event.Fields) == 0 {
+			var err error
+			if def, err = g.typeDef(event.Name); err != nil {
+				return err
+			}
+		}
+		if err := g.typeDecl(b, def); err != nil {
+			return err
+		}
+		discriminator := event.Discriminator
+		if len(discriminator) == 0 {
+			d := EventDiscriminator(event.Name)
+			discriminator = d[:]
+		}
+		if err := g.fromData(b, event.Name, discriminator, "ErrInvalidEventDataSize", "ErrInvalidEventData"); err != nil {
+			return err
+		}
+	}
+
+	if err := g.declare("DecodeEvent"); err != nil {
+		return err
+	}
+	b.WriteString("\n// DecodeEvent decodes the data of a \"Program data:\" log to the event it belongs to\n")
+	b.WriteString("func DecodeEvent(data []byte) (any, error) {\nswitch {\n")
+	for _, event := range g.idl.Events {
+		name := exportName(event.Name)
+		fmt.Fprintf(b, "case bytes.HasPrefix(data, %sDiscriminator[:]):\nreturn %sFromData(data)\n", name, name)
+	}
+	b.WriteString("}\nreturn nil, ErrUnknownEvent\n}\n")
+	return nil
+}
+
+func (g *generator) fromData(b *strings.Builder, typeName string, discriminator []byte, errSize, errData string) error {
+	name := exportName(typeName)
+	if err := g.declare(name+"Discriminator", name+"FromData"); err != nil {
+		return err
+	}
+	v := unexportName(name)
+	fmt.Fprintf(b, "\nvar %sDiscriminator = [%d]uint8%s\n\n", name, len(discriminator), byteList(discriminator))
+	fmt.Fprintf(b, "// %sFromData decodes the data of %s, the data starts with the discriminator\n", name, typeName)
+	fmt.Fprintf(b, "func %sFromData(data []byte) (%s, error) {\n", name, name)
+	fmt.Fprintf(b, "if len(data) < len(%sDiscriminator) {\nreturn %s{}, fmt.Errorf(\"%%w, size: %%v\", %s, len(data))\n}\n", name, name, errSize)
+	fmt.Fprintf(b, "if !bytes.Equal(data[:len(%sDiscriminator)], %sDiscriminator[:]) {\nreturn %s{}, fmt.Errorf(\"%%w, discriminator mismatch\", %s)\n}\n\n", name, name, name, errData)
+	fmt.Fprintf(b, "var %s %s\n", v, name)
+	fmt.Fprintf(b, "if err := borsh.Deserialize(&%s, data[len(%sDiscriminator):]); err != nil {\nreturn %s{}, fmt.Errorf(\"%%w, %%v\", %s, err)\n}\n", v, name, name, errData)
+	fmt.Fprintf(b, "return %s, nil\n}\n", v)
+	return nil
+}
+
+// typeDef finds the layout of an account or an event
+func (g *generator) typeDef(name string) (IdlTypeDef, error) {
+	for _, t := range g.idl.Types {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	for _, a := range g.idl.Accounts {
+		if a.Name == name && a.Type != nil {
+			return IdlTypeDef{Name: a.Name, Type: *a.Type}, nil
+		}
+	}
+	return IdlTypeDef{}, fmt.Errorf("%w, %v", ErrUnknownType, name)
+}
+
+func (g *generator) typesFile(b *strings.Builder) error {
+	for _, t := range g.idl.Types {
+		if g.accounts[t.Name] || g.events[t.Name] {
+			continue
+		}
+		if err := g.typeDecl(b, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) typeDecl(b *strings.Builder, t IdlTypeDef) error {
+	if t.Serialization != "" && t.Serialization != "borsh" {
+		return fmt.Errorf("%w, %v serialization of %v", ErrUnsupportedType, t.Serialization, t.Name)
+	}
+	name := exportName(t.Name)
+	if err := g.declare(name); err != nil {
+		return err
+	}
+	b.WriteString("\n")
+	writeDocs(b, t.Docs)
+
+	switch t.Type.Kind {
+	case IdlTypeDefTyKindStruct:
+		fmt.Fprintf(b, "type %s ", name)
+		if err := g.structType(b, t.Type.Fields); err != nil {
+			return fmt.Errorf("%w, type %v", err, t.Name)
+		}
+		b.WriteString("\n")
+	case IdlTypeDefTyKindAlias:
+		if t.Type.Alias == nil {
+			return fmt.Errorf("%w, alias %v", ErrInvalidIdl, t.Name)
+		}
+		typ, err := g.goType(*t.Type.Alias)
+		if err != nil {
+			return fmt.Errorf("%w, type %v", err, t.Name)
+		}
+		fmt.Fprintf(b, "type %s %s\n", name, typ)
+	case IdlTypeDefTyKindEnum:
+		return g.enumDecl(b, name, t.Type.Variants)
+	default:
+		return fmt.Errorf("%w, kind %v of %v", ErrUnsupportedType, t.Type.Kind, t.Name)
+	}
+	return nil
+}
+
+func (g *generator) structType(b *strings.Builder, fields IdlDefinedFields) error {
+	b.WriteString("struct {\n")
+	for _, f := range fields.Named {
+		typ, err := g.goType(f.Type)
+		if err != nil {
+			return fmt.Errorf("%w, field %v", err, f.Name)
+		}
+		writeDocs(b, f.Docs)
+		fmt.Fprintf(b, "%s %s\n", exportName(f.Name), typ)
+	}
+	for i, t := range fields.Tuple {
+		typ, err := g.goType(t)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "Field%d %s\n", i, typ)
+	}
+	b.WriteString("}")
+	return nil
+}
+
+// enumDecl declares an enum without fields as a borsh.Enum and an enum with fields as a borsh complex enum
+func (g *generator) enumDecl(b *strings.Builder, name string, variants []IdlEnumVariant) error {
+	g.use("borsh")
+	for _, v := range variants {
+		if err := g.declare(name + exportName(v.Name)); err != nil {
+			return err
+		}
+	}
+
+	simple := true
+	for _, v := range variants {
+		if len(v.Fields.Named) > 0 || len(v.Fields.Tuple) > 0 {
+			simple = false
+		}
+	}
+	if simple {
+		fmt.Fprintf(b, "type %s borsh.Enum\n\nconst (\n", name)
+		for i, v := range variants {
+			if i == 0 {
+				fmt.Fprintf(b, "%s%s %s = iota\n", name, exportName(v.Name), name)
+				continue
+			}
+			fmt.Fprintf(b, "%s%s\n", name, exportName(v.Name))
+		}
+		b.WriteString(")\n")
+		return nil
+	}
+
+	fmt.Fprintf(b, "type %s struct {\nEnum borsh.Enum `borsh_enum:\"true\"`\n", name)
+	for _, v := range variants {
+		if len(v.Fields.Named) == 0 && len(v.Fields.Tuple) == 0 {
+			fmt.Fprintf(b, "%s struct{}\n", exportName(v.Name))
+			continue
+		}
+		fmt.Fprintf(b, "%s %s%sFields\n", exportName(v.Name), name, exportName(v.Name))
+	}
+	b.WriteString("}\n\nconst (\n")
+	for i, v := range variants {
+		if i == 0 {
+			fmt.Fprintf(b, "%s%s borsh.Enum = iota\n", name, exportName(v.Name))
+			continue
+		}
+		fmt.Fprintf(b, "%s%s\n", name, exportName(v.Name))
+	}
+	b.WriteString(")\n")
+
+	for _, v := range variants {
+		if len(v.Fields.Named) == 0 && len(v.Fields.Tuple) == 0 {
+			continue
+		}
+		fields := name + exportName(v.Name) + "Fields"
+		if err := g.declare(fields); err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "\ntype %s ", fields)
+		if err := g.structType(b, v.Fields); err != nil {
+			return fmt.Errorf("%w, variant %v of %v", err, v.Name, name)
+		}
+		b.WriteString("\n")
+	}
+	return nil
+}
+
+var generatedPrimitives = map[string]string{
+	"bool":   "bool",
+	"u8":     "uint8",
+	"i8":     "int8",
+	"u16":    "uint16",
+	"i16":    "int16",
+	"u32":    "uint32",
+	"i32":    "int32",
+	"u64":    "uint64",
+	"i64":    "int64",
+	"f32":    "float32",
+	"f64":    "float64",
+	"string": "string",
+	"bytes":  "[]byte",
+	// borsh-go only has an unsigned u128, i128 is kept as its little endian bytes
+	"i128": "[16]uint8",
+}
+
+func (g *generator) goType(t IdlType) (string, error) {
+	switch {
+	case t.Primitive == "pubkey":
+		g.use("common")
+		return "common.PublicKey", nil
+	case t.Primitive == "u128":
+		g.use("big")
+		return "big.Int", nil
+	case t.Primitive != "":
+		typ, ok := generatedPrimitives[t.Primitive]
+		if !ok {
+			return "", fmt.Errorf("%w, %v", ErrUnsupportedType, t.Primitive)
+		}
+		return typ, nil
+	case t.Vec != nil:
+		typ, err := g.goType(*t.Vec)
+		return "[]" + typ, err
+	case t.Option != nil:
+		typ, err := g.goType(*t.Option)
+		return "*" + typ, err
+	case t.Array != nil:
+		typ, err := g.goType(*t.Array)
+		return fmt.Sprintf("[%d]%s", t.Len, typ), err
+	case t.Defined != "":
+		if _, ok := g.idl.TypeDef(t.Defined); !ok {
+			return "", fmt.Errorf("%w, %v", ErrUnknownType, t.Defined)
+		}
+		return exportName(t.Defined), nil
+	case t.COption != nil:
+		return "", fmt.Errorf("%w, coption", ErrUnsupportedType)
+	}
+	return "", fmt.Errorf("%w, empty type", ErrInvalidIdl)
+}
+
+func (g *generator) errorFile(b *strings.Builder) error {
+	var sentinels []string
+	if len(g.idl.Accounts) > 0 {
+		sentinels = append(sentinels, "ErrInvalidAccountDataSize", "ErrInvalidAccountData")
+	}
+	if len(g.idl.Events) > 0 {
+		sentinels = append(sentinels, "ErrInvalidEventDataSize", "ErrInvalidEventData", "ErrUnknownEvent")
+	}
+	if len(sentinels) > 0 {
+		g.use("errors")
+		if err := g.declare(sentinels...); err != nil {
+			return err
+		}
+		b.WriteString("var (\n")
+		for _, name := range sentinels {
+			fmt.Fprintf(b, "%s = errors.New(%q)\n", name, sentinelMessage(name))
+		}
+		b.WriteString(")\n")
+	}
+
+	if len(g.idl.Errors) == 0 {
+		return nil
+	}
+	g.use("fmt")
+	if err := g.declare("ErrorCode", "ErrorCodeFromCode", "errorCodeNames", "errorCodeMessages"); err != nil {
+		return err
+	}
+	b.WriteString("\n// ErrorCode is a custom error of the program, it is the code of \"custom program error: 0x...\"\n")
+	b.WriteString("type ErrorCode uint32\n\nconst (\n")
+	for _, e := range g.idl.Errors {
+		name := "ErrorCode" + exportName(e.Name)
+		if err := g.declare(name); err != nil {
+			return err
+		}
+		if e.Msg != "" {
+			fmt.Fprintf(b, "// %s\n", e.Msg)
+		}
+		fmt.Fprintf(b, "%s ErrorCode = %d\n", name, e.Code)
+	}
+	b.WriteString(")\n\nvar errorCodeNames = map[ErrorCode]string{\n")
+	for _, e := range g.idl.Errors {
+		fmt.Fprintf(b, "ErrorCode%s: %s,\n", exportName(e.Name), strconv.Quote(e.Name))
+	}
+	b.WriteString("}\n\nvar errorCodeMessages = map[ErrorCode]string{\n")
+	for _, e := range g.idl.Errors {
+		fmt.Fprintf(b, "ErrorCode%s: %s,\n", exportName(e.Name), strconv.Quote(e.Msg))
+	}
+	b.WriteString(`}
+
+// ErrorCodeFromCode returns the error of a custom program error code, ok is false if the program doesn't define it
+func ErrorCodeFromCode(code uint32) (ErrorCode, bool) {
+	_, ok := errorCodeNames[ErrorCode(code)]
+	return ErrorCode(code), ok
+}
+
+func (e ErrorCode) Name() string {
+	return errorCodeNames[e]
+}
+
+func (e ErrorCode) Error() string {
+	name, ok := errorCodeNames[e]
+	if !ok {
+		return fmt.Sprintf("custom program error: %#x", uint32(e))
+	}
+	if msg := errorCodeMessages[e]; msg != "" {
+		return fmt.Sprintf("%v: %v", name, msg)
+	}
+	return name
+}
+`)
+	return nil
+}
+
+// sentinelMessage turns ErrInvalidAccountData into "invalid account data"
+func sentinelMessage(name string) string {
+	return strings.ReplaceAll(toSnakeCase(strings.TrimPrefix(name, "Err")), "_", " ")
+}
+
+func writeDocs(b *strings.Builder, docs []string) {
+	for _, doc := range docs {
+		fmt.Fprintf(b, "// %s\n", strings.TrimSpace(doc))
+	}
+}
+
+func byteList(b []byte) string {
+	s := make([]string, 0, len(b))
+	for _, v := range b {
+		s = append(s, strconv.Itoa(int(v)))
+	}
+	return "{" + strings.Join(s, ", ") + "}"
+}
+
+// exportName turns an idl name into an exported go identifier
+func exportName(name string) string {
+	name = toCamelCase(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, name))
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+func unexportName(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	name = string(runes)
+	switch name {
+	case "break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for", "func",
+		"go", "goto", "if", "import", "interface", "map", "package", "range", "return", "select", "struct",
+		"switch", "type", "var", "bytes", "borsh", "fmt", "data", "err":
+		return name + "_"
+	}
+	return name
+}
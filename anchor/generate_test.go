@@ -0,0 +1,102 @@
+package anchor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	// internal/counter is generated from its idl.json, both formats have to generate it
+	dir := filepath.Join("internal", "counter")
+	want := map[string][]byte{}
+	for _, name := range []string{"instruction.go", "state.go", "event.go", "types.go", "error.go"} {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		assert.Nil(t, err)
+		want[name] = b
+	}
+	idlJson, err := os.ReadFile(filepath.Join(dir, "idl.json"))
+	assert.Nil(t, err)
+
+	for name, s := range map[string]string{"legacy": testLegacyIdl, "0.30": string(idlJson)} {
+		t.Run(name, func(t *testing.T) {
+			idl, err := ParseIdl([]byte(s))
+			assert.Nil(t, err)
+			got, err := Generate(idl, GenerateConfig{Package: "counter", ProgramID: testProgramID})
+			assert.Nil(t, err)
+			assert.Equal(t, len(want), len(got))
+			for name, src := range want {
+				assert.Equal(t, string(src), string(got[name]), name)
+			}
+		})
+	}
+}
+
+func TestGenerate_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		idl    string
+		config GenerateConfig
+		err    error
+	}{
+		{
+			name:   "missing program id",
+			idl:    `{"name": "p", "instructions": []}`,
+			config: GenerateConfig{Package: "p"},
+			err:    ErrInvalidIdl,
+		},
+		{
+			name:   "coption",
+			idl:    `{"name": "p", "instructions": [{"name": "a", "accounts": [], "args": [{"name": "x", "type": {"coption": "u64"}}]}]}`,
+			config: GenerateConfig{Package: "p", ProgramID: testProgramID},
+			err:    ErrUnsupportedType,
+		},
+		{
+			name:   "unknown type",
+			idl:    `{"name": "p", "instructions": [{"name": "a", "accounts": [], "args": [{"name": "x", "type": {"defined": "X"}}]}]}`,
+			config: GenerateConfig{Package: "p", ProgramID: testProgramID},
+			err:    ErrUnknownType,
+		},
+		{
+			name: "zero copy account",
+			idl: `{"address": "` + testProgramID + `", "instructions": [], "accounts": [{"name": "A", "discriminator": [1, 2, 3, 4, 5, 6, 7, 8]}],
+				"types": [{"name": "A", "serialization": "bytemuck", "type": {"kind": "struct", "fields": []}}]}`,
+			config: GenerateConfig{Package: "p"},
+			err:    ErrUnsupportedType,
+		},
+		{
+			name: "name conflict",
+			idl: `{"name": "p", "instructions": [{"name": "a", "accounts": [], "args": []}],
+				"types": [{"name": "A", "type": {"kind": "struct", "fields": []}}]}`,
+			config: GenerateConfig{Package: "p", ProgramID: testProgramID},
+			err:    ErrNameConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idl, err := ParseIdl([]byte(tt.idl))
+			assert.Nil(t, err)
+			_, err = Generate(idl, tt.config)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestExportName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "increment_by", want: "IncrementBy"},
+		{name: "incrementBy", want: "IncrementBy"},
+		{name: "mint-v1", want: "MintV1"},
+		{name: "2x", want: "X2x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exportName(tt.name))
+		})
+	}
+}
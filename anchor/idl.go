@@ -0,0 +1,319 @@
+package anchor
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Idl is an anchor idl. both the legacy format and the 0.30 format can be parsed,
+// the differences are smoothed out by the custom unmarshalers.
+type Idl struct {
+	// Address is only in the 0.30 format
+	Address      string           `json:"address,omitempty"`
+	Version      string           `json:"version,omitempty"`
+	Name         string           `json:"name,omitempty"`
+	Metadata     *IdlMetadata     `json:"metadata,omitempty"`
+	Instructions []IdlInstruction `json:"instructions"`
+	Accounts     []IdlAccountDef  `json:"accounts,omitempty"`
+	Types        []IdlTypeDef     `json:"types,omitempty"`
+	Events       []IdlEvent       `json:"events,omitempty"`
+	Errors       []IdlErrorCode   `json:"errors,omitempty"`
+}
+
+type IdlMetadata struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+	Address string `json:"address,omitempty"`
+}
+
+type IdlInstruction struct {
+	Name string   `json:"name"`
+	Docs []string `json:"docs,omitempty"`
+	// Discriminator is only in the 0.30 format, the legacy one uses the sighash of the name
+	Discriminator []byte           `json:"discriminator,omitempty"`
+	Accounts      []IdlAccountItem `json:"accounts"`
+	Args          []IdlField       `json:"args"`
+}
+
+// IdlAccountItem is an account of an instruction, or a group of accounts if Accounts is not empty
+type IdlAccountItem struct {
+	Name     string           `json:"name"`
+	Docs     []string         `json:"docs,omitempty"`
+	Writable bool             `json:"writable,omitempty"`
+	Signer   bool             `json:"signer,omitempty"`
+	Optional bool             `json:"optional,omitempty"`
+	Address  string           `json:"address,omitempty"`
+	Pda      *IdlPda          `json:"pda,omitempty"`
+	Accounts []IdlAccountItem `json:"accounts,omitempty"`
+}
+
+func (a *IdlAccountItem) UnmarshalJSON(b []byte) error {
+	type alias IdlAccountItem
+	var v struct {
+		alias
+		// legacy names
+		IsMut      bool `json:"isMut"`
+		IsSigner   bool `json:"isSigner"`
+		IsOptional bool `json:"isOptional"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*a = IdlAccountItem(v.alias)
+	a.Writable = a.Writable || v.IsMut
+	a.Signer = a.Signer || v.IsSigner
+	a.Optional = a.Optional || v.IsOptional
+	return nil
+}
+
+type IdlPda struct {
+	Seeds []IdlSeed `json:"seeds"`
+	// Program is the program the pda derives from, it is the program itself if nil
+	Program *IdlSeed `json:"program,omitempty"`
+}
+
+func (p *IdlPda) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Seeds   []IdlSeed `json:"seeds"`
+		Program *IdlSeed  `json:"program"`
+		// legacy name
+		ProgramId *IdlSeed `json:"programId"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	p.Seeds = v.Seeds
+	p.Program = v.Program
+	if p.Program == nil {
+		p.Program = v.ProgramId
+	}
+	return nil
+}
+
+type IdlSeedKind string
+
+const (
+	IdlSeedKindConst   IdlSeedKind = "const"
+	IdlSeedKindArg     IdlSeedKind = "arg"
+	IdlSeedKindAccount IdlSeedKind = "account"
+)
+
+type IdlSeed struct {
+	Kind IdlSeedKind `json:"kind"`
+	// Type is only in the legacy format
+	Type *IdlType `json:"type,omitempty"`
+	// Value is the const seed, it is an array of bytes in the 0.30 format and a typed value in the legacy one
+	Value json.RawMessage `json:"value,omitempty"`
+	// Path is the name of the arg or the account, a dotted path means a field of it
+	Path string `json:"path,omitempty"`
+	// Account is the type of the account the path points into
+	Account string `json:"account,omitempty"`
+}
+
+type IdlField struct {
+	Name string   `json:"name"`
+	Docs []string `json:"docs,omitempty"`
+	Type IdlType  `json:"type"`
+}
+
+// IdlAccountDef is an account type. the 0.30 format only keeps the name and the discriminator,
+// the layout is in the types.
+type IdlAccountDef struct {
+	Name          string        `json:"name"`
+	Discriminator []byte        `json:"discriminator,omitempty"`
+	Type          *IdlTypeDefTy `json:"type,omitempty"`
+}
+
+// IdlEvent is an event type. the legacy format puts the fields here, the 0.30 one puts them in the types.
+type IdlEvent struct {
+	Name          string     `json:"name"`
+	Discriminator []byte     `json:"discriminator,omitempty"`
+	Fields        []IdlField `json:"fields,omitempty"`
+}
+
+type IdlErrorCode struct {
+	Code uint32 `json:"code"`
+	Name string `json:"name"`
+	Msg  string `json:"msg,omitempty"`
+}
+
+type IdlTypeDef struct {
+	Name string   `json:"name"`
+	Docs []string `json:"docs,omitempty"`
+	// Serialization is empty or "borsh" for borsh types, zero copy types are "bytemuck"
+	Serialization string       `json:"serialization,omitempty"`
+	Type          IdlTypeDefTy `json:"type"`
+}
+
+type IdlTypeDefTyKind string
+
+const (
+	IdlTypeDefTyKindStruct IdlTypeDefTyKind = "struct"
+	IdlTypeDefTyKindEnum   IdlTypeDefTyKind = "enum"
+	IdlTypeDefTyKindAlias  IdlTypeDefTyKind = "type"
+)
+
+type IdlTypeDefTy struct {
+	Kind IdlTypeDefTyKind `json:"kind"`
+	// Fields of a struct
+	Fields IdlDefinedFields `json:"fields,omitempty"`
+	// Variants of an enum
+	Variants []IdlEnumVariant `json:"variants,omitempty"`
+	// Alias is the aliased type of a type alias
+	Alias *IdlType `json:"alias,omitempty"`
+}
+
+type IdlEnumVariant struct {
+	Name   string           `json:"name"`
+	Fields IdlDefinedFields `json:"fields,omitempty"`
+}
+
+// IdlDefinedFields are named fields, tuple fields or nothing
+type IdlDefinedFields struct {
+	Named []IdlField
+	Tuple []IdlType
+}
+
+func (f *IdlDefinedFields) UnmarshalJSON(b []byte) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(b, &raws); err != nil {
+		return err
+	}
+	if len(raws) == 0 {
+		return nil
+	}
+
+	// named fields are objects with a name and a type
+	var probe map[string]json.RawMessage
+	if json.Unmarshal(raws[0], &probe) == nil && probe["name"] != nil && probe["type"] != nil {
+		return json.Unmarshal(b, &f.Named)
+	}
+	return json.Unmarshal(b, &f.Tuple)
+}
+
+func (f IdlDefinedFields) MarshalJSON() ([]byte, error) {
+	if f.Named != nil {
+		return json.Marshal(f.Named)
+	}
+	if f.Tuple != nil {
+		return json.Marshal(f.Tuple)
+	}
+	return []byte("null"), nil
+}
+
+// IdlType is a primitive type like "u64", or a compound type like {"vec": "u8"}
+type IdlType struct {
+	// Primitive is the name of a primitive type, "publicKey" is normalized to "pubkey"
+	Primitive string
+	Vec       *IdlType
+	Option    *IdlType
+	// COption is the 4 bytes tagged option used by the spl programs
+	COption *IdlType
+	Array   *IdlType
+	Len     int
+	// Defined is the name of a type in the types
+	Defined string
+}
+
+func (t *IdlType) UnmarshalJSON(b []byte) error {
+	var primitive string
+	if json.Unmarshal(b, &primitive) == nil {
+		if primitive == "publicKey" {
+			primitive = "pubkey"
+		}
+		t.Primitive = primitive
+		return nil
+	}
+
+	var v struct {
+		Vec     *IdlType          `json:"vec"`
+		Option  *IdlType          `json:"option"`
+		COption *IdlType          `json:"coption"`
+		Array   []json.RawMessage `json:"array"`
+		Defined json.RawMessage   `json:"defined"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch {
+	case v.Vec != nil:
+		t.Vec = v.Vec
+	case v.Option != nil:
+		t.Option = v.Option
+	case v.COption != nil:
+		t.COption = v.COption
+	case v.Array != nil:
+		if len(v.Array) != 2 {
+			return fmt.Errorf("%w, array: %s", ErrInvalidIdl, b)
+		}
+		t.Array = new(IdlType)
+		if err := json.Unmarshal(v.Array[0], t.Array); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(v.Array[1], &t.Len); err != nil {
+			return fmt.Errorf("%w, array len: %s", ErrInvalidIdl, v.Array[1])
+		}
+	case v.Defined != nil:
+		// legacy: "Name", 0.30: {"name": "Name"}
+		if json.Unmarshal(v.Defined, &t.Defined) != nil {
+			var defined struct {
+				Name string `json:"name"`
+			}
+			if err := json.Unmarshal(v.Defined, &defined); err != nil {
+				return err
+			}
+			t.Defined = defined.Name
+		}
+	default:
+		return fmt.Errorf("%w, type: %s", ErrInvalidIdl, b)
+	}
+	return nil
+}
+
+func (t IdlType) MarshalJSON() ([]byte, error) {
+	switch {
+	case t.Primitive != "":
+		return json.Marshal(t.Primitive)
+	case t.Vec != nil:
+		return json.Marshal(map[string]any{"vec": t.Vec})
+	case t.Option != nil:
+		return json.Marshal(map[string]any{"option": t.Option})
+	case t.COption != nil:
+		return json.Marshal(map[string]any{"coption": t.COption})
+	case t.Array != nil:
+		return json.Marshal(map[string]any{"array": []any{t.Array, t.Len}})
+	case t.Defined != "":
+		return json.Marshal(map[string]any{"defined": map[string]string{"name": t.Defined}})
+	}
+	return nil, fmt.Errorf("%w, empty type", ErrInvalidIdl)
+}
+
+// ParseIdl parses an anchor idl json
+func ParseIdl(b []byte) (Idl, error) {
+	var idl Idl
+	if err := json.Unmarshal(b, &idl); err != nil {
+		return Idl{}, fmt.Errorf("%w, %v", ErrInvalidIdl, err)
+	}
+	if idl.Name == "" && idl.Metadata != nil {
+		idl.Name = idl.Metadata.Name
+	}
+	if idl.Address == "" && idl.Metadata != nil {
+		idl.Address = idl.Metadata.Address
+	}
+	return idl, nil
+}
+
+// TypeDef returns the layout of a defined type, the legacy format also defines types in the accounts
+func (idl Idl) TypeDef(name string) (IdlTypeDefTy, bool) {
+	for _, t := range idl.Types {
+		if t.Name == name {
+			return t.Type, true
+		}
+	}
+	for _, a := range idl.Accounts {
+		if a.Name == name && a.Type != nil {
+			return *a.Type, true
+		}
+	}
+	return IdlTypeDefTy{}, false
+}
@@ -0,0 +1,65 @@
+package anchor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIdl(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		idl  string
+	}{
+		{name: "legacy", idl: testLegacyIdl},
+		{name: "0.30", idl: testIdl},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			idl, err := ParseIdl([]byte(tt.idl))
+			assert.Nil(t, err)
+			assert.Equal(t, "counter", idl.Name)
+
+			initialize := idl.Instructions[0]
+			assert.Equal(t, IdlAccountItem{Name: "authority", Writable: true, Signer: true}, initialize.Accounts[1])
+			assert.Equal(t, IdlType{Defined: "Kind"}, initialize.Args[1].Type)
+			assert.Equal(t, IdlSeedKindArg, initialize.Accounts[0].Pda.Seeds[2].Kind)
+			assert.Equal(t, "name", initialize.Accounts[0].Pda.Seeds[2].Path)
+
+			incrementBy := idl.Instructions[1]
+			assert.Equal(t, IdlAccountItem{Name: "delegate", Optional: true}, incrementBy.Accounts[1].Accounts[1])
+
+			config, ok := idl.TypeDef("Config")
+			assert.True(t, ok)
+			assert.Equal(t, IdlTypeDefTy{
+				Kind: IdlTypeDefTyKindStruct,
+				Fields: IdlDefinedFields{
+					Named: []IdlField{
+						{Name: "step", Type: IdlType{Primitive: "u16"}},
+						{Name: "note", Type: IdlType{Option: &IdlType{Primitive: "string"}}},
+						{Name: "tags", Type: IdlType{Array: &IdlType{Primitive: "u8"}, Len: 2}},
+					},
+				},
+			}, config)
+
+			kind, ok := idl.TypeDef("Kind")
+			assert.True(t, ok)
+			assert.Equal(t, []IdlEnumVariant{
+				{Name: "Simple"},
+				{Name: "Weighted", Fields: IdlDefinedFields{Named: []IdlField{{Name: "weight", Type: IdlType{Primitive: "u8"}}}}},
+				{Name: "Pair", Fields: IdlDefinedFields{Tuple: []IdlType{{Primitive: "u8"}, {Primitive: "u16"}}}},
+			}, kind.Variants)
+
+			counter, ok := idl.TypeDef("Counter")
+			assert.True(t, ok)
+			assert.Equal(t, IdlField{Name: "authority", Type: IdlType{Primitive: "pubkey"}}, counter.Fields.Named[0])
+		})
+	}
+}
+
+func TestParseIdl_Invalid(t *testing.T) {
+	_, err := ParseIdl([]byte(`{"instructions": [{"name": "a", "accounts": [], "args": [{"name": "x", "type": {"array": ["u8"]}}]}]}`))
+	assert.ErrorIs(t, err, ErrInvalidIdl)
+
+	_, err = ParseIdl([]byte(`{"instructions": [{"name": "a", "accounts": [], "args": [{"name": "x", "type": {"map": "u8"}}]}]}`))
+	assert.ErrorIs(t, err, ErrInvalidIdl)
+}
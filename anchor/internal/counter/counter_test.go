@@ -0,0 +1,149 @@
+package counter
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/anchor"
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testProgram(t *testing.T) anchor.Program {
+	b, err := os.ReadFile("idl.json")
+	assert.Nil(t, err)
+	idl, err := anchor.ParseIdl(b)
+	assert.Nil(t, err)
+	return anchor.NewProgram(idl, ProgramID)
+}
+
+func TestInstructions(t *testing.T) {
+	authority := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	counter := common.PublicKeyFromString("AXLCELKbBgzLZHBVr6kVn8DoWgsySDfX4LGinyQ7dYB1")
+	delegate := common.PublicKeyFromString("9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g")
+	note := "hi"
+
+	tests := []struct {
+		name     string
+		got      types.Instruction
+		ix       string
+		args     map[string]any
+		accounts map[string]common.PublicKey
+	}{
+		{
+			name: "initialize",
+			got: Initialize(InitializeParam{
+				Counter:   counter,
+				Authority: authority,
+				Name:      "fox",
+				Kind:      Kind{Enum: KindPair, Pair: KindPairFields{Field0: 1, Field1: 515}},
+			}),
+			ix: "initialize",
+			args: map[string]any{
+				"name": "fox",
+				"kind": anchor.EnumValue{Variant: "Pair", Fields: []any{1, 515}},
+			},
+			accounts: map[string]common.PublicKey{"counter": counter, "authority": authority},
+		},
+		{
+			name: "increment by without delegate",
+			got: IncrementBy(IncrementByParam{
+				Counter:   counter,
+				Authority: authority,
+				Amount:    5,
+				Config:    Config{Step: 1, Tags: [2]uint8{7, 8}},
+			}),
+			ix: "increment_by",
+			args: map[string]any{
+				"amount": 5,
+				"config": map[string]any{"step": 1, "note": nil, "tags": [2]uint8{7, 8}},
+			},
+			accounts: map[string]common.PublicKey{"counter": counter, "authority": authority},
+		},
+		{
+			name: "increment by with delegate",
+			got: IncrementBy(IncrementByParam{
+				Counter:   counter,
+				Authority: authority,
+				Delegate:  &delegate,
+				Amount:    5,
+				Config:    Config{Step: 1, Note: &note, Tags: [2]uint8{7, 8}},
+			}),
+			ix: "increment_by",
+			args: map[string]any{
+				"amount": 5,
+				"config": map[string]any{"step": 1, "note": note, "tags": [2]uint8{7, 8}},
+			},
+			accounts: map[string]common.PublicKey{"counter": counter, "authority": authority, "delegate": delegate},
+		},
+	}
+	program := testProgram(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := program.Instruction(tt.ix, tt.args, tt.accounts)
+			assert.Nil(t, err)
+			assert.Equal(t, want, tt.got)
+		})
+	}
+}
+
+func TestCounterFromData(t *testing.T) {
+	data := append([]byte{}, CounterDiscriminator[:]...)
+	data = append(data, common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7").Bytes()...)
+	data = append(data, 3, 0, 0, 0, 102, 111, 120)
+	data = append(data, 7, 0, 0, 0, 0, 0, 0, 0)
+	data = append(data, 1, 3)
+	data = append(data, 2, 0, 0, 0, 255, 255, 2, 0)
+	data = append(data, 0, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 0)
+
+	got, err := CounterFromData(data)
+	assert.Nil(t, err)
+	assert.Equal(t, Counter{
+		Authority: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+		Name:      "fox",
+		Count:     7,
+		Kind:      Kind{Enum: KindWeighted, Weighted: KindWeightedFields{Weight: 3}},
+		History:   []int16{-1, 2},
+		Total:     *new(big.Int).Lsh(big.NewInt(1), 70),
+	}, got)
+
+	_, err = CounterFromData(data[:4])
+	assert.ErrorIs(t, err, ErrInvalidAccountDataSize)
+	_, err = CounterFromData(make([]byte, len(data)))
+	assert.ErrorIs(t, err, ErrInvalidAccountData)
+	_, err = CounterFromData(data[:20])
+	assert.ErrorIs(t, err, ErrInvalidAccountData)
+}
+
+func TestDecodeEvent(t *testing.T) {
+	data := append([]byte{}, IncrementedDiscriminator[:]...)
+	data = append(data, common.PublicKeyFromString("AXLCELKbBgzLZHBVr6kVn8DoWgsySDfX4LGinyQ7dYB1").Bytes()...)
+	data = append(data, 8, 0, 0, 0, 0, 0, 0, 0)
+
+	got, err := DecodeEvent(data)
+	assert.Nil(t, err)
+	assert.Equal(t, Incremented{
+		Counter: common.PublicKeyFromString("AXLCELKbBgzLZHBVr6kVn8DoWgsySDfX4LGinyQ7dYB1"),
+		Count:   8,
+	}, got)
+
+	_, err = DecodeEvent([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	assert.ErrorIs(t, err, ErrUnknownEvent)
+}
+
+func TestErrorCode(t *testing.T) {
+	code, ok := ErrorCodeFromCode(6000)
+	assert.True(t, ok)
+	assert.Equal(t, "Overflow", code.Name())
+	assert.Equal(t, "Overflow: count overflows", code.Error())
+
+	var err error = code
+	assert.True(t, errors.Is(err, ErrorCodeOverflow))
+
+	code, ok = ErrorCodeFromCode(6001)
+	assert.False(t, ok)
+	assert.Equal(t, "custom program error: 0x1771", code.Error())
+}
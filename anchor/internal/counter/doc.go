@@ -0,0 +1,4 @@
+// Package counter is generated from the counter idl of the anchor tests, it keeps the generated code compiling.
+package counter
+
+//go:generate go run ../../../cmd/anchorgen -idl idl.json -pkg counter
@@ -0,0 +1,43 @@
+// Code generated by anchorgen. DO NOT EDIT.
+
+package counter
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/near/borsh-go"
+)
+
+type Incremented struct {
+	Counter common.PublicKey
+	Count   uint64
+}
+
+var IncrementedDiscriminator = [8]uint8{92, 207, 119, 204, 71, 205, 108, 15}
+
+// IncrementedFromData decodes the data of Incremented, the data starts with the discriminator
+func IncrementedFromData(data []byte) (Incremented, error) {
+	if len(data) < len(IncrementedDiscriminator) {
+		return Incremented{}, fmt.Errorf("%w, size: %v", ErrInvalidEventDataSize, len(data))
+	}
+	if !bytes.Equal(data[:len(IncrementedDiscriminator)], IncrementedDiscriminator[:]) {
+		return Incremented{}, fmt.Errorf("%w, discriminator mismatch", ErrInvalidEventData)
+	}
+
+	var incremented Incremented
+	if err := borsh.Deserialize(&incremented, data[len(IncrementedDiscriminator):]); err != nil {
+		return Incremented{}, fmt.Errorf("%w, %v", ErrInvalidEventData, err)
+	}
+	return incremented, nil
+}
+
+// DecodeEvent decodes the data of a "Program data:" log to the event it belongs to
+func DecodeEvent(data []byte) (any, error) {
+	switch {
+	case bytes.HasPrefix(data, IncrementedDiscriminator[:]):
+		return IncrementedFromData(data)
+	}
+	return nil, ErrUnknownEvent
+}
@@ -0,0 +1,35 @@
+// Code generated by anchorgen. DO NOT EDIT.
+
+package counter
+
+import (
+	"github.com/near/borsh-go"
+)
+
+type Config struct {
+	Step uint16
+	Note *string
+	Tags [2]uint8
+}
+
+type Kind struct {
+	Enum     borsh.Enum `borsh_enum:"true"`
+	Simple   struct{}
+	Weighted KindWeightedFields
+	Pair     KindPairFields
+}
+
+const (
+	KindSimple borsh.Enum = iota
+	KindWeighted
+	KindPair
+)
+
+type KindWeightedFields struct {
+	Weight uint8
+}
+
+type KindPairFields struct {
+	Field0 uint8
+	Field1 uint16
+}
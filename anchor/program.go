@@ -0,0 +1,451 @@
+package anchor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/near/borsh-go"
+)
+
+// Program builds instructions and decodes accounts and events of an anchor program from its idl
+type Program struct {
+	ProgramID common.PublicKey
+	Idl       Idl
+}
+
+// NewProgram binds an idl to a program id. the 0.30 idl carries the address, use
+// common.PublicKeyFromString(idl.Address) for it.
+func NewProgram(idl Idl, programID common.PublicKey) Program {
+	return Program{
+		ProgramID: programID,
+		Idl:       idl,
+	}
+}
+
+// wellKnownAccounts fills the accounts the legacy idl doesn't give addresses to
+var wellKnownAccounts = map[string]common.PublicKey{
+	"systemProgram":            common.SystemProgramID,
+	"system_program":           common.SystemProgramID,
+	"tokenProgram":             common.TokenProgramID,
+	"token_program":            common.TokenProgramID,
+	"associatedTokenProgram":   common.SPLAssociatedTokenAccountProgramID,
+	"associated_token_program": common.SPLAssociatedTokenAccountProgramID,
+	"rent":                     common.SysVarRentPubkey,
+	"clock":                    common.SysVarClockPubkey,
+}
+
+// InstructionDef returns the idl instruction, the name can be in camel case or snake case
+func (p Program) InstructionDef(name string) (IdlInstruction, error) {
+	for _, ix := range p.Idl.Instructions {
+		if ix.Name == name || toSnakeCase(ix.Name) == toSnakeCase(name) {
+			return ix, nil
+		}
+	}
+	return IdlInstruction{}, fmt.Errorf("%w, %v", ErrUnknownInstruction, name)
+}
+
+// Instruction builds an instruction from named args and named accounts. accounts with a fixed address,
+// pdas the idl declares and well known programs can be left out. a nested account is named by its
+// dotted path, e.g. "group.account", or its own name.
+func (p Program) Instruction(name string, args map[string]any, accounts map[string]common.PublicKey) (types.Instruction, error) {
+	ix, err := p.InstructionDef(name)
+	if err != nil {
+		return types.Instruction{}, err
+	}
+
+	data := instructionDiscriminator(ix)
+	for _, arg := range ix.Args {
+		v, ok := args[arg.Name]
+		if !ok {
+			return types.Instruction{}, fmt.Errorf("%w, %v", ErrMissingArg, arg.Name)
+		}
+		b, err := p.Idl.Encode(arg.Type, v)
+		if err != nil {
+			return types.Instruction{}, fmt.Errorf("arg %v: %w", arg.Name, err)
+		}
+		data = append(data, b...)
+	}
+
+	resolved, err := p.ResolveAccounts(name, args, accounts)
+	if err != nil {
+		return types.Instruction{}, err
+	}
+	items := flattenAccounts("", ix.Accounts)
+	metas := make([]types.AccountMeta, 0, len(items))
+	for _, item := range items {
+		pubkey := resolved[item.path]
+		if item.Optional && pubkey == p.ProgramID {
+			// anchor takes the program id as a missing optional account
+			metas = append(metas, types.AccountMeta{PubKey: pubkey, IsSigner: false, IsWritable: false})
+			continue
+		}
+		metas = append(metas, types.AccountMeta{PubKey: pubkey, IsSigner: item.Signer, IsWritable: item.Writable})
+	}
+
+	return types.Instruction{
+		ProgramID: p.ProgramID,
+		Accounts:  metas,
+		Data:      data,
+	}, nil
+}
+
+// ResolveAccounts fills the accounts an instruction needs and returns them by dotted path
+func (p Program) ResolveAccounts(name string, args map[string]any, accounts map[string]common.PublicKey) (map[string]common.PublicKey, error) {
+	ix, err := p.InstructionDef(name)
+	if err != nil {
+		return nil, err
+	}
+
+	items := flattenAccounts("", ix.Accounts)
+	resolved := make(map[string]common.PublicKey, len(items))
+	pending := make([]flatAccount, 0, len(items))
+	for _, item := range items {
+		if pubkey, ok := accounts[item.path]; ok {
+			resolved[item.path] = pubkey
+			continue
+		}
+		if pubkey, ok := accounts[item.Name]; ok {
+			resolved[item.path] = pubkey
+			continue
+		}
+		if item.Address != "" {
+			resolved[item.path] = common.PublicKeyFromString(item.Address)
+			continue
+		}
+		// a pda may take e.g. the system program as a seed, fill them before the pdas
+		if pubkey, ok := wellKnownAccounts[item.Name]; ok && item.Pda == nil {
+			resolved[item.path] = pubkey
+			continue
+		}
+		pending = append(pending, item)
+	}
+
+	// a pda can depend on another pda, resolve them until nothing changes
+	for len(pending) > 0 {
+		var unresolved []flatAccount
+		var lastErr error
+		for _, item := range pending {
+			if item.Pda == nil {
+				unresolved = append(unresolved, item)
+				continue
+			}
+			pubkey, err := p.findPda(ix, *item.Pda, args, resolved)
+			if err != nil {
+				lastErr = err
+				unresolved = append(unresolved, item)
+				continue
+			}
+			resolved[item.path] = pubkey
+		}
+		if len(unresolved) == len(pending) {
+			for _, item := range unresolved {
+				if item.Optional {
+					resolved[item.path] = p.ProgramID
+					continue
+				}
+				if lastErr != nil && item.Pda != nil {
+					return nil, fmt.Errorf("%w, %v: %v", ErrMissingAccount, item.path, lastErr)
+				}
+				return nil, fmt.Errorf("%w, %v", ErrMissingAccount, item.path)
+			}
+			break
+		}
+		pending = unresolved
+	}
+
+	return resolved, nil
+}
+
+func (p Program) findPda(ix IdlInstruction, pda IdlPda, args map[string]any, resolved map[string]common.PublicKey) (common.PublicKey, error) {
+	seeds := make([][]byte, 0, len(pda.Seeds))
+	for _, seed := range pda.Seeds {
+		b, err := p.seedBytes(ix, seed, args, resolved)
+		if err != nil {
+			return common.PublicKey{}, err
+		}
+		seeds = append(seeds, b)
+	}
+
+	programID := p.ProgramID
+	if pda.Program != nil {
+		b, err := p.seedBytes(ix, *pda.Program, args, resolved)
+		if err != nil {
+			return common.PublicKey{}, err
+		}
+		if len(b) != 32 {
+			return common.PublicKey{}, fmt.Errorf("%w, program of a pda has %v bytes", ErrUnresolvableSeed, len(b))
+		}
+		programID = common.PublicKeyFromBytes(b)
+	}
+
+	pubkey, _, err := common.FindProgramAddress(seeds, programID)
+	if err != nil {
+		return common.PublicKey{}, err
+	}
+	return pubkey, nil
+}
+
+func (p Program) seedBytes(ix IdlInstruction, seed IdlSeed, args map[string]any, resolved map[string]common.PublicKey) ([]byte, error) {
+	switch seed.Kind {
+	case IdlSeedKindConst:
+		if seed.Type == nil {
+			var b []byte
+			if err := json.Unmarshal(seed.Value, &b); err != nil {
+				return nil, fmt.Errorf("%w, const seed: %s", ErrUnresolvableSeed, seed.Value)
+			}
+			return b, nil
+		}
+		d := json.NewDecoder(bytes.NewReader(seed.Value))
+		d.UseNumber()
+		var v any
+		if err := d.Decode(&v); err != nil {
+			return nil, fmt.Errorf("%w, const seed: %s", ErrUnresolvableSeed, seed.Value)
+		}
+		return p.seedValueBytes(*seed.Type, v)
+	case IdlSeedKindArg:
+		parts := strings.Split(seed.Path, ".")
+		var t *IdlType
+		for _, arg := range ix.Args {
+			if arg.Name == parts[0] {
+				t = &arg.Type
+				break
+			}
+		}
+		v, ok := args[parts[0]]
+		if !ok || t == nil {
+			return nil, fmt.Errorf("%w, arg %v", ErrUnresolvableSeed, seed.Path)
+		}
+		for _, part := range parts[1:] {
+			def, ok := p.Idl.TypeDef(t.Defined)
+			if !ok {
+				return nil, fmt.Errorf("%w, arg %v", ErrUnresolvableSeed, seed.Path)
+			}
+			t = nil
+			for _, field := range def.Fields.Named {
+				if field.Name == part {
+					t = &field.Type
+					break
+				}
+			}
+			if t == nil {
+				return nil, fmt.Errorf("%w, arg %v", ErrUnresolvableSeed, seed.Path)
+			}
+			if v, ok = fieldValue(v, part); !ok {
+				return nil, fmt.Errorf("%w, arg %v", ErrUnresolvableSeed, seed.Path)
+			}
+		}
+		if seed.Type != nil {
+			t = seed.Type
+		}
+		return p.seedValueBytes(*t, v)
+	case IdlSeedKindAccount:
+		if pubkey, ok := resolved[seed.Path]; ok {
+			return pubkey.Bytes(), nil
+		}
+		// the seed is a field of the account data or an account not resolved yet
+		return nil, fmt.Errorf("%w, account %v", ErrUnresolvableSeed, seed.Path)
+	}
+	return nil, fmt.Errorf("%w, kind: %v", ErrUnresolvableSeed, seed.Kind)
+}
+
+// seedValueBytes encodes a seed, strings and bytes are taken as they are without the length prefix
+func (p Program) seedValueBytes(t IdlType, v any) ([]byte, error) {
+	switch t.Primitive {
+	case "string":
+		if s, ok := v.(string); ok {
+			return []byte(s), nil
+		}
+	case "bytes":
+		if b, ok := v.([]byte); ok {
+			return b, nil
+		}
+	}
+	return p.Idl.Encode(t, v)
+}
+
+type flatAccount struct {
+	IdlAccountItem
+	path string
+}
+
+func flattenAccounts(prefix string, items []IdlAccountItem) []flatAccount {
+	var accounts []flatAccount
+	for _, item := range items {
+		path := item.Name
+		if prefix != "" {
+			path = prefix + "." + item.Name
+		}
+		if len(item.Accounts) > 0 {
+			accounts = append(accounts, flattenAccounts(path, item.Accounts)...)
+			continue
+		}
+		accounts = append(accounts, flatAccount{IdlAccountItem: item, path: path})
+	}
+	return accounts
+}
+
+func instructionDiscriminator(ix IdlInstruction) []byte {
+	if len(ix.Discriminator) > 0 {
+		return append([]byte{}, ix.Discriminator...)
+	}
+	discriminator := InstructionDiscriminator(ix.Name)
+	return discriminator[:]
+}
+
+// DecodeInstruction decodes the data of an instruction to its name and args
+func (p Program) DecodeInstruction(data []byte) (string, map[string]any, error) {
+	for _, ix := range p.Idl.Instructions {
+		discriminator := instructionDiscriminator(ix)
+		if !bytes.HasPrefix(data, discriminator) {
+			continue
+		}
+		args := make(map[string]any, len(ix.Args))
+		offset := len(discriminator)
+		for _, arg := range ix.Args {
+			v, n, err := p.Idl.Decode(arg.Type, data[offset:])
+			if err != nil {
+				return "", nil, fmt.Errorf("arg %v: %w", arg.Name, err)
+			}
+			args[arg.Name] = v
+			offset += n
+		}
+		return ix.Name, args, nil
+	}
+	return "", nil, fmt.Errorf("%w, instruction", ErrUnknownDiscriminator)
+}
+
+func accountDiscriminator(account IdlAccountDef) []byte {
+	if len(account.Discriminator) > 0 {
+		return account.Discriminator
+	}
+	discriminator := AccountDiscriminator(account.Name)
+	return discriminator[:]
+}
+
+// DecodeAccount finds the account type by its discriminator and decodes the data to a map
+func (p Program) DecodeAccount(data []byte) (string, map[string]any, error) {
+	for _, account := range p.Idl.Accounts {
+		discriminator := accountDiscriminator(account)
+		if !bytes.HasPrefix(data, discriminator) {
+			continue
+		}
+		def, ok := p.Idl.TypeDef(account.Name)
+		if !ok {
+			return "", nil, fmt.Errorf("%w, %v", ErrUnknownType, account.Name)
+		}
+		d := decoder{idl: &p.Idl, data: data, pos: len(discriminator)}
+		v, err := d.decodeFields(def.Fields)
+		if err != nil {
+			return "", nil, err
+		}
+		m, _ := v.(map[string]any)
+		return account.Name, m, nil
+	}
+	return "", nil, fmt.Errorf("%w, account", ErrUnknownDiscriminator)
+}
+
+// UnmarshalAccount checks the discriminator of the account type and borsh decodes the rest into v,
+// the fields of v have to be in the order of the idl
+func (p Program) UnmarshalAccount(name string, data []byte, v any) error {
+	for _, account := range p.Idl.Accounts {
+		if account.Name != name {
+			continue
+		}
+		discriminator := accountDiscriminator(account)
+		if !bytes.HasPrefix(data, discriminator) {
+			return fmt.Errorf("%w, data is not a %v", ErrUnknownDiscriminator, name)
+		}
+		if err := borsh.Deserialize(v, data[len(discriminator):]); err != nil {
+			return fmt.Errorf("%w, %v", ErrInvalidAccountData, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w, %v", ErrUnknownType, name)
+}
+
+type Event struct {
+	Name string
+	Data map[string]any
+}
+
+// DecodeEvent decodes the data of a "Program data:" log
+func (p Program) DecodeEvent(data []byte) (Event, error) {
+	for _, event := range p.Idl.Events {
+		discriminator := event.Discriminator
+		if len(discriminator) == 0 {
+			d := EventDiscriminator(event.Name)
+			discriminator = d[:]
+		}
+		if !bytes.HasPrefix(data, discriminator) {
+			continue
+		}
+
+		fields := IdlDefinedFields{Named: event.Fields}
+		if len(event.Fields) == 0 {
+			def, ok := p.Idl.TypeDef(event.Name)
+			if !ok {
+				return Event{}, fmt.Errorf("%w, %v", ErrUnknownType, event.Name)
+			}
+			fields = def.Fields
+		}
+		d := decoder{idl: &p.Idl, data: data, pos: len(discriminator)}
+		v, err := d.decodeFields(fields)
+		if err != nil {
+			return Event{}, err
+		}
+		m, _ := v.(map[string]any)
+		return Event{Name: event.Name, Data: m}, nil
+	}
+	return Event{}, fmt.Errorf("%w, event", ErrUnknownDiscriminator)
+}
+
+// DecodeEventsFromLogs decodes the events the program emits in the logs of a transaction.
+// only "Program data:" logs printed while the program is executing are taken.
+func (p Program) DecodeEventsFromLogs(logs []string) ([]Event, error) {
+	const (
+		programDataPrefix = "Program data: "
+		programPrefix     = "Program "
+	)
+	programID := p.ProgramID.ToBase58()
+
+	var events []Event
+	var stack []string
+	for _, log := range logs {
+		switch {
+		case strings.HasPrefix(log, programDataPrefix):
+			if len(stack) == 0 || stack[len(stack)-1] != programID {
+				continue
+			}
+			data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(log, programDataPrefix))
+			if err != nil {
+				continue
+			}
+			event, err := p.DecodeEvent(data)
+			if err != nil {
+				// events of other types can be emitted by the same program, e.g. by a library
+				continue
+			}
+			events = append(events, event)
+		case strings.HasPrefix(log, programPrefix):
+			fields := strings.Fields(log)
+			// skip "Program log:", "Program return:" and so on
+			if len(fields) < 3 || strings.HasSuffix(fields[1], ":") {
+				continue
+			}
+			switch {
+			case fields[2] == "invoke":
+				stack = append(stack, fields[1])
+			case fields[2] == "success" || fields[2] == "failed:":
+				if len(stack) > 0 {
+					stack = stack[:len(stack)-1]
+				}
+			}
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,306 @@
+package anchor
+
+import (
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/near/borsh-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func testPrograms(t *testing.T) map[string]Program {
+	programs := map[string]Program{}
+	for name, s := range map[string]string{"legacy": testLegacyIdl, "0.30": testIdl} {
+		idl, err := ParseIdl([]byte(s))
+		assert.Nil(t, err)
+		programs[name] = NewProgram(idl, common.PublicKeyFromString(testProgramID))
+	}
+	return programs
+}
+
+func TestProgram_Instruction(t *testing.T) {
+	type args struct {
+		name     string
+		args     map[string]any
+		accounts map[string]common.PublicKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+		err  error
+	}{
+		{
+			name: "pda and system program are resolved",
+			args: args{
+				name: "initialize",
+				args: map[string]any{
+					"name": "fox",
+					"kind": EnumValue{Variant: "Weighted", Fields: map[string]any{"weight": 3}},
+				},
+				accounts: map[string]common.PublicKey{
+					"authority": common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.PublicKeyFromString(testProgramID),
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("AXLCELKbBgzLZHBVr6kVn8DoWgsySDfX4LGinyQ7dYB1"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{175, 175, 109, 31, 13, 152, 155, 237, 3, 0, 0, 0, 102, 111, 120, 1, 3},
+			},
+		},
+		{
+			name: "nested accounts and a missing optional account",
+			args: args{
+				name: "incrementBy",
+				args: map[string]any{
+					"amount": uint64(5),
+					"config": map[string]any{"step": 1, "note": nil, "tags": [2]uint8{7, 8}},
+				},
+				accounts: map[string]common.PublicKey{
+					"counter":        common.PublicKeyFromString("AXLCELKbBgzLZHBVr6kVn8DoWgsySDfX4LGinyQ7dYB1"),
+					"auth.authority": common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.PublicKeyFromString(testProgramID),
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("AXLCELKbBgzLZHBVr6kVn8DoWgsySDfX4LGinyQ7dYB1"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString(testProgramID), IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{103, 82, 124, 55, 231, 50, 146, 138, 5, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 7, 8},
+			},
+		},
+		{
+			name: "optional account given by its own name",
+			args: args{
+				name: "increment_by",
+				args: map[string]any{
+					"amount": uint64(5),
+					"config": map[string]any{"step": 1, "note": nil, "tags": [2]uint8{7, 8}},
+				},
+				accounts: map[string]common.PublicKey{
+					"counter":   common.PublicKeyFromString("AXLCELKbBgzLZHBVr6kVn8DoWgsySDfX4LGinyQ7dYB1"),
+					"authority": common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					"delegate":  common.PublicKeyFromString("de1egate11111111111111111111111111111111111"),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.PublicKeyFromString(testProgramID),
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("AXLCELKbBgzLZHBVr6kVn8DoWgsySDfX4LGinyQ7dYB1"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("de1egate11111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{103, 82, 124, 55, 231, 50, 146, 138, 5, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 7, 8},
+			},
+		},
+		{
+			name: "pda seed account is missing",
+			args: args{
+				name: "initialize",
+				args: map[string]any{"name": "fox", "kind": "Simple"},
+			},
+			err: ErrMissingAccount,
+		},
+		{
+			name: "missing arg",
+			args: args{
+				name:     "initialize",
+				args:     map[string]any{"name": "fox"},
+				accounts: map[string]common.PublicKey{"authority": common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")},
+			},
+			err: ErrMissingArg,
+		},
+		{
+			name: "unknown instruction",
+			args: args{name: "decrement"},
+			err:  ErrUnknownInstruction,
+		},
+	}
+	for name, program := range testPrograms(t) {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				got, err := program.Instruction(tt.args.name, tt.args.args, tt.args.accounts)
+				assert.ErrorIs(t, err, tt.err)
+				assert.Equal(t, tt.want, got)
+			})
+		}
+	}
+}
+
+func TestProgram_ResolveAccounts_WellKnownSeed(t *testing.T) {
+	idl, err := ParseIdl([]byte(`{
+  "version": "0.1.0",
+  "name": "vault",
+  "instructions": [
+    {
+      "name": "open",
+      "accounts": [
+        {
+          "name": "vault",
+          "isMut": true,
+          "isSigner": false,
+          "pda": {
+            "seeds": [
+              {"kind": "const", "type": "string", "value": "vault"},
+              {"kind": "account", "type": "publicKey", "path": "systemProgram"}
+            ]
+          }
+        },
+        {"name": "systemProgram", "isMut": false, "isSigner": false}
+      ],
+      "args": []
+    }
+  ]
+}`))
+	assert.NoError(t, err)
+	programID := common.PublicKeyFromString(testProgramID)
+	vault, _, err := common.FindProgramAddress([][]byte{[]byte("vault"), common.SystemProgramID.Bytes()}, programID)
+	assert.NoError(t, err)
+
+	got, err := NewProgram(idl, programID).ResolveAccounts("open", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]common.PublicKey{"vault": vault, "systemProgram": common.SystemProgramID}, got)
+}
+
+func TestProgram_DecodeInstruction(t *testing.T) {
+	for name, program := range testPrograms(t) {
+		t.Run(name, func(t *testing.T) {
+			gotName, gotArgs, err := program.DecodeInstruction([]byte{175, 175, 109, 31, 13, 152, 155, 237, 3, 0, 0, 0, 102, 111, 120, 1, 3})
+			assert.Nil(t, err)
+			assert.Equal(t, "initialize", gotName)
+			assert.Equal(t, map[string]any{
+				"name": "fox",
+				"kind": EnumValue{Variant: "Weighted", Fields: map[string]any{"weight": uint8(3)}},
+			}, gotArgs)
+
+			_, _, err = program.DecodeInstruction([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+			assert.ErrorIs(t, err, ErrUnknownDiscriminator)
+		})
+	}
+}
+
+func testCounterAccountData() []byte {
+	data := []byte{255, 176, 4, 245, 188, 253, 124, 25}
+	data = append(data, common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7").Bytes()...)
+	// name
+	data = append(data, 3, 0, 0, 0, 102, 111, 120)
+	// count
+	data = append(data, 7, 0, 0, 0, 0, 0, 0, 0)
+	// kind
+	data = append(data, 2, 1, 3, 2)
+	// history
+	data = append(data, 2, 0, 0, 0, 255, 255, 2, 0)
+	// total, 1 << 70
+	data = append(data, 0, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 0)
+	return data
+}
+
+func TestProgram_DecodeAccount(t *testing.T) {
+	for name, program := range testPrograms(t) {
+		t.Run(name, func(t *testing.T) {
+			gotName, got, err := program.DecodeAccount(testCounterAccountData())
+			assert.Nil(t, err)
+			assert.Equal(t, "Counter", gotName)
+			assert.Equal(t, map[string]any{
+				"authority": common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				"name":      "fox",
+				"count":     uint64(7),
+				"kind":      EnumValue{Variant: "Pair", Fields: []any{uint8(1), uint16(515)}},
+				"history":   []any{int16(-1), int16(2)},
+				"total":     new(big.Int).Lsh(big.NewInt(1), 70),
+			}, got)
+
+			_, _, err = program.DecodeAccount(make([]byte, 8))
+			assert.ErrorIs(t, err, ErrUnknownDiscriminator)
+
+			data := testCounterAccountData()
+			_, _, err = program.DecodeAccount(data[:len(data)-1])
+			assert.ErrorIs(t, err, ErrInvalidAccountDataSize)
+		})
+	}
+}
+
+func TestProgram_UnmarshalAccount(t *testing.T) {
+	type Counter struct {
+		Authority common.PublicKey
+		Name      string
+		Count     uint64
+		Kind      struct {
+			Enum     borsh.Enum `borsh_enum:"true"`
+			Simple   struct{}
+			Weighted struct{ Weight uint8 }
+			Pair     struct {
+				A uint8
+				B uint16
+			}
+		}
+		History []int16
+		Total   [16]uint8
+	}
+	for name, program := range testPrograms(t) {
+		t.Run(name, func(t *testing.T) {
+			var counter Counter
+			err := program.UnmarshalAccount("Counter", testCounterAccountData(), &counter)
+			assert.Nil(t, err)
+			assert.Equal(t, common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), counter.Authority)
+			assert.Equal(t, "fox", counter.Name)
+			assert.Equal(t, uint64(7), counter.Count)
+			assert.Equal(t, borsh.Enum(2), counter.Kind.Enum)
+			assert.Equal(t, uint16(515), counter.Kind.Pair.B)
+			assert.Equal(t, []int16{-1, 2}, counter.History)
+
+			err = program.UnmarshalAccount("Counter", make([]byte, 8), &counter)
+			assert.ErrorIs(t, err, ErrUnknownDiscriminator)
+
+			err = program.UnmarshalAccount("Other", testCounterAccountData(), &counter)
+			assert.ErrorIs(t, err, ErrUnknownType)
+		})
+	}
+}
+
+func TestProgram_DecodeEventsFromLogs(t *testing.T) {
+	data := []byte{92, 207, 119, 204, 71, 205, 108, 15}
+	data = append(data, common.PublicKeyFromString("AXLCELKbBgzLZHBVr6kVn8DoWgsySDfX4LGinyQ7dYB1").Bytes()...)
+	data = append(data, 8, 0, 0, 0, 0, 0, 0, 0)
+	event := base64.StdEncoding.EncodeToString(data)
+
+	logs := []string{
+		"Program " + testProgramID + " invoke [1]",
+		"Program log: Instruction: IncrementBy",
+		"Program data: " + event,
+		"Program 11111111111111111111111111111111 invoke [2]",
+		// emitted by another program
+		"Program data: " + event,
+		"Program 11111111111111111111111111111111 success",
+		// unknown discriminator
+		"Program data: AQIDBAUGBwg=",
+		"Program " + testProgramID + " consumed 5000 of 200000 compute units",
+		"Program " + testProgramID + " success",
+		// outside of the program
+		"Program data: " + event,
+	}
+	for name, program := range testPrograms(t) {
+		t.Run(name, func(t *testing.T) {
+			got, err := program.DecodeEventsFromLogs(logs)
+			assert.Nil(t, err)
+			assert.Equal(t, []Event{
+				{
+					Name: "Incremented",
+					Data: map[string]any{
+						"counter": common.PublicKeyFromString("AXLCELKbBgzLZHBVr6kVn8DoWgsySDfX4LGinyQ7dYB1"),
+						"count":   uint64(8),
+					},
+				},
+			}, got)
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/address_lookup_table"
+	"github.com/EntySquare/solana-go-sdk/program/stake"
+	"github.com/EntySquare/solana-go-sdk/program/system"
+	"github.com/EntySquare/solana-go-sdk/program/token"
+)
+
+// AccountDecoder turns an account into a typed value, e.g. token.TokenAccount
+type AccountDecoder func(AccountInfo) (any, error)
+
+// DecodeData makes an AccountDecoder from a deserializer of the account data
+func DecodeData[T any](deserialize func([]byte) (T, error)) AccountDecoder {
+	return func(account AccountInfo) (any, error) {
+		return deserialize(account.Data)
+	}
+}
+
+// DecodeDataWithOwner makes an AccountDecoder from a deserializer which checks the owner of the account
+func DecodeDataWithOwner[T any](deserialize func([]byte, common.PublicKey) (T, error)) AccountDecoder {
+	return func(account AccountInfo) (any, error) {
+		return deserialize(account.Data, account.Owner)
+	}
+}
+
+var (
+	TokenAccountDecoder = DecodeData(token.TokenAccountFromData)
+	MintAccountDecoder  = DecodeData(token.MintAccountFromData)
+	NonceAccountDecoder = DecodeData(system.NonceAccountDeserialize)
+	LookupTableDecoder  = DecodeDataWithOwner(address_lookup_table.DeserializeLookupTable)
+	StakeAccountDecoder = DecodeDataWithOwner(stake.DeserializeStakeAccount)
+	// RawAccountDecoder keeps the AccountInfo as it is
+	RawAccountDecoder AccountDecoder = func(account AccountInfo) (any, error) { return account, nil }
+)
+
+const (
+	defaultPollInterval = time.Second
+	// defaultPollBatchSize is the most accounts getMultipleAccounts takes
+	defaultPollBatchSize = 100
+)
+
+// AccountUpdate is what an AccountSource has observed at a slot. a nil account doesn't exist.
+type AccountUpdate struct {
+	Slot     uint64
+	Accounts map[common.PublicKey]*AccountInfo
+	// Err is a failure of the source, e.g. a failed poll, the watcher goes on
+	Err error
+}
+
+// AccountSource delivers the updates of the watched accounts until the context is done,
+// e.g. by polling or by a subscription. addresses returns the accounts which are watched right now.
+type AccountSource interface {
+	Run(ctx context.Context, addresses func() []common.PublicKey, deliver func(AccountUpdate)) error
+}
+
+// PollingAccountSource fetches the watched accounts with getMultipleAccounts in batches
+type PollingAccountSource struct {
+	client    *Client
+	interval  time.Duration
+	batchSize int
+	config    GetMultipleAccountsConfig
+}
+
+type PollingAccountSourceOption func(*PollingAccountSource)
+
+// WithPollInterval is a PollingAccountSourceOption that sets how often the accounts are fetched, default 1s.
+// a non positive interval keeps the default.
+func WithPollInterval(interval time.Duration) PollingAccountSourceOption {
+	return func(s *PollingAccountSource) {
+		s.interval = interval
+	}
+}
+
+// WithPollBatchSize is a PollingAccountSourceOption that sets how many accounts a request fetches, default 100.
+// a non positive size keeps the default.
+func WithPollBatchSize(batchSize int) PollingAccountSourceOption {
+	return func(s *PollingAccountSource) {
+		s.batchSize = batchSize
+	}
+}
+
+// WithPollConfig is a PollingAccountSourceOption that sets the config of getMultipleAccounts, e.g. the commitment
+func WithPollConfig(cfg GetMultipleAccountsConfig) PollingAccountSourceOption {
+	return func(s *PollingAccountSource) {
+		s.config = cfg
+	}
+}
+
+func NewPollingAccountSource(c *Client, opts ...PollingAccountSourceOption) *PollingAccountSource {
+	s := &PollingAccountSource{
+		client:    c,
+		interval:  defaultPollInterval,
+		batchSize: defaultPollBatchSize,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.interval <= 0 {
+		s.interval = defaultPollInterval
+	}
+	if s.batchSize <= 0 {
+		s.batchSize = defaultPollBatchSize
+	}
+	return s
+}
+
+func (s *PollingAccountSource) Run(ctx context.Context, addresses func() []common.PublicKey, deliver func(AccountUpdate)) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.poll(ctx, addresses(), deliver)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll delivers every batch as an update since the batches may be at different slots
+func (s *PollingAccountSource) poll(ctx context.Context, addresses []common.PublicKey, deliver func(AccountUpdate)) {
+	for start := 0; start < len(addresses); start += s.batchSize {
+		end := start + s.batchSize
+		if end > len(addresses) {
+			end = len(addresses)
+		}
+		batch := addresses[start:end]
+		addrs := make([]string, 0, len(batch))
+		for _, address := range batch {
+			addrs = append(addrs, address.ToBase58())
+		}
+		res, err := s.client.GetMultipleAccountsAndContextWithConfig(ctx, addrs, s.config)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			deliver(AccountUpdate{Err: err})
+			continue
+		}
+		update := AccountUpdate{Slot: res.Context.Slot, Accounts: map[common.PublicKey]*AccountInfo{}}
+		for i, address := range batch {
+			update.Accounts[address] = nil
+			if i < len(res.Value) && res.Value[i].Lamports > 0 {
+				account := res.Value[i]
+				update.Accounts[address] = &account
+			}
+		}
+		deliver(update)
+	}
+}
+
+// AccountChange is a change of a watched account. Old and New are the decoded values,
+// nil if the account doesn't exist, Old is also nil the first time the account is seen.
+type AccountChange struct {
+	Address    common.PublicKey
+	Slot       uint64
+	Old        any
+	New        any
+	OldAccount *AccountInfo
+	NewAccount *AccountInfo
+	// Err is the error of the decoder, New is nil then
+	Err error
+}
+
+// AccountWatcher emits the changes of the watched accounts. an update older than the last one
+// of an account is dropped, so a lagging node can't roll an account back.
+type AccountWatcher struct {
+	source       AccountSource
+	errorHandler func(error)
+
+	mu       sync.Mutex
+	accounts map[common.PublicKey]*watchedAccount
+	order    []common.PublicKey
+
+	// deliverMu makes the handler see the changes one at a time
+	deliverMu sync.Mutex
+}
+
+type watchedAccount struct {
+	decoder AccountDecoder
+	seen    bool
+	slot    uint64
+	account *AccountInfo
+	value   any
+}
+
+type AccountWatcherOption func(*AccountWatcher)
+
+// WithAccountSource is an AccountWatcherOption that replaces the default source, a PollingAccountSource
+func WithAccountSource(source AccountSource) AccountWatcherOption {
+	return func(w *AccountWatcher) {
+		w.source = source
+	}
+}
+
+// WithAccountErrorHandler is an AccountWatcherOption that receives the errors of the source,
+// they are dropped by default
+func WithAccountErrorHandler(handler func(error)) AccountWatcherOption {
+	return func(w *AccountWatcher) {
+		w.errorHandler = handler
+	}
+}
+
+// NewAccountWatcher makes a watcher which polls the accounts unless WithAccountSource is given
+func (c *Client) NewAccountWatcher(opts ...AccountWatcherOption) *AccountWatcher {
+	w := &AccountWatcher{accounts: map[common.PublicKey]*watchedAccount{}}
+	for _, opt := range opts {
+		opt(w)
+	}
+	if w.source == nil {
+		w.source = NewPollingAccountSource(c)
+	}
+	return w
+}
+
+// Watch starts watching an account, the decoder of an already watched account is replaced
+// and the account is seen as new again
+func (w *AccountWatcher) Watch(address common.PublicKey, decoder AccountDecoder) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.accounts[address]; !ok {
+		w.order = append(w.order, address)
+	}
+	w.accounts[address] = &watchedAccount{decoder: decoder}
+}
+
+func (w *AccountWatcher) Unwatch(address common.PublicKey) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.accounts[address]; !ok {
+		return
+	}
+	delete(w.accounts, address)
+	for i, a := range w.order {
+		if a == address {
+			w.order = append(w.order[:i:i], w.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (w *AccountWatcher) addresses() []common.PublicKey {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]common.PublicKey{}, w.order...)
+}
+
+// Run watches the accounts until the context is done, the handler gets the changes one at a time
+func (w *AccountWatcher) Run(ctx context.Context, handler func(AccountChange)) error {
+	return w.source.Run(ctx, w.addresses, func(update AccountUpdate) {
+		w.deliverMu.Lock()
+		defer w.deliverMu.Unlock()
+		if update.Err != nil {
+			if w.errorHandler != nil {
+				w.errorHandler(update.Err)
+			}
+			return
+		}
+		for _, change := range w.apply(update) {
+			handler(change)
+		}
+	})
+}
+
+func (w *AccountWatcher) apply(update AccountUpdate) []AccountChange {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var changes []AccountChange
+	for _, address := range w.order {
+		account, ok := update.Accounts[address]
+		if !ok {
+			continue
+		}
+		watched := w.accounts[address]
+		if watched.seen && update.Slot < watched.slot {
+			continue
+		}
+		first := !watched.seen
+		watched.seen = true
+		watched.slot = update.Slot
+		if sameAccount(watched.account, account) && !(first && account != nil) {
+			continue
+		}
+
+		change := AccountChange{
+			Address:    address,
+			Slot:       update.Slot,
+			Old:        watched.value,
+			OldAccount: watched.account,
+			NewAccount: account,
+		}
+		watched.account = account
+		watched.value = nil
+		if account != nil {
+			watched.value, change.Err = watched.decoder(*account)
+			if change.Err != nil {
+				watched.value = nil
+			}
+		}
+		change.New = watched.value
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func sameAccount(a, b *AccountInfo) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Lamports == b.Lamports &&
+		a.Owner == b.Owner &&
+		a.Executable == b.Executable &&
+		a.RentEpoch == b.RentEpoch &&
+		bytes.Equal(a.Data, b.Data)
+}
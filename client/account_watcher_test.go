@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/system"
+	"github.com/EntySquare/solana-go-sdk/program/token"
+	"github.com/EntySquare/solana-go-sdk/rpc/rpctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	watchedMint  = common.PublicKeyFromString("So11111111111111111111111111111111111111112")
+	watchedOwner = common.PublicKeyFromString("RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+	watchedToken = common.PublicKeyFromString("BGEqZEJRPxHKTLbjwYoTnzY4PqmwvHMjZiFVGPDkN7T7")
+	watchedNonce = common.PublicKeyFromString("9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g")
+	watchedOther = common.PublicKeyFromString("CFxq6YEwwZMY3a1HwR8tjM5ZkKgWxXEQ7QdjLjTkbFho")
+)
+
+func tokenAccountData(amount uint64) []byte {
+	b := make([]byte, token.TokenAccountSize)
+	copy(b, watchedMint.Bytes())
+	copy(b[32:], watchedOwner.Bytes())
+	binary.LittleEndian.PutUint64(b[64:], amount)
+	b[108] = 1
+	return b
+}
+
+func nonceAccountData(nonce common.PublicKey) []byte {
+	b := make([]byte, system.NonceAccountSize)
+	binary.LittleEndian.PutUint32(b, system.NonceVersionCurrent)
+	binary.LittleEndian.PutUint32(b[4:], system.NonceStateInitialized)
+	copy(b[8:], watchedOwner.Bytes())
+	copy(b[40:], nonce.Bytes())
+	binary.LittleEndian.PutUint64(b[72:], 5000)
+	return b
+}
+
+func TestAccountWatcher(t *testing.T) {
+	server := rpctest.NewServer(
+		rpctest.WithAccount(watchedToken, rpctest.Account{Lamports: 2039280, Owner: common.TokenProgramID, Data: tokenAccountData(1)}),
+		rpctest.WithAccount(watchedNonce, rpctest.Account{Lamports: 1447680, Owner: common.SystemProgramID, Data: nonceAccountData(watchedMint)}),
+	)
+	defer server.Close()
+
+	c := NewClient(server.URL)
+	w := c.NewAccountWatcher(WithAccountSource(NewPollingAccountSource(c, WithPollInterval(10*time.Millisecond), WithPollBatchSize(2))))
+	w.Watch(watchedToken, TokenAccountDecoder)
+	w.Watch(watchedNonce, NonceAccountDecoder)
+	w.Watch(watchedOther, RawAccountDecoder)
+
+	changes := make(chan AccountChange, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- w.Run(ctx, func(change AccountChange) { changes <- change })
+	}()
+	next := func() AccountChange {
+		select {
+		case change := <-changes:
+			return change
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "no change")
+			return AccountChange{}
+		}
+	}
+
+	// the accounts are new, the missing one isn't a change
+	change := next()
+	assert.Equal(t, watchedToken, change.Address)
+	assert.Nil(t, change.Old)
+	assert.Equal(t, uint64(1), change.New.(token.TokenAccount).Amount)
+	change = next()
+	assert.Equal(t, watchedNonce, change.Address)
+	assert.Equal(t, watchedMint, change.New.(system.NonceAccount).Nonce)
+
+	server.AdvanceSlot(1)
+	server.SetAccount(watchedToken, rpctest.Account{Lamports: 2039280, Owner: common.TokenProgramID, Data: tokenAccountData(2)})
+	change = next()
+	assert.Equal(t, watchedToken, change.Address)
+	assert.Equal(t, server.Slot(), change.Slot)
+	assert.Equal(t, uint64(1), change.Old.(token.TokenAccount).Amount)
+	assert.Equal(t, uint64(2), change.New.(token.TokenAccount).Amount)
+	assert.Equal(t, tokenAccountData(1), change.OldAccount.Data)
+
+	server.SetAccount(watchedOther, rpctest.Account{Lamports: 1, Owner: common.SystemProgramID})
+	change = next()
+	assert.Equal(t, watchedOther, change.Address)
+	assert.Nil(t, change.Old)
+	assert.Equal(t, uint64(1), change.New.(AccountInfo).Lamports)
+
+	w.Unwatch(watchedOther)
+	server.DeleteAccount(watchedOther)
+	server.DeleteAccount(watchedToken)
+	change = next()
+	assert.Equal(t, watchedToken, change.Address)
+	assert.Equal(t, uint64(2), change.Old.(token.TokenAccount).Amount)
+	assert.Nil(t, change.New)
+	assert.Nil(t, change.NewAccount)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Empty(t, changes)
+}
+
+// scriptedSource delivers the updates once
+type scriptedSource []AccountUpdate
+
+func (s scriptedSource) Run(ctx context.Context, addresses func() []common.PublicKey, deliver func(AccountUpdate)) error {
+	for _, update := range s {
+		deliver(update)
+	}
+	return nil
+}
+
+func TestAccountWatcher_Updates(t *testing.T) {
+	account := func(data []byte) *AccountInfo {
+		return &AccountInfo{Lamports: 2039280, Owner: common.TokenProgramID, Data: data}
+	}
+	failure := errors.New("failed to poll")
+	var errs []error
+	w := (&Client{}).NewAccountWatcher(
+		WithAccountSource(scriptedSource{
+			{Slot: 5, Accounts: map[common.PublicKey]*AccountInfo{watchedToken: account(tokenAccountData(1)), watchedOther: account(nil)}},
+			// a lagging node
+			{Slot: 4, Accounts: map[common.PublicKey]*AccountInfo{watchedToken: account(tokenAccountData(9))}},
+			{Err: failure},
+			{Slot: 5, Accounts: map[common.PublicKey]*AccountInfo{watchedToken: account(tokenAccountData(1))}},
+			{Slot: 6, Accounts: map[common.PublicKey]*AccountInfo{watchedToken: account([]byte{1})}},
+			{Slot: 7, Accounts: map[common.PublicKey]*AccountInfo{watchedToken: account(tokenAccountData(3))}},
+		}),
+		WithAccountErrorHandler(func(err error) { errs = append(errs, err) }),
+	)
+	w.Watch(watchedToken, TokenAccountDecoder)
+
+	var changes []AccountChange
+	assert.NoError(t, w.Run(context.Background(), func(change AccountChange) { changes = append(changes, change) }))
+	assert.Equal(t, []error{failure}, errs)
+	require.Len(t, changes, 3)
+
+	assert.Equal(t, uint64(5), changes[0].Slot)
+	assert.Equal(t, uint64(1), changes[0].New.(token.TokenAccount).Amount)
+
+	assert.Equal(t, uint64(6), changes[1].Slot)
+	assert.ErrorIs(t, changes[1].Err, token.ErrInvalidAccountDataSize)
+	assert.Equal(t, uint64(1), changes[1].Old.(token.TokenAccount).Amount)
+	assert.Nil(t, changes[1].New)
+
+	assert.Equal(t, uint64(7), changes[2].Slot)
+	assert.Nil(t, changes[2].Old)
+	assert.Equal(t, uint64(3), changes[2].New.(token.TokenAccount).Amount)
+}
+
+func TestNewPollingAccountSource(t *testing.T) {
+	server := rpctest.NewServer(
+		rpctest.WithAccount(watchedToken, rpctest.Account{Lamports: 2039280, Owner: common.TokenProgramID, Data: tokenAccountData(1)}),
+	)
+	defer server.Close()
+	c := NewClient(server.URL)
+
+	for _, n := range []int{0, -1} {
+		s := NewPollingAccountSource(c, WithPollInterval(time.Duration(n)), WithPollBatchSize(n))
+		assert.Equal(t, defaultPollInterval, s.interval)
+		assert.Equal(t, defaultPollBatchSize, s.batchSize)
+
+		// a poll ends and Run doesn't panic
+		var updates []AccountUpdate
+		s.poll(context.Background(), []common.PublicKey{watchedToken, watchedOther}, func(u AccountUpdate) { updates = append(updates, u) })
+		require.Len(t, updates, 1)
+		assert.Len(t, updates[0].Accounts, 2)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := s.Run(ctx, func() []common.PublicKey { return []common.PublicKey{watchedToken} }, func(AccountUpdate) {})
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+}
@@ -253,3 +253,23 @@ func convertToIntSlice(input []any) ([]int, error) {
 	}
 	return output, nil
 }
+
+// sendInstructions builds a transaction with the latest blockhash, signs and sends it
+func (c *Client) sendInstructions(ctx context.Context, feePayer common.PublicKey, signers []types.Account, instructions []types.Instruction) (string, error) {
+	recentBlockhashRes, err := c.GetLatestBlockhash(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get recent blockhash, err: %v", err)
+	}
+	tx, err := types.NewTransaction(types.NewTransactionParam{
+		Message: types.NewMessage(types.NewMessageParam{
+			Instructions:    instructions,
+			FeePayer:        feePayer,
+			RecentBlockhash: recentBlockhashRes.Blockhash,
+		}),
+		Signers: signers,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create new tx, err: %v", err)
+	}
+	return c.SendTransaction(ctx, tx)
+}
@@ -0,0 +1,418 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/bpf_loader_upgradeable"
+	"github.com/EntySquare/solana-go-sdk/program/system"
+	"github.com/EntySquare/solana-go-sdk/rpc"
+	"github.com/EntySquare/solana-go-sdk/types"
+)
+
+// packetDataSize is the max size of a serialized transaction
+const packetDataSize = 1232
+
+const (
+	defaultDeployConcurrency = 16
+	defaultDeployWriteRounds = 5
+	deployPollInterval       = 500 * time.Millisecond
+	// deployConfirmTimeout is roughly the lifetime of a blockhash
+	deployConfirmTimeout = 90 * time.Second
+)
+
+var (
+	ErrDeployProgramAccountInUse   = errors.New("program account is not an upgradeable program")
+	ErrDeployAuthorityMismatch     = errors.New("upgrade authority mismatch")
+	ErrDeployProgramDataTooSmall   = errors.New("program data account is too small, extend the program first")
+	ErrDeployBufferMismatch        = errors.New("buffer account does not match the program")
+	ErrDeployBufferWriteIncomplete = errors.New("failed to write the whole program into the buffer")
+)
+
+type DeployProgramParam struct {
+	FeePayer types.Account
+	// Program only signs the first deployment, its PublicKey is enough for an upgrade
+	Program types.Account
+	// Buffer is created when it does not exist, a partially written buffer is resumed
+	Buffer types.Account
+	// Authority is the buffer authority and the upgrade authority
+	Authority types.Account
+	// ProgramData is the content of the ELF file
+	ProgramData []byte
+	// MaxDataLen is only used by the first deployment, 0 means twice the ELF size
+	MaxDataLen uint64
+	// Concurrency is the max number of in flight write transactions, 0 means 16
+	Concurrency int
+	// WriteRounds is how many times unwritten chunks are sent, 0 means 5
+	WriteRounds int
+}
+
+// DeployProgram writes the ELF into a buffer with concurrent write transactions,
+// then deploys it as a new program or upgrades the existing one.
+// Calling it again with the same buffer resumes an interrupted deployment.
+func (c *Client) DeployProgram(ctx context.Context, param DeployProgramParam) (string, error) {
+	if param.Concurrency <= 0 {
+		param.Concurrency = defaultDeployConcurrency
+	}
+	if param.WriteRounds <= 0 {
+		param.WriteRounds = defaultDeployWriteRounds
+	}
+
+	programData, _ := bpf_loader_upgradeable.GetProgramDataAddress(param.Program.PublicKey)
+	accountInfos, err := c.GetMultipleAccountsWithConfig(
+		ctx,
+		[]string{param.Program.PublicKey.ToBase58(), programData.ToBase58(), param.Buffer.PublicKey.ToBase58()},
+		GetMultipleAccountsConfig{Commitment: rpc.CommitmentConfirmed},
+	)
+	if err != nil {
+		return "", err
+	}
+	programInfo, programDataInfo, bufferInfo := accountInfos[0], accountInfos[1], accountInfos[2]
+
+	upgrade := programInfo.Lamports != 0
+	if upgrade {
+		if _, err := bpf_loader_upgradeable.DeserializeProgramAccount(programInfo.Data, programInfo.Owner); err != nil {
+			return "", fmt.Errorf("%w, err: %v", ErrDeployProgramAccountInUse, err)
+		}
+		programDataAccount, err := bpf_loader_upgradeable.DeserializeProgramDataAccount(programDataInfo.Data, programDataInfo.Owner)
+		if err != nil {
+			return "", fmt.Errorf("failed to deserialize program data account, err: %v", err)
+		}
+		if programDataAccount.UpgradeAuthority == nil || *programDataAccount.UpgradeAuthority != param.Authority.PublicKey {
+			return "", ErrDeployAuthorityMismatch
+		}
+		if len(programDataAccount.Data) < len(param.ProgramData) {
+			return "", fmt.Errorf("%w, size: %v, required: %v", ErrDeployProgramDataTooSmall, len(programDataAccount.Data), len(param.ProgramData))
+		}
+	}
+
+	var bufferData []byte
+	if bufferInfo.Lamports == 0 {
+		if err := c.createProgramBuffer(ctx, param); err != nil {
+			return "", err
+		}
+		bufferData = make([]byte, len(param.ProgramData))
+	} else {
+		buffer, err := bpf_loader_upgradeable.DeserializeBufferAccount(bufferInfo.Data, bufferInfo.Owner)
+		if err != nil {
+			return "", fmt.Errorf("%w, err: %v", ErrDeployBufferMismatch, err)
+		}
+		if buffer.Authority == nil || *buffer.Authority != param.Authority.PublicKey {
+			return "", fmt.Errorf("%w, authority mismatch", ErrDeployBufferMismatch)
+		}
+		if len(buffer.Data) != len(param.ProgramData) {
+			return "", fmt.Errorf("%w, size: %v, program size: %v", ErrDeployBufferMismatch, len(buffer.Data), len(param.ProgramData))
+		}
+		bufferData = buffer.Data
+	}
+
+	if err := c.writeProgramBuffer(ctx, param, bufferData); err != nil {
+		return "", err
+	}
+
+	if upgrade {
+		return c.sendInstructions(ctx, param.FeePayer.PublicKey, []types.Account{param.FeePayer, param.Authority}, []types.Instruction{
+			bpf_loader_upgradeable.Upgrade(bpf_loader_upgradeable.UpgradeParam{
+				Program:   param.Program.PublicKey,
+				Buffer:    param.Buffer.PublicKey,
+				Authority: param.Authority.PublicKey,
+				Spill:     param.FeePayer.PublicKey,
+			}),
+		})
+	}
+
+	maxDataLen := param.MaxDataLen
+	if maxDataLen == 0 {
+		maxDataLen = 2 * uint64(len(param.ProgramData))
+	}
+	rentExemptBalance, err := c.GetMinimumBalanceForRentExemption(ctx, bpf_loader_upgradeable.ProgramAccountSize)
+	if err != nil {
+		return "", fmt.Errorf("failed to get minimum balance for rent exemption, err: %v", err)
+	}
+	return c.sendInstructions(ctx, param.FeePayer.PublicKey, []types.Account{param.FeePayer, param.Program, param.Authority}, []types.Instruction{
+		system.CreateAccount(system.CreateAccountParam{
+			From:     param.FeePayer.PublicKey,
+			New:      param.Program.PublicKey,
+			Owner:    common.BPFLoaderUpgradeableProgramID,
+			Lamports: rentExemptBalance,
+			Space:    bpf_loader_upgradeable.ProgramAccountSize,
+		}),
+		bpf_loader_upgradeable.DeployWithMaxDataLen(bpf_loader_upgradeable.DeployWithMaxDataLenParam{
+			Payer:      param.FeePayer.PublicKey,
+			Program:    param.Program.PublicKey,
+			Buffer:     param.Buffer.PublicKey,
+			Authority:  param.Authority.PublicKey,
+			MaxDataLen: maxDataLen,
+		}),
+	})
+}
+
+// createProgramBuffer creates and initializes the buffer account and waits until it is confirmed
+func (c *Client) createProgramBuffer(ctx context.Context, param DeployProgramParam) error {
+	space := bpf_loader_upgradeable.BufferMetadataSize + uint64(len(param.ProgramData))
+	rentExemptBalance, err := c.GetMinimumBalanceForRentExemption(ctx, space)
+	if err != nil {
+		return fmt.Errorf("failed to get minimum balance for rent exemption, err: %v", err)
+	}
+	sig, err := c.sendInstructions(ctx, param.FeePayer.PublicKey, []types.Account{param.FeePayer, param.Buffer}, []types.Instruction{
+		system.CreateAccount(system.CreateAccountParam{
+			From:     param.FeePayer.PublicKey,
+			New:      param.Buffer.PublicKey,
+			Owner:    common.BPFLoaderUpgradeableProgramID,
+			Lamports: rentExemptBalance,
+			Space:    space,
+		}),
+		bpf_loader_upgradeable.InitializeBuffer(bpf_loader_upgradeable.InitializeBufferParam{
+			Buffer:    param.Buffer.PublicKey,
+			Authority: &param.Authority.PublicKey,
+		}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create buffer, err: %v", err)
+	}
+	statuses, err := c.waitForSignatures(ctx, []string{sig})
+	if err != nil {
+		return err
+	}
+	if statuses[0] == nil {
+		return fmt.Errorf("failed to confirm buffer creation, signature: %v", sig)
+	}
+	if statuses[0].Err != nil {
+		return fmt.Errorf("failed to create buffer, signature: %v, err: %v", sig, statuses[0].Err)
+	}
+	return nil
+}
+
+type programWrite struct {
+	Offset uint32
+	Bytes  []byte
+}
+
+// writeProgramBuffer sends the chunks which differ from the buffer until the whole program is written
+func (c *Client) writeProgramBuffer(ctx context.Context, param DeployProgramParam, bufferData []byte) error {
+	chunkSize := programWriteChunkSize(param.FeePayer.PublicKey, param.Buffer.PublicKey, param.Authority.PublicKey)
+	var lastErr error
+	for round := 0; ; round++ {
+		writes := pendingProgramWrites(bufferData, param.ProgramData, chunkSize)
+		if len(writes) == 0 {
+			return nil
+		}
+		if round == param.WriteRounds {
+			return fmt.Errorf("%w, %v chunks left, last err: %v", ErrDeployBufferWriteIncomplete, len(writes), lastErr)
+		}
+
+		sigs, err := c.sendProgramWrites(ctx, param, writes)
+		if err != nil {
+			lastErr = err
+		}
+		if _, err := c.waitForSignatures(ctx, sigs); err != nil {
+			return err
+		}
+
+		bufferInfo, err := c.GetAccountInfoWithConfig(ctx, param.Buffer.PublicKey.ToBase58(), GetAccountInfoConfig{Commitment: rpc.CommitmentConfirmed})
+		if err != nil {
+			return err
+		}
+		buffer, err := bpf_loader_upgradeable.DeserializeBufferAccount(bufferInfo.Data, bufferInfo.Owner)
+		if err != nil {
+			return fmt.Errorf("%w, err: %v", ErrDeployBufferMismatch, err)
+		}
+		if len(buffer.Data) != len(param.ProgramData) {
+			return fmt.Errorf("%w, size: %v, program size: %v", ErrDeployBufferMismatch, len(buffer.Data), len(param.ProgramData))
+		}
+		bufferData = buffer.Data
+	}
+}
+
+// sendProgramWrites sends write transactions concurrently, a batch of Concurrency writes at a time
+// with a fresh blockhash. It stops starting writes after the first failure and returns the signatures
+// of the sent ones with the error.
+func (c *Client) sendProgramWrites(ctx context.Context, param DeployProgramParam, writes []programWrite) ([]string, error) {
+	var (
+		wg              sync.WaitGroup
+		mu              sync.Mutex
+		sigs            = make([]string, 0, len(writes))
+		lastErr         error
+		sem             = make(chan struct{}, param.Concurrency)
+		recentBlockhash string
+	)
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return lastErr != nil
+	}
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if lastErr == nil {
+			lastErr = err
+		}
+	}
+
+	for i, write := range writes {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			fail(ctx.Err())
+		}
+		if failed() {
+			break
+		}
+		if i%param.Concurrency == 0 {
+			recentBlockhashRes, err := c.GetLatestBlockhash(ctx)
+			if err != nil {
+				<-sem
+				fail(fmt.Errorf("failed to get recent blockhash, err: %v", err))
+				break
+			}
+			recentBlockhash = recentBlockhashRes.Blockhash
+		}
+
+		wg.Add(1)
+		go func(write programWrite, recentBlockhash string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			sig, err := c.sendWriteTransaction(ctx, param, recentBlockhash, write)
+			if err != nil {
+				fail(fmt.Errorf("failed to write at offset %v, err: %v", write.Offset, err))
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			sigs = append(sigs, sig)
+		}(write, recentBlockhash)
+	}
+	wg.Wait()
+	return sigs, lastErr
+}
+
+func (c *Client) sendWriteTransaction(ctx context.Context, param DeployProgramParam, recentBlockhash string, write programWrite) (string, error) {
+	tx, err := types.NewTransaction(types.NewTransactionParam{
+		Message: types.NewMessage(types.NewMessageParam{
+			Instructions: []types.Instruction{
+				bpf_loader_upgradeable.Write(bpf_loader_upgradeable.WriteParam{
+					Buffer:    param.Buffer.PublicKey,
+					Authority: param.Authority.PublicKey,
+					Offset:    write.Offset,
+					Bytes:     write.Bytes,
+				}),
+			},
+			FeePayer:        param.FeePayer.PublicKey,
+			RecentBlockhash: recentBlockhash,
+		}),
+		Signers: []types.Account{param.FeePayer, param.Authority},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create new tx, err: %v", err)
+	}
+	return c.SendTransaction(ctx, tx)
+}
+
+// waitForSignatures polls signature statuses until all of them are confirmed or failed.
+// A nil status means the transaction did not land before the timeout.
+func (c *Client) waitForSignatures(ctx context.Context, sigs []string) (rpc.SignatureStatuses, error) {
+	statuses := make(rpc.SignatureStatuses, len(sigs))
+	if len(sigs) == 0 {
+		return statuses, nil
+	}
+
+	ticker := time.NewTicker(deployPollInterval)
+	defer ticker.Stop()
+	timeout := time.After(deployConfirmTimeout)
+	for {
+		done := true
+		for start := 0; start < len(sigs); start += 256 {
+			end := start + 256
+			if end > len(sigs) {
+				end = len(sigs)
+			}
+			res, err := c.GetSignatureStatuses(ctx, sigs[start:end])
+			if err != nil {
+				return nil, fmt.Errorf("failed to get signature statuses, err: %v", err)
+			}
+			for i, status := range res {
+				statuses[start+i] = status
+				if !isSignatureSettled(status) {
+					done = false
+				}
+			}
+		}
+		if done {
+			return statuses, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout:
+			for i, status := range statuses {
+				if !isSignatureSettled(status) {
+					statuses[i] = nil
+				}
+			}
+			return statuses, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func isSignatureSettled(status *rpc.SignatureStatus) bool {
+	if status == nil {
+		return false
+	}
+	if status.Err != nil {
+		return true
+	}
+	return status.ConfirmationStatus != nil &&
+		(*status.ConfirmationStatus == rpc.CommitmentConfirmed || *status.ConfirmationStatus == rpc.CommitmentFinalized)
+}
+
+// programWriteChunkSize returns the max bytes a write transaction can carry
+func programWriteChunkSize(feePayer, buffer, authority common.PublicKey) int {
+	tx, err := types.NewTransaction(types.NewTransactionParam{
+		Message: types.NewMessage(types.NewMessageParam{
+			Instructions: []types.Instruction{
+				bpf_loader_upgradeable.Write(bpf_loader_upgradeable.WriteParam{
+					Buffer:    buffer,
+					Authority: authority,
+				}),
+			},
+			FeePayer:        feePayer,
+			RecentBlockhash: common.PublicKey{}.ToBase58(),
+		}),
+	})
+	if err != nil {
+		panic(err)
+	}
+	rawTx, err := tx.Serialize()
+	if err != nil {
+		panic(err)
+	}
+	// the compact length of the instruction data takes one more byte once it is over 127 bytes
+	return packetDataSize - len(rawTx) - 1
+}
+
+// pendingProgramWrites splits the program into chunks and returns the ones which are not in the buffer yet
+func pendingProgramWrites(bufferData, programData []byte, chunkSize int) []programWrite {
+	writes := []programWrite{}
+	for offset := 0; offset < len(programData); offset += chunkSize {
+		end := offset + chunkSize
+		if end > len(programData) {
+			end = len(programData)
+		}
+		if bytes.Equal(bufferData[offset:end], programData[offset:end]) {
+			continue
+		}
+		writes = append(writes, programWrite{
+			Offset: uint32(offset),
+			Bytes:  programData[offset:end],
+		})
+	}
+	return writes
+}
@@ -0,0 +1,438 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/internal/client_test"
+	"github.com/EntySquare/solana-go-sdk/program/bpf_loader_upgradeable"
+	"github.com/EntySquare/solana-go-sdk/program/system"
+	"github.com/EntySquare/solana-go-sdk/rpc/rpctest"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_DeployProgram(t *testing.T) {
+	requestBody := `{"jsonrpc":"2.0", "id":1, "method":"getMultipleAccounts", "params":[["FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm", "9KPkw58x6ANtgGqMSv7Kvrk7LqVzvkcenQYWXFfnReje", "BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"], {"encoding": "base64", "commitment": "confirmed"}]}`
+	param := DeployProgramParam{
+		Program:     types.Account{PublicKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")},
+		Buffer:      types.Account{PublicKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")},
+		Authority:   types.Account{PublicKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")},
+		ProgramData: []byte{127, 69, 76, 70, 2},
+	}
+	otherAuthorityParam := param
+	otherAuthorityParam.Authority = types.Account{PublicKey: common.PublicKeyFromString("DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi")}
+
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				Name:         "program account in use",
+				RequestBody:  requestBody,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":[{"data":["","base64"],"executable":false,"lamports":1000000000,"owner":"11111111111111111111111111111111","rentEpoch":0},null,null]},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.DeployProgram(context.TODO(), param)
+				},
+				ExpectedValue: "",
+				ExpectedError: fmt.Errorf("%w, err: %v", ErrDeployProgramAccountInUse, bpf_loader_upgradeable.ErrInvalidAccountOwner),
+			},
+			{
+				Name:         "upgrade authority mismatch",
+				RequestBody:  requestBody,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":[{"data":["AgAAAHuTrpGH3AA9LU7Ufob1UAWCFx/xbvmNgp+xuz9cIQ6t","base64"],"executable":true,"lamports":1141440,"owner":"BPFLoaderUpgradeab1e11111111111111111111111","rentEpoch":0},{"data":["AwAAAAAP3wwAAAAAAc7Th+bDb1f+k++PUW6fMYxtieDFGDHfPXsITm1uiOTwf0VMRg==","base64"],"executable":false,"lamports":1454640,"owner":"BPFLoaderUpgradeab1e11111111111111111111111","rentEpoch":0},null]},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.DeployProgram(context.TODO(), otherAuthorityParam)
+				},
+				ExpectedValue: "",
+				ExpectedError: ErrDeployAuthorityMismatch,
+			},
+			{
+				Name:         "program data too small",
+				RequestBody:  requestBody,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":[{"data":["AgAAAHuTrpGH3AA9LU7Ufob1UAWCFx/xbvmNgp+xuz9cIQ6t","base64"],"executable":true,"lamports":1141440,"owner":"BPFLoaderUpgradeab1e11111111111111111111111","rentEpoch":0},{"data":["AwAAAAAP3wwAAAAAAc7Th+bDb1f+k++PUW6fMYxtieDFGDHfPXsITm1uiOTwf0VMRg==","base64"],"executable":false,"lamports":1454640,"owner":"BPFLoaderUpgradeab1e11111111111111111111111","rentEpoch":0},null]},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.DeployProgram(context.TODO(), param)
+				},
+				ExpectedValue: "",
+				ExpectedError: fmt.Errorf("%w, size: %v, required: %v", ErrDeployProgramDataTooSmall, 4, 5),
+			},
+		},
+	)
+}
+
+func TestProgramWriteChunkSize(t *testing.T) {
+	feePayer := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	buffer := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	authority := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+
+	txSize := func(chunkSize int) int {
+		tx, err := types.NewTransaction(types.NewTransactionParam{
+			Message: types.NewMessage(types.NewMessageParam{
+				Instructions: []types.Instruction{
+					bpf_loader_upgradeable.Write(bpf_loader_upgradeable.WriteParam{
+						Buffer:    buffer,
+						Authority: authority,
+						Bytes:     make([]byte, chunkSize),
+					}),
+				},
+				FeePayer:        feePayer,
+				RecentBlockhash: common.PublicKey{}.ToBase58(),
+			}),
+		})
+		assert.Nil(t, err)
+		rawTx, err := tx.Serialize()
+		assert.Nil(t, err)
+		return len(rawTx)
+	}
+
+	chunkSize := programWriteChunkSize(feePayer, buffer, authority)
+	assert.Equal(t, packetDataSize, txSize(chunkSize))
+	assert.Greater(t, txSize(chunkSize+1), packetDataSize)
+}
+
+func TestPendingProgramWrites(t *testing.T) {
+	type args struct {
+		bufferData  []byte
+		programData []byte
+		chunkSize   int
+	}
+	tests := []struct {
+		name string
+		args args
+		want []programWrite
+	}{
+		{
+			name: "empty buffer",
+			args: args{
+				bufferData:  []byte{0, 0, 0, 0, 0},
+				programData: []byte{1, 2, 0, 0, 5},
+				chunkSize:   2,
+			},
+			want: []programWrite{
+				{Offset: 0, Bytes: []byte{1, 2}},
+				{Offset: 4, Bytes: []byte{5}},
+			},
+		},
+		{
+			name: "partially written",
+			args: args{
+				bufferData:  []byte{1, 2, 0, 0, 0},
+				programData: []byte{1, 2, 3, 4, 5},
+				chunkSize:   2,
+			},
+			want: []programWrite{
+				{Offset: 2, Bytes: []byte{3, 4}},
+				{Offset: 4, Bytes: []byte{5}},
+			},
+		},
+		{
+			name: "fully written",
+			args: args{
+				bufferData:  []byte{1, 2, 3, 4, 5},
+				programData: []byte{1, 2, 3, 4, 5},
+				chunkSize:   2,
+			},
+			want: []programWrite{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pendingProgramWrites(tt.args.bufferData, tt.args.programData, tt.args.chunkSize); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pendingProgramWrites() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// programWriteServer runs the write instructions the client sends on the buffer of an rpctest server,
+// the rpctest server itself only records transactions
+type programWriteServer struct {
+	*httptest.Server
+	rpc    *rpctest.Server
+	buffer common.PublicKey
+
+	mu          sync.Mutex
+	offsets     []uint32
+	inFlight    int
+	maxInFlight int
+	blockhashes int
+	// failOffset makes the write at the offset fail
+	failOffset *uint32
+}
+
+func newProgramWriteServer(t *testing.T, s *rpctest.Server, buffer common.PublicKey) *programWriteServer {
+	p := &programWriteServer{rpc: s, buffer: buffer}
+	p.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		var r struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(body, &r); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.Method == "getLatestBlockhash" {
+			p.mu.Lock()
+			p.blockhashes++
+			p.mu.Unlock()
+		}
+		if r.Method == "sendTransaction" {
+			if err := p.write(r.Params[0]); err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		res, err := http.Post(s.URL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer res.Body.Close()
+		_, _ = io.Copy(rw, res.Body)
+	}))
+	t.Cleanup(p.Close)
+	return p
+}
+
+func (p *programWriteServer) write(raw json.RawMessage) error {
+	p.mu.Lock()
+	p.inFlight++
+	if p.inFlight > p.maxInFlight {
+		p.maxInFlight = p.inFlight
+	}
+	p.mu.Unlock()
+	// hold the request so the concurrent writes overlap
+	time.Sleep(20 * time.Millisecond)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inFlight--
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return err
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	tx, err := types.TransactionDeserialize(b)
+	if err != nil {
+		return err
+	}
+	for _, ix := range tx.Message.DecompileInstructions() {
+		if ix.ProgramID != common.BPFLoaderUpgradeableProgramID || binary.LittleEndian.Uint32(ix.Data) != uint32(bpf_loader_upgradeable.InstructionWrite) {
+			continue
+		}
+		account, ok := p.rpc.GetAccount(p.buffer)
+		if !ok {
+			return fmt.Errorf("buffer %v not found", p.buffer)
+		}
+		offset := binary.LittleEndian.Uint32(ix.Data[4:])
+		if p.failOffset != nil && *p.failOffset == offset {
+			return fmt.Errorf("write at %v failed", offset)
+		}
+		copy(account.Data[bpf_loader_upgradeable.BufferMetadataSize+uint64(offset):], ix.Data[16:])
+		p.rpc.SetAccount(p.buffer, account)
+		p.offsets = append(p.offsets, offset)
+	}
+	return nil
+}
+
+func TestClient_DeployProgram_Write(t *testing.T) {
+	feePayer, _ := types.AccountFromSeed(bytes.Repeat([]byte{1}, 32))
+	program, _ := types.AccountFromSeed(bytes.Repeat([]byte{2}, 32))
+	buffer, _ := types.AccountFromSeed(bytes.Repeat([]byte{3}, 32))
+	authority, _ := types.AccountFromSeed(bytes.Repeat([]byte{4}, 32))
+	programDataAddress, _ := bpf_loader_upgradeable.GetProgramDataAddress(program.PublicKey)
+
+	chunkSize := programWriteChunkSize(feePayer.PublicKey, buffer.PublicKey, authority.PublicKey)
+	elf := make([]byte, 10*chunkSize+100)
+	for i := range elf {
+		elf[i] = byte(i%251) + 1
+	}
+	// the first two chunks were written by an interrupted deployment
+	bufferData := append([]byte{1, 0, 0, 0, 1}, authority.PublicKey.Bytes()...)
+	bufferData = append(bufferData, elf[:2*chunkSize]...)
+	bufferData = append(bufferData, make([]byte, len(elf)-2*chunkSize)...)
+
+	programAccount := append([]byte{2, 0, 0, 0}, programDataAddress.Bytes()...)
+	programDataAccount := append([]byte{3, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 1}, authority.PublicKey.Bytes()...)
+	programDataAccount = append(programDataAccount, make([]byte, len(elf))...)
+
+	tests := []struct {
+		name    string
+		upgrade bool
+		want    []types.Instruction
+	}{
+		{
+			name: "deploy",
+			want: []types.Instruction{
+				system.CreateAccount(system.CreateAccountParam{
+					From:     feePayer.PublicKey,
+					New:      program.PublicKey,
+					Owner:    common.BPFLoaderUpgradeableProgramID,
+					Lamports: rpctest.MinimumBalanceForRentExemption(bpf_loader_upgradeable.ProgramAccountSize),
+					Space:    bpf_loader_upgradeable.ProgramAccountSize,
+				}),
+				bpf_loader_upgradeable.DeployWithMaxDataLen(bpf_loader_upgradeable.DeployWithMaxDataLenParam{
+					Payer:      feePayer.PublicKey,
+					Program:    program.PublicKey,
+					Buffer:     buffer.PublicKey,
+					Authority:  authority.PublicKey,
+					MaxDataLen: 2 * uint64(len(elf)),
+				}),
+			},
+		},
+		{
+			name:    "upgrade",
+			upgrade: true,
+			want: []types.Instruction{
+				bpf_loader_upgradeable.Upgrade(bpf_loader_upgradeable.UpgradeParam{
+					Program:   program.PublicKey,
+					Buffer:    buffer.PublicKey,
+					Authority: authority.PublicKey,
+					Spill:     feePayer.PublicKey,
+				}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := rpctest.NewServer(
+				rpctest.WithAccount(feePayer.PublicKey, rpctest.Account{Lamports: 100_000_000_000, Owner: common.SystemProgramID}),
+				rpctest.WithAccount(buffer.PublicKey, rpctest.Account{
+					Lamports: rpctest.MinimumBalanceForRentExemption(uint64(len(bufferData))),
+					Owner:    common.BPFLoaderUpgradeableProgramID,
+					Data:     append([]byte{}, bufferData...),
+				}),
+			)
+			defer s.Close()
+			if tt.upgrade {
+				s.SetAccount(program.PublicKey, rpctest.Account{Lamports: 1141440, Owner: common.BPFLoaderUpgradeableProgramID, Data: programAccount, Executable: true})
+				s.SetAccount(programDataAddress, rpctest.Account{Lamports: 1, Owner: common.BPFLoaderUpgradeableProgramID, Data: programDataAccount})
+			}
+			p := newProgramWriteServer(t, s, buffer.PublicKey)
+
+			_, err := NewClient(p.URL).DeployProgram(context.Background(), DeployProgramParam{
+				FeePayer:    feePayer,
+				Program:     program,
+				Buffer:      buffer,
+				Authority:   authority,
+				ProgramData: elf,
+				Concurrency: 4,
+			})
+			require.NoError(t, err)
+
+			account, _ := s.GetAccount(buffer.PublicKey)
+			assert.Equal(t, elf, account.Data[bpf_loader_upgradeable.BufferMetadataSize:])
+			// only the chunks which were missing are written, up to 4 at a time
+			wantOffsets := []uint32{}
+			for offset := 2 * chunkSize; offset < len(elf); offset += chunkSize {
+				wantOffsets = append(wantOffsets, uint32(offset))
+			}
+			p.mu.Lock()
+			assert.ElementsMatch(t, wantOffsets, p.offsets)
+			assert.Greater(t, p.maxInFlight, 1)
+			assert.LessOrEqual(t, p.maxInFlight, 4)
+			p.mu.Unlock()
+
+			assert.Len(t, s.Transactions(), len(wantOffsets)+1)
+			assertLastInstructions(t, s, tt.want)
+		})
+	}
+}
+
+func TestClient_sendProgramWrites(t *testing.T) {
+	feePayer, _ := types.AccountFromSeed(bytes.Repeat([]byte{1}, 32))
+	buffer, _ := types.AccountFromSeed(bytes.Repeat([]byte{3}, 32))
+	authority, _ := types.AccountFromSeed(bytes.Repeat([]byte{4}, 32))
+	writes := make([]programWrite, 0, 5)
+	for i := uint32(0); i < 5; i++ {
+		writes = append(writes, programWrite{Offset: 10 * i, Bytes: bytes.Repeat([]byte{1}, 10)})
+	}
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	failOffset := uint32(10)
+
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		concurrency     int
+		failOffset      *uint32
+		wantOffsets     []uint32
+		wantBlockhashes int
+		wantErr         bool
+	}{
+		{
+			name:            "a blockhash per batch",
+			ctx:             context.Background(),
+			concurrency:     2,
+			wantOffsets:     []uint32{0, 10, 20, 30, 40},
+			wantBlockhashes: 3,
+		},
+		{
+			name:            "stop after a failure",
+			ctx:             context.Background(),
+			concurrency:     1,
+			failOffset:      &failOffset,
+			wantOffsets:     []uint32{0},
+			wantBlockhashes: 2,
+			wantErr:         true,
+		},
+		{
+			name:        "canceled",
+			ctx:         canceled,
+			concurrency: 1,
+			wantOffsets: nil,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := rpctest.NewServer(
+				rpctest.WithAccount(feePayer.PublicKey, rpctest.Account{Lamports: 100_000_000_000, Owner: common.SystemProgramID}),
+				rpctest.WithAccount(buffer.PublicKey, rpctest.Account{
+					Lamports: 1,
+					Owner:    common.BPFLoaderUpgradeableProgramID,
+					Data:     make([]byte, bpf_loader_upgradeable.BufferMetadataSize+50),
+				}),
+			)
+			defer s.Close()
+			p := newProgramWriteServer(t, s, buffer.PublicKey)
+			p.failOffset = tt.failOffset
+
+			c := NewClient(p.URL)
+			sigs, err := c.sendProgramWrites(tt.ctx, DeployProgramParam{
+				FeePayer:    feePayer,
+				Buffer:      buffer,
+				Authority:   authority,
+				Concurrency: tt.concurrency,
+			}, writes)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Len(t, sigs, len(tt.wantOffsets))
+
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			assert.ElementsMatch(t, tt.wantOffsets, p.offsets)
+			assert.Equal(t, tt.wantBlockhashes, p.blockhashes)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/name_service"
+)
+
+var (
+	ErrNameAccountNotFound   = errors.New("name account not found")
+	ErrPrimaryDomainNotFound = errors.New("primary domain not found")
+)
+
+// GetNameRecord fetch and decode a name record
+func (c *Client) GetNameRecord(ctx context.Context, nameAccount common.PublicKey) (name_service.NameRecordHeader, error) {
+	accountInfo, err := c.GetAccountInfo(ctx, nameAccount.ToBase58())
+	if err != nil {
+		return name_service.NameRecordHeader{}, err
+	}
+	return nameRecordFromAccountInfo(nameAccount, accountInfo)
+}
+
+// ResolveDomain returns the owner of a .sol domain or subdomain, e.g. "alice.sol" or "pay.alice.sol"
+func (c *Client) ResolveDomain(ctx context.Context, domain string) (common.PublicKey, error) {
+	domainKey, err := name_service.GetDomainKey(domain)
+	if err != nil {
+		return common.PublicKey{}, err
+	}
+	record, err := c.GetNameRecord(ctx, domainKey.Pubkey)
+	if err != nil {
+		return common.PublicKey{}, err
+	}
+	return record.Owner, nil
+}
+
+// ReverseLookup returns the .sol domain of a name account
+func (c *Client) ReverseLookup(ctx context.Context, nameAccount common.PublicKey) (string, error) {
+	record, err := c.GetNameRecord(ctx, nameAccount)
+	if err != nil {
+		return "", err
+	}
+	return c.reverseLookup(ctx, nameAccount, record)
+}
+
+type PrimaryDomain struct {
+	// Domain is the full domain, e.g. "alice.sol"
+	Domain      string
+	NameAccount common.PublicKey
+	// Stale means the domain has changed hands after the wallet set it as primary
+	Stale bool
+}
+
+// GetPrimaryDomain returns the domain a wallet has set as its primary one
+func (c *Client) GetPrimaryDomain(ctx context.Context, owner common.PublicKey) (PrimaryDomain, error) {
+	accountInfo, err := c.GetAccountInfo(ctx, name_service.GetFavouriteDomainKey(owner).ToBase58())
+	if err != nil {
+		return PrimaryDomain{}, err
+	}
+	if accountInfo.Owner != name_service.NameOffersProgramID {
+		return PrimaryDomain{}, fmt.Errorf("%w, owner: %v", ErrPrimaryDomainNotFound, owner)
+	}
+	favourite, err := name_service.FavouriteDomainFromData(accountInfo.Data)
+	if err != nil {
+		return PrimaryDomain{}, err
+	}
+
+	record, err := c.GetNameRecord(ctx, favourite.NameAccount)
+	if err != nil {
+		return PrimaryDomain{}, err
+	}
+	domain, err := c.reverseLookup(ctx, favourite.NameAccount, record)
+	if err != nil {
+		return PrimaryDomain{}, err
+	}
+
+	return PrimaryDomain{
+		Domain:      domain,
+		NameAccount: favourite.NameAccount,
+		Stale:       record.Owner != owner,
+	}, nil
+}
+
+// reverseLookup reads the reverse records of a name account, and the one of its parent for a subdomain
+func (c *Client) reverseLookup(ctx context.Context, nameAccount common.PublicKey, record name_service.NameRecordHeader) (string, error) {
+	if record.ParentName == name_service.SolTldAuthority {
+		reverseKey := name_service.GetReverseLookupKey(nameAccount, nil)
+		reverseRecord, err := c.GetAccountInfo(ctx, reverseKey.ToBase58())
+		if err != nil {
+			return "", err
+		}
+		name, err := reverseLookupFromAccountInfo(reverseKey, reverseRecord)
+		if err != nil {
+			return "", err
+		}
+		return name + ".sol", nil
+	}
+
+	reverseKeys := []common.PublicKey{
+		name_service.GetReverseLookupKey(nameAccount, &record.ParentName),
+		name_service.GetReverseLookupKey(record.ParentName, nil),
+	}
+	reverseRecords, err := c.GetMultipleAccounts(ctx, []string{reverseKeys[0].ToBase58(), reverseKeys[1].ToBase58()})
+	if err != nil {
+		return "", err
+	}
+	names := make([]string, 0, len(reverseKeys))
+	for i, reverseRecord := range reverseRecords {
+		name, err := reverseLookupFromAccountInfo(reverseKeys[i], reverseRecord)
+		if err != nil {
+			return "", err
+		}
+		names = append(names, name)
+	}
+	return names[0] + "." + names[1] + ".sol", nil
+}
+
+func nameRecordFromAccountInfo(pubkey common.PublicKey, accountInfo AccountInfo) (name_service.NameRecordHeader, error) {
+	if accountInfo.Owner != common.SPLNameServiceProgramID {
+		return name_service.NameRecordHeader{}, fmt.Errorf("%w, address: %v", ErrNameAccountNotFound, pubkey)
+	}
+	return name_service.NameRecordHeaderFromData(accountInfo.Data)
+}
+
+func reverseLookupFromAccountInfo(pubkey common.PublicKey, accountInfo AccountInfo) (string, error) {
+	if accountInfo.Owner != common.SPLNameServiceProgramID {
+		return "", fmt.Errorf("%w, address: %v", ErrNameAccountNotFound, pubkey)
+	}
+	return name_service.ReverseLookupFromData(accountInfo.Data)
+}
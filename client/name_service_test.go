@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/internal/client_test"
+	"github.com/EntySquare/solana-go-sdk/program/name_service"
+)
+
+const (
+	// blocto.sol owned by EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7
+	testNameServiceDomainAccount = `{"data":["PVPCSzg2DtOBOiPfst/YIKtYIct5KaONLqqyUug4JZXO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=","base64"],"executable":false,"lamports":1000000,"owner":"namesLPneVptA9Z5rqUDD9tMTWEJwofgaYwp8cawRkX","rentEpoch":0}`
+	// yihau.blocto.sol owned by EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7
+	testNameServiceSubdomainAccount        = `{"data":["WK2Q1w6fmJhsGBoA3UR0iNnNGRFnfcF4Sxm+/tzw9V3O04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA","base64"],"executable":false,"lamports":1000000,"owner":"namesLPneVptA9Z5rqUDD9tMTWEJwofgaYwp8cawRkX","rentEpoch":0}`
+	testNameServiceReverseDomainAccount    = `{"data":["AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAebFjksbVKKFvPFD7mp4g1bvqG4lnz2KsWavQO9itJjh5sWOSxtUooW88UPuaniDVu+obiWfPYqxZq9A72K0mOBgAAAGJsb2N0bw==","base64"],"executable":false,"lamports":1000000,"owner":"namesLPneVptA9Z5rqUDD9tMTWEJwofgaYwp8cawRkX","rentEpoch":0}`
+	testNameServiceReverseSubdomainAccount = `{"data":["WK2Q1w6fmJhsGBoA3UR0iNnNGRFnfcF4Sxm+/tzw9V0ebFjksbVKKFvPFD7mp4g1bvqG4lnz2KsWavQO9itJjh5sWOSxtUooW88UPuaniDVu+obiWfPYqxZq9A72K0mOBgAAAAB5aWhhdQ==","base64"],"executable":false,"lamports":1000000,"owner":"namesLPneVptA9Z5rqUDD9tMTWEJwofgaYwp8cawRkX","rentEpoch":0}`
+)
+
+func TestClient_ResolveDomain(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS", {"encoding": "base64"}]}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":` + testNameServiceDomainAccount + `},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.ResolveDomain(context.TODO(), "blocto.sol")
+				},
+				ExpectedValue: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				ExpectedError: nil,
+			},
+			{
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["5Cjg2Xah4Cc24yM7zsfbyBuXKZ6Wm9ZJqHa5n47vnvNz", {"encoding": "base64"}]}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":null},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.ResolveDomain(context.TODO(), "yihau.blocto.sol")
+				},
+				ExpectedValue: common.PublicKey{},
+				ExpectedError: fmt.Errorf("%w, address: %v", ErrNameAccountNotFound, "5Cjg2Xah4Cc24yM7zsfbyBuXKZ6Wm9ZJqHa5n47vnvNz"),
+			},
+		},
+	)
+}
+
+func TestClient_ReverseLookup(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				Name: "domain",
+				Calls: []client_test.Call{
+					{
+						RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS", {"encoding": "base64"}]}`,
+						ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":` + testNameServiceDomainAccount + `},"id":1}`,
+					},
+					{
+						RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["9AXAoKtvZ6kMKZX1qT8gyjdrbknukyaUXz51NGrBN6Q8", {"encoding": "base64"}]}`,
+						ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":` + testNameServiceReverseDomainAccount + `},"id":1}`,
+					},
+				},
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.ReverseLookup(context.TODO(), common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"))
+				},
+				ExpectedValue: "blocto.sol",
+				ExpectedError: nil,
+			},
+			{
+				Name: "subdomain",
+				Calls: []client_test.Call{
+					{
+						RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["5Cjg2Xah4Cc24yM7zsfbyBuXKZ6Wm9ZJqHa5n47vnvNz", {"encoding": "base64"}]}`,
+						ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":` + testNameServiceSubdomainAccount + `},"id":1}`,
+					},
+					{
+						RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getMultipleAccounts", "params":[["2G8CP2oQJfJAqJcRrvmN4wTBqEvspX9FsqcrZ6CW1Sjb", "9AXAoKtvZ6kMKZX1qT8gyjdrbknukyaUXz51NGrBN6Q8"], {"encoding": "base64"}]}`,
+						ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":[` + testNameServiceReverseSubdomainAccount + `,` + testNameServiceReverseDomainAccount + `]},"id":1}`,
+					},
+				},
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.ReverseLookup(context.TODO(), common.PublicKeyFromString("5Cjg2Xah4Cc24yM7zsfbyBuXKZ6Wm9ZJqHa5n47vnvNz"))
+				},
+				ExpectedValue: "yihau.blocto.sol",
+				ExpectedError: nil,
+			},
+		},
+	)
+}
+
+func TestClient_GetPrimaryDomain(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				Calls: []client_test.Call{
+					{
+						RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["DQTj7xCr1TW4AfgiKhMYSsZyhsXHVz8ZnbEurn8nqcqH", {"encoding": "base64"}]}`,
+						ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":{"data":["AVitkNcOn5iYbBgaAN1EdIjZzRkRZ33BeEsZvv7c8PVd","base64"],"executable":false,"lamports":1113600,"owner":"85iDfUvr3HJyLM2zcq5BXSiDvUWfw6cSE1FfNBo8Ap29","rentEpoch":0}},"id":1}`,
+					},
+					{
+						RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS", {"encoding": "base64"}]}`,
+						ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":` + testNameServiceDomainAccount + `},"id":1}`,
+					},
+					{
+						RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["9AXAoKtvZ6kMKZX1qT8gyjdrbknukyaUXz51NGrBN6Q8", {"encoding": "base64"}]}`,
+						ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":` + testNameServiceReverseDomainAccount + `},"id":1}`,
+					},
+				},
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.GetPrimaryDomain(context.TODO(), common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"))
+				},
+				ExpectedValue: PrimaryDomain{
+					Domain:      "blocto.sol",
+					NameAccount: common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"),
+					Stale:       false,
+				},
+				ExpectedError: nil,
+			},
+			{
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["DQTj7xCr1TW4AfgiKhMYSsZyhsXHVz8ZnbEurn8nqcqH", {"encoding": "base64"}]}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":null},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.GetPrimaryDomain(context.TODO(), common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"))
+				},
+				ExpectedValue: PrimaryDomain{},
+				ExpectedError: fmt.Errorf("%w, owner: %v", ErrPrimaryDomainNotFound, "EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+			},
+		},
+	)
+}
+
+func TestClient_GetNameRecord(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS", {"encoding": "base64"}]}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":` + testNameServiceDomainAccount + `},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.GetNameRecord(context.TODO(), common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"))
+				},
+				ExpectedValue: name_service.NameRecordHeader{
+					ParentName: name_service.SolTldAuthority,
+					Owner:      common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					Class:      common.PublicKey{},
+					Data:       make([]byte, 8),
+				},
+				ExpectedError: nil,
+			},
+		},
+	)
+}
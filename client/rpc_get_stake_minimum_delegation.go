@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+
+	"github.com/EntySquare/solana-go-sdk/rpc"
+)
+
+type GetStakeMinimumDelegationConfig struct {
+	Commitment rpc.Commitment
+}
+
+func (c GetStakeMinimumDelegationConfig) toRpc() rpc.GetStakeMinimumDelegationConfig {
+	return rpc.GetStakeMinimumDelegationConfig{
+		Commitment: c.Commitment,
+	}
+}
+
+// GetStakeMinimumDelegation returns the stake minimum delegation, in lamports.
+func (c *Client) GetStakeMinimumDelegation(ctx context.Context) (uint64, error) {
+	return process(
+		func() (rpc.JsonRpcResponse[rpc.ValueWithContext[uint64]], error) {
+			return c.RpcClient.GetStakeMinimumDelegation(ctx)
+		},
+		value[uint64],
+	)
+}
+
+// GetStakeMinimumDelegationWithConfig returns the stake minimum delegation, in lamports.
+func (c *Client) GetStakeMinimumDelegationWithConfig(ctx context.Context, cfg GetStakeMinimumDelegationConfig) (uint64, error) {
+	return process(
+		func() (rpc.JsonRpcResponse[rpc.ValueWithContext[uint64]], error) {
+			return c.RpcClient.GetStakeMinimumDelegationWithConfig(ctx, cfg.toRpc())
+		},
+		value[uint64],
+	)
+}
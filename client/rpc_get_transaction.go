@@ -7,6 +7,7 @@ import (
 
 	"github.com/EntySquare/solana-go-sdk/common"
 	"github.com/EntySquare/solana-go-sdk/pkg/pointer"
+	"github.com/EntySquare/solana-go-sdk/pkg/program_log"
 	"github.com/EntySquare/solana-go-sdk/rpc"
 	"github.com/EntySquare/solana-go-sdk/types"
 	"github.com/mr-tron/base58"
@@ -194,3 +195,8 @@ func convertTransactionMeta(meta *rpc.TransactionMeta) (*TransactionMeta, error)
 		ComputeUnitsConsumed: meta.ComputeUnitsConsumed,
 	}, nil
 }
+
+// ParseLogs parses the log messages into a tree of program invocations
+func (m TransactionMeta) ParseLogs() program_log.Logs {
+	return program_log.Parse(m.LogMessages)
+}
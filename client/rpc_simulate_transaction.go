@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"fmt"
 
+	"github.com/EntySquare/solana-go-sdk/pkg/program_log"
 	"github.com/EntySquare/solana-go-sdk/rpc"
 	"github.com/EntySquare/solana-go-sdk/types"
 )
@@ -152,3 +153,8 @@ func convertSimulateTransactionAndContext(v rpc.ValueWithContext[rpc.SimulateTra
 		Value:   simulateTrasaction,
 	}, nil
 }
+
+// ParseLogs parses the logs into a tree of program invocations
+func (s SimulateTransaction) ParseLogs() program_log.Logs {
+	return program_log.Parse(s.Logs)
+}
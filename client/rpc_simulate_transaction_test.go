@@ -6,7 +6,9 @@ import (
 
 	"github.com/EntySquare/solana-go-sdk/common"
 	"github.com/EntySquare/solana-go-sdk/internal/client_test"
+	"github.com/EntySquare/solana-go-sdk/pkg/program_log"
 	"github.com/EntySquare/solana-go-sdk/rpc"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestClient_SimulateTransaction(t *testing.T) {
@@ -82,3 +84,21 @@ func TestClient_SimulateTransactionAndContext(t *testing.T) {
 		},
 	)
 }
+
+func TestSimulateTransaction_ParseLogs(t *testing.T) {
+	got := SimulateTransaction{
+		Logs: []string{
+			"Program 11111111111111111111111111111111 invoke [1]",
+			"Program 11111111111111111111111111111111 success",
+		},
+	}.ParseLogs()
+	assert.Equal(t, program_log.Logs{
+		Invocations: []*program_log.Invocation{
+			{
+				ProgramID: common.SystemProgramID,
+				Depth:     1,
+				Status:    program_log.InvocationStatusSuccess,
+			},
+		},
+	}, got)
+}
@@ -0,0 +1,287 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/stake"
+	"github.com/EntySquare/solana-go-sdk/program/system"
+	"github.com/EntySquare/solana-go-sdk/program/sysvar"
+	"github.com/EntySquare/solana-go-sdk/types"
+)
+
+var (
+	ErrStakeAccountNotFound        = errors.New("stake account not found")
+	ErrStakeAuthorityMismatch      = errors.New("stake authority mismatch")
+	ErrStakeBelowMinimumDelegation = errors.New("stake amount is below the minimum delegation")
+	ErrStakeInvalidState           = errors.New("invalid stake account state")
+	ErrStakeInvalidSplitAccount    = errors.New("invalid split stake account")
+	ErrStakeInsufficientLamports   = errors.New("insufficient stake lamports")
+)
+
+type StakeAccountInfo struct {
+	Lamports uint64
+	Account  stake.StakeAccount
+}
+
+// GetStakeAccount fetch and decode a stake account
+func (c *Client) GetStakeAccount(ctx context.Context, base58Addr string) (stake.StakeAccount, error) {
+	accountInfo, err := c.GetAccountInfo(ctx, base58Addr)
+	if err != nil {
+		return stake.StakeAccount{}, err
+	}
+	return stake.DeserializeStakeAccount(accountInfo.Data, accountInfo.Owner)
+}
+
+// GetWithdrawableStake returns how many lamports can be withdrawn from the stake account right now.
+// pass the lockup custodian if it will sign the withdraw instruction.
+func (c *Client) GetWithdrawableStake(ctx context.Context, base58Addr string, custodian *common.PublicKey) (uint64, error) {
+	accounts, clock, history, err := c.getStakeAccountsWithSysvars(ctx, base58Addr)
+	if err != nil {
+		return 0, err
+	}
+	return accounts[0].Account.Withdrawable(accounts[0].Lamports, clock.Epoch, clock.UnixTimestamp, history, custodian), nil
+}
+
+type CreateAndDelegateStakeParam struct {
+	FeePayer   common.PublicKey
+	Base       common.PublicKey
+	Seed       string
+	Authorized stake.Authorized
+	Lockup     stake.Lockup
+	Vote       common.PublicKey
+	// Amount is the lamports to delegate, the rent exempt reserve is funded on top of it
+	Amount  uint64
+	Signers []types.Account
+}
+
+// CreateAndDelegateStake creates a stake account at common.CreateWithSeed(Base, Seed, StakeProgramID),
+// initializes and delegates it to the vote account in one transaction.
+func (c *Client) CreateAndDelegateStake(ctx context.Context, param CreateAndDelegateStakeParam) (string, error) {
+	minimumDelegation, err := c.GetStakeMinimumDelegation(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get stake minimum delegation, err: %v", err)
+	}
+	if param.Amount < minimumDelegation {
+		return "", fmt.Errorf("%w, minimum: %v, got: %v", ErrStakeBelowMinimumDelegation, minimumDelegation, param.Amount)
+	}
+	rentExemptBalance, err := c.GetMinimumBalanceForRentExemption(ctx, stake.AccountSize)
+	if err != nil {
+		return "", fmt.Errorf("failed to get minimum balance for rent exemption, err: %v", err)
+	}
+
+	stakeAccount := common.CreateWithSeed(param.Base, param.Seed, common.StakeProgramID)
+	return c.sendInstructions(ctx, param.FeePayer, param.Signers, []types.Instruction{
+		system.CreateAccountWithSeed(system.CreateAccountWithSeedParam{
+			From:     param.FeePayer,
+			New:      stakeAccount,
+			Base:     param.Base,
+			Owner:    common.StakeProgramID,
+			Seed:     param.Seed,
+			Lamports: rentExemptBalance + param.Amount,
+			Space:    stake.AccountSize,
+		}),
+		stake.Initialize(stake.InitializeParam{
+			Stake:  stakeAccount,
+			Auth:   param.Authorized,
+			Lockup: param.Lockup,
+		}),
+		stake.DelegateStake(stake.DelegateStakeParam{
+			Stake: stakeAccount,
+			Auth:  param.Authorized.Staker,
+			Vote:  param.Vote,
+		}),
+	})
+}
+
+type SplitStakeParam struct {
+	FeePayer common.PublicKey
+	Stake    common.PublicKey
+	Auth     common.PublicKey
+	// SplitStake will be allocated and assigned to the stake program so it needs to sign
+	SplitStake common.PublicKey
+	Lamports   uint64
+	Signers    []types.Account
+}
+
+// SplitStake moves lamports (and its stake) into a new stake account after checking both sides stay valid.
+func (c *Client) SplitStake(ctx context.Context, param SplitStakeParam) (string, error) {
+	accountInfos, err := c.GetMultipleAccounts(ctx, []string{param.Stake.ToBase58(), param.SplitStake.ToBase58()})
+	if err != nil {
+		return "", err
+	}
+	if accountInfos[0].Owner != common.StakeProgramID {
+		return "", ErrStakeAccountNotFound
+	}
+	source, err := stake.StakeAccountFromData(accountInfos[0].Data)
+	if err != nil {
+		return "", err
+	}
+	if source.State != stake.StakeStateInitialized && source.State != stake.StakeStateStake {
+		return "", ErrStakeInvalidState
+	}
+	if source.Meta.Authorized.Staker != param.Auth {
+		return "", ErrStakeAuthorityMismatch
+	}
+
+	split := accountInfos[1]
+	if len(split.Data) != 0 || (split.Lamports != 0 && split.Owner != common.SystemProgramID) {
+		return "", ErrStakeInvalidSplitAccount
+	}
+
+	required := source.Meta.RentExemptReserve
+	if source.State == stake.StakeStateStake {
+		minimumDelegation, err := c.GetStakeMinimumDelegation(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get stake minimum delegation, err: %v", err)
+		}
+		required += minimumDelegation
+	}
+	if param.Lamports == 0 || param.Lamports > accountInfos[0].Lamports {
+		return "", fmt.Errorf("%w, balance: %v, split: %v", ErrStakeInsufficientLamports, accountInfos[0].Lamports, param.Lamports)
+	}
+	if remaining := accountInfos[0].Lamports - param.Lamports; remaining != 0 && remaining < required {
+		return "", fmt.Errorf("%w, remaining: %v, required: %v", ErrStakeInsufficientLamports, remaining, required)
+	}
+	if param.Lamports+split.Lamports < required {
+		return "", fmt.Errorf("%w, split: %v, required: %v", ErrStakeInsufficientLamports, param.Lamports+split.Lamports, required)
+	}
+
+	return c.sendInstructions(ctx, param.FeePayer, param.Signers, []types.Instruction{
+		system.Allocate(system.AllocateParam{
+			Account: param.SplitStake,
+			Space:   stake.AccountSize,
+		}),
+		system.Assign(system.AssignParam{
+			From:  param.SplitStake,
+			Owner: common.StakeProgramID,
+		}),
+		stake.Split(stake.SplitParam{
+			Stake:      param.Stake,
+			Auth:       param.Auth,
+			SplitStake: param.SplitStake,
+			Lamports:   param.Lamports,
+		}),
+	})
+}
+
+type MergeStakeParam struct {
+	FeePayer    common.PublicKey
+	Destination common.PublicKey
+	Source      common.PublicKey
+	Auth        common.PublicKey
+	Signers     []types.Account
+}
+
+// MergeStake merges source into destination after checking the stake program will accept them.
+func (c *Client) MergeStake(ctx context.Context, param MergeStakeParam) (string, error) {
+	accounts, clock, history, err := c.getStakeAccountsWithSysvars(ctx, param.Destination.ToBase58(), param.Source.ToBase58())
+	if err != nil {
+		return "", err
+	}
+	destination, source := accounts[0].Account, accounts[1].Account
+	if destination.Meta.Authorized.Staker != param.Auth {
+		return "", ErrStakeAuthorityMismatch
+	}
+	if err := stake.CheckMerge(destination, source, clock.Epoch, clock.UnixTimestamp, history); err != nil {
+		return "", err
+	}
+
+	return c.sendInstructions(ctx, param.FeePayer, param.Signers, []types.Instruction{
+		stake.Merge(stake.MergeParam{
+			From: param.Source,
+			Auth: param.Auth,
+			To:   param.Destination,
+		}),
+	})
+}
+
+type WithdrawStakeParam struct {
+	FeePayer common.PublicKey
+	Stake    common.PublicKey
+	Auth     common.PublicKey
+	To       common.PublicKey
+	// Lamports is the amount to withdraw, 0 means all withdrawable lamports
+	Lamports  uint64
+	Custodian *common.PublicKey
+	Signers   []types.Account
+}
+
+// WithdrawStake withdraws lamports which are neither staked, locked up nor reserved for rent,
+// or the whole balance of an account without stake.
+func (c *Client) WithdrawStake(ctx context.Context, param WithdrawStakeParam) (string, error) {
+	accounts, clock, history, err := c.getStakeAccountsWithSysvars(ctx, param.Stake.ToBase58())
+	if err != nil {
+		return "", err
+	}
+	account := accounts[0].Account
+
+	withdrawer := account.Meta.Authorized.Withdrawer
+	if account.State == stake.StakeStateUninitialized {
+		withdrawer = param.Stake
+	}
+	if withdrawer != param.Auth {
+		return "", ErrStakeAuthorityMismatch
+	}
+
+	// the program takes the whole balance or a part which leaves the reserve behind, nothing in between
+	balance := accounts[0].Lamports
+	partial, full := account.WithdrawLimits(balance, clock.Epoch, clock.UnixTimestamp, history, param.Custodian)
+	lamports := param.Lamports
+	if lamports == 0 {
+		lamports = account.Withdrawable(balance, clock.Epoch, clock.UnixTimestamp, history, param.Custodian)
+	}
+	if lamports == 0 || !account.CanWithdraw(lamports, balance, clock.Epoch, clock.UnixTimestamp, history, param.Custodian) {
+		return "", fmt.Errorf("%w, balance: %v, partial withdrawable: %v, full withdrawable: %v, withdraw: %v", ErrStakeInsufficientLamports, balance, partial, full, lamports)
+	}
+
+	return c.sendInstructions(ctx, param.FeePayer, param.Signers, []types.Instruction{
+		stake.Withdraw(stake.WithdrawParam{
+			Stake:     param.Stake,
+			Auth:      param.Auth,
+			To:        param.To,
+			Lamports:  lamports,
+			Custodian: param.Custodian,
+		}),
+	})
+}
+
+// getStakeAccountsWithSysvars fetches stake accounts, the clock and the stake history sysvars in one request
+func (c *Client) getStakeAccountsWithSysvars(ctx context.Context, base58Addrs ...string) ([]StakeAccountInfo, sysvar.Clock, sysvar.StakeHistory, error) {
+	addrs := make([]string, 0, len(base58Addrs)+2)
+	addrs = append(addrs, base58Addrs...)
+	addrs = append(addrs, common.SysVarClockPubkey.ToBase58(), common.SysVarStakeHistoryPubkey.ToBase58())
+	accountInfos, err := c.GetMultipleAccounts(ctx, addrs)
+	if err != nil {
+		return nil, sysvar.Clock{}, nil, err
+	}
+
+	clockInfo := accountInfos[len(base58Addrs)]
+	clock, err := sysvar.DeserializeClock(clockInfo.Data, clockInfo.Owner)
+	if err != nil {
+		return nil, sysvar.Clock{}, nil, fmt.Errorf("failed to deserialize clock sysvar, err: %v", err)
+	}
+	historyInfo := accountInfos[len(base58Addrs)+1]
+	history, err := sysvar.DeserializeStakeHistory(historyInfo.Data, historyInfo.Owner)
+	if err != nil {
+		return nil, sysvar.Clock{}, nil, fmt.Errorf("failed to deserialize stake history sysvar, err: %v", err)
+	}
+
+	accounts := make([]StakeAccountInfo, 0, len(base58Addrs))
+	for i, accountInfo := range accountInfos[:len(base58Addrs)] {
+		if accountInfo.Owner != common.StakeProgramID {
+			return nil, sysvar.Clock{}, nil, fmt.Errorf("%w, address: %v", ErrStakeAccountNotFound, base58Addrs[i])
+		}
+		account, err := stake.StakeAccountFromData(accountInfo.Data)
+		if err != nil {
+			return nil, sysvar.Clock{}, nil, fmt.Errorf("failed to deserialize stake account %v, err: %w", base58Addrs[i], err)
+		}
+		accounts = append(accounts, StakeAccountInfo{
+			Lamports: accountInfo.Lamports,
+			Account:  account,
+		})
+	}
+	return accounts, clock, history, nil
+}
@@ -0,0 +1,417 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/internal/client_test"
+	"github.com/EntySquare/solana-go-sdk/program/stake"
+	"github.com/EntySquare/solana-go-sdk/program/system"
+	"github.com/EntySquare/solana-go-sdk/program/sysvar"
+	"github.com/EntySquare/solana-go-sdk/rpc/rpctest"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetStakeAccount(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["9jgbHFVPdkzHbb3xnFQDHNaRSHGxqbRb9nJwm4oQHJH3", {"encoding": "base64"}]}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":{"data":["AgAAAIDVIgAAAAAAn7r3x6zXwx9/Ks8SwECcO2IBtAhFRsd/3J8GKEB19hPO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAN1QZm6yRd5VdEr5snklDU03YkT94TKMvep9o0wXFPWwAMqaOwAAAAD0AQAAAAAAAP//////////AAAAAAAA0D85MAAAAAAAAAAAAAA=","base64"],"executable":false,"lamports":1002282930,"owner":"Stake11111111111111111111111111111111111111","rentEpoch":0}},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.GetStakeAccount(
+						context.TODO(),
+						"9jgbHFVPdkzHbb3xnFQDHNaRSHGxqbRb9nJwm4oQHJH3",
+					)
+				},
+				ExpectedValue: stake.StakeAccount{
+					State: stake.StakeStateStake,
+					Meta: stake.Meta{
+						RentExemptReserve: 2282880,
+						Authorized: stake.Authorized{
+							Staker:     common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+							Withdrawer: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+						},
+					},
+					Stake: stake.Stake{
+						Delegation: stake.Delegation{
+							Voter:              common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+							Stake:              1000000000,
+							ActivationEpoch:    500,
+							DeactivationEpoch:  math.MaxUint64,
+							WarmupCooldownRate: 0.25,
+						},
+						CreditsObserved: 12345,
+					},
+				},
+				ExpectedError: nil,
+			},
+		},
+	)
+}
+
+func TestClient_GetWithdrawableStake(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getMultipleAccounts", "params":[["9jgbHFVPdkzHbb3xnFQDHNaRSHGxqbRb9nJwm4oQHJH3", "SysvarC1ock11111111111111111111111111111111", "SysvarStakeHistory1111111111111111111111111"], {"encoding": "base64"}]}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":[{"data":["AgAAAIDVIgAAAAAAn7r3x6zXwx9/Ks8SwECcO2IBtAhFRsd/3J8GKEB19hPO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAN1QZm6yRd5VdEr5snklDU03YkT94TKMvep9o0wXFPWwAMqaOwAAAAD0AQAAAAAAAP//////////AAAAAAAA0D85MAAAAAAAAAAAAAA=","base64"],"executable":false,"lamports":1002282930,"owner":"Stake11111111111111111111111111111111111111","rentEpoch":0},{"data":["AObfDAAAAACAWrtkAAAAAP4BAAAAAAAA/wEAAAAAAAAg4bxkAAAAAA==","base64"],"executable":false,"lamports":1169280,"owner":"Sysvar1111111111111111111111111111111111111","rentEpoch":0},{"data":["AAAAAAAAAAA=","base64"],"executable":false,"lamports":114979200,"owner":"Sysvar1111111111111111111111111111111111111","rentEpoch":0}]},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.GetWithdrawableStake(
+						context.TODO(),
+						"9jgbHFVPdkzHbb3xnFQDHNaRSHGxqbRb9nJwm4oQHJH3",
+						nil,
+					)
+				},
+				ExpectedValue: uint64(50),
+				ExpectedError: nil,
+			},
+			{
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getMultipleAccounts", "params":[["9jgbHFVPdkzHbb3xnFQDHNaRSHGxqbRb9nJwm4oQHJH3", "SysvarC1ock11111111111111111111111111111111", "SysvarStakeHistory1111111111111111111111111"], {"encoding": "base64"}]}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":[null,{"data":["AObfDAAAAACAWrtkAAAAAP4BAAAAAAAA/wEAAAAAAAAg4bxkAAAAAA==","base64"],"executable":false,"lamports":1169280,"owner":"Sysvar1111111111111111111111111111111111111","rentEpoch":0},{"data":["AAAAAAAAAAA=","base64"],"executable":false,"lamports":114979200,"owner":"Sysvar1111111111111111111111111111111111111","rentEpoch":0}]},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.GetWithdrawableStake(
+						context.TODO(),
+						"9jgbHFVPdkzHbb3xnFQDHNaRSHGxqbRb9nJwm4oQHJH3",
+						nil,
+					)
+				},
+				ExpectedValue: uint64(0),
+				ExpectedError: fmt.Errorf("%w, address: %v", ErrStakeAccountNotFound, "9jgbHFVPdkzHbb3xnFQDHNaRSHGxqbRb9nJwm4oQHJH3"),
+			},
+		},
+	)
+}
+
+func TestClient_MergeStake(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				Name:         "staker mismatch",
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getMultipleAccounts", "params":[["9jgbHFVPdkzHbb3xnFQDHNaRSHGxqbRb9nJwm4oQHJH3", "9jgbHFVPdkzHbb3xnFQDHNaRSHGxqbRb9nJwm4oQHJH3", "SysvarC1ock11111111111111111111111111111111", "SysvarStakeHistory1111111111111111111111111"], {"encoding": "base64"}]}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":[{"data":["AgAAAIDVIgAAAAAAn7r3x6zXwx9/Ks8SwECcO2IBtAhFRsd/3J8GKEB19hPO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAN1QZm6yRd5VdEr5snklDU03YkT94TKMvep9o0wXFPWwAMqaOwAAAAD0AQAAAAAAAP//////////AAAAAAAA0D85MAAAAAAAAAAAAAA=","base64"],"executable":false,"lamports":1002282930,"owner":"Stake11111111111111111111111111111111111111","rentEpoch":0},{"data":["AgAAAIDVIgAAAAAAn7r3x6zXwx9/Ks8SwECcO2IBtAhFRsd/3J8GKEB19hPO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAN1QZm6yRd5VdEr5snklDU03YkT94TKMvep9o0wXFPWwAMqaOwAAAAD0AQAAAAAAAP//////////AAAAAAAA0D85MAAAAAAAAAAAAAA=","base64"],"executable":false,"lamports":1002282930,"owner":"Stake11111111111111111111111111111111111111","rentEpoch":0},{"data":["AObfDAAAAACAWrtkAAAAAP4BAAAAAAAA/wEAAAAAAAAg4bxkAAAAAA==","base64"],"executable":false,"lamports":1169280,"owner":"Sysvar1111111111111111111111111111111111111","rentEpoch":0},{"data":["AAAAAAAAAAA=","base64"],"executable":false,"lamports":114979200,"owner":"Sysvar1111111111111111111111111111111111111","rentEpoch":0}]},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.MergeStake(
+						context.TODO(),
+						MergeStakeParam{
+							FeePayer:    common.PublicKeyFromString("RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7"),
+							Destination: common.PublicKeyFromString("9jgbHFVPdkzHbb3xnFQDHNaRSHGxqbRb9nJwm4oQHJH3"),
+							Source:      common.PublicKeyFromString("9jgbHFVPdkzHbb3xnFQDHNaRSHGxqbRb9nJwm4oQHJH3"),
+							Auth:        common.PublicKeyFromString("RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7"),
+						},
+					)
+				},
+				ExpectedValue: "",
+				ExpectedError: ErrStakeAuthorityMismatch,
+			},
+		},
+	)
+}
+
+var (
+	stakeAuthority, _ = types.AccountFromSeed(make([]byte, 32))
+	stakeVote         = common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	stakeAddress      = common.PublicKeyFromString("9jgbHFVPdkzHbb3xnFQDHNaRSHGxqbRb9nJwm4oQHJH3")
+)
+
+const stakeRentExemptReserve = 2282880
+
+// stakeAccountData encodes a stake account whose staker and withdrawer are stakeAuthority,
+// a zero amount is an initialized account
+func stakeAccountData(amount, deactivationEpoch uint64) []byte {
+	b := make([]byte, stake.AccountSize)
+	binary.LittleEndian.PutUint32(b, uint32(stake.StakeStateInitialized))
+	binary.LittleEndian.PutUint64(b[4:], stakeRentExemptReserve)
+	copy(b[12:], stakeAuthority.PublicKey.Bytes())
+	copy(b[44:], stakeAuthority.PublicKey.Bytes())
+	if amount == 0 {
+		return b
+	}
+	binary.LittleEndian.PutUint32(b, uint32(stake.StakeStateStake))
+	copy(b[124:], stakeVote.Bytes())
+	binary.LittleEndian.PutUint64(b[156:], amount)
+	binary.LittleEndian.PutUint64(b[164:], 500)
+	binary.LittleEndian.PutUint64(b[172:], deactivationEpoch)
+	binary.LittleEndian.PutUint64(b[180:], math.Float64bits(0.25))
+	return b
+}
+
+// testStakeHistory has the cluster cool down 10% of its stake in epoch 509
+var testStakeHistory = sysvar.StakeHistory{
+	{Epoch: 509, Effective: 100_000_000_000, Deactivating: 10_000_000_000},
+}
+
+func stakeHistoryData(history sysvar.StakeHistory) []byte {
+	b := binary.LittleEndian.AppendUint64(nil, uint64(len(history)))
+	for _, entry := range history {
+		b = binary.LittleEndian.AppendUint64(b, entry.Epoch)
+		b = binary.LittleEndian.AppendUint64(b, entry.Effective)
+		b = binary.LittleEndian.AppendUint64(b, entry.Activating)
+		b = binary.LittleEndian.AppendUint64(b, entry.Deactivating)
+	}
+	return b
+}
+
+// newStakeServer serves the clock at epoch 510, the stake history and a funded stakeAuthority
+func newStakeServer(t *testing.T) (*rpctest.Server, *Client) {
+	clock := make([]byte, sysvar.ClockSize)
+	binary.LittleEndian.PutUint64(clock[16:], 510)
+	s := rpctest.NewServer(
+		rpctest.WithAccount(common.SysVarClockPubkey, rpctest.Account{Lamports: 1169280, Owner: common.SysVarPubkey, Data: clock}),
+		rpctest.WithAccount(common.SysVarStakeHistoryPubkey, rpctest.Account{Lamports: 114979200, Owner: common.SysVarPubkey, Data: stakeHistoryData(testStakeHistory)}),
+		rpctest.WithAccount(stakeAuthority.PublicKey, rpctest.Account{Lamports: 10_000_000_000, Owner: common.SystemProgramID}),
+	)
+	t.Cleanup(s.Close)
+	return s, NewClient(s.URL)
+}
+
+// assertLastInstructions compares the instructions of the last transaction the server got with want.
+// the flags of an account are merged across a message so only the program, the accounts and the data are compared.
+func assertLastInstructions(t *testing.T, s *rpctest.Server, want []types.Instruction) {
+	txs := s.Transactions()
+	require.NotEmpty(t, txs)
+	got := txs[len(txs)-1].Transaction.Message.DecompileInstructions()
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.Equal(t, want[i].ProgramID, got[i].ProgramID)
+		assert.Equal(t, want[i].Data, got[i].Data)
+		require.Len(t, got[i].Accounts, len(want[i].Accounts))
+		for j := range want[i].Accounts {
+			assert.Equal(t, want[i].Accounts[j].PubKey, got[i].Accounts[j].PubKey)
+		}
+	}
+}
+
+func TestClient_CreateAndDelegateStake(t *testing.T) {
+	s, c := newStakeServer(t)
+	param := CreateAndDelegateStakeParam{
+		FeePayer:   stakeAuthority.PublicKey,
+		Base:       stakeAuthority.PublicKey,
+		Seed:       "stake:0",
+		Authorized: stake.Authorized{Staker: stakeAuthority.PublicKey, Withdrawer: stakeAuthority.PublicKey},
+		Vote:       stakeVote,
+		Amount:     1_000_000_000,
+		Signers:    []types.Account{stakeAuthority},
+	}
+	_, err := c.CreateAndDelegateStake(context.Background(), param)
+	require.NoError(t, err)
+
+	stakeAccount := common.CreateWithSeed(stakeAuthority.PublicKey, "stake:0", common.StakeProgramID)
+	assertLastInstructions(t, s, []types.Instruction{
+		system.CreateAccountWithSeed(system.CreateAccountWithSeedParam{
+			From:     stakeAuthority.PublicKey,
+			New:      stakeAccount,
+			Base:     stakeAuthority.PublicKey,
+			Owner:    common.StakeProgramID,
+			Seed:     "stake:0",
+			Lamports: rpctest.MinimumBalanceForRentExemption(stake.AccountSize) + 1_000_000_000,
+			Space:    stake.AccountSize,
+		}),
+		stake.Initialize(stake.InitializeParam{Stake: stakeAccount, Auth: param.Authorized}),
+		stake.DelegateStake(stake.DelegateStakeParam{Stake: stakeAccount, Auth: stakeAuthority.PublicKey, Vote: stakeVote}),
+	})
+
+	param.Amount = 999_999_999
+	_, err = c.CreateAndDelegateStake(context.Background(), param)
+	assert.ErrorIs(t, err, ErrStakeBelowMinimumDelegation)
+}
+
+func TestClient_SplitStake(t *testing.T) {
+	split, _ := types.AccountFromSeed(append(make([]byte, 31), 1))
+	tests := []struct {
+		name     string
+		data     []byte
+		balance  uint64
+		auth     common.PublicKey
+		lamports uint64
+		err      error
+	}{
+		{
+			name:     "active",
+			data:     stakeAccountData(3_000_000_000, math.MaxUint64),
+			balance:  stakeRentExemptReserve + 3_000_000_000,
+			auth:     stakeAuthority.PublicKey,
+			lamports: stakeRentExemptReserve + 1_000_000_000,
+		},
+		{
+			name:     "initialized",
+			data:     stakeAccountData(0, 0),
+			balance:  2 * stakeRentExemptReserve,
+			auth:     stakeAuthority.PublicKey,
+			lamports: stakeRentExemptReserve,
+		},
+		{
+			name:     "remaining below minimum delegation",
+			data:     stakeAccountData(3_000_000_000, math.MaxUint64),
+			balance:  stakeRentExemptReserve + 3_000_000_000,
+			auth:     stakeAuthority.PublicKey,
+			lamports: 2_500_000_000,
+			err:      ErrStakeInsufficientLamports,
+		},
+		{
+			name:     "split below minimum delegation",
+			data:     stakeAccountData(3_000_000_000, math.MaxUint64),
+			balance:  stakeRentExemptReserve + 3_000_000_000,
+			auth:     stakeAuthority.PublicKey,
+			lamports: 500_000_000,
+			err:      ErrStakeInsufficientLamports,
+		},
+		{
+			name:     "staker mismatch",
+			data:     stakeAccountData(3_000_000_000, math.MaxUint64),
+			balance:  stakeRentExemptReserve + 3_000_000_000,
+			auth:     split.PublicKey,
+			lamports: stakeRentExemptReserve + 1_000_000_000,
+			err:      ErrStakeAuthorityMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, c := newStakeServer(t)
+			s.SetAccount(stakeAddress, rpctest.Account{Lamports: tt.balance, Owner: common.StakeProgramID, Data: tt.data})
+			_, err := c.SplitStake(context.Background(), SplitStakeParam{
+				FeePayer:   stakeAuthority.PublicKey,
+				Stake:      stakeAddress,
+				Auth:       tt.auth,
+				SplitStake: split.PublicKey,
+				Lamports:   tt.lamports,
+				Signers:    []types.Account{stakeAuthority, split},
+			})
+			assert.ErrorIs(t, err, tt.err)
+			if tt.err != nil {
+				assert.Empty(t, s.Transactions())
+				return
+			}
+			assertLastInstructions(t, s, []types.Instruction{
+				system.Allocate(system.AllocateParam{Account: split.PublicKey, Space: stake.AccountSize}),
+				system.Assign(system.AssignParam{From: split.PublicKey, Owner: common.StakeProgramID}),
+				stake.Split(stake.SplitParam{Stake: stakeAddress, Auth: stakeAuthority.PublicKey, SplitStake: split.PublicKey, Lamports: tt.lamports}),
+			})
+		})
+	}
+}
+
+func TestClient_WithdrawStake(t *testing.T) {
+	const active = stakeRentExemptReserve + 1_000_000_000 + 50
+	tests := []struct {
+		name     string
+		data     []byte
+		balance  uint64
+		auth     common.PublicKey
+		lamports uint64
+		want     uint64
+		err      error
+	}{
+		{
+			name:    "initialized all",
+			data:    stakeAccountData(0, 0),
+			balance: stakeRentExemptReserve + 1000,
+			auth:    stakeAuthority.PublicKey,
+			want:    stakeRentExemptReserve + 1000,
+		},
+		{
+			name:     "initialized leaves the reserve",
+			data:     stakeAccountData(0, 0),
+			balance:  stakeRentExemptReserve + 1000,
+			auth:     stakeAuthority.PublicKey,
+			lamports: 1000,
+			want:     1000,
+		},
+		{
+			name:     "initialized takes part of the reserve",
+			data:     stakeAccountData(0, 0),
+			balance:  stakeRentExemptReserve + 1000,
+			auth:     stakeAuthority.PublicKey,
+			lamports: 1001,
+			err:      ErrStakeInsufficientLamports,
+		},
+		{
+			name:     "deactivated all",
+			data:     stakeAccountData(1_000_000_000, 505),
+			balance:  active,
+			auth:     stakeAuthority.PublicKey,
+			lamports: active,
+			want:     active,
+		},
+		{
+			name:     "deactivated takes part of the reserve",
+			data:     stakeAccountData(1_000_000_000, 505),
+			balance:  active,
+			auth:     stakeAuthority.PublicKey,
+			lamports: active - 1,
+			err:      ErrStakeInsufficientLamports,
+		},
+		{
+			name:    "cooling down takes what has cooled down",
+			data:    stakeAccountData(1_000_000_000, 509),
+			balance: active,
+			auth:    stakeAuthority.PublicKey,
+			want:    900_000_050,
+		},
+		{
+			name:     "cooling down all",
+			data:     stakeAccountData(1_000_000_000, 509),
+			balance:  active,
+			auth:     stakeAuthority.PublicKey,
+			lamports: active,
+			err:      ErrStakeInsufficientLamports,
+		},
+		{
+			name:    "active takes what is not staked",
+			data:    stakeAccountData(1_000_000_000, math.MaxUint64),
+			balance: active,
+			auth:    stakeAuthority.PublicKey,
+			want:    50,
+		},
+		{
+			name:     "active all",
+			data:     stakeAccountData(1_000_000_000, math.MaxUint64),
+			balance:  active,
+			auth:     stakeAuthority.PublicKey,
+			lamports: active,
+			err:      ErrStakeInsufficientLamports,
+		},
+		{
+			name:    "withdrawer mismatch",
+			data:    stakeAccountData(0, 0),
+			balance: stakeRentExemptReserve + 1000,
+			auth:    stakeVote,
+			err:     ErrStakeAuthorityMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, c := newStakeServer(t)
+			s.SetAccount(stakeAddress, rpctest.Account{Lamports: tt.balance, Owner: common.StakeProgramID, Data: tt.data})
+			_, err := c.WithdrawStake(context.Background(), WithdrawStakeParam{
+				FeePayer: stakeAuthority.PublicKey,
+				Stake:    stakeAddress,
+				Auth:     tt.auth,
+				To:       stakeAuthority.PublicKey,
+				Lamports: tt.lamports,
+				Signers:  []types.Account{stakeAuthority},
+			})
+			assert.ErrorIs(t, err, tt.err)
+			if tt.err != nil {
+				assert.Empty(t, s.Transactions())
+				return
+			}
+			assertLastInstructions(t, s, []types.Instruction{
+				stake.Withdraw(stake.WithdrawParam{Stake: stakeAddress, Auth: stakeAuthority.PublicKey, To: stakeAuthority.PublicKey, Lamports: tt.want}),
+			})
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/sysvar"
+)
+
+// GetSysvarClock fetch and decode the clock sysvar
+func (c *Client) GetSysvarClock(ctx context.Context) (sysvar.Clock, error) {
+	return getSysvar(ctx, c, common.SysVarClockPubkey, sysvar.DeserializeClock)
+}
+
+// GetSysvarRent fetch and decode the rent sysvar
+func (c *Client) GetSysvarRent(ctx context.Context) (sysvar.Rent, error) {
+	return getSysvar(ctx, c, common.SysVarRentPubkey, sysvar.DeserializeRent)
+}
+
+// GetSysvarEpochSchedule fetch and decode the epoch schedule sysvar
+func (c *Client) GetSysvarEpochSchedule(ctx context.Context) (sysvar.EpochSchedule, error) {
+	return getSysvar(ctx, c, common.SysVarEpochSchedulePubkey, sysvar.DeserializeEpochSchedule)
+}
+
+// GetSysvarFees fetch and decode the fees sysvar
+func (c *Client) GetSysvarFees(ctx context.Context) (sysvar.Fees, error) {
+	return getSysvar(ctx, c, common.SysVarFeesPubkey, sysvar.DeserializeFees)
+}
+
+// GetSysvarRecentBlockhashes fetch and decode the recent blockhashes sysvar
+func (c *Client) GetSysvarRecentBlockhashes(ctx context.Context) (sysvar.RecentBlockhashes, error) {
+	return getSysvar(ctx, c, common.SysVarRecentBlockhashsPubkey, sysvar.DeserializeRecentBlockhashes)
+}
+
+// GetSysvarStakeHistory fetch and decode the stake history sysvar
+func (c *Client) GetSysvarStakeHistory(ctx context.Context) (sysvar.StakeHistory, error) {
+	return getSysvar(ctx, c, common.SysVarStakeHistoryPubkey, sysvar.DeserializeStakeHistory)
+}
+
+// GetSysvarEpochRewards fetch and decode the epoch rewards sysvar
+func (c *Client) GetSysvarEpochRewards(ctx context.Context) (sysvar.EpochRewards, error) {
+	return getSysvar(ctx, c, common.SysVarEpochRewardsPubkey, sysvar.DeserializeEpochRewards)
+}
+
+// GetSysvarSlotHashes fetch and decode the slot hashes sysvar
+func (c *Client) GetSysvarSlotHashes(ctx context.Context) (sysvar.SlotHashes, error) {
+	return getSysvar(ctx, c, common.SysVarSlotHashesPubkey, sysvar.DeserializeSlotHashes)
+}
+
+func getSysvar[T any](ctx context.Context, c *Client, pubkey common.PublicKey, deserialize func([]byte, common.PublicKey) (T, error)) (T, error) {
+	accountInfo, err := c.GetAccountInfo(ctx, pubkey.ToBase58())
+	if err != nil {
+		var output T
+		return output, err
+	}
+	return deserialize(accountInfo.Data, accountInfo.Owner)
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/internal/client_test"
+	"github.com/EntySquare/solana-go-sdk/program/sysvar"
+)
+
+func TestClient_GetSysvarClock(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["SysvarC1ock11111111111111111111111111111111", {"encoding": "base64"}]}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":{"data":["AObfDAAAAACAWrtkAAAAAP4BAAAAAAAA/wEAAAAAAAAg4bxkAAAAAA==","base64"],"executable":false,"lamports":1169280,"owner":"Sysvar1111111111111111111111111111111111111","rentEpoch":0}},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.GetSysvarClock(context.TODO())
+				},
+				ExpectedValue: sysvar.Clock{
+					Slot:                216000000,
+					EpochStartTimestamp: 1690000000,
+					Epoch:               510,
+					LeaderScheduleEpoch: 511,
+					UnixTimestamp:       1690100000,
+				},
+				ExpectedError: nil,
+			},
+			{
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["SysvarC1ock11111111111111111111111111111111", {"encoding": "base64"}]}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":null},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.GetSysvarClock(context.TODO())
+				},
+				ExpectedValue: sysvar.Clock{},
+				ExpectedError: sysvar.ErrInvalidAccountOwner,
+			},
+		},
+	)
+}
+
+func TestClient_GetSysvarRent(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["SysvarRent111111111111111111111111111111111", {"encoding": "base64"}]}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":{"data":["mA0AAAAAAAAAAAAAAAAAQDI=","base64"],"executable":false,"lamports":1009200,"owner":"Sysvar1111111111111111111111111111111111111","rentEpoch":0}},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.GetSysvarRent(context.TODO())
+				},
+				ExpectedValue: sysvar.Rent{
+					LamportsPerByteYear: 3480,
+					ExemptionThreshold:  2,
+					BurnPercent:         50,
+				},
+				ExpectedError: nil,
+			},
+		},
+	)
+}
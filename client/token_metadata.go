@@ -0,0 +1,116 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/EntySquare/solana-go-sdk/program/token"
+)
+
+// getMultipleAccountsLimit is the max number of accounts a getMultipleAccounts request can ask for
+const getMultipleAccountsLimit = 100
+
+type NFT struct {
+	Mint            common.PublicKey
+	TokenAccount    common.PublicKey
+	MetadataAccount common.PublicKey
+	Metadata        token_metadata.Metadata
+	// EditionAccount is the master edition or edition pda of the mint
+	EditionAccount common.PublicKey
+	// EditionKey is KeyMasterEditionV1, KeyMasterEditionV2 or KeyEditionV1,
+	// it is KeyUninitialized when the mint has no edition account
+	EditionKey token_metadata.Key
+	// Edition is the decoded edition account, one of MasterEditionV1, MasterEditionV2 and Edition
+	Edition any
+	// CollectionVerified means the collection of the metadata has been verified by its authority
+	CollectionVerified bool
+}
+
+// GetNFTsByOwner lists the NFTs a wallet holds. A token account holds an NFT when its amount is 1,
+// the decimals of its mint are 0 and the mint has a metadata account.
+func (c *Client) GetNFTsByOwner(ctx context.Context, owner common.PublicKey) ([]NFT, error) {
+	tokenAccounts, err := c.GetTokenAccountsByOwner(ctx, owner.ToBase58())
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]NFT, 0, len(tokenAccounts))
+	for pubkey, tokenAccount := range tokenAccounts {
+		if tokenAccount.Amount != 1 {
+			continue
+		}
+		metadataAccount, err := token_metadata.GetTokenMetaPubkey(tokenAccount.Mint)
+		if err != nil {
+			return nil, err
+		}
+		editionAccount, err := token_metadata.GetMasterEdition(tokenAccount.Mint)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, NFT{
+			Mint:            tokenAccount.Mint,
+			TokenAccount:    pubkey,
+			MetadataAccount: metadataAccount,
+			EditionAccount:  editionAccount,
+		})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return bytes.Compare(candidates[i].TokenAccount[:], candidates[j].TokenAccount[:]) < 0
+	})
+
+	// every candidate asks for its mint, metadata and edition
+	const accountsPerNFT = 3
+	nfts := make([]NFT, 0, len(candidates))
+	for start := 0; start < len(candidates); start += getMultipleAccountsLimit / accountsPerNFT {
+		end := start + getMultipleAccountsLimit/accountsPerNFT
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+
+		addrs := make([]string, 0, (end-start)*accountsPerNFT)
+		for _, nft := range candidates[start:end] {
+			addrs = append(addrs, nft.Mint.ToBase58(), nft.MetadataAccount.ToBase58(), nft.EditionAccount.ToBase58())
+		}
+		accountInfos, err := c.GetMultipleAccounts(ctx, addrs)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, nft := range candidates[start:end] {
+			mintAccountInfo := accountInfos[i*accountsPerNFT]
+			metadataAccountInfo := accountInfos[i*accountsPerNFT+1]
+			editionAccountInfo := accountInfos[i*accountsPerNFT+2]
+
+			if mintAccountInfo.Owner != common.TokenProgramID || metadataAccountInfo.Owner != common.MetaplexTokenMetaProgramID {
+				continue
+			}
+			mint, err := token.MintAccountFromData(mintAccountInfo.Data)
+			if err != nil || mint.Decimals != 0 {
+				continue
+			}
+			// an account the decoder doesn't know drops the nft, not the listing
+			metadata, err := token_metadata.MetadataDeserialize(metadataAccountInfo.Data)
+			if err != nil {
+				continue
+			}
+			nft.Metadata = metadata
+			nft.CollectionVerified = metadata.Collection != nil && metadata.Collection.Verified
+
+			if editionAccountInfo.Owner == common.MetaplexTokenMetaProgramID {
+				edition, err := token_metadata.AccountDeserialize(editionAccountInfo.Data)
+				if err != nil {
+					continue
+				}
+				nft.EditionKey = token_metadata.Key(editionAccountInfo.Data[0])
+				nft.Edition = edition
+			}
+
+			nfts = append(nfts, nft)
+		}
+	}
+
+	return nfts, nil
+}
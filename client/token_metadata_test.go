@@ -0,0 +1,219 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/internal/client_test"
+	"github.com/EntySquare/solana-go-sdk/pkg/pointer"
+	"github.com/EntySquare/solana-go-sdk/program/metaplex/token_metadata"
+)
+
+func TestClient_GetNFTsByOwner(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				Calls: []client_test.Call{
+					{
+						RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getTokenAccountsByOwner", "params":["EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7", {"programId": "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"}, {"encoding": "base64"}]}`,
+						ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":[{"account":{"data":["C3TPEokRRjiOPqsCkpTk8Npv+kyqiCPIavGRQAAAAADO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AIAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA","base64"],"executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0,"space":165},"pubkey":"tokenAccount4111111111111111111111111111111"},{"account":{"data":["C3TPEifXSbtzKXhDn0DoPdZFPT7mVYk/esvLAAAAAADO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AEAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA","base64"],"executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0,"space":165},"pubkey":"tokenAccount3111111111111111111111111111111"},{"account":{"data":["C3TPEcadTT5YFEWEq+zritIagDEiIu62iqYEwAAAAADO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AEAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA","base64"],"executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0,"space":165},"pubkey":"tokenAccount2111111111111111111111111111111"},{"account":{"data":["C3TPEWVjUME8/xLFuJju183vwyNd8FQtmoA+gAAAAADO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AEAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA","base64"],"executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0,"space":165},"pubkey":"tokenAccount1111111111111111111111111111111"}]},"id":1}`,
+					},
+					{
+						RequestBody: `{"jsonrpc":"2.0", "id":1, "method":"getMultipleAccounts", "params":[["mint111111111111111111111111111111111111111", "GnPUDj6mrPngva7VgUrcBV9qJBMJHHFvDYH9yBV8W2r8", "GuawUt2ngqmrkeUtFAVCnvA7f1U69LKmfTL3iJyBAPNJ", "mint211111111111111111111111111111111111111", "BVxfT4kkhL9k3rV2tTYWYDngXBTr8fheiGNHPzKJXjQL", "EmQPPDUkFLWk2U7FNBaDoYwCoPxSuCHQLQZarJmjVujE", "mint311111111111111111111111111111111111111", "D3G6V9UNRnCsLWbEW2cVdjvpTCkWFq1Q42AMtDo7JjKB", "3YdQhhGzqmppmmvowq5edjE7F9qbyb2ZDDgPRTjWMeFc"], {"encoding": "base64"}]}`,
+						ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":[` +
+							`{"data":["AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA==","base64"],"executable":false,"lamports":1461600,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0},` +
+							`{"data":["BM7Th+bDb1f+k++PUW6fMYxtieDFGDHfPXsITm1uiOTwC3TPEWVjUME8/xLFuJju183vwyNd8FQtmoA+gAAAAAADAAAAb25lAQAAAFQcAAAAaHR0cHM6Ly9leGFtcGxlLmNvbS9vbmUuanNvbgAAAAABAAEAAQEJK1dFUUi0pPGOy/YBOelk5+op42Hm7vbujjpiAAAAAAAA","base64"],"executable":false,"lamports":5616720,"owner":"metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s","rentEpoch":0},` +
+							`{"data":["BgEAAAAAAAAAAQAAAAAAAAAA","base64"],"executable":false,"lamports":2853600,"owner":"metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s","rentEpoch":0},` +
+							`{"data":["AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA==","base64"],"executable":false,"lamports":1461600,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0},` +
+							`{"data":["BM7Th+bDb1f+k++PUW6fMYxtieDFGDHfPXsITm1uiOTwC3TPEcadTT5YFEWEq+zritIagDEiIu62iqYEwAAAAAADAAAAdHdvAQAAAFQcAAAAaHR0cHM6Ly9leGFtcGxlLmNvbS90d28uanNvbgAAAAABAAEAAQAJK1dFUUi0pPGOy/YBOelk5+op42Hm7vbujjpiAAAAAAAA","base64"],"executable":false,"lamports":5616720,"owner":"metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s","rentEpoch":0},` +
+							`{"data":["AexX4Zuj2lEo3JD1o8f9UWfrnPcWzavbwWxwCw0zkO+zAQAAAAAAAAA=","base64"],"executable":false,"lamports":1689120,"owner":"metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s","rentEpoch":0},` +
+							`{"data":["AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAGAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA==","base64"],"executable":false,"lamports":1461600,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0},` +
+							`{"data":["BM7Th+bDb1f+k++PUW6fMYxtieDFGDHfPXsITm1uiOTwC3TPEifXSbtzKXhDn0DoPdZFPT7mVYk/esvLAAAAAAAFAAAAdGhyZWUBAAAAVB4AAABodHRwczovL2V4YW1wbGUuY29tL3RocmVlLmpzb24AAAAAAQABAAEACStXRVFItKTxjsv2ATnpZOfqKeNh5u727o46YgAAAAAAAA==","base64"],"executable":false,"lamports":5616720,"owner":"metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s","rentEpoch":0},` +
+							`null]},"id":1}`,
+					},
+				},
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.GetNFTsByOwner(context.TODO(), common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"))
+				},
+				ExpectedValue: []NFT{
+					{
+						Mint:            common.PublicKeyFromString("mint111111111111111111111111111111111111111"),
+						TokenAccount:    common.PublicKeyFromString("tokenAccount1111111111111111111111111111111"),
+						MetadataAccount: common.PublicKeyFromString("GnPUDj6mrPngva7VgUrcBV9qJBMJHHFvDYH9yBV8W2r8"),
+						Metadata: token_metadata.Metadata{
+							Key:             token_metadata.KeyMetadataV1,
+							UpdateAuthority: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+							Mint:            common.PublicKeyFromString("mint111111111111111111111111111111111111111"),
+							Data: token_metadata.Data{
+								Name:   "one",
+								Symbol: "T",
+								Uri:    "https://example.com/one.json",
+							},
+							IsMutable:     true,
+							TokenStandard: pointer.Get(token_metadata.NonFungible),
+							Collection: &token_metadata.Collection{
+								Verified: true,
+								Key:      common.PublicKeyFromString("co11ection111111111111111111111111111111111"),
+							},
+						},
+						EditionAccount: common.PublicKeyFromString("GuawUt2ngqmrkeUtFAVCnvA7f1U69LKmfTL3iJyBAPNJ"),
+						EditionKey:     token_metadata.KeyMasterEditionV2,
+						Edition: token_metadata.MasterEditionV2{
+							Key:       token_metadata.KeyMasterEditionV2,
+							Supply:    1,
+							MaxSupply: pointer.Get[uint64](0),
+						},
+						CollectionVerified: true,
+					},
+					{
+						Mint:            common.PublicKeyFromString("mint211111111111111111111111111111111111111"),
+						TokenAccount:    common.PublicKeyFromString("tokenAccount2111111111111111111111111111111"),
+						MetadataAccount: common.PublicKeyFromString("BVxfT4kkhL9k3rV2tTYWYDngXBTr8fheiGNHPzKJXjQL"),
+						Metadata: token_metadata.Metadata{
+							Key:             token_metadata.KeyMetadataV1,
+							UpdateAuthority: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+							Mint:            common.PublicKeyFromString("mint211111111111111111111111111111111111111"),
+							Data: token_metadata.Data{
+								Name:   "two",
+								Symbol: "T",
+								Uri:    "https://example.com/two.json",
+							},
+							IsMutable:     true,
+							TokenStandard: pointer.Get(token_metadata.NonFungible),
+							Collection: &token_metadata.Collection{
+								Verified: false,
+								Key:      common.PublicKeyFromString("co11ection111111111111111111111111111111111"),
+							},
+						},
+						EditionAccount: common.PublicKeyFromString("EmQPPDUkFLWk2U7FNBaDoYwCoPxSuCHQLQZarJmjVujE"),
+						EditionKey:     token_metadata.KeyEditionV1,
+						Edition: token_metadata.Edition{
+							Key:     token_metadata.KeyEditionV1,
+							Parent:  common.PublicKeyFromString("GuawUt2ngqmrkeUtFAVCnvA7f1U69LKmfTL3iJyBAPNJ"),
+							Edition: 1,
+						},
+						CollectionVerified: false,
+					},
+				},
+				ExpectedError: nil,
+			},
+			{
+				Calls: []client_test.Call{
+					{
+						RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getTokenAccountsByOwner", "params":["EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7", {"programId": "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"}, {"encoding": "base64"}]}`,
+						ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":[{"account":{"data":["C3TPEokRRjiOPqsCkpTk8Npv+kyqiCPIavGRQAAAAADO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AIAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA","base64"],"executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0,"space":165},"pubkey":"tokenAccount4111111111111111111111111111111"},{"account":{"data":["C3TPEifXSbtzKXhDn0DoPdZFPT7mVYk/esvLAAAAAADO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AEAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA","base64"],"executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0,"space":165},"pubkey":"tokenAccount3111111111111111111111111111111"},{"account":{"data":["C3TPEcadTT5YFEWEq+zritIagDEiIu62iqYEwAAAAADO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AEAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA","base64"],"executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0,"space":165},"pubkey":"tokenAccount2111111111111111111111111111111"},{"account":{"data":["C3TPEWVjUME8/xLFuJju183vwyNd8FQtmoA+gAAAAADO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AEAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA","base64"],"executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0,"space":165},"pubkey":"tokenAccount1111111111111111111111111111111"}]},"id":1}`,
+					},
+					{
+						RequestBody: `{"jsonrpc":"2.0", "id":1, "method":"getMultipleAccounts", "params":[["mint111111111111111111111111111111111111111", "GnPUDj6mrPngva7VgUrcBV9qJBMJHHFvDYH9yBV8W2r8", "GuawUt2ngqmrkeUtFAVCnvA7f1U69LKmfTL3iJyBAPNJ", "mint211111111111111111111111111111111111111", "BVxfT4kkhL9k3rV2tTYWYDngXBTr8fheiGNHPzKJXjQL", "EmQPPDUkFLWk2U7FNBaDoYwCoPxSuCHQLQZarJmjVujE", "mint311111111111111111111111111111111111111", "D3G6V9UNRnCsLWbEW2cVdjvpTCkWFq1Q42AMtDo7JjKB", "3YdQhhGzqmppmmvowq5edjE7F9qbyb2ZDDgPRTjWMeFc"], {"encoding": "base64"}]}`,
+						ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":[` +
+							`{"data":["AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA==","base64"],"executable":false,"lamports":1461600,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0},` +
+							`{"data":["BAE=","base64"],"executable":false,"lamports":5616720,"owner":"metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s","rentEpoch":0},` +
+							`{"data":["BgEAAAAAAAAAAQAAAAAAAAAA","base64"],"executable":false,"lamports":2853600,"owner":"metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s","rentEpoch":0},` +
+							`{"data":["AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA==","base64"],"executable":false,"lamports":1461600,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0},` +
+							`{"data":["BM7Th+bDb1f+k++PUW6fMYxtieDFGDHfPXsITm1uiOTwC3TPEcadTT5YFEWEq+zritIagDEiIu62iqYEwAAAAAADAAAAdHdvAQAAAFQcAAAAaHR0cHM6Ly9leGFtcGxlLmNvbS90d28uanNvbgAAAAABAAEAAQAJK1dFUUi0pPGOy/YBOelk5+op42Hm7vbujjpiAAAAAAAA","base64"],"executable":false,"lamports":5616720,"owner":"metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s","rentEpoch":0},` +
+							`{"data":["AexX4Zuj2lEo3JD1o8f9UWfrnPcWzavbwWxwCw0zkO+zAQAAAAAAAAA=","base64"],"executable":false,"lamports":1689120,"owner":"metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s","rentEpoch":0},` +
+							`{"data":["AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAGAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA==","base64"],"executable":false,"lamports":1461600,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0},` +
+							`{"data":["BM7Th+bDb1f+k++PUW6fMYxtieDFGDHfPXsITm1uiOTwC3TPEifXSbtzKXhDn0DoPdZFPT7mVYk/esvLAAAAAAAFAAAAdGhyZWUBAAAAVB4AAABodHRwczovL2V4YW1wbGUuY29tL3RocmVlLmpzb24AAAAAAQABAAEACStXRVFItKTxjsv2ATnpZOfqKeNh5u727o46YgAAAAAAAA==","base64"],"executable":false,"lamports":5616720,"owner":"metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s","rentEpoch":0},` +
+							`null]},"id":1}`,
+					},
+				},
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.GetNFTsByOwner(context.TODO(), common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"))
+				},
+				ExpectedValue: []NFT{
+					{
+						Mint:            common.PublicKeyFromString("mint211111111111111111111111111111111111111"),
+						TokenAccount:    common.PublicKeyFromString("tokenAccount2111111111111111111111111111111"),
+						MetadataAccount: common.PublicKeyFromString("BVxfT4kkhL9k3rV2tTYWYDngXBTr8fheiGNHPzKJXjQL"),
+						Metadata: token_metadata.Metadata{
+							Key:             token_metadata.KeyMetadataV1,
+							UpdateAuthority: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+							Mint:            common.PublicKeyFromString("mint211111111111111111111111111111111111111"),
+							Data: token_metadata.Data{
+								Name:   "two",
+								Symbol: "T",
+								Uri:    "https://example.com/two.json",
+							},
+							IsMutable:     true,
+							TokenStandard: pointer.Get(token_metadata.NonFungible),
+							Collection: &token_metadata.Collection{
+								Verified: false,
+								Key:      common.PublicKeyFromString("co11ection111111111111111111111111111111111"),
+							},
+						},
+						EditionAccount: common.PublicKeyFromString("EmQPPDUkFLWk2U7FNBaDoYwCoPxSuCHQLQZarJmjVujE"),
+						EditionKey:     token_metadata.KeyEditionV1,
+						Edition: token_metadata.Edition{
+							Key:     token_metadata.KeyEditionV1,
+							Parent:  common.PublicKeyFromString("GuawUt2ngqmrkeUtFAVCnvA7f1U69LKmfTL3iJyBAPNJ"),
+							Edition: 1,
+						},
+						CollectionVerified: false,
+					},
+				},
+				ExpectedError: nil,
+			},
+			{
+				Calls: []client_test.Call{
+					{
+						RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getTokenAccountsByOwner", "params":["EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7", {"programId": "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"}, {"encoding": "base64"}]}`,
+						ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":[{"account":{"data":["C3TPEokRRjiOPqsCkpTk8Npv+kyqiCPIavGRQAAAAADO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AIAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA","base64"],"executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0,"space":165},"pubkey":"tokenAccount4111111111111111111111111111111"},{"account":{"data":["C3TPEifXSbtzKXhDn0DoPdZFPT7mVYk/esvLAAAAAADO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AEAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA","base64"],"executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0,"space":165},"pubkey":"tokenAccount3111111111111111111111111111111"},{"account":{"data":["C3TPEcadTT5YFEWEq+zritIagDEiIu62iqYEwAAAAADO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AEAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA","base64"],"executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0,"space":165},"pubkey":"tokenAccount2111111111111111111111111111111"},{"account":{"data":["C3TPEWVjUME8/xLFuJju183vwyNd8FQtmoA+gAAAAADO04fmw29X/pPvj1FunzGMbYngxRgx3z17CE5tbojk8AEAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA","base64"],"executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0,"space":165},"pubkey":"tokenAccount1111111111111111111111111111111"}]},"id":1}`,
+					},
+					{
+						RequestBody: `{"jsonrpc":"2.0", "id":1, "method":"getMultipleAccounts", "params":[["mint111111111111111111111111111111111111111", "GnPUDj6mrPngva7VgUrcBV9qJBMJHHFvDYH9yBV8W2r8", "GuawUt2ngqmrkeUtFAVCnvA7f1U69LKmfTL3iJyBAPNJ", "mint211111111111111111111111111111111111111", "BVxfT4kkhL9k3rV2tTYWYDngXBTr8fheiGNHPzKJXjQL", "EmQPPDUkFLWk2U7FNBaDoYwCoPxSuCHQLQZarJmjVujE", "mint311111111111111111111111111111111111111", "D3G6V9UNRnCsLWbEW2cVdjvpTCkWFq1Q42AMtDo7JjKB", "3YdQhhGzqmppmmvowq5edjE7F9qbyb2ZDDgPRTjWMeFc"], {"encoding": "base64"}]}`,
+						ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":216000000},"value":[` +
+							`{"data":["AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA==","base64"],"executable":false,"lamports":1461600,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0},` +
+							`{"data":["BM7Th+bDb1f+k++PUW6fMYxtieDFGDHfPXsITm1uiOTwC3TPEWVjUME8/xLFuJju183vwyNd8FQtmoA+gAAAAAADAAAAb25lAQAAAFQcAAAAaHR0cHM6Ly9leGFtcGxlLmNvbS9vbmUuanNvbgAAAAABAAEAAQEJK1dFUUi0pPGOy/YBOelk5+op42Hm7vbujjpiAAAAAAAA","base64"],"executable":false,"lamports":5616720,"owner":"metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s","rentEpoch":0},` +
+							`{"data":["BgEAAAAAAAAAAQAAAAAAAAAA","base64"],"executable":false,"lamports":2853600,"owner":"metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s","rentEpoch":0},` +
+							`{"data":["AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA==","base64"],"executable":false,"lamports":1461600,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0},` +
+							`{"data":["BM7Th+bDb1f+k++PUW6fMYxtieDFGDHfPXsITm1uiOTwC3TPEcadTT5YFEWEq+zritIagDEiIu62iqYEwAAAAAADAAAAdHdvAQAAAFQcAAAAaHR0cHM6Ly9leGFtcGxlLmNvbS90d28uanNvbgAAAAABAAEAAQAJK1dFUUi0pPGOy/YBOelk5+op42Hm7vbujjpiAAAAAAAA","base64"],"executable":false,"lamports":5616720,"owner":"metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s","rentEpoch":0},` +
+							`{"data":["AQE=","base64"],"executable":false,"lamports":1689120,"owner":"metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s","rentEpoch":0},` +
+							`{"data":["AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAGAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA==","base64"],"executable":false,"lamports":1461600,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0},` +
+							`{"data":["BM7Th+bDb1f+k++PUW6fMYxtieDFGDHfPXsITm1uiOTwC3TPEifXSbtzKXhDn0DoPdZFPT7mVYk/esvLAAAAAAAFAAAAdGhyZWUBAAAAVB4AAABodHRwczovL2V4YW1wbGUuY29tL3RocmVlLmpzb24AAAAAAQABAAEACStXRVFItKTxjsv2ATnpZOfqKeNh5u727o46YgAAAAAAAA==","base64"],"executable":false,"lamports":5616720,"owner":"metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s","rentEpoch":0},` +
+							`null]},"id":1}`,
+					},
+				},
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.GetNFTsByOwner(context.TODO(), common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"))
+				},
+				ExpectedValue: []NFT{
+					{
+						Mint:            common.PublicKeyFromString("mint111111111111111111111111111111111111111"),
+						TokenAccount:    common.PublicKeyFromString("tokenAccount1111111111111111111111111111111"),
+						MetadataAccount: common.PublicKeyFromString("GnPUDj6mrPngva7VgUrcBV9qJBMJHHFvDYH9yBV8W2r8"),
+						Metadata: token_metadata.Metadata{
+							Key:             token_metadata.KeyMetadataV1,
+							UpdateAuthority: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+							Mint:            common.PublicKeyFromString("mint111111111111111111111111111111111111111"),
+							Data: token_metadata.Data{
+								Name:   "one",
+								Symbol: "T",
+								Uri:    "https://example.com/one.json",
+							},
+							IsMutable:     true,
+							TokenStandard: pointer.Get(token_metadata.NonFungible),
+							Collection: &token_metadata.Collection{
+								Verified: true,
+								Key:      common.PublicKeyFromString("co11ection111111111111111111111111111111111"),
+							},
+						},
+						EditionAccount: common.PublicKeyFromString("GuawUt2ngqmrkeUtFAVCnvA7f1U69LKmfTL3iJyBAPNJ"),
+						EditionKey:     token_metadata.KeyMasterEditionV2,
+						Edition: token_metadata.MasterEditionV2{
+							Key:       token_metadata.KeyMasterEditionV2,
+							Supply:    1,
+							MaxSupply: pointer.Get[uint64](0),
+						},
+						CollectionVerified: true,
+					},
+				},
+				ExpectedError: nil,
+			},
+		},
+	)
+}
@@ -0,0 +1,56 @@
+// Command anchorgen generates a go package from an anchor idl, e.g.
+//
+//	//go:generate go run github.com/EntySquare/solana-go-sdk/cmd/anchorgen -idl idl.json -pkg counter
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/EntySquare/solana-go-sdk/anchor"
+)
+
+func main() {
+	idlPath := flag.String("idl", "", "path of the idl json")
+	out := flag.String("out", ".", "output directory")
+	pkg := flag.String("pkg", "", "package name, defaults to the idl name")
+	programID := flag.String("program-id", "", "program id, defaults to the idl address")
+	flag.Parse()
+
+	if *idlPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	b, err := os.ReadFile(*idlPath)
+	if err != nil {
+		log.Fatalf("failed to read the idl, err: %v", err)
+	}
+	idl, err := anchor.ParseIdl(b)
+	if err != nil {
+		log.Fatalf("failed to parse the idl, err: %v", err)
+	}
+	if *pkg == "" {
+		*pkg = strings.ToLower(strings.ReplaceAll(idl.Name, "-", "_"))
+	}
+
+	files, err := anchor.Generate(idl, anchor.GenerateConfig{
+		Package:   *pkg,
+		ProgramID: *programID,
+	})
+	if err != nil {
+		log.Fatalf("failed to generate, err: %v", err)
+	}
+
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		log.Fatalf("failed to create the output directory, err: %v", err)
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(*out, name), src, 0o644); err != nil {
+			log.Fatalf("failed to write %v, err: %v", name, err)
+		}
+	}
+}
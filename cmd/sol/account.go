@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/EntySquare/solana-go-sdk/client"
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/address_lookup_table"
+	"github.com/EntySquare/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/EntySquare/solana-go-sdk/program/system"
+	"github.com/EntySquare/solana-go-sdk/program/token"
+)
+
+// decoders turn the data of an account into fields, "auto" picks one by the owner and the size
+var decoders = map[string]func(client.AccountInfo) (object, error){
+	"token":        decodeTokenAccount,
+	"mint":         decodeMint,
+	"multisig":     decodeMultisig,
+	"nonce":        decodeNonce,
+	"lookup-table": decodeLookupTable,
+	"metadata":     decodeMetadata,
+	"raw":          decodeRaw,
+}
+
+func detectAccountType(account client.AccountInfo) string {
+	switch account.Owner {
+	case common.TokenProgramID:
+		switch len(account.Data) {
+		case token.TokenAccountSize:
+			return "token"
+		case token.MintAccountSize:
+			return "mint"
+		case token.MultisigAccountSize:
+			return "multisig"
+		}
+	case common.SystemProgramID:
+		if len(account.Data) == system.NonceAccountSize {
+			return "nonce"
+		}
+	case common.AddressLookupTableProgramID:
+		return "lookup-table"
+	case common.MetaplexTokenMetaProgramID:
+		if key, err := token_metadata.GetAccountKey(account.Data); err == nil && key == token_metadata.KeyMetadataV1 {
+			return "metadata"
+		}
+	}
+	return "raw"
+}
+
+func runAccount(e *env, arguments []string) error {
+	fs := e.flags(false)
+	accountType := fs.String("type", "auto", "auto, token, mint, multisig, nonce, lookup-table, metadata or raw")
+	metadataOf := fs.Bool("metadata", false, "the address is a mint, decode its metadata account")
+	if err := e.parse(fs, arguments); err != nil {
+		return err
+	}
+	if err := args(fs, 1, "<address>"); err != nil {
+		return err
+	}
+	address, err := parsePubkey("address", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *metadataOf {
+		if address, err = token_metadata.GetTokenMetaPubkey(address); err != nil {
+			return err
+		}
+	}
+	return e.printAccount(address, *accountType)
+}
+
+func (e *env) printAccount(address common.PublicKey, accountType string) error {
+	account, err := e.client().GetAccountInfo(context.Background(), address.ToBase58())
+	if err != nil {
+		return err
+	}
+	// an account without lamports doesn't exist, the system program is the zero pubkey so the owner can't tell
+	if account.Lamports == 0 {
+		return fmt.Errorf("account %v not found", address.ToBase58())
+	}
+	if accountType == "auto" {
+		accountType = detectAccountType(account)
+	}
+	decode, ok := decoders[accountType]
+	if !ok {
+		return fmt.Errorf("unknown account type: %v", accountType)
+	}
+	fields, err := decode(account)
+	if err != nil {
+		return fmt.Errorf("failed to decode the account as %v, err: %v", accountType, err)
+	}
+
+	o := object{
+		{"address", address.ToBase58()},
+		{"type", accountType},
+		{"lamports", account.Lamports},
+		{"owner", account.Owner.ToBase58()},
+	}
+	return e.print(append(o, fields...))
+}
+
+func optionalPubkey(pubkey *common.PublicKey) *string {
+	if pubkey == nil {
+		return nil
+	}
+	s := pubkey.ToBase58()
+	return &s
+}
+
+func decodeTokenAccount(account client.AccountInfo) (object, error) {
+	tokenAccount, err := token.DeserializeTokenAccount(account.Data, account.Owner)
+	if err != nil {
+		return nil, err
+	}
+	state := map[token.TokenAccountState]string{
+		token.TokenAccountStateUninitialized: "uninitialized",
+		token.TokenAccountStateInitialized:   "initialized",
+		token.TokenAccountFrozen:             "frozen",
+	}[tokenAccount.State]
+	return object{
+		{"mint", tokenAccount.Mint.ToBase58()},
+		{"tokenOwner", tokenAccount.Owner.ToBase58()},
+		{"amount", tokenAccount.Amount},
+		{"delegate", optionalPubkey(tokenAccount.Delegate)},
+		{"delegatedAmount", tokenAccount.DelegatedAmount},
+		{"state", state},
+		{"isNative", tokenAccount.IsNative != nil},
+		{"closeAuthority", optionalPubkey(tokenAccount.CloseAuthority)},
+	}, nil
+}
+
+func decodeMint(account client.AccountInfo) (object, error) {
+	if account.Owner != common.TokenProgramID {
+		return nil, errors.New("the account is not owned by the token program")
+	}
+	mint, err := token.MintAccountFromData(account.Data)
+	if err != nil {
+		return nil, err
+	}
+	return object{
+		{"mintAuthority", optionalPubkey(mint.MintAuthority)},
+		{"supply", mint.Supply},
+		{"uiSupply", formatAmount(mint.Supply, mint.Decimals)},
+		{"decimals", mint.Decimals},
+		{"isInitialized", mint.IsInitialized},
+		{"freezeAuthority", optionalPubkey(mint.FreezeAuthority)},
+	}, nil
+}
+
+func decodeMultisig(account client.AccountInfo) (object, error) {
+	if account.Owner != common.TokenProgramID {
+		return nil, errors.New("the account is not owned by the token program")
+	}
+	multisig, err := token.MultisigAccountFromData(account.Data)
+	if err != nil {
+		return nil, err
+	}
+	signers := make([]string, 0, len(multisig.Signers))
+	for _, signer := range multisig.Signers {
+		signers = append(signers, signer.ToBase58())
+	}
+	return object{
+		{"m", multisig.M},
+		{"n", multisig.N},
+		{"isInitialized", multisig.IsInitialized},
+		{"signers", signers},
+	}, nil
+}
+
+func decodeNonce(account client.AccountInfo) (object, error) {
+	if account.Owner != common.SystemProgramID {
+		return nil, errors.New("the account is not owned by the system program")
+	}
+	nonce, err := system.NonceAccountDeserialize(account.Data)
+	if err != nil {
+		return nil, err
+	}
+	if nonce.State != system.NonceStateInitialized {
+		return object{{"state", "uninitialized"}}, nil
+	}
+	return object{
+		{"state", "initialized"},
+		{"authority", nonce.AuthorizedPubkey.ToBase58()},
+		{"nonce", nonce.Nonce.ToBase58()},
+		{"lamportsPerSignature", nonce.FeeCalculator.LamportsPerSignature},
+	}, nil
+}
+
+func decodeLookupTable(account client.AccountInfo) (object, error) {
+	table, err := address_lookup_table.DeserializeLookupTable(account.Data, account.Owner)
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]string, 0, len(table.Addresses))
+	for _, address := range table.Addresses {
+		addresses = append(addresses, address.ToBase58())
+	}
+	deactivationSlot := any(table.DeactivationSlot)
+	if table.DeactivationSlot == ^uint64(0) {
+		deactivationSlot = nil
+	}
+	return object{
+		{"authority", optionalPubkey(table.Authority)},
+		{"deactivationSlot", deactivationSlot},
+		{"lastExtendedSlot", table.LastExtendedSlot},
+		{"lastExtendedSlotStartIndex", table.LastExtendedSlotStartIndex},
+		{"addresses", addresses},
+	}, nil
+}
+
+var tokenStandards = map[token_metadata.TokenStandard]string{
+	token_metadata.NonFungible:             "NonFungible",
+	token_metadata.FungibleAsset:           "FungibleAsset",
+	token_metadata.Fungible:                "Fungible",
+	token_metadata.NonFungibleEdition:      "NonFungibleEdition",
+	token_metadata.ProgrammableNonFungible: "ProgrammableNonFungible",
+}
+
+func decodeMetadata(account client.AccountInfo) (object, error) {
+	if account.Owner != common.MetaplexTokenMetaProgramID {
+		return nil, errors.New("the account is not owned by the token metadata program")
+	}
+	metadata, err := token_metadata.MetadataDeserialize(account.Data)
+	if err != nil {
+		return nil, err
+	}
+	var creators []object
+	if metadata.Data.Creators != nil {
+		for _, creator := range *metadata.Data.Creators {
+			creators = append(creators, object{
+				{"address", creator.Address.ToBase58()},
+				{"verified", creator.Verified},
+				{"share", creator.Share},
+			})
+		}
+	}
+	var tokenStandard *string
+	if metadata.TokenStandard != nil {
+		s := tokenStandards[*metadata.TokenStandard]
+		tokenStandard = &s
+	}
+	var collection *string
+	if metadata.Collection != nil {
+		s := metadata.Collection.Key.ToBase58()
+		if !metadata.Collection.Verified {
+			s += " (unverified)"
+		}
+		collection = &s
+	}
+	trim := func(s string) string { return strings.TrimRight(s, "\x00") }
+	return object{
+		{"mint", metadata.Mint.ToBase58()},
+		{"updateAuthority", metadata.UpdateAuthority.ToBase58()},
+		{"name", trim(metadata.Data.Name)},
+		{"symbol", trim(metadata.Data.Symbol)},
+		{"uri", trim(metadata.Data.Uri)},
+		{"sellerFeeBasisPoints", metadata.Data.SellerFeeBasisPoints},
+		{"creators", creators},
+		{"primarySaleHappened", metadata.PrimarySaleHappened},
+		{"isMutable", metadata.IsMutable},
+		{"tokenStandard", tokenStandard},
+		{"collection", collection},
+	}, nil
+}
+
+func decodeRaw(account client.AccountInfo) (object, error) {
+	return object{
+		{"executable", account.Executable},
+		{"rentEpoch", account.RentEpoch},
+		{"size", len(account.Data)},
+		{"data", base64.StdEncoding.EncodeToString(account.Data)},
+	}, nil
+}
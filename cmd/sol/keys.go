@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/EntySquare/solana-go-sdk/pkg/hdwallet"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/tyler-smith/go-bip39"
+)
+
+func defaultKeypairPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "id.json"
+	}
+	return filepath.Join(home, ".config", "solana", "id.json")
+}
+
+// loadKeypair reads a keypair file of the solana cli, a json array of the 64 bytes private key.
+// a base58 private key is accepted as well.
+func loadKeypair(path string) (types.Account, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("failed to read the keypair file, err: %v", err)
+	}
+	s := strings.TrimSpace(string(b))
+	if !strings.HasPrefix(s, "[") {
+		return types.AccountFromBase58(s)
+	}
+	var key []byte
+	var ints []int
+	if err := json.Unmarshal([]byte(s), &ints); err != nil {
+		return types.Account{}, fmt.Errorf("failed to decode the keypair file, err: %v", err)
+	}
+	for _, i := range ints {
+		if i < 0 || i > 255 {
+			return types.Account{}, errors.New("failed to decode the keypair file, a byte is out of range")
+		}
+		key = append(key, byte(i))
+	}
+	return types.AccountFromBytes(key)
+}
+
+func saveKeypair(path string, account types.Account, force bool) error {
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("%v exists, use -force to overwrite it", path)
+	}
+	ints := make([]int, 0, len(account.PrivateKey))
+	for _, b := range account.PrivateKey {
+		ints = append(ints, int(b))
+	}
+	b, err := json.Marshal(ints)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o600)
+}
+
+// accountFromMnemonic derives the account the way the wallets do. without a derivation path it is
+// the first 32 bytes of the bip39 seed like solana-keygen, with a path, e.g. m/44'/501'/0'/0', it is bip44.
+func accountFromMnemonic(mnemonic, passphrase, path string) (types.Account, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return types.Account{}, fmt.Errorf("a mnemonic has 12, 15, 18, 21 or 24 words, got %d", len(words))
+	}
+	// a typo would recover another account, check the words and the checksum first
+	mnemonic = strings.Join(words, " ")
+	if _, err := bip39.EntropyFromMnemonic(mnemonic); err != nil {
+		return types.Account{}, fmt.Errorf("invalid mnemonic, err: %v", err)
+	}
+	seed := bip39.NewSeed(mnemonic, passphrase)
+	if path == "" {
+		return types.AccountFromSeed(seed[:32])
+	}
+	key, err := hdwallet.Derived(path, seed)
+	if err != nil {
+		return types.Account{}, err
+	}
+	return types.AccountFromSeed(key.PrivateKey)
+}
+
+func runKeygen(e *env, arguments []string) error {
+	fs := e.flags(false)
+	mnemonic := fs.String("mnemonic", "", "recover the keypair from a bip39 mnemonic")
+	passphrase := fs.String("passphrase", "", "passphrase of the mnemonic")
+	path := fs.String("derivation-path", "", "bip44 derivation path, e.g. m/44'/501'/0'/0'")
+	outfile := fs.String("outfile", defaultKeypairPath(), "write the keypair into the file")
+	force := fs.Bool("force", false, "overwrite the outfile")
+	if err := e.parse(fs, arguments); err != nil {
+		return err
+	}
+	if err := args(fs, 0, ""); err != nil {
+		return err
+	}
+
+	account := types.NewAccount()
+	if *mnemonic != "" {
+		var err error
+		if account, err = accountFromMnemonic(*mnemonic, *passphrase, *path); err != nil {
+			return err
+		}
+	}
+	// the keypair is always saved, like solana-keygen new, a printed pubkey alone would lose the private key
+	if err := saveKeypair(*outfile, account, *force); err != nil {
+		return err
+	}
+	return e.print(object{{"pubkey", account.PublicKey.ToBase58()}, {"outfile", *outfile}})
+}
+
+func runPubkey(e *env, arguments []string) error {
+	fs := e.flags(false)
+	keypair := fs.String("keypair", defaultKeypairPath(), "keypair file")
+	mnemonic := fs.String("mnemonic", "", "bip39 mnemonic, it replaces the keypair file")
+	passphrase := fs.String("passphrase", "", "passphrase of the mnemonic")
+	path := fs.String("derivation-path", "", "bip44 derivation path, e.g. m/44'/501'/0'/0'")
+	if err := e.parse(fs, arguments); err != nil {
+		return err
+	}
+	if err := args(fs, 0, ""); err != nil {
+		return err
+	}
+
+	var account types.Account
+	var err error
+	if *mnemonic != "" {
+		account, err = accountFromMnemonic(*mnemonic, *passphrase, *path)
+	} else {
+		account, err = loadKeypair(*keypair)
+	}
+	if err != nil {
+		return err
+	}
+	return e.print(object{{"pubkey", account.PublicKey.ToBase58()}})
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+
+	"github.com/EntySquare/solana-go-sdk/client"
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/address_lookup_table"
+	"github.com/EntySquare/solana-go-sdk/rpc"
+	"github.com/EntySquare/solana-go-sdk/types"
+)
+
+const lookupTableUsage = "usage: sol lookup-table <create | extend | deactivate | close | show> [flags] [args]"
+
+func runLookupTable(e *env, arguments []string) error {
+	if len(arguments) == 0 {
+		return errors.New(lookupTableUsage)
+	}
+	action := arguments[0]
+	switch action {
+	case "create", "extend", "deactivate", "close", "show":
+	default:
+		return errors.New(lookupTableUsage)
+	}
+	e.name += " " + action
+	fs := e.flags(action != "show")
+	if err := e.parse(fs, arguments[1:]); err != nil {
+		return err
+	}
+	if action == "show" {
+		if err := args(fs, 1, "<table>"); err != nil {
+			return err
+		}
+		table, err := parsePubkey("table", fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		return e.printAccount(table, "lookup-table")
+	}
+
+	signer, err := loadKeypair(e.keypair)
+	if err != nil {
+		return err
+	}
+	if action == "create" {
+		return e.createLookupTable(fs, signer)
+	}
+	return e.updateLookupTable(fs, action, signer)
+}
+
+func (e *env) createLookupTable(fs *flag.FlagSet, signer types.Account) error {
+	if err := args(fs, 0, ""); err != nil {
+		return err
+	}
+	ctx := context.Background()
+	// the slot has to be in the slot hashes sysvar, a finalized one is
+	slot, err := e.client().GetSlotWithConfig(ctx, client.GetSlotConfig{Commitment: rpc.CommitmentFinalized})
+	if err != nil {
+		return err
+	}
+	table, bump := address_lookup_table.DeriveLookupTableAddress(signer.PublicKey, slot)
+	signature, err := e.sendAndConfirm(ctx, []types.Instruction{
+		address_lookup_table.CreateLookupTable(address_lookup_table.CreateLookupTableParams{
+			LookupTable: table,
+			Authority:   signer.PublicKey,
+			Payer:       signer.PublicKey,
+			RecentSlot:  slot,
+			BumpSeed:    bump,
+		}),
+	}, signer)
+	if err != nil {
+		return err
+	}
+	return e.print(object{{"signature", signature}, {"table", table.ToBase58()}})
+}
+
+func (e *env) updateLookupTable(fs *flag.FlagSet, action string, signer types.Account) error {
+	if action == "extend" && fs.NArg() < 2 {
+		return errors.New("usage: sol " + fs.Name() + " [flags] <table> <address>...")
+	}
+	if action != "extend" {
+		if err := args(fs, 1, "<table>"); err != nil {
+			return err
+		}
+	}
+	table, err := parsePubkey("table", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var instruction types.Instruction
+	switch action {
+	case "extend":
+		addresses := make([]common.PublicKey, 0, fs.NArg()-1)
+		for _, arg := range fs.Args()[1:] {
+			address, err := parsePubkey("address", arg)
+			if err != nil {
+				return err
+			}
+			addresses = append(addresses, address)
+		}
+		instruction = address_lookup_table.ExtendLookupTable(address_lookup_table.ExtendLookupTableParams{
+			LookupTable: table,
+			Authority:   signer.PublicKey,
+			Payer:       &signer.PublicKey,
+			Addresses:   addresses,
+		})
+	case "deactivate":
+		instruction = address_lookup_table.DeactivateLookupTable(address_lookup_table.DeactivateLookupTableParams{
+			LookupTable: table,
+			Authority:   signer.PublicKey,
+		})
+	case "close":
+		instruction = address_lookup_table.CloseLookupTable(address_lookup_table.CloseLookupTableParams{
+			LookupTable: table,
+			Authority:   signer.PublicKey,
+			Recipient:   signer.PublicKey,
+		})
+	}
+	signature, err := e.sendAndConfirm(context.Background(), []types.Instruction{instruction}, signer)
+	if err != nil {
+		return err
+	}
+	return e.print(object{{"signature", signature}, {"table", table.ToBase58()}})
+}
@@ -0,0 +1,136 @@
+// Command sol wraps the sdk for everyday wallet and debugging tasks, e.g.
+//
+//	go run github.com/EntySquare/solana-go-sdk/cmd/sol balance -url devnet 9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g
+//
+// every command takes -url, a cluster name or an rpc endpoint, and -output, text or json.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/EntySquare/solana-go-sdk/client"
+	"github.com/EntySquare/solana-go-sdk/rpc"
+)
+
+type command struct {
+	usage string
+	run   func(e *env, args []string) error
+}
+
+var commands = map[string]command{
+	"keygen":        {usage: "generate a keypair, or recover it from a mnemonic", run: runKeygen},
+	"pubkey":        {usage: "show the public key of a keypair file or a mnemonic", run: runPubkey},
+	"balance":       {usage: "show the SOL balance of an address", run: runBalance},
+	"transfer":      {usage: "transfer SOL", run: runTransfer},
+	"token-balance": {usage: "show the balance of a token account", run: runTokenBalance},
+	"token-transfer": {
+		usage: "transfer tokens between the associated token accounts of two wallets",
+		run:   runTokenTransfer,
+	},
+	"create-ata":   {usage: "create an associated token account", run: runCreateATA},
+	"account":      {usage: "decode an account: token, mint, nonce, lookup table, metadata or raw", run: runAccount},
+	"decode-tx":    {usage: "decode a base58 or base64 transaction", run: runDecodeTx},
+	"simulate":     {usage: "simulate a base58 or base64 transaction", run: runSimulate},
+	"send":         {usage: "send a signed base58 or base64 transaction and wait for the confirmation", run: runSend},
+	"lookup-table": {usage: "create, extend, deactivate, close or show an address lookup table", run: runLookupTable},
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stdout)
+		return nil
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		usage(stdout)
+		return fmt.Errorf("unknown command: %v", args[0])
+	}
+	return cmd.run(&env{stdout: stdout, name: args[0]}, args[1:])
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: sol <command> [flags] [args]")
+	fmt.Fprintln(w)
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-16v%v\n", name, commands[name].usage)
+	}
+}
+
+// env is what the flags every command shares configure
+type env struct {
+	stdout  io.Writer
+	name    string
+	url     string
+	output  string
+	keypair string
+	timeout time.Duration
+}
+
+// flags returns the flag set of the command with the shared flags, signer adds the flags to sign transactions
+func (e *env) flags(signer bool) *flag.FlagSet {
+	fs := flag.NewFlagSet(e.name, flag.ContinueOnError)
+	fs.SetOutput(e.stdout)
+	fs.StringVar(&e.url, "url", "devnet", "cluster: mainnet, devnet, testnet, localnet or an rpc endpoint")
+	fs.StringVar(&e.output, "output", "text", "output format: text or json")
+	if signer {
+		fs.StringVar(&e.keypair, "keypair", defaultKeypairPath(), "keypair file of the fee payer and signer")
+		fs.DurationVar(&e.timeout, "timeout", time.Minute, "how long to wait for the confirmation")
+	}
+	return fs
+}
+
+func (e *env) parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if e.output != "text" && e.output != "json" {
+		return fmt.Errorf("unknown output format: %v", e.output)
+	}
+	return nil
+}
+
+func (e *env) client() *client.Client {
+	return client.NewClient(endpoint(e.url))
+}
+
+// endpoint maps a cluster name to its rpc endpoint, anything else is taken as an endpoint
+func endpoint(url string) string {
+	switch strings.ToLower(url) {
+	case "mainnet", "mainnet-beta", "m":
+		return rpc.MainnetRPCEndpoint
+	case "devnet", "d":
+		return rpc.DevnetRPCEndpoint
+	case "testnet", "t":
+		return rpc.TestnetRPCEndpoint
+	case "localnet", "localhost", "l":
+		return rpc.LocalnetRPCEndpoint
+	}
+	return url
+}
+
+// args checks the number of positional arguments
+func args(fs *flag.FlagSet, n int, names string) error {
+	if fs.NArg() != n {
+		return errors.New("usage: sol " + fs.Name() + " [flags] " + names)
+	}
+	return nil
+}
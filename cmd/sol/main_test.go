@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/system"
+	"github.com/EntySquare/solana-go-sdk/rpc/rpctest"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	alice, _ = types.AccountFromSeed(make([]byte, 32))
+	bob      = common.PublicKeyFromString("9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g")
+	mint     = common.PublicKeyFromString("So11111111111111111111111111111111111111112")
+	ata      = common.PublicKeyFromString("BGEqZEJRPxHKTLbjwYoTnzY4PqmwvHMjZiFVGPDkN7T7")
+)
+
+func runJSON(t *testing.T, args ...string) map[string]any {
+	var stdout bytes.Buffer
+	require.NoError(t, run(append(args[:1:1], append([]string{"-output", "json"}, args[1:]...)...), &stdout))
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &m), stdout.String())
+	return m
+}
+
+func TestKeys(t *testing.T) {
+	mnemonic := "neither lonely flavor argue grass remind eye tag avocado spot unusual intact"
+	m := runJSON(t, "pubkey", "-mnemonic", mnemonic, "-derivation-path", "m/44'/501'/0'/0'")
+	assert.Equal(t, "5vftMkHL72JaJG6ExQfGAsT2uGVHpRR7oTNUPMs68Y2N", m["pubkey"])
+	m = runJSON(t, "pubkey", "-mnemonic", mnemonic, "-derivation-path", "m/44'/501'/1'/0'")
+	assert.Equal(t, "GcXbfQ5yY3uxCyBNDPBbR5FjumHf89E7YHXuULfGDBBv", m["pubkey"])
+
+	path := filepath.Join(t.TempDir(), "id.json")
+	generated := runJSON(t, "keygen", "-outfile", path)
+	m = runJSON(t, "pubkey", "-keypair", path)
+	assert.Equal(t, generated["pubkey"], m["pubkey"])
+
+	err := run([]string{"keygen", "-outfile", path}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "use -force to overwrite it")
+	err = run([]string{"pubkey", "-mnemonic", "too short"}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "got 2")
+	// the last word carries the checksum
+	err = run([]string{"pubkey", "-mnemonic", strings.Replace(mnemonic, "intact", "inside", 1)}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "invalid mnemonic")
+	err = run([]string{"pubkey", "-mnemonic", strings.Replace(mnemonic, "lonely", "lonley", 1)}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "invalid mnemonic")
+
+	// without an outfile the keypair goes to the default keypair file
+	t.Setenv("HOME", t.TempDir())
+	generated = runJSON(t, "keygen")
+	assert.Equal(t, defaultKeypairPath(), generated["outfile"])
+	m = runJSON(t, "pubkey")
+	assert.Equal(t, generated["pubkey"], m["pubkey"])
+	err = run([]string{"keygen"}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "use -force to overwrite it")
+	replaced := runJSON(t, "keygen", "-force")
+	assert.NotEqual(t, generated["pubkey"], replaced["pubkey"])
+}
+
+func TestBalance(t *testing.T) {
+	s := rpctest.NewServer(rpctest.WithAccount(alice.PublicKey, rpctest.Account{Lamports: 1_500_000_000, Owner: common.SystemProgramID}))
+	defer s.Close()
+
+	var stdout bytes.Buffer
+	require.NoError(t, run([]string{"balance", "-url", s.URL, alice.PublicKey.ToBase58()}, &stdout))
+	assert.Equal(t, "address:  "+alice.PublicKey.ToBase58()+"\nlamports: 1500000000\nsol:      1.5\n", stdout.String())
+
+	err := run([]string{"balance", "-url", s.URL, "not-an-address"}, &stdout)
+	assert.ErrorContains(t, err, "invalid address")
+	err = run([]string{"balance", "-url", s.URL}, &stdout)
+	assert.EqualError(t, err, "usage: sol balance [flags] <address>")
+}
+
+func TestAccount(t *testing.T) {
+	mintData := make([]byte, 82)
+	copy(mintData, []byte{1, 0, 0, 0})
+	copy(mintData[4:], alice.PublicKey.Bytes())
+	binary.LittleEndian.PutUint64(mintData[36:], 1500)
+	mintData[44] = 2
+	mintData[45] = 1
+
+	tokenData := make([]byte, 165)
+	copy(tokenData, mint.Bytes())
+	copy(tokenData[32:], bob.Bytes())
+	binary.LittleEndian.PutUint64(tokenData[64:], 1234)
+	tokenData[108] = 1
+
+	nonceData := make([]byte, 80)
+	nonceData[0], nonceData[4] = 1, 1
+	copy(nonceData[8:], alice.PublicKey.Bytes())
+	copy(nonceData[40:], bob.Bytes())
+	binary.LittleEndian.PutUint64(nonceData[72:], 5000)
+
+	table := common.PublicKeyFromString("AddressLookupTab1e1111111111111111111111112")
+	tableData := make([]byte, 56, 56+64)
+	tableData[0] = 1
+	binary.LittleEndian.PutUint64(tableData[4:], ^uint64(0))
+	tableData[21] = 1
+	copy(tableData[22:], alice.PublicKey.Bytes())
+	tableData = append(append(tableData, mint.Bytes()...), bob.Bytes()...)
+
+	nonce := types.NewAccount().PublicKey
+	s := rpctest.NewServer(
+		rpctest.WithAccount(mint, rpctest.Account{Lamports: 1, Owner: common.TokenProgramID, Data: mintData}),
+		rpctest.WithAccount(ata, rpctest.Account{Lamports: 2, Owner: common.TokenProgramID, Data: tokenData}),
+		rpctest.WithAccount(nonce, rpctest.Account{Lamports: 3, Owner: common.SystemProgramID, Data: nonceData}),
+		rpctest.WithAccount(table, rpctest.Account{Lamports: 4, Owner: common.AddressLookupTableProgramID, Data: tableData}),
+		rpctest.WithAccount(alice.PublicKey, rpctest.Account{Lamports: 5, Owner: common.SystemProgramID}),
+	)
+	defer s.Close()
+
+	tests := []struct {
+		name    string
+		address common.PublicKey
+		want    map[string]any
+	}{
+		{
+			name:    "mint",
+			address: mint,
+			want: map[string]any{
+				"address":         mint.ToBase58(),
+				"type":            "mint",
+				"lamports":        float64(1),
+				"owner":           common.TokenProgramID.ToBase58(),
+				"mintAuthority":   alice.PublicKey.ToBase58(),
+				"supply":          float64(1500),
+				"uiSupply":        "15",
+				"decimals":        float64(2),
+				"isInitialized":   true,
+				"freezeAuthority": nil,
+			},
+		},
+		{
+			name:    "token",
+			address: ata,
+			want: map[string]any{
+				"address":         ata.ToBase58(),
+				"type":            "token",
+				"lamports":        float64(2),
+				"owner":           common.TokenProgramID.ToBase58(),
+				"mint":            mint.ToBase58(),
+				"tokenOwner":      bob.ToBase58(),
+				"amount":          float64(1234),
+				"delegate":        nil,
+				"delegatedAmount": float64(0),
+				"state":           "initialized",
+				"isNative":        false,
+				"closeAuthority":  nil,
+			},
+		},
+		{
+			name:    "nonce",
+			address: nonce,
+			want: map[string]any{
+				"address":              nonce.ToBase58(),
+				"type":                 "nonce",
+				"lamports":             float64(3),
+				"owner":                common.SystemProgramID.ToBase58(),
+				"state":                "initialized",
+				"authority":            alice.PublicKey.ToBase58(),
+				"nonce":                bob.ToBase58(),
+				"lamportsPerSignature": float64(5000),
+			},
+		},
+		{
+			name:    "lookup table",
+			address: table,
+			want: map[string]any{
+				"address":                    table.ToBase58(),
+				"type":                       "lookup-table",
+				"lamports":                   float64(4),
+				"owner":                      common.AddressLookupTableProgramID.ToBase58(),
+				"authority":                  alice.PublicKey.ToBase58(),
+				"deactivationSlot":           nil,
+				"lastExtendedSlot":           float64(0),
+				"lastExtendedSlotStartIndex": float64(0),
+				"addresses":                  []any{mint.ToBase58(), bob.ToBase58()},
+			},
+		},
+		{
+			name:    "raw",
+			address: alice.PublicKey,
+			want: map[string]any{
+				"address":    alice.PublicKey.ToBase58(),
+				"type":       "raw",
+				"lamports":   float64(5),
+				"owner":      common.SystemProgramID.ToBase58(),
+				"executable": false,
+				"rentEpoch":  float64(0),
+				"size":       float64(0),
+				"data":       "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, runJSON(t, "account", "-url", s.URL, tt.address.ToBase58()))
+		})
+	}
+
+	err := run([]string{"account", "-url", s.URL, "-type", "mint", ata.ToBase58()}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "failed to decode the account as mint")
+	err = run([]string{"account", "-url", s.URL, bob.ToBase58()}, &bytes.Buffer{})
+	assert.EqualError(t, err, "account "+bob.ToBase58()+" not found")
+}
+
+func TestTransferAndDecodeTx(t *testing.T) {
+	s := rpctest.NewServer(rpctest.WithAccount(alice.PublicKey, rpctest.Account{Lamports: 1_000_000_000, Owner: common.SystemProgramID}))
+	defer s.Close()
+	keypair := filepath.Join(t.TempDir(), "id.json")
+	require.NoError(t, saveKeypair(keypair, alice, false))
+
+	m := runJSON(t, "transfer", "-url", s.URL, "-keypair", keypair, "-memo", "hi", bob.ToBase58(), "0.25")
+	assert.Equal(t, float64(250_000_000), m["lamports"])
+	records := s.Transactions()
+	require.Len(t, records, 1)
+	assert.Equal(t, records[0].Signature, m["signature"])
+
+	raw, err := records[0].Transaction.Serialize()
+	require.NoError(t, err)
+	for _, encoded := range []string{base58.Encode(raw), base64.StdEncoding.EncodeToString(raw)} {
+		m = runJSON(t, "decode-tx", encoded)
+		assert.Equal(t, []any{map[string]any{"signature": records[0].Signature, "status": "valid"}}, m["signatures"])
+		assert.Equal(t, "legacy", m["version"])
+		instructions := m["instructions"].([]any)
+		require.Len(t, instructions, 2)
+		assert.Equal(t, map[string]any{
+			"program":  "11111111111111111111111111111111 (system)",
+			"accounts": []any{alice.PublicKey.ToBase58(), bob.ToBase58()},
+			"data":     base58.Encode(system.Transfer(system.TransferParam{Amount: 250_000_000}).Data),
+		}, instructions[0])
+	}
+
+	var stdout bytes.Buffer
+	require.NoError(t, run([]string{"decode-tx", base58.Encode(raw)}, &stdout))
+	assert.True(t, strings.HasPrefix(stdout.String(), "signatures:\n  #0\n    signature: "+records[0].Signature+"\n    status:    valid\n"), stdout.String())
+
+	err = run([]string{"decode-tx", "-encoding", "base64", base58.Encode(raw)}, &stdout)
+	assert.ErrorContains(t, err, "failed to decode the transaction")
+	err = run([]string{"transfer", "-url", s.URL, "-keypair", keypair, bob.ToBase58(), "0.0000000001"}, &stdout)
+	assert.ErrorContains(t, err, "more than 9 decimals")
+}
+
+func TestAmount(t *testing.T) {
+	tests := []struct {
+		s        string
+		decimals uint8
+		amount   uint64
+		format   string
+		err      string
+	}{
+		{s: "1", decimals: 9, amount: 1_000_000_000, format: "1"},
+		{s: "1.5", decimals: 9, amount: 1_500_000_000, format: "1.5"},
+		{s: ".000000001", decimals: 9, amount: 1, format: "0.000000001"},
+		{s: "42", decimals: 0, amount: 42, format: "42"},
+		{s: "1.", decimals: 2, amount: 100, format: "1"},
+		{s: "1.001", decimals: 2, err: "more than 2 decimals"},
+		{s: "-1", decimals: 2, err: "invalid amount"},
+		{s: ".", decimals: 2, err: "invalid amount"},
+		{s: "18446744073709551616", decimals: 0, err: "out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			amount, err := parseAmount(tt.s, tt.decimals)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.amount, amount)
+			assert.Equal(t, tt.format, formatAmount(amount, tt.decimals))
+		})
+	}
+}
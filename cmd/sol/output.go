@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// object is an ordered list of fields, the text output prints a field a line and the json output an object
+type object []field
+
+type field struct {
+	name  string
+	value any
+}
+
+func (o object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		name, err := json.Marshal(f.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(name)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func (e *env) print(o object) error {
+	if e.output == "json" {
+		b, err := json.MarshalIndent(o, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(e.stdout, string(b))
+		return err
+	}
+	return printText(e.stdout, o, "")
+}
+
+func printText(w io.Writer, o object, indent string) error {
+	width := 0
+	for _, f := range o {
+		if len(f.name) > width {
+			width = len(f.name)
+		}
+	}
+	for _, f := range o {
+		var err error
+		switch v := f.value.(type) {
+		case object:
+			_, err = fmt.Fprintf(w, "%v%v:\n", indent, f.name)
+			if err == nil {
+				err = printText(w, v, indent+"  ")
+			}
+		case []object:
+			_, err = fmt.Fprintf(w, "%v%v:%v\n", indent, f.name, emptyList(len(v)))
+			for i := 0; err == nil && i < len(v); i++ {
+				_, err = fmt.Fprintf(w, "%v  #%d\n", indent, i)
+				if err == nil {
+					err = printText(w, v[i], indent+"    ")
+				}
+			}
+		case []string:
+			_, err = fmt.Fprintf(w, "%v%v:%v\n", indent, f.name, emptyList(len(v)))
+			for i := 0; err == nil && i < len(v); i++ {
+				_, err = fmt.Fprintf(w, "%v  %v\n", indent, v[i])
+			}
+		default:
+			_, err = fmt.Fprintf(w, "%v%-*v %v\n", indent, width+1, f.name+":", text(v))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func emptyList(n int) string {
+	if n == 0 {
+		return " -"
+	}
+	return ""
+}
+
+func text(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "-"
+	case *string:
+		if v == nil {
+			return "-"
+		}
+		return *v
+	case json.RawMessage:
+		return string(v)
+	}
+	return fmt.Sprint(v)
+}
+
+// formatAmount shows an integer amount with its decimals, e.g. 1500 with 3 decimals is 1.5
+func formatAmount(amount uint64, decimals uint8) string {
+	s := strconv.FormatUint(amount, 10)
+	if decimals == 0 {
+		return s
+	}
+	if len(s) <= int(decimals) {
+		s = strings.Repeat("0", int(decimals)-len(s)+1) + s
+	}
+	i := len(s) - int(decimals)
+	fraction := strings.TrimRight(s[i:], "0")
+	if fraction == "" {
+		return s[:i]
+	}
+	return s[:i] + "." + fraction
+}
+
+// parseAmount is the reverse of formatAmount, it refuses more fraction digits than the decimals
+func parseAmount(s string, decimals uint8) (uint64, error) {
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole == "" && fraction == "" || strings.Trim(whole+fraction, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid amount: %v", s)
+	}
+	if len(fraction) > int(decimals) {
+		return 0, fmt.Errorf("invalid amount: %v, it has more than %d decimals", s, decimals)
+	}
+	amount, err := strconv.ParseUint(whole+fraction+strings.Repeat("0", int(decimals)-len(fraction)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %v, err: %v", s, err)
+	}
+	return amount, nil
+}
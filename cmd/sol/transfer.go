@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/associated_token_account"
+	"github.com/EntySquare/solana-go-sdk/program/memo"
+	"github.com/EntySquare/solana-go-sdk/program/system"
+	"github.com/EntySquare/solana-go-sdk/program/token"
+	"github.com/EntySquare/solana-go-sdk/types"
+)
+
+const solDecimals = 9
+
+func parsePubkey(name, s string) (common.PublicKey, error) {
+	pubkey := common.PublicKeyFromString(s)
+	if s == "" || pubkey.ToBase58() != s {
+		return common.PublicKey{}, fmt.Errorf("invalid %v: %q", name, s)
+	}
+	return pubkey, nil
+}
+
+func runBalance(e *env, arguments []string) error {
+	fs := e.flags(false)
+	if err := e.parse(fs, arguments); err != nil {
+		return err
+	}
+	if err := args(fs, 1, "<address>"); err != nil {
+		return err
+	}
+	address, err := parsePubkey("address", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	lamports, err := e.client().GetBalance(context.Background(), address.ToBase58())
+	if err != nil {
+		return err
+	}
+	return e.print(object{
+		{"address", address.ToBase58()},
+		{"lamports", lamports},
+		{"sol", formatAmount(lamports, solDecimals)},
+	})
+}
+
+func runTransfer(e *env, arguments []string) error {
+	fs := e.flags(true)
+	memoText := fs.String("memo", "", "attach a memo")
+	if err := e.parse(fs, arguments); err != nil {
+		return err
+	}
+	if err := args(fs, 2, "<recipient> <amount in SOL>"); err != nil {
+		return err
+	}
+	to, err := parsePubkey("recipient", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	lamports, err := parseAmount(fs.Arg(1), solDecimals)
+	if err != nil {
+		return err
+	}
+	signer, err := loadKeypair(e.keypair)
+	if err != nil {
+		return err
+	}
+
+	instructions := []types.Instruction{
+		system.Transfer(system.TransferParam{From: signer.PublicKey, To: to, Amount: lamports}),
+	}
+	instructions = appendMemo(instructions, *memoText, signer.PublicKey)
+	signature, err := e.sendAndConfirm(context.Background(), instructions, signer)
+	if err != nil {
+		return err
+	}
+	return e.print(object{
+		{"signature", signature},
+		{"from", signer.PublicKey.ToBase58()},
+		{"to", to.ToBase58()},
+		{"lamports", lamports},
+	})
+}
+
+func appendMemo(instructions []types.Instruction, text string, signer common.PublicKey) []types.Instruction {
+	if text == "" {
+		return instructions
+	}
+	return append(instructions, memo.BuildMemo(memo.BuildMemoParam{
+		SignerPubkeys: []common.PublicKey{signer},
+		Memo:          []byte(text),
+	}))
+}
+
+func runTokenBalance(e *env, arguments []string) error {
+	fs := e.flags(false)
+	mintFlag := fs.String("mint", "", "the address is a wallet, show its associated token account of the mint")
+	if err := e.parse(fs, arguments); err != nil {
+		return err
+	}
+	if err := args(fs, 1, "<token account or wallet>"); err != nil {
+		return err
+	}
+	address, err := parsePubkey("address", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *mintFlag != "" {
+		mint, err := parsePubkey("mint", *mintFlag)
+		if err != nil {
+			return err
+		}
+		address, _, _ = common.FindAssociatedTokenAddress(address, mint)
+	}
+
+	balance, err := e.client().GetTokenAccountBalance(context.Background(), address.ToBase58())
+	if err != nil {
+		return err
+	}
+	return e.print(object{
+		{"address", address.ToBase58()},
+		{"amount", balance.Amount},
+		{"decimals", balance.Decimals},
+		{"uiAmount", balance.UIAmountString},
+	})
+}
+
+func runTokenTransfer(e *env, arguments []string) error {
+	fs := e.flags(true)
+	fund := fs.Bool("fund-recipient", true, "create the associated token account of the recipient if it doesn't exist")
+	memoText := fs.String("memo", "", "attach a memo")
+	if err := e.parse(fs, arguments); err != nil {
+		return err
+	}
+	if err := args(fs, 3, "<mint> <recipient wallet> <amount>"); err != nil {
+		return err
+	}
+	mint, err := parsePubkey("mint", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	recipient, err := parsePubkey("recipient", fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	signer, err := loadKeypair(e.keypair)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	supply, err := e.client().GetTokenSupply(ctx, mint.ToBase58())
+	if err != nil {
+		return fmt.Errorf("failed to get the mint, err: %v", err)
+	}
+	amount, err := parseAmount(fs.Arg(2), supply.Decimals)
+	if err != nil {
+		return err
+	}
+
+	from, _, _ := common.FindAssociatedTokenAddress(signer.PublicKey, mint)
+	to, _, _ := common.FindAssociatedTokenAddress(recipient, mint)
+	var instructions []types.Instruction
+	if *fund {
+		instructions = append(instructions, associated_token_account.CreateIdempotent(associated_token_account.CreateIdempotentParam{
+			Funder:                 signer.PublicKey,
+			Owner:                  recipient,
+			Mint:                   mint,
+			AssociatedTokenAccount: to,
+		}))
+	}
+	instructions = append(instructions, token.TransferChecked(token.TransferCheckedParam{
+		From:     from,
+		To:       to,
+		Mint:     mint,
+		Auth:     signer.PublicKey,
+		Amount:   amount,
+		Decimals: supply.Decimals,
+	}))
+	instructions = appendMemo(instructions, *memoText, signer.PublicKey)
+	signature, err := e.sendAndConfirm(ctx, instructions, signer)
+	if err != nil {
+		return err
+	}
+	return e.print(object{
+		{"signature", signature},
+		{"from", from.ToBase58()},
+		{"to", to.ToBase58()},
+		{"amount", amount},
+		{"uiAmount", formatAmount(amount, supply.Decimals)},
+	})
+}
+
+func runCreateATA(e *env, arguments []string) error {
+	fs := e.flags(true)
+	ownerFlag := fs.String("owner", "", "wallet of the account, defaults to the keypair")
+	if err := e.parse(fs, arguments); err != nil {
+		return err
+	}
+	if err := args(fs, 1, "<mint>"); err != nil {
+		return err
+	}
+	mint, err := parsePubkey("mint", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	signer, err := loadKeypair(e.keypair)
+	if err != nil {
+		return err
+	}
+	owner := signer.PublicKey
+	if *ownerFlag != "" {
+		if owner, err = parsePubkey("owner", *ownerFlag); err != nil {
+			return err
+		}
+	}
+
+	ata, _, _ := common.FindAssociatedTokenAddress(owner, mint)
+	signature, err := e.sendAndConfirm(context.Background(), []types.Instruction{
+		associated_token_account.CreateIdempotent(associated_token_account.CreateIdempotentParam{
+			Funder:                 signer.PublicKey,
+			Owner:                  owner,
+			Mint:                   mint,
+			AssociatedTokenAccount: ata,
+		}),
+	}, signer)
+	if err != nil {
+		return err
+	}
+	return e.print(object{
+		{"signature", signature},
+		{"address", ata.ToBase58()},
+		{"owner", owner.ToBase58()},
+		{"mint", mint.ToBase58()},
+	})
+}
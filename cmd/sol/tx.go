@@ -0,0 +1,313 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/EntySquare/solana-go-sdk/client"
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/rpc"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/mr-tron/base58"
+)
+
+// pollInterval is how often the status of a sent transaction is checked
+var pollInterval = 500 * time.Millisecond
+
+// sendAndConfirm signs the instructions with a recent blockhash, the first signer pays the fee
+func (e *env) sendAndConfirm(ctx context.Context, instructions []types.Instruction, signers ...types.Account) (string, error) {
+	c := e.client()
+	latest, err := c.GetLatestBlockhash(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the latest blockhash, err: %v", err)
+	}
+	tx, err := types.NewTransaction(types.NewTransactionParam{
+		Message: types.NewMessage(types.NewMessageParam{
+			FeePayer:        signers[0].PublicKey,
+			RecentBlockhash: latest.Blockhash,
+			Instructions:    instructions,
+		}),
+		Signers: signers,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign the transaction, err: %v", err)
+	}
+	return e.sendTransaction(ctx, c, tx)
+}
+
+func (e *env) sendTransaction(ctx context.Context, c *client.Client, tx types.Transaction) (string, error) {
+	signature, err := c.SendTransaction(ctx, tx)
+	if err != nil {
+		return "", fmt.Errorf("failed to send the transaction, err: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+	for {
+		status, err := c.GetSignatureStatus(ctx, signature)
+		if err != nil {
+			return signature, fmt.Errorf("failed to get the status of %v, err: %v", signature, err)
+		}
+		if status != nil {
+			if status.Err != nil {
+				b, _ := json.Marshal(status.Err)
+				return signature, fmt.Errorf("transaction %v failed, err: %s", signature, b)
+			}
+			if status.ConfirmationStatus != nil && *status.ConfirmationStatus != rpc.CommitmentProcessed {
+				return signature, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return signature, fmt.Errorf("transaction %v is not confirmed after %v", signature, e.timeout)
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// readTransaction decodes a base58 or base64 transaction, "-" reads it from the stdin
+func readTransaction(s string, encoding string) (types.Transaction, error) {
+	if s == "-" {
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return types.Transaction{}, err
+		}
+		s = string(b)
+	}
+	s = strings.TrimSpace(s)
+
+	var decoders []func(string) ([]byte, error)
+	switch encoding {
+	case "base58":
+		decoders = append(decoders, base58.Decode)
+	case "base64":
+		decoders = append(decoders, base64.StdEncoding.DecodeString)
+	case "auto":
+		// a base58 string may be valid base64 as well, the one which deserializes wins
+		decoders = append(decoders, base58.Decode, base64.StdEncoding.DecodeString)
+	default:
+		return types.Transaction{}, fmt.Errorf("unknown encoding: %v", encoding)
+	}
+	err := errors.New("empty transaction")
+	for _, decode := range decoders {
+		b, derr := decode(s)
+		if derr != nil {
+			err = derr
+			continue
+		}
+		var tx types.Transaction
+		if tx, err = types.TransactionDeserialize(b); err == nil {
+			return tx, nil
+		}
+	}
+	return types.Transaction{}, fmt.Errorf("failed to decode the transaction, err: %v", err)
+}
+
+func runDecodeTx(e *env, arguments []string) error {
+	fs := e.flags(false)
+	encoding := fs.String("encoding", "auto", "encoding of the transaction: base58, base64 or auto")
+	if err := e.parse(fs, arguments); err != nil {
+		return err
+	}
+	if err := args(fs, 1, "<transaction or - for stdin>"); err != nil {
+		return err
+	}
+	tx, err := readTransaction(fs.Arg(0), *encoding)
+	if err != nil {
+		return err
+	}
+	return e.print(describeTransaction(tx))
+}
+
+var programNames = map[common.PublicKey]string{
+	common.SystemProgramID:                    "system",
+	common.ConfigProgramID:                    "config",
+	common.StakeProgramID:                     "stake",
+	common.VoteProgramID:                      "vote",
+	common.BPFLoaderProgramID:                 "bpf loader",
+	common.BPFLoaderUpgradeableProgramID:      "bpf loader upgradeable",
+	common.Secp256k1ProgramID:                 "secp256k1",
+	common.Ed25519ProgramID:                   "ed25519",
+	common.TokenProgramID:                     "token",
+	common.MemoProgramID:                      "memo",
+	common.SPLAssociatedTokenAccountProgramID: "associated token account",
+	common.SPLNameServiceProgramID:            "name service",
+	common.MetaplexTokenMetaProgramID:         "token metadata",
+	common.ComputeBudgetProgramID:             "compute budget",
+	common.AddressLookupTableProgramID:        "address lookup table",
+	common.SPLAccountCompressionProgramID:     "account compression",
+	common.SPLNoopProgramID:                   "noop",
+	common.MetaplexBubblegumProgramID:         "bubblegum",
+}
+
+func describeTransaction(tx types.Transaction) object {
+	message := tx.Message
+	messageData, _ := message.Serialize()
+
+	signatures := make([]object, 0, len(tx.Signatures))
+	for i, signature := range tx.Signatures {
+		status := "missing"
+		switch {
+		case i >= len(message.Accounts):
+		case ed25519.Verify(message.Accounts[i].Bytes(), messageData, signature):
+			status = "valid"
+		case !isZero(signature):
+			status = "invalid"
+		}
+		signatures = append(signatures, object{
+			{"signature", base58.Encode(signature)},
+			{"status", status},
+		})
+	}
+
+	// the accounts of the lookup tables follow the static ones, the writable of all tables first
+	keys := make([]string, 0, len(message.Accounts))
+	accounts := make([]object, 0, len(message.Accounts))
+	header := message.Header
+	for i, key := range message.Accounts {
+		signer := i < int(header.NumRequireSignatures)
+		writable := i < int(header.NumRequireSignatures-header.NumReadonlySignedAccounts) ||
+			!signer && i < len(message.Accounts)-int(header.NumReadonlyUnsignedAccounts)
+		keys = append(keys, key.ToBase58())
+		accounts = append(accounts, object{{"address", key.ToBase58()}, {"signer", signer}, {"writable", writable}})
+	}
+	var lookups []object
+	for _, writable := range []bool{true, false} {
+		for _, table := range message.AddressLookupTables {
+			indexes := table.ReadonlyIndexes
+			if writable {
+				indexes = table.WritableIndexes
+			}
+			for _, index := range indexes {
+				keys = append(keys, fmt.Sprintf("%v[%d]", table.AccountKey.ToBase58(), index))
+				accounts = append(accounts, object{{"address", keys[len(keys)-1]}, {"signer", false}, {"writable", writable}})
+			}
+		}
+	}
+	for _, table := range message.AddressLookupTables {
+		lookups = append(lookups, object{
+			{"table", table.AccountKey.ToBase58()},
+			{"writableIndexes", indexesOf(table.WritableIndexes)},
+			{"readonlyIndexes", indexesOf(table.ReadonlyIndexes)},
+		})
+	}
+
+	key := func(i int) string {
+		if i < 0 || i >= len(keys) {
+			return fmt.Sprintf("invalid index %d", i)
+		}
+		return keys[i]
+	}
+	instructions := make([]object, 0, len(message.Instructions))
+	for _, instruction := range message.Instructions {
+		program := key(instruction.ProgramIDIndex)
+		if name, ok := programNames[common.PublicKeyFromString(program)]; ok {
+			program += " (" + name + ")"
+		}
+		instructionAccounts := make([]string, 0, len(instruction.Accounts))
+		for _, i := range instruction.Accounts {
+			instructionAccounts = append(instructionAccounts, key(i))
+		}
+		instructions = append(instructions, object{
+			{"program", program},
+			{"accounts", instructionAccounts},
+			{"data", base58.Encode(instruction.Data)},
+		})
+	}
+
+	o := object{
+		{"signatures", signatures},
+		{"version", string(message.Version)},
+		{"recentBlockhash", message.RecentBlockHash},
+		{"accounts", accounts},
+		{"instructions", instructions},
+	}
+	if message.Version == types.MessageVersionV0 {
+		o = append(o, field{"addressLookupTables", lookups})
+	}
+	return o
+}
+
+func indexesOf(b []uint8) []string {
+	indexes := make([]string, 0, len(b))
+	for _, i := range b {
+		indexes = append(indexes, fmt.Sprint(i))
+	}
+	return indexes
+}
+
+func isZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func runSimulate(e *env, arguments []string) error {
+	fs := e.flags(false)
+	encoding := fs.String("encoding", "auto", "encoding of the transaction: base58, base64 or auto")
+	sigVerify := fs.Bool("sig-verify", false, "verify the signatures, it conflicts with -replace-blockhash")
+	replaceBlockhash := fs.Bool("replace-blockhash", true, "replace the blockhash of the transaction with the latest one")
+	if err := e.parse(fs, arguments); err != nil {
+		return err
+	}
+	if err := args(fs, 1, "<transaction or - for stdin>"); err != nil {
+		return err
+	}
+	tx, err := readTransaction(fs.Arg(0), *encoding)
+	if err != nil {
+		return err
+	}
+
+	res, err := e.client().SimulateTransactionWithConfig(context.Background(), tx, client.SimulateTransactionConfig{
+		SigVerify:              *sigVerify,
+		ReplaceRecentBlockhash: *replaceBlockhash && !*sigVerify,
+	})
+	if err != nil {
+		return err
+	}
+	var txErr any
+	if res.Err != nil {
+		b, _ := json.Marshal(res.Err)
+		txErr = json.RawMessage(b)
+	}
+	logs := res.Logs
+	if logs == nil {
+		logs = []string{}
+	}
+	return e.print(object{
+		{"err", txErr},
+		{"logs", logs},
+	})
+}
+
+func runSend(e *env, arguments []string) error {
+	fs := e.flags(false)
+	encoding := fs.String("encoding", "auto", "encoding of the transaction: base58, base64 or auto")
+	fs.DurationVar(&e.timeout, "timeout", time.Minute, "how long to wait for the confirmation")
+	if err := e.parse(fs, arguments); err != nil {
+		return err
+	}
+	if err := args(fs, 1, "<transaction or - for stdin>"); err != nil {
+		return err
+	}
+	tx, err := readTransaction(fs.Arg(0), *encoding)
+	if err != nil {
+		return err
+	}
+	signature, err := e.sendTransaction(context.Background(), e.client(), tx)
+	if err != nil {
+		return err
+	}
+	return e.print(object{{"signature", signature}})
+}
@@ -0,0 +1,117 @@
+package common
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/mr-tron/base58"
+)
+
+var (
+	ErrInvalidBase58 = errors.New("invalid base58")
+	ErrInvalidLength = errors.New("invalid length")
+	ErrInvalidScan   = errors.New("invalid scan source")
+)
+
+// decodeFixed decodes the base58 string of a fixed size value, name is how errors call it
+func decodeFixed(dst []byte, s, name string) error {
+	b, err := base58.Decode(s)
+	if err != nil {
+		return fmt.Errorf("%w, %v: %q, err: %v", ErrInvalidBase58, name, s, err)
+	}
+	return copyFixed(dst, b, name)
+}
+
+func copyFixed(dst, b []byte, name string) error {
+	if len(b) != len(dst) {
+		return fmt.Errorf("%w, %v should be %v bytes, got: %v", ErrInvalidLength, name, len(dst), len(b))
+	}
+	copy(dst, b)
+	return nil
+}
+
+// unmarshalJSONFixed takes a base58 string, null leaves the value as it is.
+// the array of numbers is what the value marshaled into before it had a MarshalJSON, it is still taken.
+func unmarshalJSONFixed(dst []byte, data []byte, name string) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '[' {
+		var ints []int
+		if err := json.Unmarshal(data, &ints); err != nil {
+			return fmt.Errorf("%v should be a base58 string or an array of bytes, err: %v", name, err)
+		}
+		b := make([]byte, 0, len(ints))
+		for _, i := range ints {
+			if i < 0 || i > 255 {
+				return fmt.Errorf("%v has a byte out of range: %v", name, i)
+			}
+			b = append(b, byte(i))
+		}
+		return copyFixed(dst, b, name)
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%v should be a base58 string, err: %v", name, err)
+	}
+	return decodeFixed(dst, s, name)
+}
+
+// scanFixed takes the base58 string of a column, a driver may return a text column as []byte.
+// raw bytes are not taken, a base58 string can be as long as the raw value, e.g. the system program id.
+func scanFixed(dst []byte, src any, name string) error {
+	switch v := src.(type) {
+	case string:
+		return decodeFixed(dst, v, name)
+	case []byte:
+		return decodeFixed(dst, string(v), name)
+	case nil:
+		return fmt.Errorf("%w, can't scan NULL into %v, scan into a pointer instead", ErrInvalidScan, name)
+	default:
+		return fmt.Errorf("%w, can't scan %T into %v", ErrInvalidScan, src, name)
+	}
+}
+
+// valueFixed stores the base58 string
+func valueFixed(b []byte) (driver.Value, error) {
+	return base58.Encode(b), nil
+}
+
+// formatFixed prints the base58 string for %s, %v and %q, and the bytes for %x, %X and %d.
+// %#v prints the go syntax which builds the value, constructor is e.g. "common.PublicKeyFromString".
+func formatFixed(f fmt.State, verb rune, b []byte, constructor, typeName string) {
+	switch verb {
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprintf(f, "%v(%q)", constructor, base58.Encode(b))
+			return
+		}
+		fmt.Fprintf(f, formatDirective(f, 's'), base58.Encode(b))
+	case 's', 'q':
+		fmt.Fprintf(f, formatDirective(f, verb), base58.Encode(b))
+	case 'x', 'X', 'd':
+		fmt.Fprintf(f, formatDirective(f, verb), b)
+	default:
+		fmt.Fprintf(f, "%%!%c(%v=%v)", verb, typeName, base58.Encode(b))
+	}
+}
+
+// formatDirective rebuilds the directive with the flags, the width and the precision of the state
+func formatDirective(f fmt.State, verb rune) string {
+	directive := "%"
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			directive += string(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		directive += strconv.Itoa(width)
+	}
+	if precision, ok := f.Precision(); ok {
+		directive += "." + strconv.Itoa(precision)
+	}
+	return directive + string(verb)
+}
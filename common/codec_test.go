@@ -0,0 +1,204 @@
+package common
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPubkey    = "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7"
+	testSignature = "5Pzqj1cNTpq5Zpyh8Ycyf5kaV8ZPBBCwJHyaStd6NSZvSvhZQbAtZb2GvfAJjwuVXbaiuBS7tqjD2fAhmbwQ9Hj"
+	testHash      = "EtWTRABZaYq6iMfeYKouRu166VU2xqa1wcaWoxPkrZBG"
+)
+
+var (
+	_ json.Unmarshaler = (*PublicKey)(nil)
+	_ sql.Scanner      = (*Signature)(nil)
+	_ driver.Valuer    = Hash{}
+	_ fmt.Formatter    = PublicKey{}
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		parse   func(string) (any, error)
+		s       string
+		wantErr error
+	}{
+		{name: "public key", parse: func(s string) (any, error) { return ParsePublicKey(s) }, s: testPubkey},
+		{name: "public key typo", parse: func(s string) (any, error) { return ParsePublicKey(s) }, s: "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ0", wantErr: ErrInvalidBase58},
+		{name: "public key short", parse: func(s string) (any, error) { return ParsePublicKey(s) }, s: "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uch", wantErr: ErrInvalidLength},
+		{name: "public key empty", parse: func(s string) (any, error) { return ParsePublicKey(s) }, s: "", wantErr: ErrInvalidBase58},
+		{name: "signature", parse: func(s string) (any, error) { return ParseSignature(s) }, s: testSignature},
+		{name: "signature of a public key", parse: func(s string) (any, error) { return ParseSignature(s) }, s: testPubkey, wantErr: ErrInvalidLength},
+		{name: "hash", parse: func(s string) (any, error) { return ParseHash(s) }, s: testHash},
+		{name: "hash of a signature", parse: func(s string) (any, error) { return ParseHash(s) }, s: testSignature, wantErr: ErrInvalidLength},
+		{name: "hash with space", parse: func(s string) (any, error) { return ParseHash(s) }, s: " " + testHash, wantErr: ErrInvalidBase58},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.parse(tt.s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.s, fmt.Sprint(v))
+			}
+		})
+	}
+
+	// the lenient constructor is kept as it is
+	assert.Equal(t, PublicKey{}, PublicKeyFromString("RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ0"))
+	assert.Panics(t, func() { MustParsePublicKey("0") })
+}
+
+func TestNew(t *testing.T) {
+	pubkey, err := NewPublicKey(MustParsePublicKey(testPubkey).Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, testPubkey, pubkey.String())
+	_, err = NewPublicKey([]byte{1})
+	assert.ErrorIs(t, err, ErrInvalidLength)
+
+	sig, err := NewSignature(MustParseSignature(testSignature).Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, testSignature, sig.String())
+	_, err = NewSignature(make([]byte, 65))
+	assert.ErrorIs(t, err, ErrInvalidLength)
+
+	h, err := NewHash(MustParseHash(testHash).Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, testHash, h.String())
+	_, err = NewHash(nil)
+	assert.ErrorIs(t, err, ErrInvalidLength)
+	assert.True(t, Hash{}.IsZero())
+}
+
+// transactionRecord is how a struct of rpc or client would use the types
+type transactionRecord struct {
+	Signature Signature            `json:"signature"`
+	Blockhash Hash                 `json:"blockhash"`
+	FeePayer  PublicKey            `json:"feePayer"`
+	Program   *PublicKey           `json:"program"`
+	Balances  map[PublicKey]uint64 `json:"balances"`
+}
+
+func TestJSON(t *testing.T) {
+	body := `{"signature":"` + testSignature + `","blockhash":"` + testHash + `","feePayer":"` + testPubkey + `","program":null,"balances":{"` + testPubkey + `":5000}}`
+	var record transactionRecord
+	require.NoError(t, json.Unmarshal([]byte(body), &record))
+	assert.Equal(t, MustParseSignature(testSignature), record.Signature)
+	assert.Equal(t, MustParseHash(testHash), record.Blockhash)
+	assert.Equal(t, MustParsePublicKey(testPubkey), record.FeePayer)
+	assert.Nil(t, record.Program)
+	assert.Equal(t, map[PublicKey]uint64{MustParsePublicKey(testPubkey): 5000}, record.Balances)
+
+	// a value, not only a pointer, marshals as base58
+	b, err := json.Marshal(record)
+	require.NoError(t, err)
+	assert.JSONEq(t, body, string(b))
+
+	// the array of bytes is how the values marshaled before
+	legacy, err := json.Marshal(map[string]any{
+		"signature": [SignatureLength]byte(MustParseSignature(testSignature)),
+		"blockhash": [HashLength]byte(MustParseHash(testHash)),
+		"feePayer":  [PublicKeyLength]byte(MustParsePublicKey(testPubkey)),
+	})
+	require.NoError(t, err)
+	var legacyRecord transactionRecord
+	require.NoError(t, json.Unmarshal(legacy, &legacyRecord))
+	assert.Equal(t, record.Signature, legacyRecord.Signature)
+	assert.Equal(t, record.Blockhash, legacyRecord.Blockhash)
+	assert.Equal(t, record.FeePayer, legacyRecord.FeePayer)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "typo", body: `{"feePayer":"RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ0"}`},
+		{name: "short", body: `{"blockhash":"EtWTRABZaYq6iMfeYKouRu166VU2xqa1wcaWoxPk"}`},
+		{name: "short bytes", body: `{"signature":[1,2,3]}`},
+		{name: "byte out of range", body: `{"feePayer":[256,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0]}`},
+		{name: "map key", body: `{"balances":{"11":1}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, json.Unmarshal([]byte(tt.body), &transactionRecord{}))
+		})
+	}
+}
+
+func TestSQL(t *testing.T) {
+	pubkey := MustParsePublicKey(testPubkey)
+	value, err := pubkey.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, testPubkey, value)
+
+	tests := []struct {
+		name    string
+		src     any
+		wantErr error
+	}{
+		{name: "text", src: testPubkey},
+		{name: "text bytes", src: []byte(testPubkey)},
+		{name: "raw bytes", src: pubkey.Bytes(), wantErr: ErrInvalidBase58},
+		{name: "null", src: nil, wantErr: ErrInvalidScan},
+		{name: "int", src: int64(1), wantErr: ErrInvalidScan},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got PublicKey
+			err := got.Scan(tt.src)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, pubkey, got)
+			}
+		})
+	}
+
+	// the base58 form of the system program id is 32 characters, as long as the raw bytes
+	var system PublicKey
+	assert.NoError(t, system.Scan([]byte(SystemProgramID.ToBase58())))
+	assert.Equal(t, SystemProgramID, system)
+
+	sig := MustParseSignature(testSignature)
+	var gotSig Signature
+	value, err = sig.Value()
+	assert.NoError(t, err)
+	assert.NoError(t, gotSig.Scan(value))
+	assert.Equal(t, sig, gotSig)
+
+	var gotHash Hash
+	assert.ErrorIs(t, gotHash.Scan(testSignature), ErrInvalidLength)
+}
+
+func TestFormat(t *testing.T) {
+	pubkey := MustParsePublicKey(testPubkey)
+	h := MustParseHash("11111111111111111111111111111112")
+	tests := []struct {
+		format string
+		value  any
+		want   string
+	}{
+		{format: "%v", value: pubkey, want: testPubkey},
+		{format: "%s", value: &pubkey, want: testPubkey},
+		{format: "%q", value: pubkey, want: `"` + testPubkey + `"`},
+		{format: "%48s|", value: pubkey, want: "     " + testPubkey + "|"},
+		{format: "%-8.4s|", value: pubkey, want: "RNfp    |"},
+		{format: "%x", value: h, want: "0000000000000000000000000000000000000000000000000000000000000001"},
+		{format: "%X", value: Hash{0xab}, want: "AB00000000000000000000000000000000000000000000000000000000000000"},
+		{format: "%#v", value: pubkey, want: `common.PublicKeyFromString("` + testPubkey + `")`},
+		{format: "%#v", value: h, want: `common.MustParseHash("11111111111111111111111111111112")`},
+		{format: "%d", value: Hash{31: 1}, want: "[0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 1]"},
+		{format: "%b", value: h, want: "%!b(common.Hash=11111111111111111111111111111112)"},
+		{format: "%v", value: []Signature{MustParseSignature(testSignature)}, want: "[" + testSignature + "]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			assert.Equal(t, tt.want, fmt.Sprintf(tt.format, tt.value))
+		})
+	}
+}
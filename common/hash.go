@@ -0,0 +1,76 @@
+package common
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/mr-tron/base58"
+)
+
+const HashLength = 32
+
+// Hash is a sha256 hash, e.g. a blockhash
+type Hash [HashLength]byte
+
+// ParseHash decodes a base58 hash, it fails if the string is not base58 or not 32 bytes
+func ParseHash(s string) (Hash, error) {
+	var h Hash
+	err := decodeFixed(h[:], s, "hash")
+	return h, err
+}
+
+// NewHash copies a 32 byte hash
+func NewHash(b []byte) (Hash, error) {
+	var h Hash
+	err := copyFixed(h[:], b, "hash")
+	return h, err
+}
+
+func (h Hash) IsZero() bool {
+	return h == Hash{}
+}
+
+func (h Hash) String() string {
+	return base58.Encode(h[:])
+}
+
+func (h Hash) Bytes() []byte {
+	return h[:]
+}
+
+func (h Hash) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+func (h *Hash) UnmarshalText(text []byte) error {
+	return decodeFixed(h[:], string(text), "hash")
+}
+
+func (h Hash) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + h.String() + `"`), nil
+}
+
+func (h *Hash) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONFixed(h[:], data, "hash")
+}
+
+func (h *Hash) Scan(src any) error {
+	return scanFixed(h[:], src, "hash")
+}
+
+func (h Hash) Value() (driver.Value, error) {
+	return valueFixed(h[:])
+}
+
+func (h Hash) Format(f fmt.State, verb rune) {
+	formatFixed(f, verb, h[:], "common.MustParseHash", "common.Hash")
+}
+
+// MustParseHash is ParseHash which panics, for constants
+func MustParseHash(s string) Hash {
+	h, err := ParseHash(s)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
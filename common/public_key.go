@@ -2,8 +2,9 @@ package common
 
 import (
 	"crypto/sha256"
-	"encoding/json"
+	"database/sql/driver"
 	"errors"
+	"fmt"
 
 	"filippo.io/edwards25519"
 	"github.com/mr-tron/base58"
@@ -21,11 +22,38 @@ func (p PublicKey) String() string {
 	return p.ToBase58()
 }
 
+// PublicKeyFromString decodes a base58 public key. it is lenient, an invalid string is the zero key and
+// a short one is padded, use ParsePublicKey to get an error instead.
 func PublicKeyFromString(s string) PublicKey {
 	d, _ := base58.Decode(s)
 	return PublicKeyFromBytes(d)
 }
 
+// ParsePublicKey decodes a base58 public key, it fails if the string is not base58 or not 32 bytes
+func ParsePublicKey(s string) (PublicKey, error) {
+	var pubkey PublicKey
+	err := decodeFixed(pubkey[:], s, "public key")
+	return pubkey, err
+}
+
+// MustParsePublicKey is ParsePublicKey which panics, for constants
+func MustParsePublicKey(s string) PublicKey {
+	pubkey, err := ParsePublicKey(s)
+	if err != nil {
+		panic(err)
+	}
+	return pubkey
+}
+
+// NewPublicKey copies a 32 byte public key, unlike PublicKeyFromBytes it doesn't pad or truncate
+func NewPublicKey(b []byte) (PublicKey, error) {
+	var pubkey PublicKey
+	err := copyFixed(pubkey[:], b, "public key")
+	return pubkey, err
+}
+
+// PublicKeyFromBytes pads a short slice with leading zeros and truncates a long one, use NewPublicKey
+// to get an error instead
 func PublicKeyFromBytes(b []byte) PublicKey {
 	var pubkey PublicKey
 	if len(b) > PublicKeyLength {
@@ -66,8 +94,36 @@ func (p PublicKey) Bytes() []byte {
 	return p[:]
 }
 
-func (p *PublicKey) MarshalJSON() ([]byte, error) {
-	return json.Marshal(p.ToBase58())
+func (p PublicKey) IsZero() bool {
+	return p == PublicKey{}
+}
+
+func (p PublicKey) MarshalText() ([]byte, error) {
+	return []byte(p.ToBase58()), nil
+}
+
+func (p *PublicKey) UnmarshalText(text []byte) error {
+	return decodeFixed(p[:], string(text), "public key")
+}
+
+func (p PublicKey) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + p.ToBase58() + `"`), nil
+}
+
+func (p *PublicKey) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONFixed(p[:], data, "public key")
+}
+
+func (p *PublicKey) Scan(src any) error {
+	return scanFixed(p[:], src, "public key")
+}
+
+func (p PublicKey) Value() (driver.Value, error) {
+	return valueFixed(p[:])
+}
+
+func (p PublicKey) Format(f fmt.State, verb rune) {
+	formatFixed(f, verb, p[:], "common.PublicKeyFromString", "common.PublicKey")
 }
 
 func IsOnCurve(p PublicKey) bool {
@@ -0,0 +1,82 @@
+package common
+
+import (
+	"crypto/ed25519"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/mr-tron/base58"
+)
+
+const SignatureLength = 64
+
+// Signature is an ed25519 signature, e.g. the id of a transaction
+type Signature [SignatureLength]byte
+
+// ParseSignature decodes a base58 signature, it fails if the string is not base58 or not 64 bytes
+func ParseSignature(s string) (Signature, error) {
+	var sig Signature
+	err := decodeFixed(sig[:], s, "signature")
+	return sig, err
+}
+
+// NewSignature copies a 64 byte signature, e.g. a signature of types.Transaction
+func NewSignature(b []byte) (Signature, error) {
+	var sig Signature
+	err := copyFixed(sig[:], b, "signature")
+	return sig, err
+}
+
+// Verify checks the signature of the message is made by the public key
+func (s Signature) Verify(pubkey PublicKey, message []byte) bool {
+	return ed25519.Verify(pubkey[:], message, s[:])
+}
+
+func (s Signature) IsZero() bool {
+	return s == Signature{}
+}
+
+func (s Signature) String() string {
+	return base58.Encode(s[:])
+}
+
+func (s Signature) Bytes() []byte {
+	return s[:]
+}
+
+func (s Signature) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Signature) UnmarshalText(text []byte) error {
+	return decodeFixed(s[:], string(text), "signature")
+}
+
+func (s Signature) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s.String() + `"`), nil
+}
+
+func (s *Signature) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONFixed(s[:], data, "signature")
+}
+
+func (s *Signature) Scan(src any) error {
+	return scanFixed(s[:], src, "signature")
+}
+
+func (s Signature) Value() (driver.Value, error) {
+	return valueFixed(s[:])
+}
+
+func (s Signature) Format(f fmt.State, verb rune) {
+	formatFixed(f, verb, s[:], "common.MustParseSignature", "common.Signature")
+}
+
+// MustParseSignature is ParseSignature which panics, for constants
+func MustParseSignature(s string) Signature {
+	sig, err := ParseSignature(s)
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
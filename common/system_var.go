@@ -5,6 +5,9 @@ var (
 	SysVarClockPubkey            = PublicKeyFromString("SysvarC1ock11111111111111111111111111111111")
 	SysVarRecentBlockhashsPubkey = PublicKeyFromString("SysvarRecentB1ockHashes11111111111111111111")
 	SysVarRentPubkey             = PublicKeyFromString("SysvarRent111111111111111111111111111111111")
+	SysVarEpochSchedulePubkey    = PublicKeyFromString("SysvarEpochSchedu1e111111111111111111111111")
+	SysVarFeesPubkey             = PublicKeyFromString("SysvarFees111111111111111111111111111111111")
+	SysVarEpochRewardsPubkey     = PublicKeyFromString("SysvarEpochRewards1111111111111111111111111")
 	SysVarRewardsPubkey          = PublicKeyFromString("SysvarRewards111111111111111111111111111111")
 	SysVarStakeHistoryPubkey     = PublicKeyFromString("SysvarStakeHistory1111111111111111111111111")
 	SysVarInstructionsPubkey     = PublicKeyFromString("Sysvar1nstructions1111111111111111111111111")
@@ -4,13 +4,17 @@ go 1.19
 
 require (
 	filippo.io/edwards25519 v1.0.0
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.2.0
 	github.com/mr-tron/base58 v1.2.0
 	github.com/near/borsh-go v0.3.2-0.20220516180422-1ff87d108454
 	github.com/stretchr/testify v1.8.3
+	github.com/tyler-smith/go-bip39 v1.1.0
+	golang.org/x/crypto v0.14.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.13.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
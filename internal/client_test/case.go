@@ -4,20 +4,28 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
 type Param struct {
-	Name          string
-	RequestBody   string
-	ResponseBody  string
+	Name         string
+	RequestBody  string
+	ResponseBody string
+	// Calls replaces RequestBody and ResponseBody for functions which send several requests in order
+	Calls         []Call
 	F             func(url string) (any, error)
 	ExpectedValue any
 	ExpectedError error
 }
 
+type Call struct {
+	RequestBody  string
+	ResponseBody string
+}
+
 func TestAll(t *testing.T, params []Param) {
 	for _, param := range params {
 		t.Run(param.Name, func(t *testing.T) {
@@ -27,17 +35,33 @@ func TestAll(t *testing.T, params []Param) {
 }
 
 func Test(t *testing.T, param Param) {
+	calls := param.Calls
+	if len(calls) == 0 {
+		calls = []Call{{RequestBody: param.RequestBody, ResponseBody: param.ResponseBody}}
+	}
+
 	// setup test server
+	var mu sync.Mutex
+	idx := 0
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if idx >= len(calls) {
+			t.Errorf("unexpected request #%d", idx+1)
+			return
+		}
+		call := calls[idx]
+		idx++
+
 		// check request body match
 		body, err := io.ReadAll(req.Body)
 		assert.Nil(t, err)
-		assert.JSONEq(t, param.RequestBody, string(body))
+		assert.JSONEq(t, call.RequestBody, string(body))
 
 		// check write response body success
-		n, err := rw.Write([]byte(param.ResponseBody))
+		n, err := rw.Write([]byte(call.ResponseBody))
 		assert.Nil(t, err)
-		assert.Equal(t, len([]byte(param.ResponseBody)), n)
+		assert.Equal(t, len([]byte(call.ResponseBody)), n)
 	}))
 
 	// test function
@@ -46,4 +70,7 @@ func Test(t *testing.T, param Param) {
 	assert.Equal(t, param.ExpectedError, err)
 
 	server.Close()
+	if len(param.Calls) > 0 {
+		assert.Equal(t, len(calls), idx, "unconsumed calls")
+	}
 }
@@ -0,0 +1,197 @@
+package bank
+
+import (
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/associated_token_account"
+	"github.com/EntySquare/solana-go-sdk/program/system"
+	"github.com/EntySquare/solana-go-sdk/program/token"
+)
+
+func processAssociatedTokenAccount(ctx *invokeContext) error {
+	// an empty data is the legacy create
+	instruction := associated_token_account.InstructionCreate
+	if len(ctx.data) > 0 {
+		instruction = associated_token_account.Instruction(ctx.data[0])
+		if len(ctx.data) != 1 {
+			return ErrInvalidInstructionData
+		}
+	}
+
+	switch instruction {
+	case associated_token_account.InstructionCreate:
+		ctx.log("Create")
+		return createAssociatedTokenAccount(ctx, false)
+	case associated_token_account.InstructionCreateIdempotent:
+		ctx.log("CreateIdempotent")
+		return createAssociatedTokenAccount(ctx, true)
+	case associated_token_account.InstructionRecoverNested:
+		ctx.log("RecoverNested")
+		return recoverNested(ctx)
+	}
+	return ErrInvalidInstructionData
+}
+
+func associatedTokenAddress(wallet, mint common.PublicKey) common.PublicKey {
+	address, _, _ := common.FindAssociatedTokenAddress(wallet, mint)
+	return address
+}
+
+func createAssociatedTokenAccount(ctx *invokeContext, idempotent bool) error {
+	return ctx.withAccounts(6, func(accounts []instructionAccount) error {
+		funder, associatedAccount, wallet, mintAccount, tokenProgram := accounts[0], accounts[1], accounts[2], accounts[3], accounts[5]
+		if tokenProgram.pubkey != common.TokenProgramID {
+			return ErrIncorrectProgramID
+		}
+		if associatedTokenAddress(wallet.pubkey, mintAccount.pubkey) != associatedAccount.pubkey {
+			ctx.log("Error: Associated address does not match seed derivation")
+			return ErrInvalidSeeds
+		}
+
+		if idempotent && associatedAccount.Owner == common.TokenProgramID {
+			tokenAccount, err := loadTokenAccount(associatedAccount)
+			if err != nil {
+				return err
+			}
+			if tokenAccount.Owner != wallet.pubkey {
+				ctx.log("Error: owner does not match")
+				return AssociatedTokenAccountErrorInvalidOwner
+			}
+			if tokenAccount.Mint != mintAccount.pubkey {
+				return ErrInvalidAccountData
+			}
+			return nil
+		}
+		if associatedAccount.Owner != common.SystemProgramID {
+			return ErrIllegalOwner
+		}
+		if _, err := loadMint(mintAccount); err != nil {
+			return err
+		}
+
+		rentExempt := ctx.bank.rent.MinimumBalance(token.TokenAccountSize)
+		if associatedAccount.Lamports > 0 {
+			// someone has sent lamports to the address, top it up instead of creating it
+			if associatedAccount.Lamports < rentExempt {
+				err := ctx.invoke(system.Transfer(system.TransferParam{
+					From:   funder.pubkey,
+					To:     associatedAccount.pubkey,
+					Amount: rentExempt - associatedAccount.Lamports,
+				}))
+				if err != nil {
+					return err
+				}
+			}
+			err := ctx.invoke(system.Allocate(system.AllocateParam{
+				Account: associatedAccount.pubkey,
+				Space:   token.TokenAccountSize,
+			}), associatedAccount.pubkey)
+			if err != nil {
+				return err
+			}
+			err = ctx.invoke(system.Assign(system.AssignParam{
+				From:  associatedAccount.pubkey,
+				Owner: common.TokenProgramID,
+			}), associatedAccount.pubkey)
+			if err != nil {
+				return err
+			}
+		} else {
+			err := ctx.invoke(system.CreateAccount(system.CreateAccountParam{
+				From:     funder.pubkey,
+				New:      associatedAccount.pubkey,
+				Owner:    common.TokenProgramID,
+				Lamports: rentExempt,
+				Space:    token.TokenAccountSize,
+			}), associatedAccount.pubkey)
+			if err != nil {
+				return err
+			}
+		}
+
+		ctx.log("Initialize the associated token account")
+		return ctx.invoke(token.InitializeAccount3(token.InitializeAccount3Param{
+			Account: associatedAccount.pubkey,
+			Mint:    mintAccount.pubkey,
+			Owner:   wallet.pubkey,
+		}))
+	})
+}
+
+// recoverNested moves the tokens of an associated token account owned by another associated token account
+// of the wallet to the associated token account of the wallet and closes it
+func recoverNested(ctx *invokeContext) error {
+	return ctx.withAccounts(7, func(accounts []instructionAccount) error {
+		nestedAccount, nestedMint, destinationAccount := accounts[0], accounts[1], accounts[2]
+		ownerAccount, ownerMint, wallet, tokenProgram := accounts[3], accounts[4], accounts[5], accounts[6]
+		if tokenProgram.pubkey != common.TokenProgramID {
+			return ErrIncorrectProgramID
+		}
+		if associatedTokenAddress(wallet.pubkey, ownerMint.pubkey) != ownerAccount.pubkey {
+			ctx.log("Error: Owner associated address does not match seed derivation")
+			return ErrInvalidSeeds
+		}
+		if associatedTokenAddress(ownerAccount.pubkey, nestedMint.pubkey) != nestedAccount.pubkey {
+			ctx.log("Error: Nested associated address does not match seed derivation")
+			return ErrInvalidSeeds
+		}
+		if associatedTokenAddress(wallet.pubkey, nestedMint.pubkey) != destinationAccount.pubkey {
+			ctx.log("Error: Destination associated address does not match seed derivation")
+			return ErrInvalidSeeds
+		}
+		if !wallet.isSigner {
+			ctx.log("Wallet of the owner associated token account must sign")
+			return ErrMissingRequiredSignature
+		}
+		if ownerMint.Owner != common.TokenProgramID {
+			ctx.log("Owner mint not owned by provided token program")
+			return ErrIllegalOwner
+		}
+
+		if ownerAccount.Owner != common.TokenProgramID {
+			ctx.log("Owner associated token account not owned by provided token program, recreate the owner associated token account first")
+			return ErrIllegalOwner
+		}
+		owner, err := loadTokenAccount(ownerAccount)
+		if err != nil {
+			return err
+		}
+		if owner.Owner != wallet.pubkey {
+			ctx.log("Owner associated token account not owned by provided wallet")
+			return AssociatedTokenAccountErrorInvalidOwner
+		}
+
+		if nestedAccount.Owner != common.TokenProgramID {
+			ctx.log("Nested associated token account not owned by provided token program")
+			return ErrIllegalOwner
+		}
+		nested, err := loadTokenAccount(nestedAccount)
+		if err != nil {
+			return err
+		}
+		if nested.Owner != ownerAccount.pubkey {
+			ctx.log("Nested associated token account not owned by provided associated token account")
+			return AssociatedTokenAccountErrorInvalidOwner
+		}
+		mint, err := loadMint(nestedMint)
+		if err != nil {
+			return err
+		}
+
+		err = ctx.invoke(token.TransferChecked(token.TransferCheckedParam{
+			From:     nestedAccount.pubkey,
+			To:       destinationAccount.pubkey,
+			Mint:     nestedMint.pubkey,
+			Auth:     ownerAccount.pubkey,
+			Amount:   nested.Amount,
+			Decimals: mint.Decimals,
+		}), ownerAccount.pubkey)
+		if err != nil {
+			return err
+		}
+		return ctx.invoke(token.CloseAccount(token.CloseAccountParam{
+			Account: nestedAccount.pubkey,
+			Auth:    ownerAccount.pubkey,
+			To:      wallet.pubkey,
+		}), ownerAccount.pubkey)
+	})
+}
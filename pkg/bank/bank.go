@@ -0,0 +1,616 @@
+package bank
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/program_log"
+	"github.com/EntySquare/solana-go-sdk/program/address_lookup_table"
+	"github.com/EntySquare/solana-go-sdk/program/sysvar"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/mr-tron/base58"
+)
+
+const (
+	DefaultLamportsPerSignature uint64 = 5000
+	// DefaultBlockhash is the blockhash durable nonces are derived from until SetBlockhash is called
+	DefaultBlockhash = "11111111111111111111111111111111"
+)
+
+// DefaultRent is the rent of mainnet
+var DefaultRent = sysvar.Rent{
+	LamportsPerByteYear: 3480,
+	ExemptionThreshold:  2,
+	BurnPercent:         50,
+}
+
+// Account is an account of the bank
+type Account struct {
+	Lamports   uint64
+	Owner      common.PublicKey
+	Data       []byte
+	Executable bool
+	RentEpoch  uint64
+}
+
+func (a Account) clone() Account {
+	if a.Data != nil {
+		a.Data = append([]byte{}, a.Data...)
+	}
+	return a
+}
+
+// Bank executes transactions against an in-memory account map. it implements the System, Token,
+// Associated Token Account, Memo and Compute Budget programs natively, other programs are unsupported.
+// the recent blockhash of a transaction is not checked. a Bank is not safe for concurrent use.
+type Bank struct {
+	accounts             map[common.PublicKey]Account
+	signatures           map[string]struct{}
+	rent                 sysvar.Rent
+	lamportsPerSignature uint64
+	blockhash            string
+}
+
+type Option func(*Bank)
+
+func WithRent(rent sysvar.Rent) Option {
+	return func(b *Bank) {
+		b.rent = rent
+	}
+}
+
+func WithLamportsPerSignature(lamports uint64) Option {
+	return func(b *Bank) {
+		b.lamportsPerSignature = lamports
+	}
+}
+
+// WithAccount puts an account into the bank
+func WithAccount(pubkey common.PublicKey, account Account) Option {
+	return func(b *Bank) {
+		b.accounts[pubkey] = account.clone()
+	}
+}
+
+func New(opts ...Option) *Bank {
+	b := &Bank{
+		accounts:             map[common.PublicKey]Account{},
+		signatures:           map[string]struct{}{},
+		rent:                 DefaultRent,
+		lamportsPerSignature: DefaultLamportsPerSignature,
+		blockhash:            DefaultBlockhash,
+	}
+	for programID, p := range programs {
+		owner := bpfLoader2ProgramID
+		if p.builtin {
+			owner = nativeLoaderProgramID
+		}
+		b.accounts[programID] = Account{Lamports: 1, Owner: owner, Executable: true}
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// SetAccount puts an account into the bank, it replaces the old one
+func (b *Bank) SetAccount(pubkey common.PublicKey, account Account) {
+	b.accounts[pubkey] = account.clone()
+}
+
+// GetAccount returns an account of the bank, accounts without lamports don't exist
+func (b *Bank) GetAccount(pubkey common.PublicKey) (Account, bool) {
+	account, ok := b.accounts[pubkey]
+	return account.clone(), ok
+}
+
+// SetBlockhash sets the blockhash the system program advances durable nonces to
+func (b *Bank) SetBlockhash(blockhash string) {
+	b.blockhash = blockhash
+}
+
+// MinimumBalanceForRentExemption returns the lamports an account with the data size needs to be rent exempt
+func (b *Bank) MinimumBalanceForRentExemption(size uint64) uint64 {
+	return b.rent.MinimumBalance(size)
+}
+
+// Result is the outcome of a transaction
+type Result struct {
+	Signature string
+	Fee       uint64
+	Logs      []string
+	// ComputeUnitsConsumed is the sum of the costs of the executed instructions
+	ComputeUnitsConsumed uint64
+	// AccountKeys are the accounts of the transaction including the ones loaded from the lookup tables
+	AccountKeys []common.PublicKey
+	// Accounts are the states after the transaction in the order of AccountKeys,
+	// an account without lamports doesn't exist
+	Accounts     []Account
+	PreBalances  []uint64
+	PostBalances []uint64
+}
+
+// ParseLogs parses the logs into a tree of invocations
+func (r Result) ParseLogs() program_log.Logs {
+	return program_log.Parse(r.Logs)
+}
+
+// Execute verifies the signatures and executes the transaction. an error which is an *InstructionError
+// or a *RentError comes with a Result, the fee is charged and the other changes are rolled back.
+// other errors mean the transaction changes nothing.
+func (b *Bank) Execute(tx types.Transaction) (Result, error) {
+	return b.process(tx, true)
+}
+
+// Simulate executes the transaction without verifying the signatures or changing the bank
+func (b *Bank) Simulate(tx types.Transaction) (Result, error) {
+	return b.process(tx, false)
+}
+
+func (b *Bank) process(tx types.Transaction, commit bool) (Result, error) {
+	message := tx.Message
+	if err := sanitize(tx); err != nil {
+		return Result{}, err
+	}
+	if commit {
+		if err := verifySignatures(tx); err != nil {
+			return Result{}, err
+		}
+		if _, ok := b.signatures[string(tx.Signatures[0])]; ok {
+			return Result{}, ErrAlreadyProcessed
+		}
+	}
+
+	keys, writable, err := b.loadAccountKeys(message)
+	if err != nil {
+		return Result{}, err
+	}
+	budget, err := parseComputeBudget(message, keys)
+	if err != nil {
+		return Result{}, err
+	}
+
+	fee := uint64(len(tx.Signatures))*b.lamportsPerSignature + budget.priorityFee()
+	payer, ok := b.accounts[keys[0]]
+	if !ok {
+		return Result{}, ErrAccountNotFound
+	}
+	if payer.Owner != common.SystemProgramID {
+		return Result{}, ErrInvalidAccountForFee
+	}
+	if payer.Lamports < fee {
+		return Result{}, ErrInsufficientFundsForFee
+	}
+
+	txCtx := &transactionContext{
+		bank:       b,
+		unitsLimit: budget.unitsLimit,
+	}
+	preAccounts := make([]Account, 0, len(keys))
+	for i, key := range keys {
+		account, ok := b.accounts[key]
+		if !ok {
+			account = Account{Owner: common.SystemProgramID}
+		}
+		preAccounts = append(preAccounts, account.clone())
+		txCtx.accounts = append(txCtx.accounts, &txAccount{
+			Account:    account.clone(),
+			pubkey:     key,
+			isSigner:   i < int(message.Header.NumRequireSignatures),
+			isWritable: writable[i],
+		})
+	}
+	txCtx.accounts[0].Lamports -= fee
+
+	result := Result{
+		Signature:   base58.Encode(tx.Signatures[0]),
+		Fee:         fee,
+		AccountKeys: keys,
+	}
+	for _, account := range preAccounts {
+		result.PreBalances = append(result.PreBalances, account.Lamports)
+	}
+
+	var txErr error
+	for i, instruction := range message.Instructions {
+		accounts := make([]instructionAccount, 0, len(instruction.Accounts))
+		for _, index := range instruction.Accounts {
+			account := txCtx.accounts[index]
+			accounts = append(accounts, instructionAccount{
+				txAccount:  account,
+				isSigner:   account.isSigner,
+				isWritable: account.isWritable,
+			})
+		}
+		if err := txCtx.invoke(keys[instruction.ProgramIDIndex], accounts, instruction.Data, 1); err != nil {
+			txErr = &InstructionError{Index: i, Err: err}
+			break
+		}
+	}
+	if txErr == nil {
+		txErr = b.checkRent(preAccounts, txCtx.accounts)
+	}
+
+	// a failed transaction only pays the fee
+	if txErr != nil {
+		for i, account := range preAccounts {
+			txCtx.accounts[i].Account = account.clone()
+		}
+		txCtx.accounts[0].Lamports -= fee
+	}
+	result.Logs = txCtx.logs
+	result.ComputeUnitsConsumed = txCtx.unitsConsumed
+	for _, account := range txCtx.accounts {
+		result.Accounts = append(result.Accounts, account.Account.clone())
+		result.PostBalances = append(result.PostBalances, account.Lamports)
+	}
+
+	if commit {
+		for i, account := range txCtx.accounts {
+			if !writable[i] {
+				continue
+			}
+			if account.Lamports == 0 {
+				delete(b.accounts, account.pubkey)
+				continue
+			}
+			b.accounts[account.pubkey] = account.Account.clone()
+		}
+		b.signatures[string(tx.Signatures[0])] = struct{}{}
+	}
+	return result, txErr
+}
+
+func sanitize(tx types.Transaction) error {
+	message := tx.Message
+	header := message.Header
+	if header.NumRequireSignatures == 0 || len(tx.Signatures) != int(header.NumRequireSignatures) {
+		return fmt.Errorf("%w, signatures and header mismatch", ErrSanitizeFailure)
+	}
+	if int(header.NumRequireSignatures)+int(header.NumReadonlyUnsignedAccounts) > len(message.Accounts) ||
+		header.NumReadonlySignedAccounts >= header.NumRequireSignatures {
+		return fmt.Errorf("%w, invalid header", ErrSanitizeFailure)
+	}
+	numAccounts := len(message.Accounts)
+	for _, lookup := range message.AddressLookupTables {
+		numAccounts += len(lookup.WritableIndexes) + len(lookup.ReadonlyIndexes)
+	}
+	for _, instruction := range message.Instructions {
+		// a program can't be the fee payer or a loaded account
+		if instruction.ProgramIDIndex == 0 || instruction.ProgramIDIndex >= len(message.Accounts) {
+			return fmt.Errorf("%w, invalid program id index", ErrSanitizeFailure)
+		}
+		for _, index := range instruction.Accounts {
+			if index >= numAccounts {
+				return fmt.Errorf("%w, invalid account index", ErrSanitizeFailure)
+			}
+		}
+	}
+	seen := map[common.PublicKey]struct{}{}
+	for _, key := range message.Accounts {
+		if _, ok := seen[key]; ok {
+			return ErrAccountLoadedTwice
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+func verifySignatures(tx types.Transaction) error {
+	message, err := tx.Message.Serialize()
+	if err != nil {
+		return fmt.Errorf("%w, %v", ErrSanitizeFailure, err)
+	}
+	for i, signature := range tx.Signatures {
+		if !ed25519.Verify(tx.Message.Accounts[i].Bytes(), message, signature) {
+			return ErrSignatureFailure
+		}
+	}
+	return nil
+}
+
+// loadAccountKeys returns the static accounts followed by the writable and the readonly accounts from the lookup tables
+func (b *Bank) loadAccountKeys(message types.Message) ([]common.PublicKey, []bool, error) {
+	header := message.Header
+	keys := append([]common.PublicKey{}, message.Accounts...)
+	writable := make([]bool, 0, len(keys))
+	for i := range message.Accounts {
+		if i < int(header.NumRequireSignatures) {
+			writable = append(writable, i < int(header.NumRequireSignatures-header.NumReadonlySignedAccounts))
+		} else {
+			writable = append(writable, i < len(message.Accounts)-int(header.NumReadonlyUnsignedAccounts))
+		}
+	}
+	if message.Version != types.MessageVersionV0 {
+		return keys, writable, nil
+	}
+
+	var readonly []common.PublicKey
+	for _, lookup := range message.AddressLookupTables {
+		account, ok := b.accounts[lookup.AccountKey]
+		if !ok {
+			return nil, nil, ErrAddressLookupTableNotFound
+		}
+		table, err := address_lookup_table.DeserializeLookupTable(account.Data, account.Owner)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w, %v", ErrAddressLookupTableNotFound, err)
+		}
+		for _, index := range lookup.WritableIndexes {
+			if int(index) >= len(table.Addresses) {
+				return nil, nil, ErrInvalidAddressLookupTableIndex
+			}
+			keys = append(keys, table.Addresses[index])
+			writable = append(writable, true)
+		}
+		for _, index := range lookup.ReadonlyIndexes {
+			if int(index) >= len(table.Addresses) {
+				return nil, nil, ErrInvalidAddressLookupTableIndex
+			}
+			readonly = append(readonly, table.Addresses[index])
+		}
+	}
+	for _, key := range readonly {
+		keys = append(keys, key)
+		writable = append(writable, false)
+	}
+
+	seen := map[common.PublicKey]struct{}{}
+	for _, key := range keys {
+		if _, ok := seen[key]; ok {
+			return nil, nil, ErrAccountLoadedTwice
+		}
+		seen[key] = struct{}{}
+	}
+	return keys, writable, nil
+}
+
+// checkRent requires the changed accounts to be rent exempt or closed, a rent paying account
+// may stay rent paying if its size doesn't change and it isn't credited
+func (b *Bank) checkRent(pre []Account, post []*txAccount) error {
+	for i, account := range post {
+		if !account.isWritable || account.Lamports == 0 {
+			continue
+		}
+		minimum := b.rent.MinimumBalance(uint64(len(account.Data)))
+		if account.Lamports >= minimum {
+			continue
+		}
+		before := pre[i]
+		wasRentPaying := before.Lamports > 0 && before.Lamports < b.rent.MinimumBalance(uint64(len(before.Data)))
+		if wasRentPaying && len(before.Data) == len(account.Data) && account.Lamports <= before.Lamports {
+			continue
+		}
+		return &RentError{AccountIndex: i}
+	}
+	return nil
+}
+
+// txAccount is an account of the transaction being executed, instructions share it
+type txAccount struct {
+	Account
+	pubkey     common.PublicKey
+	isSigner   bool
+	isWritable bool
+}
+
+// instructionAccount is an account of an instruction, the privileges of a cross program invocation
+// can differ from the ones of the transaction
+type instructionAccount struct {
+	*txAccount
+	isSigner   bool
+	isWritable bool
+}
+
+type transactionContext struct {
+	bank          *Bank
+	accounts      []*txAccount
+	logs          []string
+	unitsConsumed uint64
+	unitsLimit    uint64
+	// invoking are the programs on the call stack
+	invoking []common.PublicKey
+}
+
+const maxInvokeDepth = 5
+
+// invoke runs a program and checks what it did to the accounts
+func (t *transactionContext) invoke(programID common.PublicKey, accounts []instructionAccount, data []byte, depth int) error {
+	p, ok := programs[programID]
+	if !ok {
+		t.logs = append(t.logs, fmt.Sprintf("Program %v invoke [%d]", programID, depth))
+		t.logs = append(t.logs, fmt.Sprintf("Program %v failed: %v", programID, ErrUnsupportedProgramID))
+		return ErrUnsupportedProgramID
+	}
+	if depth > maxInvokeDepth {
+		return ErrCallDepth
+	}
+	for _, invoking := range t.invoking {
+		if invoking == programID {
+			return ErrReentrancyNotAllowed
+		}
+	}
+
+	t.logs = append(t.logs, fmt.Sprintf("Program %v invoke [%d]", programID, depth))
+	remaining := t.unitsLimit - t.unitsConsumed
+	startConsumed := t.unitsConsumed
+
+	ctx := &invokeContext{
+		transactionContext: t,
+		programID:          programID,
+		accounts:           accounts,
+		data:               data,
+		depth:              depth,
+	}
+	ctx.snapshot()
+	t.invoking = append(t.invoking, programID)
+	err := ctx.consume(p.units)
+	if err == nil {
+		err = p.process(ctx)
+	}
+	t.invoking = t.invoking[:len(t.invoking)-1]
+	if err == nil {
+		err = ctx.verify()
+	}
+
+	if !p.builtin {
+		t.logs = append(t.logs, fmt.Sprintf("Program %v consumed %d of %d compute units", programID, t.unitsConsumed-startConsumed, remaining))
+	}
+	if err != nil {
+		t.logs = append(t.logs, fmt.Sprintf("Program %v failed: %v", programID, errorLog(err)))
+		return err
+	}
+	t.logs = append(t.logs, fmt.Sprintf("Program %v success", programID))
+	return nil
+}
+
+// snapshot keeps the accounts of the instruction to verify the changes later
+func (c *invokeContext) snapshot() {
+	c.pre = map[*txAccount]Account{}
+	c.writable = map[*txAccount]bool{}
+	for _, account := range c.accounts {
+		c.pre[account.txAccount] = account.Account.clone()
+		c.writable[account.txAccount] = c.writable[account.txAccount] || account.isWritable
+	}
+}
+
+// verify enforces the rules of the runtime on the changes the program has made since the snapshot
+func (c *invokeContext) verify() error {
+	var preLamports, postLamports uint64
+	for account, before := range c.pre {
+		after := account.Account
+		writable := c.writable[account]
+		preLamports += before.Lamports
+		postLamports += after.Lamports
+
+		if before.Owner != after.Owner {
+			if !writable || before.Owner != c.programID || before.Executable || !isZeroed(after.Data) {
+				return ErrModifiedProgramID
+			}
+		}
+		if before.Lamports != after.Lamports && !writable {
+			return ErrReadonlyLamportChange
+		}
+		if after.Lamports < before.Lamports && before.Owner != c.programID {
+			return ErrExternalAccountLamportSpend
+		}
+		if !bytes.Equal(before.Data, after.Data) {
+			if !writable {
+				return ErrReadonlyDataModified
+			}
+			if before.Owner != c.programID {
+				return ErrExternalAccountDataModified
+			}
+		}
+		if before.Executable != after.Executable {
+			return ErrExecutableModified
+		}
+	}
+	if preLamports != postLamports {
+		return ErrUnbalancedInstruction
+	}
+	return nil
+}
+
+func isZeroed(data []byte) bool {
+	for _, b := range data {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type invokeContext struct {
+	*transactionContext
+	programID common.PublicKey
+	accounts  []instructionAccount
+	data      []byte
+	depth     int
+	// pre and writable are the accounts at the snapshot
+	pre      map[*txAccount]Account
+	writable map[*txAccount]bool
+}
+
+func (c *invokeContext) account(i int) (instructionAccount, error) {
+	if i >= len(c.accounts) {
+		return instructionAccount{}, ErrNotEnoughAccountKeys
+	}
+	return c.accounts[i], nil
+}
+
+func (c *invokeContext) log(format string, a ...any) {
+	c.logs = append(c.logs, "Program log: "+fmt.Sprintf(format, a...))
+}
+
+func (c *invokeContext) consume(units uint64) error {
+	if c.unitsConsumed+units > c.unitsLimit {
+		c.unitsConsumed = c.unitsLimit
+		return ErrComputationalBudgetExceeded
+	}
+	c.unitsConsumed += units
+	return nil
+}
+
+// invoke makes a cross program invocation, signers are the program derived addresses the caller signs for
+func (c *invokeContext) invoke(instruction types.Instruction, signers ...common.PublicKey) error {
+	accounts := make([]instructionAccount, 0, len(instruction.Accounts))
+	for _, meta := range instruction.Accounts {
+		var caller *instructionAccount
+		for i := range c.accounts {
+			if c.accounts[i].pubkey == meta.PubKey {
+				caller = &c.accounts[i]
+				break
+			}
+		}
+		if caller == nil {
+			return ErrNotEnoughAccountKeys
+		}
+		isSigner := caller.isSigner
+		for _, signer := range signers {
+			isSigner = isSigner || signer == meta.PubKey
+		}
+		if (meta.IsSigner && !isSigner) || (meta.IsWritable && !caller.isWritable) {
+			return ErrPrivilegeEscalation
+		}
+		accounts = append(accounts, instructionAccount{
+			txAccount:  caller.txAccount,
+			isSigner:   meta.IsSigner,
+			isWritable: meta.IsWritable,
+		})
+	}
+	// the caller answers for its changes before the callee runs, the callee for its own changes after
+	if err := c.verify(); err != nil {
+		return err
+	}
+	err := c.transactionContext.invoke(instruction.ProgramID, accounts, instruction.Data, c.depth+1)
+	c.snapshot()
+	return err
+}
+
+type program struct {
+	process func(*invokeContext) error
+	// units are what an instruction of the program costs
+	units uint64
+	// builtin programs don't log the consumed compute units
+	builtin bool
+}
+
+var programs map[common.PublicKey]program
+
+// the loaders own the program accounts the bank starts with
+var (
+	nativeLoaderProgramID = common.PublicKeyFromString("NativeLoader1111111111111111111111111111111")
+	bpfLoader2ProgramID   = common.PublicKeyFromString("BPFLoader2111111111111111111111111111111111")
+)
+
+func init() {
+	programs = map[common.PublicKey]program{
+		common.SystemProgramID:                    {process: processSystem, units: 150, builtin: true},
+		common.ComputeBudgetProgramID:             {process: processComputeBudget, units: 150, builtin: true},
+		common.TokenProgramID:                     {process: processToken, units: 3000},
+		common.SPLAssociatedTokenAccountProgramID: {process: processAssociatedTokenAccount, units: 12000},
+		common.MemoProgramID:                      {process: processMemo, units: 500},
+	}
+}
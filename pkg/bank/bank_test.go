@@ -0,0 +1,266 @@
+package bank_test
+
+import (
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/bank"
+	"github.com/EntySquare/solana-go-sdk/program/compute_budget"
+	"github.com/EntySquare/solana-go-sdk/program/memo"
+	"github.com/EntySquare/solana-go-sdk/program/system"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	alice, _ = types.AccountFromSeed(make([]byte, 32))
+	bob, _   = types.AccountFromSeed([]byte("bobbobbobbobbobbobbobbobbobbobbo"))
+)
+
+func newTransaction(t *testing.T, b *bank.Bank, instructions []types.Instruction, signers ...types.Account) types.Transaction {
+	tx, err := types.NewTransaction(types.NewTransactionParam{
+		Message: types.NewMessage(types.NewMessageParam{
+			FeePayer:        signers[0].PublicKey,
+			RecentBlockhash: bank.DefaultBlockhash,
+			Instructions:    instructions,
+		}),
+		Signers: signers,
+	})
+	assert.NoError(t, err)
+	return tx
+}
+
+func newBank(lamports uint64) *bank.Bank {
+	return bank.New(bank.WithAccount(alice.PublicKey, bank.Account{Lamports: lamports, Owner: common.SystemProgramID}))
+}
+
+func balance(b *bank.Bank, pubkey common.PublicKey) uint64 {
+	account, _ := b.GetAccount(pubkey)
+	return account.Lamports
+}
+
+func TestBank_Transfer(t *testing.T) {
+	b := newBank(1_000_000_000)
+	rentExempt := b.MinimumBalanceForRentExemption(0)
+	tx := newTransaction(t, b, []types.Instruction{
+		system.Transfer(system.TransferParam{From: alice.PublicKey, To: bob.PublicKey, Amount: rentExempt}),
+	}, alice)
+
+	result, err := b.Execute(tx)
+	assert.NoError(t, err)
+	assert.Equal(t, bank.DefaultLamportsPerSignature, result.Fee)
+	assert.Equal(t, []uint64{1_000_000_000, 0, 1}, result.PreBalances)
+	assert.Equal(t, []uint64{1_000_000_000 - rentExempt - 5000, rentExempt, 1}, result.PostBalances)
+	assert.Equal(t, []string{
+		"Program 11111111111111111111111111111111 invoke [1]",
+		"Program 11111111111111111111111111111111 success",
+	}, result.Logs)
+	assert.Equal(t, uint64(150), result.ComputeUnitsConsumed)
+	assert.Equal(t, 1_000_000_000-rentExempt-5000, balance(b, alice.PublicKey))
+	assert.Equal(t, rentExempt, balance(b, bob.PublicKey))
+
+	_, err = b.Execute(tx)
+	assert.ErrorIs(t, err, bank.ErrAlreadyProcessed)
+}
+
+func TestBank_TransactionErrors(t *testing.T) {
+	b := newBank(1_000_000_000)
+	rentExempt := b.MinimumBalanceForRentExemption(0)
+
+	tx := newTransaction(t, b, []types.Instruction{
+		system.Transfer(system.TransferParam{From: alice.PublicKey, To: bob.PublicKey, Amount: rentExempt}),
+	}, alice)
+	tx.Signatures[0][0] ^= 1
+	_, err := b.Execute(tx)
+	assert.ErrorIs(t, err, bank.ErrSignatureFailure)
+
+	tx = newTransaction(t, b, []types.Instruction{
+		system.Transfer(system.TransferParam{From: bob.PublicKey, To: alice.PublicKey, Amount: 1}),
+	}, bob)
+	_, err = b.Execute(tx)
+	assert.ErrorIs(t, err, bank.ErrAccountNotFound)
+
+	b.SetAccount(bob.PublicKey, bank.Account{Lamports: 4999, Owner: common.SystemProgramID})
+	_, err = b.Execute(tx)
+	assert.ErrorIs(t, err, bank.ErrInsufficientFundsForFee)
+
+	// simulate neither checks the signatures nor changes the bank
+	tx = newTransaction(t, b, []types.Instruction{
+		system.Transfer(system.TransferParam{From: alice.PublicKey, To: bob.PublicKey, Amount: rentExempt}),
+	}, alice)
+	tx.Signatures[0][0] ^= 1
+	result, err := b.Simulate(tx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1_000_000_000-rentExempt-5000, result.PostBalances[0])
+	assert.Equal(t, uint64(1_000_000_000), balance(b, alice.PublicKey))
+}
+
+func TestBank_InstructionErrors(t *testing.T) {
+	tokenOwned := types.NewAccount()
+	tests := []struct {
+		name         string
+		instructions []types.Instruction
+		signers      []types.Account
+		err          error
+		index        int
+	}{
+		{
+			name: "insufficient lamports",
+			instructions: []types.Instruction{
+				system.Transfer(system.TransferParam{From: alice.PublicKey, To: bob.PublicKey, Amount: 2_000_000_000}),
+			},
+			err:   bank.SystemErrorResultWithNegativeLamports,
+			index: 0,
+		},
+		{
+			name: "missing signature",
+			instructions: []types.Instruction{
+				memo.BuildMemo(memo.BuildMemoParam{Memo: []byte("hi")}),
+				{
+					ProgramID: common.SystemProgramID,
+					Accounts: []types.AccountMeta{
+						{PubKey: bob.PublicKey, IsSigner: false, IsWritable: true},
+						{PubKey: alice.PublicKey, IsSigner: false, IsWritable: true},
+					},
+					Data: system.Transfer(system.TransferParam{}).Data,
+				},
+			},
+			err:   bank.ErrMissingRequiredSignature,
+			index: 1,
+		},
+		{
+			name: "external account lamport spend",
+			instructions: []types.Instruction{
+				system.Transfer(system.TransferParam{From: tokenOwned.PublicKey, To: bob.PublicKey, Amount: 1}),
+			},
+			signers: []types.Account{tokenOwned},
+			err:     bank.ErrExternalAccountLamportSpend,
+			index:   0,
+		},
+		{
+			name: "unsupported program",
+			instructions: []types.Instruction{
+				{ProgramID: common.StakeProgramID},
+			},
+			err:   bank.ErrUnsupportedProgramID,
+			index: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBank(1_000_000_000)
+			b.SetAccount(bob.PublicKey, bank.Account{Lamports: 1_000_000, Owner: common.SystemProgramID})
+			b.SetAccount(tokenOwned.PublicKey, bank.Account{Lamports: 1_000_000, Owner: common.TokenProgramID})
+			tx := newTransaction(t, b, tt.instructions, append([]types.Account{alice}, tt.signers...)...)
+			result, err := b.Execute(tx)
+			assert.ErrorIs(t, err, tt.err)
+			var instructionErr *bank.InstructionError
+			if assert.ErrorAs(t, err, &instructionErr) {
+				assert.Equal(t, tt.index, instructionErr.Index)
+			}
+			// only the fee is charged
+			fee := uint64(1+len(tt.signers)) * bank.DefaultLamportsPerSignature
+			assert.Equal(t, 1_000_000_000-fee, result.PostBalances[0])
+			assert.Equal(t, 1_000_000_000-fee, balance(b, alice.PublicKey))
+			assert.Equal(t, uint64(1_000_000), balance(b, bob.PublicKey))
+		})
+	}
+}
+
+func TestBank_Rent(t *testing.T) {
+	b := newBank(1_000_000_000)
+	tx := newTransaction(t, b, []types.Instruction{
+		system.Transfer(system.TransferParam{From: alice.PublicKey, To: bob.PublicKey, Amount: 1}),
+	}, alice)
+	result, err := b.Execute(tx)
+	var rentErr *bank.RentError
+	if assert.ErrorAs(t, err, &rentErr) {
+		assert.Equal(t, 1, rentErr.AccountIndex)
+	}
+	assert.ErrorIs(t, err, bank.ErrInsufficientFundsForRent)
+	assert.Equal(t, []uint64{1_000_000_000 - 5000, 0, 1}, result.PostBalances)
+	_, ok := b.GetAccount(bob.PublicKey)
+	assert.False(t, ok)
+}
+
+func TestBank_ComputeBudget(t *testing.T) {
+	b := newBank(1_000_000_000)
+	instructions := func(units uint32) []types.Instruction {
+		return []types.Instruction{
+			compute_budget.SetComputeUnitLimit(compute_budget.SetComputeUnitLimitParam{Units: units}),
+			compute_budget.SetComputeUnitPrice(compute_budget.SetComputeUnitPriceParam{MicroLamports: 1_000_001}),
+			memo.BuildMemo(memo.BuildMemoParam{SignerPubkeys: []common.PublicKey{alice.PublicKey}, Memo: []byte("hello")}),
+		}
+	}
+
+	result, err := b.Execute(newTransaction(t, b, instructions(1000), alice))
+	assert.NoError(t, err)
+	// 1000 units * 1.000001 lamports, rounded up
+	assert.Equal(t, uint64(5000+1001), result.Fee)
+	assert.Equal(t, uint64(800), result.ComputeUnitsConsumed)
+	assert.Equal(t, []string{
+		"Program ComputeBudget111111111111111111111111111111 invoke [1]",
+		"Program ComputeBudget111111111111111111111111111111 success",
+		"Program ComputeBudget111111111111111111111111111111 invoke [1]",
+		"Program ComputeBudget111111111111111111111111111111 success",
+		"Program MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr invoke [1]",
+		"Program log: Signed by " + alice.PublicKey.ToBase58(),
+		`Program log: Memo (len 5): "hello"`,
+		"Program MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr consumed 500 of 700 compute units",
+		"Program MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr success",
+	}, result.Logs)
+
+	result, err = b.Execute(newTransaction(t, b, instructions(600), alice))
+	assert.ErrorIs(t, err, bank.ErrComputationalBudgetExceeded)
+	assert.Equal(t, uint64(5000+601), result.Fee)
+	assert.Equal(t, uint64(1_000_000_000-2*5000-1001-601), balance(b, alice.PublicKey))
+}
+
+func TestBank_Nonce(t *testing.T) {
+	b := newBank(1_000_000_000)
+	nonce := types.NewAccount()
+	rentExempt := b.MinimumBalanceForRentExemption(system.NonceAccountSize)
+	tx := newTransaction(t, b, []types.Instruction{
+		system.CreateAccount(system.CreateAccountParam{
+			From:     alice.PublicKey,
+			New:      nonce.PublicKey,
+			Owner:    common.SystemProgramID,
+			Lamports: rentExempt,
+			Space:    system.NonceAccountSize,
+		}),
+		system.InitializeNonceAccount(system.InitializeNonceAccountParam{Nonce: nonce.PublicKey, Auth: alice.PublicKey}),
+	}, alice, nonce)
+	_, err := b.Execute(tx)
+	assert.NoError(t, err)
+
+	account, _ := b.GetAccount(nonce.PublicKey)
+	state, err := system.NonceAccountDeserialize(account.Data)
+	assert.NoError(t, err)
+	assert.Equal(t, system.NonceStateInitialized, state.State)
+	assert.Equal(t, alice.PublicKey, state.AuthorizedPubkey)
+	assert.Equal(t, bank.DefaultLamportsPerSignature, state.FeeCalculator.LamportsPerSignature)
+
+	advance := []types.Instruction{
+		system.AdvanceNonceAccount(system.AdvanceNonceAccountParam{Nonce: nonce.PublicKey, Auth: alice.PublicKey}),
+	}
+	_, err = b.Execute(newTransaction(t, b, advance, alice))
+	assert.ErrorIs(t, err, bank.SystemErrorNonceBlockhashNotExpired)
+
+	// a durable transaction uses the nonce as its blockhash
+	b.SetBlockhash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	tx, err = types.NewTransaction(types.NewTransactionParam{
+		Message: types.NewMessage(types.NewMessageParam{
+			FeePayer:        alice.PublicKey,
+			RecentBlockhash: state.Nonce.ToBase58(),
+			Instructions:    advance,
+		}),
+		Signers: []types.Account{alice},
+	})
+	assert.NoError(t, err)
+	_, err = b.Execute(tx)
+	assert.NoError(t, err)
+	account, _ = b.GetAccount(nonce.PublicKey)
+	advanced, err := system.NonceAccountDeserialize(account.Data)
+	assert.NoError(t, err)
+	assert.NotEqual(t, state.Nonce, advanced.Nonce)
+}
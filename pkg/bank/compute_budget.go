@@ -0,0 +1,118 @@
+package bank
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/compute_budget"
+	"github.com/EntySquare/solana-go-sdk/types"
+)
+
+const (
+	DefaultInstructionComputeUnitLimit uint64 = 200_000
+	MaxComputeUnitLimit                uint64 = 1_400_000
+
+	minHeapFrameBytes = 32 * 1024
+	maxHeapFrameBytes = 256 * 1024
+	// instructionSetLoadedAccountsDataSizeLimit isn't built by the compute_budget package
+	instructionSetLoadedAccountsDataSizeLimit = compute_budget.InstructionSetComputeUnitPrice + 1
+)
+
+type computeBudget struct {
+	unitsLimit uint64
+	// unitPrice is in micro-lamports
+	unitPrice uint64
+}
+
+// priorityFee is unitPrice * unitsLimit in lamports, rounded up
+func (c computeBudget) priorityFee() uint64 {
+	hi, lo := bits.Mul64(c.unitPrice, c.unitsLimit)
+	lo, carry := bits.Add64(lo, 999_999, 0)
+	hi += carry
+	if hi >= 1_000_000 {
+		return math.MaxUint64
+	}
+	fee, _ := bits.Div64(hi, lo, 1_000_000)
+	return fee
+}
+
+// parseComputeBudget reads the compute budget instructions before the transaction is executed like the runtime does
+func parseComputeBudget(message types.Message, keys []common.PublicKey) (computeBudget, error) {
+	var unitsLimit, unitPrice *uint64
+	var heapFrame, loadedAccountsDataSize bool
+
+	for i, instruction := range message.Instructions {
+		if keys[instruction.ProgramIDIndex] != common.ComputeBudgetProgramID {
+			continue
+		}
+		invalid := &InstructionError{Index: i, Err: ErrInvalidInstructionData}
+		duplicate := fmt.Errorf("%w, instruction %d", ErrDuplicateInstruction, i)
+		data := instruction.Data
+		if len(data) == 0 {
+			return computeBudget{}, invalid
+		}
+		switch compute_budget.Instruction(data[0]) {
+		case compute_budget.InstructionRequestHeapFrame:
+			if len(data) != 5 {
+				return computeBudget{}, invalid
+			}
+			b := binary.LittleEndian.Uint32(data[1:])
+			if b%1024 != 0 || b < minHeapFrameBytes || b > maxHeapFrameBytes {
+				return computeBudget{}, invalid
+			}
+			if heapFrame {
+				return computeBudget{}, duplicate
+			}
+			heapFrame = true
+		case compute_budget.InstructionSetComputeUnitLimit:
+			if len(data) != 5 {
+				return computeBudget{}, invalid
+			}
+			if unitsLimit != nil {
+				return computeBudget{}, duplicate
+			}
+			units := uint64(binary.LittleEndian.Uint32(data[1:]))
+			unitsLimit = &units
+		case compute_budget.InstructionSetComputeUnitPrice:
+			if len(data) != 9 {
+				return computeBudget{}, invalid
+			}
+			if unitPrice != nil {
+				return computeBudget{}, duplicate
+			}
+			price := binary.LittleEndian.Uint64(data[1:])
+			unitPrice = &price
+		case instructionSetLoadedAccountsDataSizeLimit:
+			if len(data) != 5 {
+				return computeBudget{}, invalid
+			}
+			if loadedAccountsDataSize {
+				return computeBudget{}, duplicate
+			}
+			loadedAccountsDataSize = true
+		default:
+			// including the deprecated RequestUnits
+			return computeBudget{}, invalid
+		}
+	}
+
+	budget := computeBudget{unitsLimit: uint64(len(message.Instructions)) * DefaultInstructionComputeUnitLimit}
+	if unitsLimit != nil {
+		budget.unitsLimit = *unitsLimit
+	}
+	if budget.unitsLimit > MaxComputeUnitLimit {
+		budget.unitsLimit = MaxComputeUnitLimit
+	}
+	if unitPrice != nil {
+		budget.unitPrice = *unitPrice
+	}
+	return budget, nil
+}
+
+// processComputeBudget does nothing, the instructions are read before the transaction is executed
+func processComputeBudget(ctx *invokeContext) error {
+	return nil
+}
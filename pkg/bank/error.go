@@ -0,0 +1,208 @@
+package bank
+
+import (
+	"errors"
+	"fmt"
+)
+
+// transaction errors, a transaction which fails with them changes nothing
+var (
+	ErrSanitizeFailure                = errors.New("transaction failed to sanitize accounts offsets correctly")
+	ErrAccountLoadedTwice             = errors.New("account loaded twice")
+	ErrSignatureFailure               = errors.New("transaction did not pass signature verification")
+	ErrAlreadyProcessed               = errors.New("this transaction has already been processed")
+	ErrAccountNotFound                = errors.New("attempt to debit an account but found no record of a prior credit")
+	ErrInvalidAccountForFee           = errors.New("this account may not be used to pay transaction fees")
+	ErrInsufficientFundsForFee        = errors.New("insufficient funds for fee")
+	ErrAddressLookupTableNotFound     = errors.New("transaction loads an address table account that doesn't exist")
+	ErrInvalidAddressLookupTableIndex = errors.New("transaction address table lookup uses an invalid index")
+	ErrDuplicateInstruction           = errors.New("transaction contains a duplicate instruction that is not allowed")
+	ErrInsufficientFundsForRent       = errors.New("transaction results in an account with insufficient funds for rent")
+)
+
+// instruction errors, they are wrapped in InstructionError
+var (
+	ErrGenericError                = errors.New("generic instruction error")
+	ErrInvalidArgument             = errors.New("invalid program argument")
+	ErrInvalidInstructionData      = errors.New("invalid instruction data")
+	ErrInvalidAccountData          = errors.New("invalid account data for instruction")
+	ErrAccountDataTooSmall         = errors.New("account data too small for instruction")
+	ErrInsufficientFunds           = errors.New("insufficient funds for instruction")
+	ErrIncorrectProgramID          = errors.New("incorrect program id for instruction")
+	ErrMissingRequiredSignature    = errors.New("missing required signature for instruction")
+	ErrAccountAlreadyInitialized   = errors.New("instruction requires an uninitialized account")
+	ErrUninitializedAccount        = errors.New("instruction requires an initialized account")
+	ErrUnbalancedInstruction       = errors.New("sum of account balances before and after instruction do not match")
+	ErrModifiedProgramID           = errors.New("instruction illegally modified the program id of an account")
+	ErrExternalAccountLamportSpend = errors.New("instruction spent from the balance of an account it does not own")
+	ErrExternalAccountDataModified = errors.New("instruction modified data of an account it does not own")
+	ErrReadonlyLamportChange       = errors.New("instruction changed the balance of a read-only account")
+	ErrReadonlyDataModified        = errors.New("instruction modified data of a read-only account")
+	ErrExecutableModified          = errors.New("instruction changed executable bit of an account")
+	ErrNotEnoughAccountKeys        = errors.New("insufficient account keys for instruction")
+	ErrPrivilegeEscalation         = errors.New("cross-program invocation with unauthorized signer or writable account")
+	ErrComputationalBudgetExceeded = errors.New("computational budget exceeded")
+	ErrUnsupportedProgramID        = errors.New("unsupported program id")
+	ErrArithmeticOverflow          = errors.New("program arithmetic overflowed")
+	ErrInvalidSeeds                = errors.New("provided seeds do not result in a valid address")
+	ErrIllegalOwner                = errors.New("provided owner is not allowed")
+	ErrInvalidAccountOwner         = errors.New("invalid account owner")
+	ErrCallDepth                   = errors.New("cross-program invocation call depth too deep")
+	ErrReentrancyNotAllowed        = errors.New("cross-program invocation reentrancy not allowed for this instruction")
+)
+
+// InstructionError is the error of an instruction, the transaction is rolled back except for the fee
+type InstructionError struct {
+	Index int
+	Err   error
+}
+
+func (e *InstructionError) Error() string {
+	return fmt.Sprintf("error processing instruction %d: %v", e.Index, e.Err)
+}
+
+func (e *InstructionError) Unwrap() error {
+	return e.Err
+}
+
+// RentError means the transaction leaves an account with lamports below the rent exempt minimum,
+// the transaction is rolled back except for the fee
+type RentError struct {
+	AccountIndex int
+}
+
+func (e *RentError) Error() string {
+	return fmt.Sprintf("%v, account index: %d", ErrInsufficientFundsForRent, e.AccountIndex)
+}
+
+func (e *RentError) Unwrap() error {
+	return ErrInsufficientFundsForRent
+}
+
+// CustomError is a program specific error, the runtime shows it as "custom program error: 0x.."
+type CustomError interface {
+	error
+	Code() uint32
+}
+
+type SystemError uint32
+
+const (
+	SystemErrorAccountAlreadyInUse SystemError = iota
+	SystemErrorResultWithNegativeLamports
+	SystemErrorInvalidProgramId
+	SystemErrorInvalidAccountDataLength
+	SystemErrorMaxSeedLengthExceeded
+	SystemErrorAddressWithSeedMismatch
+	SystemErrorNonceNoRecentBlockhashes
+	SystemErrorNonceBlockhashNotExpired
+	SystemErrorNonceUnexpectedBlockhashValue
+)
+
+var systemErrorMessages = map[SystemError]string{
+	SystemErrorAccountAlreadyInUse:           "an account with the same address already exists",
+	SystemErrorResultWithNegativeLamports:    "account does not have enough SOL to perform the operation",
+	SystemErrorInvalidProgramId:              "cannot assign account to this program id",
+	SystemErrorInvalidAccountDataLength:      "cannot allocate account data of this length",
+	SystemErrorMaxSeedLengthExceeded:         "length of requested seed is too long",
+	SystemErrorAddressWithSeedMismatch:       "provided address does not match addressed derived from seed",
+	SystemErrorNonceNoRecentBlockhashes:      "advancing stored nonce requires a populated RecentBlockhashes sysvar",
+	SystemErrorNonceBlockhashNotExpired:      "stored nonce is still in recent_blockhashes",
+	SystemErrorNonceUnexpectedBlockhashValue: "specified nonce does not match stored nonce",
+}
+
+func (e SystemError) Code() uint32 {
+	return uint32(e)
+}
+
+func (e SystemError) Error() string {
+	if message, ok := systemErrorMessages[e]; ok {
+		return message
+	}
+	return fmt.Sprintf("unknown system error: %d", uint32(e))
+}
+
+type TokenError uint32
+
+const (
+	TokenErrorNotRentExempt TokenError = iota
+	TokenErrorInsufficientFunds
+	TokenErrorInvalidMint
+	TokenErrorMintMismatch
+	TokenErrorOwnerMismatch
+	TokenErrorFixedSupply
+	TokenErrorAlreadyInUse
+	TokenErrorInvalidNumberOfProvidedSigners
+	TokenErrorInvalidNumberOfRequiredSigners
+	TokenErrorUninitializedState
+	TokenErrorNativeNotSupported
+	TokenErrorNonNativeHasBalance
+	TokenErrorInvalidInstruction
+	TokenErrorInvalidState
+	TokenErrorOverflow
+	TokenErrorAuthorityTypeNotSupported
+	TokenErrorMintCannotFreeze
+	TokenErrorAccountFrozen
+	TokenErrorMintDecimalsMismatch
+	TokenErrorNonNativeNotSupported
+)
+
+var tokenErrorMessages = map[TokenError]string{
+	TokenErrorNotRentExempt:                  "lamport balance below rent-exempt threshold",
+	TokenErrorInsufficientFunds:              "insufficient funds",
+	TokenErrorInvalidMint:                    "invalid Mint",
+	TokenErrorMintMismatch:                   "account not associated with this Mint",
+	TokenErrorOwnerMismatch:                  "owner does not match",
+	TokenErrorFixedSupply:                    "fixed supply",
+	TokenErrorAlreadyInUse:                   "already in use",
+	TokenErrorInvalidNumberOfProvidedSigners: "invalid number of provided signers",
+	TokenErrorInvalidNumberOfRequiredSigners: "invalid number of required signers",
+	TokenErrorUninitializedState:             "state is uninitialized",
+	TokenErrorNativeNotSupported:             "instruction does not support native tokens",
+	TokenErrorNonNativeHasBalance:            "non-native account can only be closed if its balance is zero",
+	TokenErrorInvalidInstruction:             "invalid instruction",
+	TokenErrorInvalidState:                   "state is invalid for requested operation",
+	TokenErrorOverflow:                       "operation overflowed",
+	TokenErrorAuthorityTypeNotSupported:      "account does not support specified authority type",
+	TokenErrorMintCannotFreeze:               "this token mint cannot freeze accounts",
+	TokenErrorAccountFrozen:                  "account is frozen",
+	TokenErrorMintDecimalsMismatch:           "the provided decimals value different from the Mint decimals",
+	TokenErrorNonNativeNotSupported:          "instruction does not support non-native tokens",
+}
+
+func (e TokenError) Code() uint32 {
+	return uint32(e)
+}
+
+func (e TokenError) Error() string {
+	if message, ok := tokenErrorMessages[e]; ok {
+		return message
+	}
+	return fmt.Sprintf("unknown token error: %d", uint32(e))
+}
+
+type AssociatedTokenAccountError uint32
+
+const (
+	AssociatedTokenAccountErrorInvalidOwner AssociatedTokenAccountError = iota
+)
+
+func (e AssociatedTokenAccountError) Code() uint32 {
+	return uint32(e)
+}
+
+func (e AssociatedTokenAccountError) Error() string {
+	if e == AssociatedTokenAccountErrorInvalidOwner {
+		return "associated token account owner does not match address derivation"
+	}
+	return fmt.Sprintf("unknown associated token account error: %d", uint32(e))
+}
+
+// errorLog is how the runtime shows the error in "Program xxx failed: <message>"
+func errorLog(err error) string {
+	var custom CustomError
+	if errors.As(err, &custom) {
+		return fmt.Sprintf("custom program error: 0x%x", custom.Code())
+	}
+	return err.Error()
+}
@@ -0,0 +1,35 @@
+package bank
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+func processMemo(ctx *invokeContext) error {
+	missingSignature := false
+	for _, account := range ctx.accounts {
+		if !account.isSigner {
+			missingSignature = true
+			continue
+		}
+		ctx.log("Signed by %v", account.pubkey)
+	}
+	if missingSignature {
+		return ErrMissingRequiredSignature
+	}
+
+	if !utf8.Valid(ctx.data) {
+		validUpTo := 0
+		for validUpTo < len(ctx.data) {
+			r, size := utf8.DecodeRune(ctx.data[validUpTo:])
+			if r == utf8.RuneError && size <= 1 {
+				break
+			}
+			validUpTo += size
+		}
+		ctx.log("Invalid UTF-8, from byte %d", validUpTo)
+		return ErrInvalidInstructionData
+	}
+	ctx.log("Memo (len %d): %v", len(ctx.data), strconv.Quote(string(ctx.data)))
+	return nil
+}
@@ -0,0 +1,133 @@
+package bank
+
+import (
+	"encoding/binary"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/system"
+	"github.com/EntySquare/solana-go-sdk/program/token"
+)
+
+// reader reads the little endian fields of instruction data
+type reader struct {
+	data []byte
+	err  error
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.err != nil || len(r.data) < n {
+		r.err = ErrInvalidInstructionData
+		return make([]byte, n)
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *reader) u8() uint8 {
+	return r.bytes(1)[0]
+}
+
+func (r *reader) u32() uint32 {
+	return binary.LittleEndian.Uint32(r.bytes(4))
+}
+
+func (r *reader) u64() uint64 {
+	return binary.LittleEndian.Uint64(r.bytes(8))
+}
+
+func (r *reader) bool() bool {
+	switch r.u8() {
+	case 0:
+		return false
+	case 1:
+		return true
+	}
+	r.err = ErrInvalidInstructionData
+	return false
+}
+
+func (r *reader) publicKey() common.PublicKey {
+	return common.PublicKeyFromBytes(r.bytes(common.PublicKeyLength))
+}
+
+// string reads a bincode string, a u64 length followed by the bytes
+func (r *reader) string() string {
+	n := r.u64()
+	if r.err != nil || n > uint64(len(r.data)) {
+		r.err = ErrInvalidInstructionData
+		return ""
+	}
+	return string(r.bytes(int(n)))
+}
+
+// optionalPublicKey reads a 1 byte tag followed by the pubkey, the pubkey is there even if the tag is 0
+func (r *reader) optionalPublicKey() *common.PublicKey {
+	some := r.bool()
+	pubkey := r.publicKey()
+	if !some {
+		return nil
+	}
+	return &pubkey
+}
+
+func putCOptionPublicKey(b []byte, pubkey *common.PublicKey) {
+	if pubkey == nil {
+		copy(b, token.None)
+		copy(b[4:36], make([]byte, 32))
+		return
+	}
+	copy(b, token.Some)
+	copy(b[4:36], pubkey.Bytes())
+}
+
+func encodeMint(mint token.MintAccount) []byte {
+	b := make([]byte, token.MintAccountSize)
+	putCOptionPublicKey(b[:36], mint.MintAuthority)
+	binary.LittleEndian.PutUint64(b[36:44], mint.Supply)
+	b[44] = mint.Decimals
+	if mint.IsInitialized {
+		b[45] = 1
+	}
+	putCOptionPublicKey(b[46:82], mint.FreezeAuthority)
+	return b
+}
+
+func encodeTokenAccount(account token.TokenAccount) []byte {
+	b := make([]byte, token.TokenAccountSize)
+	copy(b[:32], account.Mint.Bytes())
+	copy(b[32:64], account.Owner.Bytes())
+	binary.LittleEndian.PutUint64(b[64:72], account.Amount)
+	putCOptionPublicKey(b[72:108], account.Delegate)
+	b[108] = uint8(account.State)
+	if account.IsNative != nil {
+		copy(b[109:113], token.Some)
+		binary.LittleEndian.PutUint64(b[113:121], *account.IsNative)
+	}
+	binary.LittleEndian.PutUint64(b[121:129], account.DelegatedAmount)
+	putCOptionPublicKey(b[129:165], account.CloseAuthority)
+	return b
+}
+
+func encodeMultisig(multisig token.MultisigAccount) []byte {
+	b := make([]byte, token.MultisigAccountSize)
+	b[0] = multisig.M
+	b[1] = multisig.N
+	if multisig.IsInitialized {
+		b[2] = 1
+	}
+	for i, signer := range multisig.Signers {
+		copy(b[3+32*i:], signer.Bytes())
+	}
+	return b
+}
+
+func encodeNonceAccount(nonce system.NonceAccount) []byte {
+	b := make([]byte, system.NonceAccountSize)
+	binary.LittleEndian.PutUint32(b[0:4], nonce.Version)
+	binary.LittleEndian.PutUint32(b[4:8], nonce.State)
+	copy(b[8:40], nonce.AuthorizedPubkey.Bytes())
+	copy(b[40:72], nonce.Nonce.Bytes())
+	binary.LittleEndian.PutUint64(b[72:80], nonce.FeeCalculator.LamportsPerSignature)
+	return b
+}
@@ -0,0 +1,393 @@
+package bank
+
+import (
+	"crypto/sha256"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/system"
+	"github.com/mr-tron/base58"
+)
+
+const (
+	// MaxPermittedDataLength is the largest data an account can have
+	MaxPermittedDataLength = 10 * 1024 * 1024
+	maxSeedLength          = 32
+)
+
+func processSystem(ctx *invokeContext) error {
+	r := &reader{data: ctx.data}
+	instruction := system.Instruction(r.u32())
+	if r.err != nil {
+		return r.err
+	}
+
+	switch instruction {
+	case system.InstructionCreateAccount:
+		lamports, space, owner := r.u64(), r.u64(), r.publicKey()
+		if r.err != nil {
+			return r.err
+		}
+		return ctx.withAccounts(2, func(accounts []instructionAccount) error {
+			return createAccount(ctx, accounts[0], accounts[1], accounts[1].pubkey, lamports, space, owner)
+		})
+	case system.InstructionAssign:
+		owner := r.publicKey()
+		if r.err != nil {
+			return r.err
+		}
+		return ctx.withAccounts(1, func(accounts []instructionAccount) error {
+			return assign(ctx, accounts[0], accounts[0].pubkey, owner)
+		})
+	case system.InstructionTransfer:
+		lamports := r.u64()
+		if r.err != nil {
+			return r.err
+		}
+		return ctx.withAccounts(2, func(accounts []instructionAccount) error {
+			if !accounts[0].isSigner {
+				ctx.log("Transfer: `from` account %v must sign", accounts[0].pubkey)
+				return ErrMissingRequiredSignature
+			}
+			return transfer(ctx, accounts[0], accounts[1], lamports)
+		})
+	case system.InstructionCreateAccountWithSeed:
+		base, seed, lamports, space, owner := r.publicKey(), r.string(), r.u64(), r.u64(), r.publicKey()
+		if r.err != nil {
+			return r.err
+		}
+		return ctx.withAccounts(2, func(accounts []instructionAccount) error {
+			if err := checkAddressWithSeed(ctx, accounts[1].pubkey, base, seed, owner); err != nil {
+				return err
+			}
+			return createAccount(ctx, accounts[0], accounts[1], base, lamports, space, owner)
+		})
+	case system.InstructionAdvanceNonceAccount:
+		return ctx.withAccounts(1, func(accounts []instructionAccount) error {
+			return advanceNonceAccount(ctx, accounts[0])
+		})
+	case system.InstructionWithdrawNonceAccount:
+		lamports := r.u64()
+		if r.err != nil {
+			return r.err
+		}
+		return ctx.withAccounts(2, func(accounts []instructionAccount) error {
+			return withdrawNonceAccount(ctx, accounts[0], accounts[1], lamports)
+		})
+	case system.InstructionInitializeNonceAccount:
+		authority := r.publicKey()
+		if r.err != nil {
+			return r.err
+		}
+		return ctx.withAccounts(1, func(accounts []instructionAccount) error {
+			return initializeNonceAccount(ctx, accounts[0], authority)
+		})
+	case system.InstructionAuthorizeNonceAccount:
+		authority := r.publicKey()
+		if r.err != nil {
+			return r.err
+		}
+		return ctx.withAccounts(1, func(accounts []instructionAccount) error {
+			return authorizeNonceAccount(ctx, accounts[0], authority)
+		})
+	case system.InstructionAllocate:
+		space := r.u64()
+		if r.err != nil {
+			return r.err
+		}
+		return ctx.withAccounts(1, func(accounts []instructionAccount) error {
+			return allocate(ctx, accounts[0], accounts[0].pubkey, space)
+		})
+	case system.InstructionAllocateWithSeed:
+		base, seed, space, owner := r.publicKey(), r.string(), r.u64(), r.publicKey()
+		if r.err != nil {
+			return r.err
+		}
+		return ctx.withAccounts(1, func(accounts []instructionAccount) error {
+			if err := checkAddressWithSeed(ctx, accounts[0].pubkey, base, seed, owner); err != nil {
+				return err
+			}
+			if err := allocate(ctx, accounts[0], base, space); err != nil {
+				return err
+			}
+			return assign(ctx, accounts[0], base, owner)
+		})
+	case system.InstructionAssignWithSeed:
+		base, seed, owner := r.publicKey(), r.string(), r.publicKey()
+		if r.err != nil {
+			return r.err
+		}
+		return ctx.withAccounts(1, func(accounts []instructionAccount) error {
+			if err := checkAddressWithSeed(ctx, accounts[0].pubkey, base, seed, owner); err != nil {
+				return err
+			}
+			return assign(ctx, accounts[0], base, owner)
+		})
+	case system.InstructionTransferWithSeed:
+		lamports, seed, owner := r.u64(), r.string(), r.publicKey()
+		if r.err != nil {
+			return r.err
+		}
+		return ctx.withAccounts(3, func(accounts []instructionAccount) error {
+			from, base, to := accounts[0], accounts[1], accounts[2]
+			if !base.isSigner {
+				ctx.log("Transfer: `from` account %v must sign", base.pubkey)
+				return ErrMissingRequiredSignature
+			}
+			if err := checkAddressWithSeed(ctx, from.pubkey, base.pubkey, seed, owner); err != nil {
+				return err
+			}
+			return transfer(ctx, from, to, lamports)
+		})
+	case system.InstructionUpgradeNonceAccount:
+		return ctx.withAccounts(1, func(accounts []instructionAccount) error {
+			return upgradeNonceAccount(ctx, accounts[0])
+		})
+	}
+	return ErrInvalidInstructionData
+}
+
+// withAccounts calls f with the first n accounts of the instruction
+func (c *invokeContext) withAccounts(n int, f func([]instructionAccount) error) error {
+	if len(c.accounts) < n {
+		return ErrNotEnoughAccountKeys
+	}
+	return f(c.accounts[:n])
+}
+
+// signed reports whether an account of the instruction is the pubkey and signs
+func (c *invokeContext) signed(pubkey common.PublicKey) bool {
+	for _, account := range c.accounts {
+		if account.isSigner && account.pubkey == pubkey {
+			return true
+		}
+	}
+	return false
+}
+
+func checkAddressWithSeed(ctx *invokeContext, address, base common.PublicKey, seed string, owner common.PublicKey) error {
+	if len(seed) > maxSeedLength {
+		return SystemErrorMaxSeedLengthExceeded
+	}
+	if common.CreateWithSeed(base, seed, owner) != address {
+		ctx.log("Create: address %v does not match derived address %v", address, common.CreateWithSeed(base, seed, owner))
+		return SystemErrorAddressWithSeedMismatch
+	}
+	return nil
+}
+
+func createAccount(ctx *invokeContext, from, to instructionAccount, address common.PublicKey, lamports, space uint64, owner common.PublicKey) error {
+	if to.Lamports > 0 {
+		ctx.log("Create Account: account %v already in use", to.pubkey)
+		return SystemErrorAccountAlreadyInUse
+	}
+	if err := allocate(ctx, to, address, space); err != nil {
+		return err
+	}
+	if err := assign(ctx, to, address, owner); err != nil {
+		return err
+	}
+	if !from.isSigner {
+		ctx.log("Transfer: `from` account %v must sign", from.pubkey)
+		return ErrMissingRequiredSignature
+	}
+	return transfer(ctx, from, to, lamports)
+}
+
+// allocate sizes the account, address is the key which has to sign for it
+func allocate(ctx *invokeContext, account instructionAccount, address common.PublicKey, space uint64) error {
+	if !ctx.signed(address) {
+		ctx.log("Allocate: 'to' account %v must sign", address)
+		return ErrMissingRequiredSignature
+	}
+	if len(account.Data) != 0 || account.Owner != common.SystemProgramID {
+		ctx.log("Allocate: account %v already in use", account.pubkey)
+		return SystemErrorAccountAlreadyInUse
+	}
+	if space > MaxPermittedDataLength {
+		ctx.log("Allocate: requested %d, max allowed %d", space, MaxPermittedDataLength)
+		return SystemErrorInvalidAccountDataLength
+	}
+	account.Data = make([]byte, space)
+	return nil
+}
+
+// assign changes the owner, address is the key which has to sign for it
+func assign(ctx *invokeContext, account instructionAccount, address, owner common.PublicKey) error {
+	if account.Owner == owner {
+		return nil
+	}
+	if !ctx.signed(address) {
+		ctx.log("Assign: account %v must sign", address)
+		return ErrMissingRequiredSignature
+	}
+	account.Owner = owner
+	return nil
+}
+
+func transfer(ctx *invokeContext, from, to instructionAccount, lamports uint64) error {
+	if len(from.Data) != 0 {
+		ctx.log("Transfer: `from` must not carry data")
+		return ErrInvalidArgument
+	}
+	if lamports > from.Lamports {
+		ctx.log("Transfer: insufficient lamports %d, need %d", from.Lamports, lamports)
+		return SystemErrorResultWithNegativeLamports
+	}
+	return moveLamports(from, to, lamports)
+}
+
+func moveLamports(from, to instructionAccount, lamports uint64) error {
+	if lamports > from.Lamports {
+		return ErrInsufficientFunds
+	}
+	from.Lamports -= lamports
+	if to.Lamports+lamports < to.Lamports {
+		return ErrArithmeticOverflow
+	}
+	to.Lamports += lamports
+	return nil
+}
+
+// durableNonce is the nonce derived from the blockhash of the bank
+func (b *Bank) durableNonce() common.PublicKey {
+	blockhash, _ := base58.Decode(b.blockhash)
+	h := sha256.Sum256(append([]byte("DURABLE_NONCE"), blockhash...))
+	return common.PublicKeyFromBytes(h[:])
+}
+
+func nonceAccount(ctx *invokeContext, account instructionAccount) (system.NonceAccount, error) {
+	if !account.isWritable {
+		ctx.log("Nonce account %v must be writeable", account.pubkey)
+		return system.NonceAccount{}, ErrInvalidArgument
+	}
+	if account.Owner != common.SystemProgramID {
+		return system.NonceAccount{}, ErrInvalidAccountOwner
+	}
+	nonce, err := system.NonceAccountDeserialize(account.Data)
+	if err != nil {
+		return system.NonceAccount{}, ErrInvalidAccountData
+	}
+	return nonce, nil
+}
+
+func (c *invokeContext) nextNonce() system.NonceAccount {
+	return system.NonceAccount{
+		Version:       system.NonceVersionCurrent,
+		State:         system.NonceStateInitialized,
+		Nonce:         c.bank.durableNonce(),
+		FeeCalculator: system.FeeCalculator{LamportsPerSignature: c.bank.lamportsPerSignature},
+	}
+}
+
+func initializeNonceAccount(ctx *invokeContext, account instructionAccount, authority common.PublicKey) error {
+	nonce, err := nonceAccount(ctx, account)
+	if err != nil {
+		return err
+	}
+	if nonce.State != system.NonceStateUninitialized {
+		ctx.log("Initialize nonce account: Account %v state is invalid", account.pubkey)
+		return ErrInvalidAccountData
+	}
+	minimum := ctx.bank.rent.MinimumBalance(uint64(len(account.Data)))
+	if account.Lamports < minimum {
+		ctx.log("Initialize nonce account: insufficient lamports %d, need %d", account.Lamports, minimum)
+		return ErrInsufficientFunds
+	}
+	next := ctx.nextNonce()
+	next.AuthorizedPubkey = authority
+	account.Data = encodeNonceAccount(next)
+	return nil
+}
+
+func advanceNonceAccount(ctx *invokeContext, account instructionAccount) error {
+	nonce, err := nonceAccount(ctx, account)
+	if err != nil {
+		return err
+	}
+	if nonce.State != system.NonceStateInitialized {
+		ctx.log("Advance nonce account: Account %v state is invalid", account.pubkey)
+		return ErrInvalidAccountData
+	}
+	if !ctx.signed(nonce.AuthorizedPubkey) {
+		ctx.log("Advance nonce account: Account %v must be a signer", nonce.AuthorizedPubkey)
+		return ErrMissingRequiredSignature
+	}
+	next := ctx.nextNonce()
+	if nonce.Nonce == next.Nonce {
+		ctx.log("Advance nonce account: nonce can only advance once per slot")
+		return SystemErrorNonceBlockhashNotExpired
+	}
+	next.AuthorizedPubkey = nonce.AuthorizedPubkey
+	account.Data = encodeNonceAccount(next)
+	return nil
+}
+
+func withdrawNonceAccount(ctx *invokeContext, account, to instructionAccount, lamports uint64) error {
+	nonce, err := nonceAccount(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	signer := account.pubkey
+	switch nonce.State {
+	case system.NonceStateUninitialized:
+		if lamports > account.Lamports {
+			ctx.log("Withdraw nonce account: insufficient lamports %d, need %d", account.Lamports, lamports)
+			return ErrInsufficientFunds
+		}
+	case system.NonceStateInitialized:
+		signer = nonce.AuthorizedPubkey
+		if lamports == account.Lamports {
+			if nonce.Nonce == ctx.bank.durableNonce() {
+				ctx.log("Withdraw nonce account: nonce can only advance once per slot")
+				return SystemErrorNonceBlockhashNotExpired
+			}
+			account.Data = encodeNonceAccount(system.NonceAccount{Version: system.NonceVersionCurrent})
+		} else {
+			minimum := ctx.bank.rent.MinimumBalance(uint64(len(account.Data)))
+			if lamports+minimum > account.Lamports {
+				ctx.log("Withdraw nonce account: insufficient lamports %d, need %d", account.Lamports, lamports+minimum)
+				return ErrInsufficientFunds
+			}
+		}
+	}
+	if !ctx.signed(signer) {
+		ctx.log("Withdraw nonce account: Account %v must sign", signer)
+		return ErrMissingRequiredSignature
+	}
+	return moveLamports(account, to, lamports)
+}
+
+func authorizeNonceAccount(ctx *invokeContext, account instructionAccount, authority common.PublicKey) error {
+	nonce, err := nonceAccount(ctx, account)
+	if err != nil {
+		return err
+	}
+	if nonce.State != system.NonceStateInitialized {
+		ctx.log("Authorize nonce account: Account %v state is invalid", account.pubkey)
+		return ErrInvalidArgument
+	}
+	if !ctx.signed(nonce.AuthorizedPubkey) {
+		ctx.log("Authorize nonce account: Account %v must sign", nonce.AuthorizedPubkey)
+		return ErrMissingRequiredSignature
+	}
+	nonce.AuthorizedPubkey = authority
+	account.Data = encodeNonceAccount(nonce)
+	return nil
+}
+
+func upgradeNonceAccount(ctx *invokeContext, account instructionAccount) error {
+	nonce, err := nonceAccount(ctx, account)
+	if err != nil {
+		return err
+	}
+	if nonce.Version != system.NonceVersionLegacy || nonce.State != system.NonceStateInitialized {
+		return ErrInvalidArgument
+	}
+	// the legacy nonce is the blockhash itself, the current one is derived from it
+	h := sha256.Sum256(append([]byte("DURABLE_NONCE"), nonce.Nonce.Bytes()...))
+	nonce.Version = system.NonceVersionCurrent
+	nonce.Nonce = common.PublicKeyFromBytes(h[:])
+	account.Data = encodeNonceAccount(nonce)
+	return nil
+}
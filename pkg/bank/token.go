@@ -0,0 +1,656 @@
+package bank
+
+import (
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/token"
+)
+
+// NativeMint is the mint of wrapped SOL
+var NativeMint = common.PublicKeyFromString("So11111111111111111111111111111111111111112")
+
+var tokenInstructionNames = map[token.Instruction]string{
+	token.InstructionInitializeMint:      "InitializeMint",
+	token.InstructionInitializeAccount:   "InitializeAccount",
+	token.InstructionInitializeMultisig:  "InitializeMultisig",
+	token.InstructionTransfer:            "Transfer",
+	token.InstructionApprove:             "Approve",
+	token.InstructionRevoke:              "Revoke",
+	token.InstructionSetAuthority:        "SetAuthority",
+	token.InstructionMintTo:              "MintTo",
+	token.InstructionBurn:                "Burn",
+	token.InstructionCloseAccount:        "CloseAccount",
+	token.InstructionFreezeAccount:       "FreezeAccount",
+	token.InstructionThawAccount:         "ThawAccount",
+	token.InstructionTransferChecked:     "TransferChecked",
+	token.InstructionApproveChecked:      "ApproveChecked",
+	token.InstructionMintToChecked:       "MintToChecked",
+	token.InstructionBurnChecked:         "BurnChecked",
+	token.InstructionInitializeAccount2:  "InitializeAccount2",
+	token.InstructionSyncNative:          "SyncNative",
+	token.InstructionInitializeAccount3:  "InitializeAccount3",
+	token.InstructionInitializeMultisig2: "InitializeMultisig2",
+	token.InstructionInitializeMint2:     "InitializeMint2",
+}
+
+func processToken(ctx *invokeContext) error {
+	err := processTokenInstruction(ctx)
+	if tokenErr, ok := err.(TokenError); ok {
+		ctx.log("Error: %v", tokenErr)
+	}
+	return err
+}
+
+func processTokenInstruction(ctx *invokeContext) error {
+	r := &reader{data: ctx.data}
+	instruction := token.Instruction(r.u8())
+	name, ok := tokenInstructionNames[instruction]
+	if r.err != nil || !ok {
+		return TokenErrorInvalidInstruction
+	}
+	ctx.log("Instruction: %v", name)
+
+	switch instruction {
+	case token.InstructionInitializeMint, token.InstructionInitializeMint2:
+		decimals, mintAuthority, freezeAuthority := r.u8(), r.publicKey(), r.optionalPublicKey()
+		if r.err != nil {
+			return TokenErrorInvalidInstruction
+		}
+		return initializeMint(ctx, decimals, mintAuthority, freezeAuthority)
+	case token.InstructionInitializeAccount:
+		return ctx.withAccounts(3, func(accounts []instructionAccount) error {
+			return initializeTokenAccount(ctx, accounts[0], accounts[1], accounts[2].pubkey)
+		})
+	case token.InstructionInitializeAccount2, token.InstructionInitializeAccount3:
+		owner := r.publicKey()
+		if r.err != nil {
+			return TokenErrorInvalidInstruction
+		}
+		return ctx.withAccounts(2, func(accounts []instructionAccount) error {
+			return initializeTokenAccount(ctx, accounts[0], accounts[1], owner)
+		})
+	case token.InstructionInitializeMultisig, token.InstructionInitializeMultisig2:
+		m := r.u8()
+		if r.err != nil {
+			return TokenErrorInvalidInstruction
+		}
+		signersFrom := 2
+		if instruction == token.InstructionInitializeMultisig2 {
+			signersFrom = 1
+		}
+		return ctx.withAccounts(signersFrom, func(accounts []instructionAccount) error {
+			return initializeMultisig(ctx, accounts[0], ctx.accounts[signersFrom:], m)
+		})
+	case token.InstructionTransfer:
+		amount := r.u64()
+		if r.err != nil {
+			return TokenErrorInvalidInstruction
+		}
+		return ctx.withAccounts(3, func(accounts []instructionAccount) error {
+			return transferToken(ctx, accounts[0], nil, accounts[1], accounts[2], ctx.accounts[3:], amount, nil)
+		})
+	case token.InstructionTransferChecked:
+		amount, decimals := r.u64(), r.u8()
+		if r.err != nil {
+			return TokenErrorInvalidInstruction
+		}
+		return ctx.withAccounts(4, func(accounts []instructionAccount) error {
+			return transferToken(ctx, accounts[0], &accounts[1], accounts[2], accounts[3], ctx.accounts[4:], amount, &decimals)
+		})
+	case token.InstructionApprove:
+		amount := r.u64()
+		if r.err != nil {
+			return TokenErrorInvalidInstruction
+		}
+		return ctx.withAccounts(3, func(accounts []instructionAccount) error {
+			return approve(ctx, accounts[0], nil, accounts[1], accounts[2], ctx.accounts[3:], amount, nil)
+		})
+	case token.InstructionApproveChecked:
+		amount, decimals := r.u64(), r.u8()
+		if r.err != nil {
+			return TokenErrorInvalidInstruction
+		}
+		return ctx.withAccounts(4, func(accounts []instructionAccount) error {
+			return approve(ctx, accounts[0], &accounts[1], accounts[2], accounts[3], ctx.accounts[4:], amount, &decimals)
+		})
+	case token.InstructionRevoke:
+		return ctx.withAccounts(2, func(accounts []instructionAccount) error {
+			return revoke(ctx, accounts[0], accounts[1], ctx.accounts[2:])
+		})
+	case token.InstructionSetAuthority:
+		authorityType, newAuthority := token.AuthorityType(r.u8()), r.optionalPublicKey()
+		if r.err != nil {
+			return TokenErrorInvalidInstruction
+		}
+		return ctx.withAccounts(2, func(accounts []instructionAccount) error {
+			return setAuthority(ctx, accounts[0], accounts[1], ctx.accounts[2:], authorityType, newAuthority)
+		})
+	case token.InstructionMintTo:
+		amount := r.u64()
+		if r.err != nil {
+			return TokenErrorInvalidInstruction
+		}
+		return ctx.withAccounts(3, func(accounts []instructionAccount) error {
+			return mintTo(ctx, accounts[0], accounts[1], accounts[2], ctx.accounts[3:], amount, nil)
+		})
+	case token.InstructionMintToChecked:
+		amount, decimals := r.u64(), r.u8()
+		if r.err != nil {
+			return TokenErrorInvalidInstruction
+		}
+		return ctx.withAccounts(3, func(accounts []instructionAccount) error {
+			return mintTo(ctx, accounts[0], accounts[1], accounts[2], ctx.accounts[3:], amount, &decimals)
+		})
+	case token.InstructionBurn:
+		amount := r.u64()
+		if r.err != nil {
+			return TokenErrorInvalidInstruction
+		}
+		return ctx.withAccounts(3, func(accounts []instructionAccount) error {
+			return burn(ctx, accounts[0], accounts[1], accounts[2], ctx.accounts[3:], amount, nil)
+		})
+	case token.InstructionBurnChecked:
+		amount, decimals := r.u64(), r.u8()
+		if r.err != nil {
+			return TokenErrorInvalidInstruction
+		}
+		return ctx.withAccounts(3, func(accounts []instructionAccount) error {
+			return burn(ctx, accounts[0], accounts[1], accounts[2], ctx.accounts[3:], amount, &decimals)
+		})
+	case token.InstructionCloseAccount:
+		return ctx.withAccounts(3, func(accounts []instructionAccount) error {
+			return closeTokenAccount(ctx, accounts[0], accounts[1], accounts[2], ctx.accounts[3:])
+		})
+	case token.InstructionFreezeAccount, token.InstructionThawAccount:
+		return ctx.withAccounts(3, func(accounts []instructionAccount) error {
+			return freezeOrThaw(ctx, accounts[0], accounts[1], accounts[2], ctx.accounts[3:], instruction == token.InstructionFreezeAccount)
+		})
+	case token.InstructionSyncNative:
+		return ctx.withAccounts(1, func(accounts []instructionAccount) error {
+			return syncNative(ctx, accounts[0])
+		})
+	}
+	return TokenErrorInvalidInstruction
+}
+
+func loadMint(account instructionAccount) (token.MintAccount, error) {
+	if account.Owner != common.TokenProgramID {
+		return token.MintAccount{}, ErrIncorrectProgramID
+	}
+	mint, err := token.MintAccountFromData(account.Data)
+	if err != nil {
+		return token.MintAccount{}, ErrInvalidAccountData
+	}
+	if !mint.IsInitialized {
+		return token.MintAccount{}, ErrUninitializedAccount
+	}
+	return mint, nil
+}
+
+func loadTokenAccount(account instructionAccount) (token.TokenAccount, error) {
+	if account.Owner != common.TokenProgramID {
+		return token.TokenAccount{}, ErrIncorrectProgramID
+	}
+	tokenAccount, err := token.TokenAccountFromData(account.Data)
+	if err != nil {
+		return token.TokenAccount{}, ErrInvalidAccountData
+	}
+	if tokenAccount.State == token.TokenAccountStateUninitialized {
+		return token.TokenAccount{}, ErrUninitializedAccount
+	}
+	return tokenAccount, nil
+}
+
+func (c *invokeContext) rentExempt(account instructionAccount) bool {
+	return account.Lamports >= c.bank.rent.MinimumBalance(uint64(len(account.Data)))
+}
+
+// validateOwner checks the authority is the expected one and it signs, the authority can be a multisig
+func validateOwner(expected common.PublicKey, authority instructionAccount, signers []instructionAccount) error {
+	if expected != authority.pubkey {
+		return TokenErrorOwnerMismatch
+	}
+	if authority.Owner == common.TokenProgramID && len(authority.Data) == token.MultisigAccountSize {
+		multisig, err := token.MultisigAccountFromData(authority.Data)
+		if err != nil || !multisig.IsInitialized {
+			return ErrUninitializedAccount
+		}
+		matched := make([]bool, len(multisig.Signers))
+		numSigners := 0
+		for _, signer := range signers {
+			for i, key := range multisig.Signers {
+				if key == signer.pubkey && !matched[i] {
+					if !signer.isSigner {
+						return ErrMissingRequiredSignature
+					}
+					matched[i] = true
+					numSigners++
+				}
+			}
+		}
+		if numSigners < int(multisig.M) {
+			return ErrMissingRequiredSignature
+		}
+		return nil
+	}
+	if !authority.isSigner {
+		return ErrMissingRequiredSignature
+	}
+	return nil
+}
+
+func initializeMint(ctx *invokeContext, decimals uint8, mintAuthority common.PublicKey, freezeAuthority *common.PublicKey) error {
+	mintAccount, err := ctx.account(0)
+	if err != nil {
+		return err
+	}
+	if len(mintAccount.Data) != token.MintAccountSize {
+		return ErrInvalidAccountData
+	}
+	mint, _ := token.MintAccountFromData(mintAccount.Data)
+	if mint.IsInitialized {
+		return TokenErrorAlreadyInUse
+	}
+	if !ctx.rentExempt(mintAccount) {
+		return TokenErrorNotRentExempt
+	}
+	mintAccount.Data = encodeMint(token.MintAccount{
+		MintAuthority:   &mintAuthority,
+		Decimals:        decimals,
+		IsInitialized:   true,
+		FreezeAuthority: freezeAuthority,
+	})
+	return nil
+}
+
+func initializeTokenAccount(ctx *invokeContext, account, mintAccount instructionAccount, owner common.PublicKey) error {
+	if len(account.Data) != token.TokenAccountSize {
+		return ErrInvalidAccountData
+	}
+	tokenAccount, err := token.TokenAccountFromData(account.Data)
+	if err != nil {
+		return ErrInvalidAccountData
+	}
+	if tokenAccount.State != token.TokenAccountStateUninitialized {
+		return TokenErrorAlreadyInUse
+	}
+	if !ctx.rentExempt(account) {
+		return TokenErrorNotRentExempt
+	}
+	if mintAccount.pubkey != NativeMint {
+		if _, err := loadMint(mintAccount); err != nil {
+			return TokenErrorInvalidMint
+		}
+	}
+
+	tokenAccount = token.TokenAccount{
+		Mint:  mintAccount.pubkey,
+		Owner: owner,
+		State: token.TokenAccountStateInitialized,
+	}
+	if mintAccount.pubkey == NativeMint {
+		rentExempt := ctx.bank.rent.MinimumBalance(uint64(len(account.Data)))
+		tokenAccount.IsNative = &rentExempt
+		tokenAccount.Amount = account.Lamports - rentExempt
+	}
+	account.Data = encodeTokenAccount(tokenAccount)
+	return nil
+}
+
+func initializeMultisig(ctx *invokeContext, account instructionAccount, signers []instructionAccount, m uint8) error {
+	if len(account.Data) != token.MultisigAccountSize {
+		return ErrInvalidAccountData
+	}
+	multisig, err := token.MultisigAccountFromData(account.Data)
+	if err != nil {
+		return ErrInvalidAccountData
+	}
+	if multisig.IsInitialized {
+		return TokenErrorAlreadyInUse
+	}
+	if !ctx.rentExempt(account) {
+		return TokenErrorNotRentExempt
+	}
+	if len(signers) < 1 || len(signers) > token.MaxSigners {
+		return TokenErrorInvalidNumberOfProvidedSigners
+	}
+	if m < 1 || int(m) > len(signers) {
+		return TokenErrorInvalidNumberOfRequiredSigners
+	}
+	multisig = token.MultisigAccount{M: m, N: uint8(len(signers)), IsInitialized: true}
+	for _, signer := range signers {
+		multisig.Signers = append(multisig.Signers, signer.pubkey)
+	}
+	account.Data = encodeMultisig(multisig)
+	return nil
+}
+
+// checkMint checks the mint and the decimals of a checked instruction
+func checkMint(mintAccount *instructionAccount, expected common.PublicKey, decimals *uint8) error {
+	if mintAccount == nil {
+		return nil
+	}
+	if mintAccount.pubkey != expected {
+		return TokenErrorMintMismatch
+	}
+	mint, err := loadMint(*mintAccount)
+	if err != nil {
+		return err
+	}
+	if decimals != nil && mint.Decimals != *decimals {
+		return TokenErrorMintDecimalsMismatch
+	}
+	return nil
+}
+
+// spendAsDelegate validates the authority of the source, a delegate spends from the delegated amount
+func spendAsDelegate(source *token.TokenAccount, authority instructionAccount, signers []instructionAccount, amount uint64) error {
+	if source.Delegate != nil && *source.Delegate == authority.pubkey {
+		if err := validateOwner(*source.Delegate, authority, signers); err != nil {
+			return err
+		}
+		if source.DelegatedAmount < amount {
+			return TokenErrorInsufficientFunds
+		}
+		source.DelegatedAmount -= amount
+		if source.DelegatedAmount == 0 {
+			source.Delegate = nil
+		}
+		return nil
+	}
+	return validateOwner(source.Owner, authority, signers)
+}
+
+func transferToken(ctx *invokeContext, sourceAccount instructionAccount, mintAccount *instructionAccount, destinationAccount, authority instructionAccount, signers []instructionAccount, amount uint64, decimals *uint8) error {
+	source, err := loadTokenAccount(sourceAccount)
+	if err != nil {
+		return err
+	}
+	destination, err := loadTokenAccount(destinationAccount)
+	if err != nil {
+		return err
+	}
+	if source.State == token.TokenAccountFrozen || destination.State == token.TokenAccountFrozen {
+		return TokenErrorAccountFrozen
+	}
+	if source.Amount < amount {
+		return TokenErrorInsufficientFunds
+	}
+	if source.Mint != destination.Mint {
+		return TokenErrorMintMismatch
+	}
+	if err := checkMint(mintAccount, source.Mint, decimals); err != nil {
+		return err
+	}
+	if err := spendAsDelegate(&source, authority, signers, amount); err != nil {
+		return err
+	}
+
+	// a self transfer only validates the authority
+	if sourceAccount.txAccount == destinationAccount.txAccount {
+		return nil
+	}
+	source.Amount -= amount
+	destination.Amount += amount
+	if source.IsNative != nil {
+		if err := moveLamports(sourceAccount, destinationAccount, amount); err != nil {
+			return err
+		}
+	}
+	sourceAccount.Data = encodeTokenAccount(source)
+	destinationAccount.Data = encodeTokenAccount(destination)
+	return nil
+}
+
+func approve(ctx *invokeContext, sourceAccount instructionAccount, mintAccount *instructionAccount, delegate, owner instructionAccount, signers []instructionAccount, amount uint64, decimals *uint8) error {
+	source, err := loadTokenAccount(sourceAccount)
+	if err != nil {
+		return err
+	}
+	if source.State == token.TokenAccountFrozen {
+		return TokenErrorAccountFrozen
+	}
+	if err := checkMint(mintAccount, source.Mint, decimals); err != nil {
+		return err
+	}
+	if err := validateOwner(source.Owner, owner, signers); err != nil {
+		return err
+	}
+	source.Delegate = &delegate.pubkey
+	source.DelegatedAmount = amount
+	sourceAccount.Data = encodeTokenAccount(source)
+	return nil
+}
+
+func revoke(ctx *invokeContext, sourceAccount, owner instructionAccount, signers []instructionAccount) error {
+	source, err := loadTokenAccount(sourceAccount)
+	if err != nil {
+		return err
+	}
+	if source.State == token.TokenAccountFrozen {
+		return TokenErrorAccountFrozen
+	}
+	if err := validateOwner(source.Owner, owner, signers); err != nil {
+		return err
+	}
+	source.Delegate = nil
+	source.DelegatedAmount = 0
+	sourceAccount.Data = encodeTokenAccount(source)
+	return nil
+}
+
+func setAuthority(ctx *invokeContext, account, authority instructionAccount, signers []instructionAccount, authorityType token.AuthorityType, newAuthority *common.PublicKey) error {
+	switch len(account.Data) {
+	case token.TokenAccountSize:
+		tokenAccount, err := loadTokenAccount(account)
+		if err != nil {
+			return err
+		}
+		if tokenAccount.State == token.TokenAccountFrozen {
+			return TokenErrorAccountFrozen
+		}
+		switch authorityType {
+		case token.AuthorityTypeAccountOwner:
+			if err := validateOwner(tokenAccount.Owner, authority, signers); err != nil {
+				return err
+			}
+			if newAuthority == nil {
+				return TokenErrorInvalidInstruction
+			}
+			tokenAccount.Owner = *newAuthority
+			tokenAccount.Delegate = nil
+			tokenAccount.DelegatedAmount = 0
+			if tokenAccount.IsNative != nil {
+				tokenAccount.CloseAuthority = nil
+			}
+		case token.AuthorityTypeCloseAccount:
+			expected := tokenAccount.Owner
+			if tokenAccount.CloseAuthority != nil {
+				expected = *tokenAccount.CloseAuthority
+			}
+			if err := validateOwner(expected, authority, signers); err != nil {
+				return err
+			}
+			tokenAccount.CloseAuthority = newAuthority
+		default:
+			return TokenErrorAuthorityTypeNotSupported
+		}
+		account.Data = encodeTokenAccount(tokenAccount)
+		return nil
+	case token.MintAccountSize:
+		mint, err := loadMint(account)
+		if err != nil {
+			return err
+		}
+		switch authorityType {
+		case token.AuthorityTypeMintTokens:
+			if mint.MintAuthority == nil {
+				return TokenErrorFixedSupply
+			}
+			if err := validateOwner(*mint.MintAuthority, authority, signers); err != nil {
+				return err
+			}
+			mint.MintAuthority = newAuthority
+		case token.AuthorityTypeFreezeAccount:
+			if mint.FreezeAuthority == nil {
+				return TokenErrorMintCannotFreeze
+			}
+			if err := validateOwner(*mint.FreezeAuthority, authority, signers); err != nil {
+				return err
+			}
+			mint.FreezeAuthority = newAuthority
+		default:
+			return TokenErrorAuthorityTypeNotSupported
+		}
+		account.Data = encodeMint(mint)
+		return nil
+	}
+	return ErrInvalidArgument
+}
+
+func mintTo(ctx *invokeContext, mintAccount, destinationAccount, authority instructionAccount, signers []instructionAccount, amount uint64, decimals *uint8) error {
+	destination, err := loadTokenAccount(destinationAccount)
+	if err != nil {
+		return err
+	}
+	if destination.State == token.TokenAccountFrozen {
+		return TokenErrorAccountFrozen
+	}
+	if destination.IsNative != nil {
+		return TokenErrorNativeNotSupported
+	}
+	if mintAccount.pubkey != destination.Mint {
+		return TokenErrorMintMismatch
+	}
+	mint, err := loadMint(mintAccount)
+	if err != nil {
+		return err
+	}
+	if decimals != nil && *decimals != mint.Decimals {
+		return TokenErrorMintDecimalsMismatch
+	}
+	if mint.MintAuthority == nil {
+		return TokenErrorFixedSupply
+	}
+	if err := validateOwner(*mint.MintAuthority, authority, signers); err != nil {
+		return err
+	}
+	if mint.Supply+amount < mint.Supply {
+		return TokenErrorOverflow
+	}
+	destination.Amount += amount
+	mint.Supply += amount
+	mintAccount.Data = encodeMint(mint)
+	destinationAccount.Data = encodeTokenAccount(destination)
+	return nil
+}
+
+func burn(ctx *invokeContext, sourceAccount, mintAccount, authority instructionAccount, signers []instructionAccount, amount uint64, decimals *uint8) error {
+	source, err := loadTokenAccount(sourceAccount)
+	if err != nil {
+		return err
+	}
+	if source.State == token.TokenAccountFrozen {
+		return TokenErrorAccountFrozen
+	}
+	if source.IsNative != nil {
+		return TokenErrorNativeNotSupported
+	}
+	if source.Amount < amount {
+		return TokenErrorInsufficientFunds
+	}
+	if mintAccount.pubkey != source.Mint {
+		return TokenErrorMintMismatch
+	}
+	mint, err := loadMint(mintAccount)
+	if err != nil {
+		return err
+	}
+	if decimals != nil && *decimals != mint.Decimals {
+		return TokenErrorMintDecimalsMismatch
+	}
+	if err := spendAsDelegate(&source, authority, signers, amount); err != nil {
+		return err
+	}
+	source.Amount -= amount
+	mint.Supply -= amount
+	sourceAccount.Data = encodeTokenAccount(source)
+	mintAccount.Data = encodeMint(mint)
+	return nil
+}
+
+func closeTokenAccount(ctx *invokeContext, sourceAccount, destinationAccount, authority instructionAccount, signers []instructionAccount) error {
+	if sourceAccount.txAccount == destinationAccount.txAccount {
+		return ErrInvalidAccountData
+	}
+	source, err := loadTokenAccount(sourceAccount)
+	if err != nil {
+		return err
+	}
+	if source.IsNative == nil && source.Amount != 0 {
+		return TokenErrorNonNativeHasBalance
+	}
+	expected := source.Owner
+	if source.CloseAuthority != nil {
+		expected = *source.CloseAuthority
+	}
+	if err := validateOwner(expected, authority, signers); err != nil {
+		return err
+	}
+	if err := moveLamports(sourceAccount, destinationAccount, sourceAccount.Lamports); err != nil {
+		return err
+	}
+	sourceAccount.Data = make([]byte, len(sourceAccount.Data))
+	return nil
+}
+
+func freezeOrThaw(ctx *invokeContext, account, mintAccount, authority instructionAccount, signers []instructionAccount, freeze bool) error {
+	tokenAccount, err := loadTokenAccount(account)
+	if err != nil {
+		return err
+	}
+	if tokenAccount.IsNative != nil {
+		return TokenErrorNativeNotSupported
+	}
+	if mintAccount.pubkey != tokenAccount.Mint {
+		return TokenErrorMintMismatch
+	}
+	if freeze == (tokenAccount.State == token.TokenAccountFrozen) {
+		return TokenErrorInvalidState
+	}
+	mint, err := loadMint(mintAccount)
+	if err != nil {
+		return err
+	}
+	if mint.FreezeAuthority == nil {
+		return TokenErrorMintCannotFreeze
+	}
+	if err := validateOwner(*mint.FreezeAuthority, authority, signers); err != nil {
+		return err
+	}
+	tokenAccount.State = token.TokenAccountStateInitialized
+	if freeze {
+		tokenAccount.State = token.TokenAccountFrozen
+	}
+	account.Data = encodeTokenAccount(tokenAccount)
+	return nil
+}
+
+func syncNative(ctx *invokeContext, account instructionAccount) error {
+	tokenAccount, err := loadTokenAccount(account)
+	if err != nil {
+		return err
+	}
+	if tokenAccount.IsNative == nil {
+		return TokenErrorNonNativeNotSupported
+	}
+	if account.Lamports < *tokenAccount.IsNative {
+		return TokenErrorInvalidState
+	}
+	amount := account.Lamports - *tokenAccount.IsNative
+	if amount < tokenAccount.Amount {
+		return TokenErrorInvalidState
+	}
+	tokenAccount.Amount = amount
+	account.Data = encodeTokenAccount(tokenAccount)
+	return nil
+}
@@ -0,0 +1,171 @@
+package bank_test
+
+import (
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/bank"
+	"github.com/EntySquare/solana-go-sdk/program/associated_token_account"
+	"github.com/EntySquare/solana-go-sdk/program/system"
+	"github.com/EntySquare/solana-go-sdk/program/token"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func createMint(t *testing.T, b *bank.Bank, mint types.Account, decimals uint8) {
+	tx := newTransaction(t, b, []types.Instruction{
+		system.CreateAccount(system.CreateAccountParam{
+			From:     alice.PublicKey,
+			New:      mint.PublicKey,
+			Owner:    common.TokenProgramID,
+			Lamports: b.MinimumBalanceForRentExemption(token.MintAccountSize),
+			Space:    token.MintAccountSize,
+		}),
+		token.InitializeMint2(token.InitializeMint2Param{
+			Decimals:   decimals,
+			Mint:       mint.PublicKey,
+			MintAuth:   alice.PublicKey,
+			FreezeAuth: &alice.PublicKey,
+		}),
+	}, alice, mint)
+	_, err := b.Execute(tx)
+	assert.NoError(t, err)
+}
+
+func createAssociatedTokenAccount(owner, mint common.PublicKey) (common.PublicKey, types.Instruction) {
+	ata, _, _ := common.FindAssociatedTokenAddress(owner, mint)
+	return ata, associated_token_account.CreateIdempotent(associated_token_account.CreateIdempotentParam{
+		Funder:                 alice.PublicKey,
+		Owner:                  owner,
+		Mint:                   mint,
+		AssociatedTokenAccount: ata,
+	})
+}
+
+func tokenAccount(t *testing.T, b *bank.Bank, pubkey common.PublicKey) token.TokenAccount {
+	account, ok := b.GetAccount(pubkey)
+	assert.True(t, ok)
+	tokenAccount, err := token.DeserializeTokenAccount(account.Data, account.Owner)
+	assert.NoError(t, err)
+	return tokenAccount
+}
+
+func TestBank_Token(t *testing.T) {
+	b := newBank(1_000_000_000)
+	mint := types.NewAccount()
+	createMint(t, b, mint, 2)
+
+	aliceATA, createAlice := createAssociatedTokenAccount(alice.PublicKey, mint.PublicKey)
+	bobATA, createBob := createAssociatedTokenAccount(bob.PublicKey, mint.PublicKey)
+	result, err := b.Execute(newTransaction(t, b, []types.Instruction{
+		createAlice,
+		createBob,
+		token.MintTo(token.MintToParam{Mint: mint.PublicKey, To: aliceATA, Auth: alice.PublicKey, Amount: 1000}),
+		token.TransferChecked(token.TransferCheckedParam{
+			From:     aliceATA,
+			To:       bobATA,
+			Mint:     mint.PublicKey,
+			Auth:     alice.PublicKey,
+			Amount:   400,
+			Decimals: 2,
+		}),
+	}, alice))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"Program ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL invoke [1]",
+		"Program log: CreateIdempotent",
+		"Program 11111111111111111111111111111111 invoke [2]",
+		"Program 11111111111111111111111111111111 success",
+		"Program log: Initialize the associated token account",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [2]",
+		"Program log: Instruction: InitializeAccount3",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA consumed 3000 of 787850 compute units",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA success",
+		"Program ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL consumed 15150 of 800000 compute units",
+		"Program ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL success",
+	}, result.Logs[:11])
+	assert.Equal(t, uint64(600), tokenAccount(t, b, aliceATA).Amount)
+	assert.Equal(t, token.TokenAccount{
+		Mint:   mint.PublicKey,
+		Owner:  bob.PublicKey,
+		Amount: 400,
+		State:  token.TokenAccountStateInitialized,
+	}, tokenAccount(t, b, bobATA))
+	assert.Equal(t, b.MinimumBalanceForRentExemption(token.TokenAccountSize), balance(b, bobATA))
+
+	// creating it again is a no op
+	_, err = b.Execute(newTransaction(t, b, []types.Instruction{
+		createBob,
+		token.Transfer(token.TransferParam{From: aliceATA, To: bobATA, Auth: alice.PublicKey, Amount: 601}),
+	}, alice))
+	assert.ErrorIs(t, err, bank.TokenErrorInsufficientFunds)
+	var instructionErr *bank.InstructionError
+	if assert.ErrorAs(t, err, &instructionErr) {
+		assert.Equal(t, 1, instructionErr.Index)
+	}
+
+	_, err = b.Execute(newTransaction(t, b, []types.Instruction{
+		token.FreezeAccount(token.FreezeAccountParam{Account: bobATA, Mint: mint.PublicKey, Auth: alice.PublicKey}),
+		token.Transfer(token.TransferParam{From: aliceATA, To: bobATA, Auth: alice.PublicKey, Amount: 1}),
+	}, alice))
+	assert.ErrorIs(t, err, bank.TokenErrorAccountFrozen)
+
+	// the owner has to sign
+	_, err = b.Execute(newTransaction(t, b, []types.Instruction{
+		token.Transfer(token.TransferParam{From: bobATA, To: aliceATA, Auth: alice.PublicKey, Amount: 1}),
+	}, alice))
+	assert.ErrorIs(t, err, bank.TokenErrorOwnerMismatch)
+
+	b.SetAccount(bob.PublicKey, bank.Account{Lamports: 1_000_000_000, Owner: common.SystemProgramID})
+	ataBalance := balance(b, bobATA)
+	_, err = b.Execute(newTransaction(t, b, []types.Instruction{
+		token.Burn(token.BurnParam{Account: bobATA, Mint: mint.PublicKey, Auth: bob.PublicKey, Amount: 400}),
+		token.CloseAccount(token.CloseAccountParam{Account: bobATA, Auth: bob.PublicKey, To: bob.PublicKey}),
+	}, bob))
+	assert.NoError(t, err)
+	_, ok := b.GetAccount(bobATA)
+	assert.False(t, ok)
+	assert.Equal(t, 1_000_000_000-bank.DefaultLamportsPerSignature+ataBalance, balance(b, bob.PublicKey))
+
+	account, _ := b.GetAccount(mint.PublicKey)
+	mintAccount, err := token.MintAccountFromData(account.Data)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(600), mintAccount.Supply)
+}
+
+func TestBank_AssociatedTokenAccountErrors(t *testing.T) {
+	b := newBank(1_000_000_000)
+	mint := types.NewAccount()
+	createMint(t, b, mint, 0)
+
+	// the address has to be derived from the wallet and the mint
+	ata, _ := createAssociatedTokenAccount(bob.PublicKey, mint.PublicKey)
+	_, err := b.Execute(newTransaction(t, b, []types.Instruction{
+		associated_token_account.Create(associated_token_account.CreateParam{
+			Funder:                 alice.PublicKey,
+			Owner:                  alice.PublicKey,
+			Mint:                   mint.PublicKey,
+			AssociatedTokenAccount: ata,
+		}),
+	}, alice))
+	assert.ErrorIs(t, err, bank.ErrInvalidSeeds)
+
+	// a prefunded address is topped up
+	b.SetAccount(ata, bank.Account{Lamports: 1000, Owner: common.SystemProgramID})
+	_, create := createAssociatedTokenAccount(bob.PublicKey, mint.PublicKey)
+	_, err = b.Execute(newTransaction(t, b, []types.Instruction{create}, alice))
+	assert.NoError(t, err)
+	assert.Equal(t, b.MinimumBalanceForRentExemption(token.TokenAccountSize), balance(b, ata))
+	assert.Equal(t, bob.PublicKey, tokenAccount(t, b, ata).Owner)
+
+	// the non idempotent create fails on an existing account
+	_, err = b.Execute(newTransaction(t, b, []types.Instruction{
+		associated_token_account.Create(associated_token_account.CreateParam{
+			Funder:                 alice.PublicKey,
+			Owner:                  bob.PublicKey,
+			Mint:                   mint.PublicKey,
+			AssociatedTokenAccount: ata,
+		}),
+	}, alice))
+	assert.ErrorIs(t, err, bank.ErrIllegalOwner)
+}
@@ -53,6 +53,11 @@ func serializeData(v reflect.Value) ([]byte, error) {
 		return b, nil
 	case reflect.Slice:
 		switch v.Type().Elem().Kind() {
+		case reflect.Uint8:
+			b := make([]byte, 8+v.Len())
+			binary.LittleEndian.PutUint64(b, uint64(v.Len()))
+			copy(b[8:], v.Bytes())
+			return b, nil
 		case reflect.Array:
 			l := v.Len()
 			output := make([]byte, 0, 8+l*v.Type().Elem().Len())
@@ -40,3 +40,37 @@ func GetBytes32(curr *int, data []byte) ([32]byte, error) {
 
 	return v, nil
 }
+
+func GetUint8(curr *int, data []byte) (uint8, error) {
+	if curr == nil {
+		return 0, fmt.Errorf("index is nil")
+	}
+	if data == nil {
+		return 0, fmt.Errorf("data is nil")
+	}
+	if len(data[*curr:]) < 1 {
+		return 0, fmt.Errorf("insufficient data length")
+	}
+
+	v := data[*curr]
+	*curr += 1
+
+	return v, nil
+}
+
+func GetUint32(curr *int, data []byte) (uint32, error) {
+	if curr == nil {
+		return 0, fmt.Errorf("index is nil")
+	}
+	if data == nil {
+		return 0, fmt.Errorf("data is nil")
+	}
+	if len(data[*curr:]) < 4 {
+		return 0, fmt.Errorf("insufficient data length")
+	}
+
+	v := binary.LittleEndian.Uint32(data[*curr : *curr+4])
+	*curr += 4
+
+	return v, nil
+}
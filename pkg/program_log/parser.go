@@ -0,0 +1,204 @@
+package program_log
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+)
+
+type InvocationStatus uint8
+
+const (
+	// InvocationStatusUnknown means the logs end before the invocation does, e.g. the logs are truncated
+	InvocationStatusUnknown InvocationStatus = iota
+	InvocationStatusSuccess
+	InvocationStatusFailed
+)
+
+// Invocation is a program invocation, the top level ones are the instructions of the transaction
+// and the inner ones are the cross program invocations
+type Invocation struct {
+	ProgramID common.PublicKey
+	// Depth starts from 1 for the instructions of the transaction
+	Depth int
+	// Logs are the "Program log:" messages, other messages the runtime prints for the program are kept as they are
+	Logs []string
+	// Data are the "Program data:" payloads, one log can carry several of them
+	Data [][]byte
+	// ReturnData is the "Program return:" data
+	ReturnData []byte
+	// ComputeUnitsConsumed and ComputeUnitsBudget are from "consumed x of y compute units",
+	// they are 0 if the line is missing
+	ComputeUnitsConsumed uint64
+	ComputeUnitsBudget   uint64
+	Status               InvocationStatus
+	// Err is the message of "Program xxx failed: <message>"
+	Err         string
+	Invocations []*Invocation
+}
+
+type Logs struct {
+	Invocations []*Invocation
+	// Truncated is true if the runtime dropped the rest of the logs
+	Truncated bool
+	// Others are the messages printed outside of any invocation
+	Others []string
+}
+
+const (
+	prefixProgram       = "Program "
+	prefixProgramLog    = "Program log: "
+	prefixProgramData   = "Program data: "
+	prefixProgramReturn = "Program return: "
+	logTruncated        = "Log truncated"
+)
+
+// Parse parses the log messages of a transaction into a tree of invocations
+func Parse(logs []string) Logs {
+	var result Logs
+	var stack []*Invocation
+
+	current := func() *Invocation {
+		if len(stack) == 0 {
+			return nil
+		}
+		return stack[len(stack)-1]
+	}
+	other := func(log string) {
+		if invocation := current(); invocation != nil {
+			invocation.Logs = append(invocation.Logs, log)
+			return
+		}
+		result.Others = append(result.Others, log)
+	}
+
+	for _, log := range logs {
+		switch {
+		case log == logTruncated:
+			result.Truncated = true
+		case strings.HasPrefix(log, prefixProgramLog):
+			other(strings.TrimPrefix(log, prefixProgramLog))
+		case strings.HasPrefix(log, prefixProgramData):
+			invocation := current()
+			if invocation == nil {
+				result.Others = append(result.Others, log)
+				continue
+			}
+			for _, field := range strings.Fields(strings.TrimPrefix(log, prefixProgramData)) {
+				data, err := base64.StdEncoding.DecodeString(field)
+				if err != nil {
+					invocation.Logs = append(invocation.Logs, log)
+					break
+				}
+				invocation.Data = append(invocation.Data, data)
+			}
+		case strings.HasPrefix(log, prefixProgramReturn):
+			invocation := current()
+			fields := strings.Fields(strings.TrimPrefix(log, prefixProgramReturn))
+			if invocation == nil || len(fields) != 2 {
+				other(log)
+				continue
+			}
+			data, err := base64.StdEncoding.DecodeString(fields[1])
+			if err != nil {
+				other(log)
+				continue
+			}
+			invocation.ReturnData = data
+		case strings.HasPrefix(log, prefixProgram):
+			if !parseProgramLog(log, &result, &stack) {
+				other(log)
+			}
+		default:
+			other(log)
+		}
+	}
+	return result
+}
+
+// parseProgramLog parses "Program <id> invoke [n]", "Program <id> consumed x of y compute units",
+// "Program <id> success" and "Program <id> failed: <message>", it returns false for other logs
+func parseProgramLog(log string, result *Logs, stack *[]*Invocation) bool {
+	fields := strings.Fields(log)
+	if len(fields) < 3 {
+		return false
+	}
+	programID := common.PublicKeyFromString(fields[1])
+	if programID.ToBase58() != fields[1] {
+		return false
+	}
+
+	switch {
+	case fields[2] == "invoke" && len(fields) == 4:
+		depth, err := strconv.Atoi(strings.Trim(fields[3], "[]"))
+		if err != nil || depth < 1 {
+			return false
+		}
+		// a missing success or failed line leaves invocations open, they end where a shallower one starts
+		for len(*stack) >= depth {
+			*stack = (*stack)[:len(*stack)-1]
+		}
+		invocation := &Invocation{ProgramID: programID, Depth: depth}
+		if len(*stack) == 0 {
+			result.Invocations = append(result.Invocations, invocation)
+		} else {
+			parent := (*stack)[len(*stack)-1]
+			parent.Invocations = append(parent.Invocations, invocation)
+		}
+		*stack = append(*stack, invocation)
+		return true
+	case fields[2] == "consumed" && len(fields) == 8:
+		invocation := find(*stack, programID)
+		if invocation == nil {
+			return false
+		}
+		consumed, err1 := strconv.ParseUint(fields[3], 10, 64)
+		budget, err2 := strconv.ParseUint(fields[5], 10, 64)
+		if err1 != nil || err2 != nil {
+			return false
+		}
+		invocation.ComputeUnitsConsumed = consumed
+		invocation.ComputeUnitsBudget = budget
+		return true
+	case fields[2] == "success" && len(fields) == 3:
+		invocation := pop(stack, programID)
+		if invocation == nil {
+			return false
+		}
+		invocation.Status = InvocationStatusSuccess
+		return true
+	case fields[2] == "failed:":
+		invocation := pop(stack, programID)
+		if invocation == nil {
+			return false
+		}
+		invocation.Status = InvocationStatusFailed
+		invocation.Err = strings.TrimSpace(strings.SplitN(log, "failed:", 2)[1])
+		return true
+	}
+	return false
+}
+
+// find returns the innermost open invocation of the program
+func find(stack []*Invocation, programID common.PublicKey) *Invocation {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i].ProgramID == programID {
+			return stack[i]
+		}
+	}
+	return nil
+}
+
+// pop closes the innermost open invocation of the program and the ones inside it
+func pop(stack *[]*Invocation, programID common.PublicKey) *Invocation {
+	for i := len(*stack) - 1; i >= 0; i-- {
+		if (*stack)[i].ProgramID == programID {
+			invocation := (*stack)[i]
+			*stack = (*stack)[:i]
+			return invocation
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package program_log
+
+import (
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	type args struct {
+		logs []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want Logs
+	}{
+		{
+			name: "nested invocations",
+			args: args{
+				logs: []string{
+					"Program ComputeBudget111111111111111111111111111111 invoke [1]",
+					"Program ComputeBudget111111111111111111111111111111 success",
+					"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 invoke [1]",
+					"Program log: Instruction: Route",
+					"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [2]",
+					"Program log: Instruction: Transfer",
+					"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA consumed 4645 of 180000 compute units",
+					"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA success",
+					"Program 11111111111111111111111111111111 invoke [2]",
+					"Program 11111111111111111111111111111111 success",
+					"Program data: AQID BAU=",
+					"Program return: JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 CQ==",
+					"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 consumed 30000 of 199850 compute units",
+					"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 success",
+				},
+			},
+			want: Logs{
+				Invocations: []*Invocation{
+					{
+						ProgramID: common.ComputeBudgetProgramID,
+						Depth:     1,
+						Status:    InvocationStatusSuccess,
+					},
+					{
+						ProgramID:            common.PublicKeyFromString("JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4"),
+						Depth:                1,
+						Logs:                 []string{"Instruction: Route"},
+						Data:                 [][]byte{{1, 2, 3}, {4, 5}},
+						ReturnData:           []byte{9},
+						ComputeUnitsConsumed: 30000,
+						ComputeUnitsBudget:   199850,
+						Status:               InvocationStatusSuccess,
+						Invocations: []*Invocation{
+							{
+								ProgramID:            common.TokenProgramID,
+								Depth:                2,
+								Logs:                 []string{"Instruction: Transfer"},
+								ComputeUnitsConsumed: 4645,
+								ComputeUnitsBudget:   180000,
+								Status:               InvocationStatusSuccess,
+							},
+							{
+								ProgramID: common.SystemProgramID,
+								Depth:     2,
+								Status:    InvocationStatusSuccess,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "failed",
+			args: args{
+				logs: []string{
+					"Program 11111111111111111111111111111111 invoke [1]",
+					"Transfer: `from` must not carry data",
+					"Program 11111111111111111111111111111111 failed: invalid program argument",
+				},
+			},
+			want: Logs{
+				Invocations: []*Invocation{
+					{
+						ProgramID: common.SystemProgramID,
+						Depth:     1,
+						Logs:      []string{"Transfer: `from` must not carry data"},
+						Status:    InvocationStatusFailed,
+						Err:       "invalid program argument",
+					},
+				},
+			},
+		},
+		{
+			name: "failure of an inner invocation fails the outer one",
+			args: args{
+				logs: []string{
+					"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 invoke [1]",
+					"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [2]",
+					"Program log: Error: insufficient funds",
+					"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA consumed 4381 of 180000 compute units",
+					"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA failed: custom program error: 0x1",
+					"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 consumed 20000 of 200000 compute units",
+					"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 failed: custom program error: 0x1",
+				},
+			},
+			want: Logs{
+				Invocations: []*Invocation{
+					{
+						ProgramID:            common.PublicKeyFromString("JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4"),
+						Depth:                1,
+						ComputeUnitsConsumed: 20000,
+						ComputeUnitsBudget:   200000,
+						Status:               InvocationStatusFailed,
+						Err:                  "custom program error: 0x1",
+						Invocations: []*Invocation{
+							{
+								ProgramID:            common.TokenProgramID,
+								Depth:                2,
+								Logs:                 []string{"Error: insufficient funds"},
+								ComputeUnitsConsumed: 4381,
+								ComputeUnitsBudget:   180000,
+								Status:               InvocationStatusFailed,
+								Err:                  "custom program error: 0x1",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "truncated",
+			args: args{
+				logs: []string{
+					"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 invoke [1]",
+					"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [2]",
+					"Program log: Instruction: Transfer",
+					"Log truncated",
+				},
+			},
+			want: Logs{
+				Invocations: []*Invocation{
+					{
+						ProgramID: common.PublicKeyFromString("JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4"),
+						Depth:     1,
+						Invocations: []*Invocation{
+							{
+								ProgramID: common.TokenProgramID,
+								Depth:     2,
+								Logs:      []string{"Instruction: Transfer"},
+							},
+						},
+					},
+				},
+				Truncated: true,
+			},
+		},
+		{
+			name: "missing end of an invocation",
+			args: args{
+				logs: []string{
+					"Program 11111111111111111111111111111111 invoke [1]",
+					"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [1]",
+					"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA success",
+					"Program is not deployed",
+				},
+			},
+			want: Logs{
+				Invocations: []*Invocation{
+					{
+						ProgramID: common.SystemProgramID,
+						Depth:     1,
+					},
+					{
+						ProgramID: common.TokenProgramID,
+						Depth:     1,
+						Status:    InvocationStatusSuccess,
+					},
+				},
+				Others: []string{"Program is not deployed"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.args.logs))
+		})
+	}
+}
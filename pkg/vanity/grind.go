@@ -0,0 +1,220 @@
+package vanity
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"math"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/types"
+)
+
+// Progress is how far a search is
+type Progress struct {
+	Attempts uint64
+	Elapsed  time.Duration
+	// Rate is the attempts per second
+	Rate float64
+	// ExpectedAttempts is how many attempts a match takes on average
+	ExpectedAttempts float64
+	// ETA is the expected time to the next match at the current rate, math.MaxInt64 if it is too far.
+	// every attempt is independent, so it doesn't shrink as the attempts grow.
+	ETA time.Duration
+}
+
+type config struct {
+	workers          int
+	progressInterval time.Duration
+	progress         func(Progress)
+}
+
+type Option func(*config)
+
+// WithWorkers is an Option that sets how many goroutines search, default runtime.NumCPU()
+func WithWorkers(workers int) Option {
+	return func(c *config) {
+		c.workers = workers
+	}
+}
+
+// defaultProgressInterval is the interval of WithProgress when it isn't positive
+const defaultProgressInterval = time.Second
+
+// WithProgress is an Option that reports the progress every interval, a non positive interval is 1s
+func WithProgress(interval time.Duration, f func(Progress)) Option {
+	return func(c *config) {
+		c.progressInterval = interval
+		c.progress = f
+	}
+}
+
+// GrindAccount searches for a keypair whose address matches the pattern
+func GrindAccount(ctx context.Context, pattern Pattern, opts ...Option) (types.Account, error) {
+	return grind(ctx, pattern, opts, func() func(uint64) (types.Account, *common.PublicKey, bool) {
+		// a buffered reader saves a syscall per key, the seeds are still from crypto/rand
+		r := bufio.NewReaderSize(rand.Reader, 32*1024)
+		seed := make([]byte, ed25519.SeedSize)
+		var pubkey common.PublicKey
+		return func(uint64) (types.Account, *common.PublicKey, bool) {
+			if _, err := io.ReadFull(r, seed); err != nil {
+				return types.Account{}, nil, false
+			}
+			privateKey := ed25519.NewKeyFromSeed(seed)
+			copy(pubkey[:], privateKey[ed25519.SeedSize:])
+			return types.Account{PublicKey: pubkey, PrivateKey: privateKey}, &pubkey, true
+		}
+	})
+}
+
+// SeedAddress is an address made by common.CreateWithSeed
+type SeedAddress struct {
+	Seed    string
+	Address common.PublicKey
+}
+
+// GrindSeed searches for a seed whose common.CreateWithSeed address matches the pattern,
+// the seeds are counters in base 36
+func GrindSeed(ctx context.Context, base, programID common.PublicKey, pattern Pattern, opts ...Option) (SeedAddress, error) {
+	return grind(ctx, pattern, opts, func() func(uint64) (SeedAddress, *common.PublicKey, bool) {
+		return func(n uint64) (SeedAddress, *common.PublicKey, bool) {
+			seed := strconv.FormatUint(n, 36)
+			address := common.CreateWithSeed(base, seed, programID)
+			return SeedAddress{Seed: seed, Address: address}, &address, true
+		}
+	})
+}
+
+// ProgramAddress is an address made by common.FindProgramAddress from the seeds and the suffix
+type ProgramAddress struct {
+	// Suffix is the last seed, a little endian u64
+	Suffix  []byte
+	Address common.PublicKey
+	Bump    uint8
+}
+
+// GrindProgramAddress searches for a suffix seed whose common.FindProgramAddress address matches the pattern
+func GrindProgramAddress(ctx context.Context, seeds [][]byte, programID common.PublicKey, pattern Pattern, opts ...Option) (ProgramAddress, error) {
+	return grind(ctx, pattern, opts, func() func(uint64) (ProgramAddress, *common.PublicKey, bool) {
+		withSuffix := append(append([][]byte{}, seeds...), nil)
+		return func(n uint64) (ProgramAddress, *common.PublicKey, bool) {
+			suffix := binary.LittleEndian.AppendUint64(nil, n)
+			withSuffix[len(seeds)] = suffix
+			address, bump, err := common.FindProgramAddress(withSuffix, programID)
+			if err != nil {
+				return ProgramAddress{}, nil, false
+			}
+			return ProgramAddress{Suffix: suffix, Address: address, Bump: bump}, &address, true
+		}
+	})
+}
+
+// attemptBatch is how many attempts a worker makes before it adds them to the total
+const attemptBatch = 256
+
+// grind runs the workers until one finds a match or the context is done. a worker tries the counters
+// worker, worker+workers, worker+2*workers... so the counters are never tried twice.
+func grind[T any](ctx context.Context, pattern Pattern, opts []Option, newWorker func() func(n uint64) (T, *common.PublicKey, bool)) (T, error) {
+	var zero T
+	m, err := newMatcher(pattern)
+	if err != nil {
+		return zero, err
+	}
+	cfg := config{workers: runtime.NumCPU()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.workers < 1 {
+		cfg.workers = 1
+	}
+	if cfg.progressInterval <= 0 {
+		cfg.progressInterval = defaultProgressInterval
+	}
+
+	searchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		attempts uint64
+		once     sync.Once
+		matched  bool
+		found    T
+		wg       sync.WaitGroup
+	)
+	start := time.Now()
+	for i := 0; i < cfg.workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			try := newWorker()
+			// the attempts are added once they have run
+			var tried uint64
+			defer func() { atomic.AddUint64(&attempts, tried) }()
+			for n := uint64(worker); ; n += uint64(cfg.workers) {
+				if tried == attemptBatch {
+					atomic.AddUint64(&attempts, tried)
+					tried = 0
+				}
+				if tried == 0 && searchCtx.Err() != nil {
+					return
+				}
+				v, pubkey, ok := try(n)
+				tried++
+				if ok && m.match(pubkey) {
+					once.Do(func() {
+						matched = true
+						found = v
+						cancel()
+					})
+					return
+				}
+			}
+		}(i)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	if cfg.progress != nil {
+		reportProgress(done, start, &attempts, pattern.ExpectedAttempts(), cfg)
+	}
+	<-done
+
+	if !matched {
+		return zero, ctx.Err()
+	}
+	return found, nil
+}
+
+func reportProgress(done <-chan struct{}, start time.Time, attempts *uint64, expected float64, cfg config) {
+	ticker := time.NewTicker(cfg.progressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			elapsed := time.Since(start)
+			p := Progress{
+				Attempts:         atomic.LoadUint64(attempts),
+				Elapsed:          elapsed,
+				ExpectedAttempts: expected,
+			}
+			p.Rate = float64(p.Attempts) / elapsed.Seconds()
+			p.ETA = time.Duration(math.MaxInt64)
+			if eta := expected / p.Rate * float64(time.Second); p.Rate > 0 && eta < math.MaxInt64 {
+				p.ETA = time.Duration(eta)
+			}
+			cfg.progress(p)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package vanity
+
+import (
+	"context"
+	"crypto/ed25519"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrindAccount(t *testing.T) {
+	account, err := GrindAccount(context.Background(), Pattern{Prefix: "a", Suffix: "b", IgnoreCase: true}, WithWorkers(4))
+	require.NoError(t, err)
+	address := strings.ToLower(account.PublicKey.ToBase58())
+	assert.True(t, strings.HasPrefix(address, "a") && strings.HasSuffix(address, "b"), address)
+
+	// the keypair signs for the address
+	signature := account.Sign([]byte("vanity"))
+	assert.True(t, ed25519.Verify(account.PublicKey.Bytes(), []byte("vanity"), signature))
+	restored, err := types.AccountFromBytes(account.PrivateKey)
+	require.NoError(t, err)
+	assert.Equal(t, account.PublicKey, restored.PublicKey)
+}
+
+func TestGrindSeed(t *testing.T) {
+	base := common.PublicKeyFromString("RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+	res, err := GrindSeed(context.Background(), base, common.StakeProgramID, Pattern{Prefix: "Stk"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(res.Address.ToBase58(), "Stk"))
+	assert.Equal(t, common.CreateWithSeed(base, res.Seed, common.StakeProgramID), res.Address)
+}
+
+func TestGrindProgramAddress(t *testing.T) {
+	seeds := [][]byte{[]byte("deposit")}
+	res, err := GrindProgramAddress(context.Background(), seeds, common.TokenProgramID, Pattern{Suffix: "pay", IgnoreCase: true})
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(strings.ToLower(res.Address.ToBase58()), "pay"))
+	assert.Len(t, res.Suffix, 8)
+
+	address, bump, err := common.FindProgramAddress([][]byte{[]byte("deposit"), res.Suffix}, common.TokenProgramID)
+	require.NoError(t, err)
+	assert.Equal(t, address, res.Address)
+	assert.Equal(t, bump, res.Bump)
+	assert.Equal(t, [][]byte{[]byte("deposit")}, seeds)
+}
+
+func TestGrind_Cancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	var mu sync.Mutex
+	var progress []Progress
+	pattern := Pattern{Prefix: "So1anaSo1anaSo1ana"}
+	_, err := GrindSeed(ctx, common.SystemProgramID, common.SystemProgramID, pattern,
+		WithWorkers(2),
+		WithProgress(20*time.Millisecond, func(p Progress) {
+			mu.Lock()
+			defer mu.Unlock()
+			progress = append(progress, p)
+		}),
+	)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, progress)
+	last := progress[len(progress)-1]
+	assert.Greater(t, last.Attempts, uint64(0))
+	assert.Greater(t, last.Rate, 0.0)
+	assert.Equal(t, pattern.ExpectedAttempts(), last.ExpectedAttempts)
+	assert.Greater(t, last.ETA, time.Hour)
+}
+
+func TestGrind_InvalidPattern(t *testing.T) {
+	_, err := GrindAccount(context.Background(), Pattern{Prefix: "0"})
+	assert.ErrorIs(t, err, ErrInvalidPattern)
+}
+
+func BenchmarkGrindAccount(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, err := GrindAccount(context.Background(), Pattern{Prefix: "ab", IgnoreCase: true})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGrindAccountBase58 is the same search with types.NewAccount and PublicKey.ToBase58 on one goroutine
+func BenchmarkGrindAccountBase58(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for {
+			account := types.NewAccount()
+			if strings.HasPrefix(strings.ToLower(account.PublicKey.ToBase58()), "ab") {
+				break
+			}
+		}
+	}
+}
+
+func TestGrind_Progress(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// the reported attempts never run ahead of the attempts which have run
+	var mu sync.Mutex
+	var tried uint64
+	var progress []Progress
+	_, err := grind(ctx, Pattern{Prefix: "So1anaSo1anaSo1ana"},
+		[]Option{
+			WithWorkers(4),
+			WithProgress(time.Millisecond, func(p Progress) {
+				mu.Lock()
+				defer mu.Unlock()
+				assert.LessOrEqual(t, p.Attempts, tried)
+				progress = append(progress, p)
+			}),
+		},
+		func() func(uint64) (struct{}, *common.PublicKey, bool) {
+			return func(uint64) (struct{}, *common.PublicKey, bool) {
+				mu.Lock()
+				defer mu.Unlock()
+				tried++
+				return struct{}{}, &common.PublicKey{}, true
+			}
+		},
+	)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.NotEmpty(t, progress)
+
+	// a non positive interval is the default one
+	_, err = GrindSeed(context.Background(), common.SystemProgramID, common.SystemProgramID, Pattern{Prefix: "a"}, WithProgress(0, func(Progress) {}))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,198 @@
+package vanity
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+)
+
+var (
+	ErrEmptyPattern   = errors.New("empty pattern")
+	ErrInvalidPattern = errors.New("invalid pattern")
+)
+
+const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// maxEncodedLength is the longest base58 form of a public key
+const maxEncodedLength = 44
+
+// Pattern is what the base58 form of an address has to look like
+type Pattern struct {
+	Prefix string
+	Suffix string
+	// IgnoreCase matches the prefix and the suffix regardless of case, e.g. "sol" matches "SoL"
+	IgnoreCase bool
+}
+
+func (p Pattern) String() string {
+	s := p.Prefix + "*" + p.Suffix
+	if p.IgnoreCase {
+		s += " (ignore case)"
+	}
+	return s
+}
+
+// Validate checks the pattern can be matched, every character has to be in the base58 alphabet
+func (p Pattern) Validate() error {
+	if p.Prefix == "" && p.Suffix == "" {
+		return ErrEmptyPattern
+	}
+	if len(p.Prefix)+len(p.Suffix) > maxEncodedLength {
+		return fmt.Errorf("%w, the pattern is longer than an address", ErrInvalidPattern)
+	}
+	for _, c := range p.Prefix + p.Suffix {
+		if len(p.candidates(c)) == 0 {
+			return fmt.Errorf("%w, %q is not a base58 character", ErrInvalidPattern, c)
+		}
+	}
+	return nil
+}
+
+// candidates returns the digits a character of the pattern matches
+func (p Pattern) candidates(c rune) []int {
+	var digits []int
+	for i, d := range alphabet {
+		if d == c || (p.IgnoreCase && strings.EqualFold(string(d), string(c))) {
+			digits = append(digits, i)
+		}
+	}
+	return digits
+}
+
+var (
+	// keySpace is the count of 32 byte keys
+	keySpace    = new(big.Int).Lsh(big.NewInt(1), 256)
+	base58Pow42 = new(big.Int).Exp(big.NewInt(58), big.NewInt(42), nil)
+	base58Pow43 = new(big.Int).Exp(big.NewInt(58), big.NewInt(43), nil)
+)
+
+// Probability returns the chance that a random address matches. the first character of a 44 character
+// address can only be one of the low digits, it is taken into account, the other digits are taken as uniform.
+func (p Pattern) Probability() float64 {
+	probability := 1.0
+	prefix := []rune(p.Prefix)
+	if len(prefix) > 0 {
+		probability = firstDigitProbability(p.candidates(prefix[0]))
+		prefix = prefix[1:]
+	}
+	for _, c := range append(prefix, []rune(p.Suffix)...) {
+		probability *= float64(len(p.candidates(c))) / 58
+	}
+	return probability
+}
+
+// ExpectedAttempts returns how many addresses are tried on average until one matches
+func (p Pattern) ExpectedAttempts() float64 {
+	return 1 / p.Probability()
+}
+
+// firstDigitProbability sums the chance of the keys in [d*58^n, (d+1)*58^n) for n = 43, the 44 character
+// addresses, and n = 42, most of the rest
+func firstDigitProbability(digits []int) float64 {
+	count := new(big.Int)
+	for _, d := range digits {
+		if d == 0 {
+			// a leading "1" is a leading zero byte
+			count.Add(count, new(big.Int).Lsh(big.NewInt(1), 248))
+			continue
+		}
+		for _, pow := range []*big.Int{base58Pow43, base58Pow42} {
+			lo := new(big.Int).Mul(big.NewInt(int64(d)), pow)
+			hi := new(big.Int).Add(lo, pow)
+			if lo.Cmp(keySpace) >= 0 {
+				continue
+			}
+			if hi.Cmp(keySpace) > 0 {
+				hi = new(big.Int).Set(keySpace)
+			}
+			count.Add(count, hi.Sub(hi, lo))
+		}
+	}
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(count), new(big.Float).SetInt(keySpace)).Float64()
+	return f
+}
+
+// matcher compares the base58 form of addresses with a pattern without allocating
+type matcher struct {
+	prefix     []byte
+	suffix     []byte
+	ignoreCase bool
+}
+
+func newMatcher(p Pattern) (*matcher, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	m := &matcher{prefix: []byte(p.Prefix), suffix: []byte(p.Suffix), ignoreCase: p.IgnoreCase}
+	if m.ignoreCase {
+		m.prefix = []byte(strings.ToLower(p.Prefix))
+		m.suffix = []byte(strings.ToLower(p.Suffix))
+	}
+	return m, nil
+}
+
+func (m *matcher) match(pubkey *common.PublicKey) bool {
+	var buf [encodeBufferSize]byte
+	encoded := buf[encode(&buf, pubkey):]
+	if len(encoded) < len(m.prefix)+len(m.suffix) {
+		return false
+	}
+	return m.equal(encoded[:len(m.prefix)], m.prefix) && m.equal(encoded[len(encoded)-len(m.suffix):], m.suffix)
+}
+
+func (m *matcher) equal(encoded, pattern []byte) bool {
+	for i, c := range pattern {
+		e := encoded[i]
+		if m.ignoreCase && 'A' <= e && e <= 'Z' {
+			e += 'a' - 'A'
+		}
+		if e != c {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeBufferSize fits 5 rounds of 10 digits
+const encodeBufferSize = 50
+
+// base58Pow10 is the largest power of 58 which fits in an uint64
+const base58Pow10 = 58 * 58 * 58 * 58 * 58 * 58 * 58 * 58 * 58 * 58
+
+// encode writes the base58 form of the key at the end of buf and returns where it starts, it is the same
+// as base58.Encode. the key is divided as 4 uint64 limbs by 58^10 so a round yields 10 digits.
+func encode(buf *[encodeBufferSize]byte, pubkey *common.PublicKey) int {
+	var limbs [4]uint64
+	for i := range limbs {
+		limbs[i] = binary.BigEndian.Uint64(pubkey[i*8:])
+	}
+	i := len(buf)
+	for limbs != [4]uint64{} {
+		var rem uint64
+		for j := range limbs {
+			limbs[j], rem = bits.Div64(rem, limbs[j], base58Pow10)
+		}
+		for k := 0; k < 10; k++ {
+			i--
+			buf[i] = alphabet[rem%58]
+			rem /= 58
+		}
+	}
+	// the last round pads with zero digits, a leading zero byte is a "1" instead
+	for i < len(buf) && buf[i] == alphabet[0] {
+		i++
+	}
+	for _, b := range pubkey {
+		if b != 0 {
+			break
+		}
+		i--
+		buf[i] = alphabet[0]
+	}
+	return i
+}
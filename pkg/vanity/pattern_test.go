@@ -0,0 +1,153 @@
+package vanity
+
+import (
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+)
+
+func randomPublicKey(t testing.TB) common.PublicKey {
+	var pubkey common.PublicKey
+	if _, err := rand.Read(pubkey[:]); err != nil {
+		t.Fatal(err)
+	}
+	return pubkey
+}
+
+func TestEncode(t *testing.T) {
+	pubkeys := []common.PublicKey{
+		{},
+		{31: 1},
+		{0, 0, 0, 1},
+		common.SystemProgramID,
+		common.TokenProgramID,
+		common.PublicKeyFromString("RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7"),
+	}
+	var max common.PublicKey
+	for i := range max {
+		max[i] = 0xff
+	}
+	pubkeys = append(pubkeys, max)
+	for i := 0; i < 1000; i++ {
+		pubkeys = append(pubkeys, randomPublicKey(t))
+	}
+	for _, pubkey := range pubkeys {
+		var buf [encodeBufferSize]byte
+		assert.Equal(t, base58.Encode(pubkey[:]), string(buf[encode(&buf, &pubkey):]))
+	}
+}
+
+func TestPattern_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern Pattern
+		wantErr error
+	}{
+		{name: "prefix", pattern: Pattern{Prefix: "So1"}},
+		{name: "suffix", pattern: Pattern{Suffix: "pay"}},
+		{name: "empty", pattern: Pattern{}, wantErr: ErrEmptyPattern},
+		{name: "zero", pattern: Pattern{Prefix: "0x"}, wantErr: ErrInvalidPattern},
+		{name: "upper o", pattern: Pattern{Suffix: "O"}, wantErr: ErrInvalidPattern},
+		{name: "upper o ignore case", pattern: Pattern{Suffix: "O", IgnoreCase: true}},
+		{name: "lower l ignore case", pattern: Pattern{Prefix: "l", IgnoreCase: true}},
+		{name: "too long", pattern: Pattern{Prefix: strings.Repeat("a", 45)}, wantErr: ErrInvalidPattern},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.pattern.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestPattern_Probability(t *testing.T) {
+	assert.InDelta(t, 1.0/58, Pattern{Suffix: "a"}.Probability(), 1e-12)
+	assert.InDelta(t, 4.0/58/58, Pattern{Suffix: "ab", IgnoreCase: true}.Probability(), 1e-12)
+	assert.InDelta(t, 1.0/58/58, Pattern{Suffix: "ol", IgnoreCase: true}.Probability(), 1e-12)
+
+	// the first digit of most addresses is low, compare the estimate with random keys
+	const samples = 200000
+	patterns := []Pattern{{Prefix: "2"}, {Prefix: "z"}, {Prefix: "h", IgnoreCase: true}}
+	counts := make([]int, len(patterns))
+	matchers := make([]*matcher, len(patterns))
+	for i, p := range patterns {
+		m, err := newMatcher(p)
+		assert.NoError(t, err)
+		matchers[i] = m
+	}
+	for i := 0; i < samples; i++ {
+		pubkey := randomPublicKey(t)
+		for j, m := range matchers {
+			if m.match(&pubkey) {
+				counts[j]++
+			}
+		}
+	}
+	for i, p := range patterns {
+		assert.InEpsilon(t, p.Probability(), float64(counts[i])/samples, 0.15, p.String())
+	}
+	assert.Greater(t, Pattern{Prefix: "2"}.Probability(), 1.0/58)
+	assert.Less(t, Pattern{Prefix: "z"}.Probability(), 1.0/58)
+}
+
+func TestMatcher(t *testing.T) {
+	pubkey := common.PublicKeyFromString("RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+	tests := []struct {
+		pattern Pattern
+		want    bool
+	}{
+		{pattern: Pattern{Prefix: "RNfp"}, want: true},
+		{pattern: Pattern{Prefix: "rnfp"}, want: false},
+		{pattern: Pattern{Prefix: "rnfp", IgnoreCase: true}, want: true},
+		{pattern: Pattern{Suffix: "uchZ7"}, want: true},
+		{pattern: Pattern{Suffix: "UCHZ7", IgnoreCase: true}, want: true},
+		{pattern: Pattern{Prefix: "RN", Suffix: "Z7"}, want: true},
+		{pattern: Pattern{Prefix: "RN", Suffix: "Z8"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern.String(), func(t *testing.T) {
+			m, err := newMatcher(tt.pattern)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, m.match(&pubkey))
+		})
+	}
+}
+
+func BenchmarkEncode(b *testing.B) {
+	pubkey := randomPublicKey(b)
+	var buf [encodeBufferSize]byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		encode(&buf, &pubkey)
+	}
+}
+
+func BenchmarkBase58Encode(b *testing.B) {
+	pubkey := randomPublicKey(b)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		base58.Encode(pubkey[:])
+	}
+}
+
+func BenchmarkMatch(b *testing.B) {
+	pubkey := randomPublicKey(b)
+	m, _ := newMatcher(Pattern{Prefix: "sol", Suffix: "pay", IgnoreCase: true})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.match(&pubkey)
+	}
+}
+
+// BenchmarkMatchBase58 is the match through PublicKey.ToBase58
+func BenchmarkMatchBase58(b *testing.B) {
+	pubkey := randomPublicKey(b)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s := strings.ToLower(pubkey.ToBase58())
+		_ = strings.HasPrefix(s, "sol") && strings.HasSuffix(s, "pay")
+	}
+}
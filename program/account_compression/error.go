@@ -0,0 +1,9 @@
+package account_compression
+
+import "errors"
+
+var (
+	ErrInvalidAccountDataSize = errors.New("invalid account data size")
+	ErrInvalidAccountData     = errors.New("invalid account data")
+	ErrInvalidProof           = errors.New("invalid proof")
+)
@@ -0,0 +1,108 @@
+package account_compression
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// EmptyNode is the value of an empty leaf
+var EmptyNode [32]byte
+
+// HashNodes hashes two children into their parent with keccak-256
+func HashNodes(left, right [32]byte) [32]byte {
+	var node [32]byte
+	h := sha3.NewLegacyKeccak256()
+	h.Write(left[:])
+	h.Write(right[:])
+	h.Sum(node[:0])
+	return node
+}
+
+// EmptyNodeAtLevel returns the root of an empty subtree of the given height
+func EmptyNodeAtLevel(level uint32) [32]byte {
+	node := EmptyNode
+	for i := uint32(0); i < level; i++ {
+		node = HashNodes(node, node)
+	}
+	return node
+}
+
+// ComputeRoot walks a leaf up to the root with its proof, proof[0] is the sibling of the leaf
+func ComputeRoot(leaf [32]byte, index uint32, proof [][32]byte) [32]byte {
+	node := leaf
+	for i, sibling := range proof {
+		if (index>>i)&1 == 0 {
+			node = HashNodes(node, sibling)
+		} else {
+			node = HashNodes(sibling, node)
+		}
+	}
+	return node
+}
+
+// VerifyProof checks the leaf at the index belongs to the root
+func VerifyProof(root, leaf [32]byte, index uint32, proof [][32]byte) error {
+	if len(proof) < 32 && uint64(index) >= 1<<len(proof) {
+		return fmt.Errorf("%w, index %v is out of a tree with depth %v", ErrInvalidProof, index, len(proof))
+	}
+	if ComputeRoot(leaf, index, proof) != root {
+		return fmt.Errorf("%w, root mismatch", ErrInvalidProof)
+	}
+	return nil
+}
+
+// VerifyProof checks the leaf at the index belongs to the current root of the tree.
+// the proof can be a full one or one truncated by the canopy.
+func (a ConcurrentMerkleTreeAccount) VerifyProof(leaf [32]byte, index uint32, proof [][32]byte) error {
+	fullProof, err := a.FillProofFromCanopy(index, proof)
+	if err != nil {
+		return err
+	}
+	return VerifyProof(a.Root(), leaf, index, fullProof)
+}
+
+// TruncateProof drops the proof nodes the canopy caches, the remains are what a transaction has to carry
+func (a ConcurrentMerkleTreeAccount) TruncateProof(proof [][32]byte) [][32]byte {
+	n := int(a.Header.MaxDepth) - int(a.CanopyDepth())
+	if n < 0 {
+		n = 0
+	}
+	if len(proof) <= n {
+		return proof
+	}
+	return proof[:n]
+}
+
+// FillProofFromCanopy completes a truncated proof with the nodes cached in the canopy
+func (a ConcurrentMerkleTreeAccount) FillProofFromCanopy(index uint32, proof [][32]byte) ([][32]byte, error) {
+	maxDepth := a.Header.MaxDepth
+	if uint32(len(proof)) > maxDepth {
+		return nil, fmt.Errorf("%w, proof length %v is greater than max depth %v", ErrInvalidProof, len(proof), maxDepth)
+	}
+	if uint32(len(proof)) == maxDepth {
+		return proof, nil
+	}
+	if uint32(len(proof))+a.CanopyDepth() < maxDepth {
+		return nil, fmt.Errorf("%w, proof length %v with canopy depth %v can't reach max depth %v", ErrInvalidProof, len(proof), a.CanopyDepth(), maxDepth)
+	}
+
+	fullProof := make([][32]byte, 0, maxDepth)
+	fullProof = append(fullProof, proof...)
+	// the heap index of the ancestor at the level the proof stops
+	nodeIndex := ((uint64(1) << maxDepth) + uint64(index)) >> len(proof)
+	level := uint32(len(proof))
+	for nodeIndex > 1 && uint32(len(fullProof)) < maxDepth {
+		// the canopy skips the root, so heap index i is stored at i-2
+		canopyIndex := nodeIndex - 2
+		siblingIndex := canopyIndex ^ 1
+		sibling := a.Canopy[siblingIndex]
+		if sibling == EmptyNode {
+			sibling = EmptyNodeAtLevel(level)
+		}
+		fullProof = append(fullProof, sibling)
+		nodeIndex >>= 1
+		level++
+	}
+	return fullProof, nil
+}
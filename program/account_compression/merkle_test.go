@@ -0,0 +1,203 @@
+package account_compression
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// testTree keeps every level of a tree, levels[0] are the leaves and the last level is the root
+type testTree struct {
+	levels [][][32]byte
+}
+
+func newTestTree(depth int, leaves [][32]byte) testTree {
+	level := make([][32]byte, 1<<depth)
+	copy(level, leaves)
+	levels := [][][32]byte{level}
+	for d := 0; d < depth; d++ {
+		next := make([][32]byte, len(level)/2)
+		for i := range next {
+			next[i] = HashNodes(level[2*i], level[2*i+1])
+		}
+		levels = append(levels, next)
+		level = next
+	}
+	return testTree{levels: levels}
+}
+
+func (t testTree) root() [32]byte {
+	return t.levels[len(t.levels)-1][0]
+}
+
+func (t testTree) proof(index uint32) [][32]byte {
+	proof := make([][32]byte, 0, len(t.levels)-1)
+	for d := 0; d < len(t.levels)-1; d++ {
+		proof = append(proof, t.levels[d][(index>>d)^1])
+	}
+	return proof
+}
+
+// accountData encodes the tree like the program does after appending the leaves
+func (t testTree) accountData(maxBufferSize uint32, canopy [][32]byte, numMinted uint32) []byte {
+	depth := uint32(len(t.levels) - 1)
+	data := []byte{uint8(CompressionAccountTypeConcurrentMerkleTree), uint8(ConcurrentMerkleTreeHeaderVersionV1)}
+	data = binary.LittleEndian.AppendUint32(data, maxBufferSize)
+	data = binary.LittleEndian.AppendUint32(data, depth)
+	data = append(data, common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7").Bytes()...)
+	data = binary.LittleEndian.AppendUint64(data, 100)
+	data = append(data, make([]byte, 6)...)
+
+	data = binary.LittleEndian.AppendUint64(data, uint64(numMinted))
+	// active index
+	data = binary.LittleEndian.AppendUint64(data, 1)
+	data = binary.LittleEndian.AppendUint64(data, uint64(maxBufferSize))
+	for i := uint32(0); i < maxBufferSize; i++ {
+		root := [32]byte{}
+		if i == 1 {
+			root = t.root()
+		}
+		data = append(data, root[:]...)
+		data = append(data, make([]byte, 32*depth)...)
+		data = binary.LittleEndian.AppendUint32(data, numMinted-1)
+		data = append(data, make([]byte, 4)...)
+	}
+	for _, node := range t.proof(numMinted - 1) {
+		data = append(data, node[:]...)
+	}
+	data = append(data, t.levels[0][numMinted-1][:]...)
+	data = binary.LittleEndian.AppendUint32(data, numMinted)
+	data = append(data, make([]byte, 4)...)
+
+	for _, node := range canopy {
+		data = append(data, node[:]...)
+	}
+	return data
+}
+
+func testLeaves() [][32]byte {
+	return [][32]byte{{1}, {2}, {3}, {4}}
+}
+
+func TestEmptyNodeAtLevel(t *testing.T) {
+	assert.Equal(t, EmptyNode, EmptyNodeAtLevel(0))
+	got := EmptyNodeAtLevel(1)
+	assert.Equal(t, "ad3228b676f7d3cd4284a5443f17f1962b36e491b30a40b2405849e597ba5fb5", hex.EncodeToString(got[:]))
+}
+
+func TestVerifyProof(t *testing.T) {
+	tree := newTestTree(3, testLeaves())
+	type args struct {
+		root  [32]byte
+		leaf  [32]byte
+		index uint32
+		proof [][32]byte
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "first leaf",
+			args: args{root: tree.root(), leaf: [32]byte{1}, index: 0, proof: tree.proof(0)},
+			err:  nil,
+		},
+		{
+			name: "last minted leaf",
+			args: args{root: tree.root(), leaf: [32]byte{4}, index: 3, proof: tree.proof(3)},
+			err:  nil,
+		},
+		{
+			name: "empty leaf",
+			args: args{root: tree.root(), leaf: EmptyNode, index: 7, proof: tree.proof(7)},
+			err:  nil,
+		},
+		{
+			name: "wrong leaf",
+			args: args{root: tree.root(), leaf: [32]byte{5}, index: 3, proof: tree.proof(3)},
+			err:  ErrInvalidProof,
+		},
+		{
+			name: "wrong index",
+			args: args{root: tree.root(), leaf: [32]byte{4}, index: 2, proof: tree.proof(3)},
+			err:  ErrInvalidProof,
+		},
+		{
+			name: "index out of tree",
+			args: args{root: tree.root(), leaf: [32]byte{4}, index: 11, proof: tree.proof(3)},
+			err:  ErrInvalidProof,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyProof(tt.args.root, tt.args.leaf, tt.args.index, tt.args.proof)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestConcurrentMerkleTreeAccount_VerifyProof(t *testing.T) {
+	tree := newTestTree(3, testLeaves())
+	// the right child of the root has never been written, the program leaves it zero
+	account, err := ConcurrentMerkleTreeAccountDeserialize(tree.accountData(2, [][32]byte{tree.levels[2][0], {}}, 4))
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1), account.CanopyDepth())
+
+	type args struct {
+		leaf  [32]byte
+		index uint32
+		proof [][32]byte
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "full proof",
+			args: args{leaf: [32]byte{2}, index: 1, proof: tree.proof(1)},
+			err:  nil,
+		},
+		{
+			name: "truncated proof",
+			args: args{leaf: [32]byte{2}, index: 1, proof: account.TruncateProof(tree.proof(1))},
+			err:  nil,
+		},
+		{
+			name: "truncated proof of an empty leaf, filled with an empty node",
+			args: args{leaf: EmptyNode, index: 6, proof: account.TruncateProof(tree.proof(6))},
+			err:  nil,
+		},
+		{
+			name: "wrong leaf",
+			args: args{leaf: [32]byte{3}, index: 1, proof: account.TruncateProof(tree.proof(1))},
+			err:  ErrInvalidProof,
+		},
+		{
+			name: "proof too short",
+			args: args{leaf: [32]byte{2}, index: 1, proof: tree.proof(1)[:1]},
+			err:  ErrInvalidProof,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := account.VerifyProof(tt.args.leaf, tt.args.index, tt.args.proof)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestConcurrentMerkleTreeAccount_TruncateProof(t *testing.T) {
+	tree := newTestTree(3, testLeaves())
+	account, err := ConcurrentMerkleTreeAccountDeserialize(tree.accountData(2, [][32]byte{tree.levels[2][0], tree.levels[2][1]}, 4))
+	assert.Nil(t, err)
+	assert.Equal(t, tree.proof(1)[:2], account.TruncateProof(tree.proof(1)))
+
+	account, err = ConcurrentMerkleTreeAccountDeserialize(tree.accountData(2, nil, 4))
+	assert.Nil(t, err)
+	assert.Equal(t, tree.proof(1), account.TruncateProof(tree.proof(1)))
+}
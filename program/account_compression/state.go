@@ -0,0 +1,197 @@
+package account_compression
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/bytes_decoder"
+)
+
+type CompressionAccountType uint8
+
+const (
+	CompressionAccountTypeUninitialized CompressionAccountType = iota
+	CompressionAccountTypeConcurrentMerkleTree
+)
+
+type ConcurrentMerkleTreeHeaderVersion uint8
+
+const (
+	ConcurrentMerkleTreeHeaderVersionV1 ConcurrentMerkleTreeHeaderVersion = iota
+)
+
+// ConcurrentMerkleTreeHeaderSize is the size of the account type, the version and the v1 header
+const ConcurrentMerkleTreeHeaderSize = 1 + 1 + 54
+
+type ConcurrentMerkleTreeHeader struct {
+	AccountType   CompressionAccountType
+	Version       ConcurrentMerkleTreeHeaderVersion
+	MaxBufferSize uint32
+	MaxDepth      uint32
+	// Authority is the account which can modify the tree, it is the tree authority pda for bubblegum trees
+	Authority    common.PublicKey
+	CreationSlot uint64
+}
+
+// ChangeLog is the root and the path of a leaf after a modification
+type ChangeLog struct {
+	Root      [32]byte
+	PathNodes [][32]byte
+	Index     uint32
+}
+
+// Path is the proof of a leaf
+type Path struct {
+	Proof [][32]byte
+	Leaf  [32]byte
+	Index uint32
+}
+
+type ConcurrentMerkleTree struct {
+	SequenceNumber uint64
+	ActiveIndex    uint64
+	BufferSize     uint64
+	ChangeLogs     []ChangeLog
+	RightMostPath  Path
+}
+
+// ConcurrentMerkleTreeAccount is the account of a concurrent merkle tree
+type ConcurrentMerkleTreeAccount struct {
+	Header ConcurrentMerkleTreeHeader
+	Tree   ConcurrentMerkleTree
+	// Canopy caches the top nodes of the tree except the root, in heap order
+	Canopy [][32]byte
+}
+
+// Root returns the current root of the tree
+func (a ConcurrentMerkleTreeAccount) Root() [32]byte {
+	return a.Tree.ChangeLogs[a.Tree.ActiveIndex].Root
+}
+
+// NumMinted returns the number of leaves which have been appended
+func (a ConcurrentMerkleTreeAccount) NumMinted() uint32 {
+	return a.Tree.RightMostPath.Index
+}
+
+// CanopyDepth returns how many levels below the root are cached
+func (a ConcurrentMerkleTreeAccount) CanopyDepth() uint32 {
+	return canopyDepth(len(a.Canopy))
+}
+
+// GetConcurrentMerkleTreeAccountSize returns the space a tree account has to be allocated with
+func GetConcurrentMerkleTreeAccountSize(maxDepth, maxBufferSize, canopyDepth uint32) uint64 {
+	return uint64(ConcurrentMerkleTreeHeaderSize) +
+		concurrentMerkleTreeSize(maxDepth, maxBufferSize) +
+		canopySize(canopyDepth)
+}
+
+func concurrentMerkleTreeSize(maxDepth, maxBufferSize uint32) uint64 {
+	// sequence number, active index, buffer size, change logs and the rightmost path
+	return 8 + 8 + 8 + uint64(maxBufferSize)*changeLogSize(maxDepth) + pathSize(maxDepth)
+}
+
+func changeLogSize(maxDepth uint32) uint64 {
+	// root, path nodes, index and padding
+	return 32 + 32*uint64(maxDepth) + 4 + 4
+}
+
+func pathSize(maxDepth uint32) uint64 {
+	// proof, leaf, index and padding
+	return 32*uint64(maxDepth) + 32 + 4 + 4
+}
+
+func canopySize(canopyDepth uint32) uint64 {
+	if canopyDepth == 0 {
+		return 0
+	}
+	return ((1 << (canopyDepth + 1)) - 2) * 32
+}
+
+func canopyDepth(canopyNodes int) uint32 {
+	if canopyNodes == 0 {
+		return 0
+	}
+	return uint32(bits.Len(uint(canopyNodes+2))) - 2
+}
+
+func ConcurrentMerkleTreeAccountDeserialize(data []byte) (ConcurrentMerkleTreeAccount, error) {
+	if len(data) < ConcurrentMerkleTreeHeaderSize {
+		return ConcurrentMerkleTreeAccount{}, fmt.Errorf("%w, size: %v", ErrInvalidAccountDataSize, len(data))
+	}
+
+	var account ConcurrentMerkleTreeAccount
+	current := 0
+
+	accountType, _ := bytes_decoder.GetUint8(&current, data)
+	account.Header.AccountType = CompressionAccountType(accountType)
+	if account.Header.AccountType != CompressionAccountTypeConcurrentMerkleTree {
+		return ConcurrentMerkleTreeAccount{}, fmt.Errorf("%w, unexpected account type: %v", ErrInvalidAccountData, accountType)
+	}
+	version, _ := bytes_decoder.GetUint8(&current, data)
+	account.Header.Version = ConcurrentMerkleTreeHeaderVersion(version)
+	if account.Header.Version != ConcurrentMerkleTreeHeaderVersionV1 {
+		return ConcurrentMerkleTreeAccount{}, fmt.Errorf("%w, unsupported header version: %v", ErrInvalidAccountData, version)
+	}
+	account.Header.MaxBufferSize, _ = bytes_decoder.GetUint32(&current, data)
+	account.Header.MaxDepth, _ = bytes_decoder.GetUint32(&current, data)
+	authority, _ := bytes_decoder.GetBytes32(&current, data)
+	account.Header.Authority = common.PublicKey(authority)
+	account.Header.CreationSlot, _ = bytes_decoder.GetUint64(&current, data)
+	// padding
+	current = ConcurrentMerkleTreeHeaderSize
+
+	maxDepth, maxBufferSize := account.Header.MaxDepth, account.Header.MaxBufferSize
+	if maxDepth == 0 || maxDepth > 30 || maxBufferSize == 0 {
+		return ConcurrentMerkleTreeAccount{}, fmt.Errorf("%w, max depth: %v, max buffer size: %v", ErrInvalidAccountData, maxDepth, maxBufferSize)
+	}
+	treeSize := concurrentMerkleTreeSize(maxDepth, maxBufferSize)
+	if uint64(len(data[current:])) < treeSize {
+		return ConcurrentMerkleTreeAccount{}, fmt.Errorf("%w, size: %v", ErrInvalidAccountDataSize, len(data))
+	}
+
+	account.Tree.SequenceNumber, _ = bytes_decoder.GetUint64(&current, data)
+	account.Tree.ActiveIndex, _ = bytes_decoder.GetUint64(&current, data)
+	account.Tree.BufferSize, _ = bytes_decoder.GetUint64(&current, data)
+	if account.Tree.ActiveIndex >= uint64(maxBufferSize) {
+		return ConcurrentMerkleTreeAccount{}, fmt.Errorf("%w, active index: %v", ErrInvalidAccountData, account.Tree.ActiveIndex)
+	}
+
+	account.Tree.ChangeLogs = make([]ChangeLog, 0, maxBufferSize)
+	for i := uint32(0); i < maxBufferSize; i++ {
+		var changeLog ChangeLog
+		changeLog.Root, _ = bytes_decoder.GetBytes32(&current, data)
+		changeLog.PathNodes = make([][32]byte, 0, maxDepth)
+		for j := uint32(0); j < maxDepth; j++ {
+			node, _ := bytes_decoder.GetBytes32(&current, data)
+			changeLog.PathNodes = append(changeLog.PathNodes, node)
+		}
+		changeLog.Index, _ = bytes_decoder.GetUint32(&current, data)
+		// padding
+		current += 4
+		account.Tree.ChangeLogs = append(account.Tree.ChangeLogs, changeLog)
+	}
+
+	account.Tree.RightMostPath.Proof = make([][32]byte, 0, maxDepth)
+	for j := uint32(0); j < maxDepth; j++ {
+		node, _ := bytes_decoder.GetBytes32(&current, data)
+		account.Tree.RightMostPath.Proof = append(account.Tree.RightMostPath.Proof, node)
+	}
+	account.Tree.RightMostPath.Leaf, _ = bytes_decoder.GetBytes32(&current, data)
+	account.Tree.RightMostPath.Index, _ = bytes_decoder.GetUint32(&current, data)
+	// padding
+	current += 4
+
+	canopy := data[current:]
+	if len(canopy)%32 != 0 || canopySize(canopyDepth(len(canopy)/32)) != uint64(len(canopy)) {
+		return ConcurrentMerkleTreeAccount{}, fmt.Errorf("%w, canopy size: %v", ErrInvalidAccountDataSize, len(canopy))
+	}
+	account.Canopy = make([][32]byte, 0, len(canopy)/32)
+	for i := 0; i < len(canopy); i += 32 {
+		var node [32]byte
+		copy(node[:], canopy[i:i+32])
+		account.Canopy = append(account.Canopy, node)
+	}
+
+	return account, nil
+}
@@ -0,0 +1,94 @@
+package account_compression
+
+import (
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetConcurrentMerkleTreeAccountSize(t *testing.T) {
+	type args struct {
+		maxDepth      uint32
+		maxBufferSize uint32
+		canopyDepth   uint32
+	}
+	tests := []struct {
+		name string
+		args args
+		want uint64
+	}{
+		{
+			args: args{maxDepth: 3, maxBufferSize: 8, canopyDepth: 0},
+			want: 1304,
+		},
+		{
+			args: args{maxDepth: 14, maxBufferSize: 64, canopyDepth: 0},
+			want: 31800,
+		},
+		{
+			args: args{maxDepth: 14, maxBufferSize: 64, canopyDepth: 10},
+			want: 97272,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetConcurrentMerkleTreeAccountSize(tt.args.maxDepth, tt.args.maxBufferSize, tt.args.canopyDepth))
+		})
+	}
+}
+
+func TestConcurrentMerkleTreeAccountDeserialize(t *testing.T) {
+	tree := newTestTree(3, testLeaves())
+	data := tree.accountData(2, [][32]byte{tree.levels[2][0], {}}, 4)
+	assert.Equal(t, GetConcurrentMerkleTreeAccountSize(3, 2, 1), uint64(len(data)))
+
+	account, err := ConcurrentMerkleTreeAccountDeserialize(data)
+	assert.Nil(t, err)
+	assert.Equal(t, ConcurrentMerkleTreeHeader{
+		AccountType:   CompressionAccountTypeConcurrentMerkleTree,
+		Version:       ConcurrentMerkleTreeHeaderVersionV1,
+		MaxBufferSize: 2,
+		MaxDepth:      3,
+		Authority:     common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+		CreationSlot:  100,
+	}, account.Header)
+	assert.Equal(t, uint64(4), account.Tree.SequenceNumber)
+	assert.Equal(t, tree.root(), account.Root())
+	assert.Equal(t, uint32(4), account.NumMinted())
+	assert.Equal(t, Path{Proof: tree.proof(3), Leaf: [32]byte{4}, Index: 4}, account.Tree.RightMostPath)
+	assert.Equal(t, [][32]byte{tree.levels[2][0], {}}, account.Canopy)
+
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{
+			name: "too short header",
+			data: data[:10],
+			err:  ErrInvalidAccountDataSize,
+		},
+		{
+			name: "uninitialized",
+			data: append([]byte{0}, data[1:]...),
+			err:  ErrInvalidAccountData,
+		},
+		{
+			name: "truncated tree",
+			data: data[:100],
+			err:  ErrInvalidAccountDataSize,
+		},
+		{
+			name: "bad canopy size",
+			data: data[:len(data)-32],
+			err:  ErrInvalidAccountDataSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConcurrentMerkleTreeAccountDeserialize(tt.data)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
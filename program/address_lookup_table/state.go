@@ -59,6 +59,10 @@ func DeserializeLookupTable(data []byte, accountOwner common.PublicKey) (Address
 		if uint(len(data)) < LOOKUP_TABLE_META_SIZE {
 			return AddressLookupTable{}, ErrInvalidAccountDataSize
 		}
+		addressesLen := uint(len(data)) - LOOKUP_TABLE_META_SIZE
+		if addressesLen%32 != 0 || addressesLen/32 > LOOKUP_TABLE_MAX_ADDRESSES {
+			return AddressLookupTable{}, ErrInvalidAccountDataSize
+		}
 		addressLookupTable := AddressLookupTable{
 			ProgramState: programState,
 		}
@@ -72,6 +76,9 @@ func DeserializeLookupTable(data []byte, accountOwner common.PublicKey) (Address
 		addressLookupTable.LastExtendedSlotStartIndex = data[current]
 		current += 1
 
+		if data[current] > 1 {
+			return AddressLookupTable{}, ErrInvalidAccountData
+		}
 		some := bool(data[current] == 1)
 		current += 1
 		if some {
@@ -83,6 +90,8 @@ func DeserializeLookupTable(data []byte, accountOwner common.PublicKey) (Address
 		addressLookupTable.padding = binary.LittleEndian.Uint16(data[current : current+2])
 		current += 2
 
+		// the meta is padded to a fixed size whether the authority is set or not
+		current = int(LOOKUP_TABLE_META_SIZE)
 		l := (len(data) - current) / 32
 		addresses := make([]common.PublicKey, 0, l)
 		for i := 0; i < l; i++ {
@@ -96,6 +96,57 @@ func TestDeserializeLookupTable(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "without authority",
+			args: args{
+				data:         append(lookupTableMeta(0), common.PublicKeyFromString("9aE476sH92Vz7DMPyq5WLPkrKWivxeuTKEFKd2sZZcde").Bytes()...),
+				accountOwner: common.AddressLookupTableProgramID,
+			},
+			want: AddressLookupTable{
+				ProgramState:     ProgramStateLookupTable,
+				DeactivationSlot: ^uint64(0),
+				Addresses: []common.PublicKey{
+					common.PublicKeyFromString("9aE476sH92Vz7DMPyq5WLPkrKWivxeuTKEFKd2sZZcde"),
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "invalid authority option",
+			args: args{
+				data:         lookupTableMeta(2),
+				accountOwner: common.AddressLookupTableProgramID,
+			},
+			want:    AddressLookupTable{},
+			wantErr: ErrInvalidAccountData,
+		},
+		{
+			name: "trailing data",
+			args: args{
+				data:         append(lookupTableMeta(0), make([]byte, 33)...),
+				accountOwner: common.AddressLookupTableProgramID,
+			},
+			want:    AddressLookupTable{},
+			wantErr: ErrInvalidAccountDataSize,
+		},
+		{
+			name: "too many addresses",
+			args: args{
+				data:         append(lookupTableMeta(0), make([]byte, 32*257)...),
+				accountOwner: common.AddressLookupTableProgramID,
+			},
+			want:    AddressLookupTable{},
+			wantErr: ErrInvalidAccountDataSize,
+		},
+		{
+			name: "unknown state",
+			args: args{
+				data:         []byte{2, 0, 0, 0},
+				accountOwner: common.AddressLookupTableProgramID,
+			},
+			want:    AddressLookupTable{},
+			wantErr: ErrInvalidAccountData,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -105,3 +156,26 @@ func TestDeserializeLookupTable(t *testing.T) {
 		})
 	}
 }
+
+// lookupTableMeta returns the meta of an active lookup table with the authority option tag
+func lookupTableMeta(authorityOption uint8) []byte {
+	b := make([]byte, LOOKUP_TABLE_META_SIZE)
+	b[0] = 1
+	for i := 4; i < 12; i++ {
+		b[i] = 255
+	}
+	b[21] = authorityOption
+	return b
+}
+
+func FuzzDeserializeLookupTable(f *testing.F) {
+	f.Add([]byte{1, 0, 0, 0, 255, 255, 255, 255, 255, 255, 255, 255, 230, 107, 61, 9, 0, 0, 0, 0, 0, 1, 215, 20, 147, 30, 186, 106, 25, 168, 244, 220, 108, 1, 154, 255, 38, 79, 95, 191, 104, 197, 162, 142, 224, 179, 185, 135, 85, 206, 57, 214, 73, 211, 0, 0, 127, 96, 107, 250, 152, 133, 208, 224, 73, 251, 113, 151, 128, 139, 86, 80, 101, 70, 138, 50, 141, 153, 218, 110, 56, 39, 122, 181, 120, 55, 86, 185, 29, 11, 113, 4, 101, 239, 39, 167, 201, 112, 156, 239, 236, 36, 251, 140, 76, 199, 150, 228, 218, 214, 20, 123, 180, 181, 103, 160, 71, 251, 237, 123})
+	f.Add(lookupTableMeta(0))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		got, err := DeserializeLookupTable(data, common.AddressLookupTableProgramID)
+		if err != nil || got.ProgramState == ProgramStateUninitialized {
+			return
+		}
+		assert.Equal(t, len(data), int(LOOKUP_TABLE_META_SIZE)+32*len(got.Addresses))
+	})
+}
@@ -0,0 +1,300 @@
+package bpf_loader_upgradeable
+
+import (
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/bincode"
+	"github.com/EntySquare/solana-go-sdk/types"
+)
+
+type Instruction uint32
+
+const (
+	InstructionInitializeBuffer Instruction = iota
+	InstructionWrite
+	InstructionDeployWithMaxDataLen
+	InstructionUpgrade
+	InstructionSetAuthority
+	InstructionClose
+	InstructionExtendProgram
+	InstructionSetAuthorityChecked
+)
+
+type InitializeBufferParam struct {
+	Buffer common.PublicKey
+	// Authority is optional, an immutable buffer is created without it
+	Authority *common.PublicKey
+}
+
+// InitializeBuffer initializes a buffer account which has been created with the loader as its owner
+func InitializeBuffer(param InitializeBufferParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionInitializeBuffer,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 2)
+	accounts = append(accounts, types.AccountMeta{PubKey: param.Buffer, IsSigner: false, IsWritable: true})
+	if param.Authority != nil {
+		accounts = append(accounts, types.AccountMeta{PubKey: *param.Authority, IsSigner: false, IsWritable: false})
+	}
+
+	return types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type WriteParam struct {
+	Buffer    common.PublicKey
+	Authority common.PublicKey
+	Offset    uint32
+	Bytes     []byte
+}
+
+// Write writes program data into a buffer account at the offset
+func Write(param WriteParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+		Offset      uint32
+		Bytes       []byte
+	}{
+		Instruction: InstructionWrite,
+		Offset:      param.Offset,
+		Bytes:       param.Bytes,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Buffer, IsSigner: false, IsWritable: true},
+			{PubKey: param.Authority, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type DeployWithMaxDataLenParam struct {
+	Payer     common.PublicKey
+	Program   common.PublicKey
+	Buffer    common.PublicKey
+	Authority common.PublicKey
+	// MaxDataLen is the max length the program can be upgraded to
+	MaxDataLen uint64
+}
+
+// DeployWithMaxDataLen deploys a program from a buffer. The program account has to be
+// created with the loader as its owner and ProgramAccountSize space in the same transaction.
+func DeployWithMaxDataLen(param DeployWithMaxDataLenParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+		MaxDataLen  uint64
+	}{
+		Instruction: InstructionDeployWithMaxDataLen,
+		MaxDataLen:  param.MaxDataLen,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	programData, _ := GetProgramDataAddress(param.Program)
+	return types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Payer, IsSigner: true, IsWritable: true},
+			{PubKey: programData, IsSigner: false, IsWritable: true},
+			{PubKey: param.Program, IsSigner: false, IsWritable: true},
+			{PubKey: param.Buffer, IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+			{PubKey: param.Authority, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type UpgradeParam struct {
+	Program   common.PublicKey
+	Buffer    common.PublicKey
+	Authority common.PublicKey
+	// Spill receives the lamports of the buffer account
+	Spill common.PublicKey
+}
+
+// Upgrade replaces the program data with the buffer content
+func Upgrade(param UpgradeParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionUpgrade,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	programData, _ := GetProgramDataAddress(param.Program)
+	return types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: programData, IsSigner: false, IsWritable: true},
+			{PubKey: param.Program, IsSigner: false, IsWritable: true},
+			{PubKey: param.Buffer, IsSigner: false, IsWritable: true},
+			{PubKey: param.Spill, IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: param.Authority, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type SetAuthorityParam struct {
+	// Account is a buffer account or a program data account
+	Account   common.PublicKey
+	Authority common.PublicKey
+	// NewAuthority is optional, the account becomes immutable without it
+	NewAuthority *common.PublicKey
+}
+
+// SetAuthority changes the authority of a buffer or a program data account
+func SetAuthority(param SetAuthorityParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionSetAuthority,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 3)
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: param.Account, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: param.Authority, IsSigner: true, IsWritable: false},
+	)
+	if param.NewAuthority != nil {
+		accounts = append(accounts, types.AccountMeta{PubKey: *param.NewAuthority, IsSigner: false, IsWritable: false})
+	}
+
+	return types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type SetAuthorityCheckedParam struct {
+	Account      common.PublicKey
+	Authority    common.PublicKey
+	NewAuthority common.PublicKey
+}
+
+// SetAuthorityChecked is the same as SetAuthority but the new authority has to sign
+func SetAuthorityChecked(param SetAuthorityCheckedParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionSetAuthorityChecked,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Account, IsSigner: false, IsWritable: true},
+			{PubKey: param.Authority, IsSigner: true, IsWritable: false},
+			{PubKey: param.NewAuthority, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type CloseParam struct {
+	// Account is a buffer, program data or uninitialized account
+	Account   common.PublicKey
+	Recipient common.PublicKey
+	// Authority is required unless the account is uninitialized
+	Authority *common.PublicKey
+	// Program is required when closing a program data account
+	Program *common.PublicKey
+}
+
+// Close closes an account and moves its lamports to the recipient
+func Close(param CloseParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionClose,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 4)
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: param.Account, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: param.Recipient, IsSigner: false, IsWritable: true},
+	)
+	if param.Authority != nil {
+		accounts = append(accounts, types.AccountMeta{PubKey: *param.Authority, IsSigner: true, IsWritable: false})
+	}
+	if param.Program != nil {
+		accounts = append(accounts, types.AccountMeta{PubKey: *param.Program, IsSigner: false, IsWritable: true})
+	}
+
+	return types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type ExtendProgramParam struct {
+	Program common.PublicKey
+	// Payer is optional, it funds the rent of the extra bytes
+	Payer           *common.PublicKey
+	AdditionalBytes uint32
+}
+
+// ExtendProgram grows the program data account so that larger programs can be upgraded
+func ExtendProgram(param ExtendProgramParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction     Instruction
+		AdditionalBytes uint32
+	}{
+		Instruction:     InstructionExtendProgram,
+		AdditionalBytes: param.AdditionalBytes,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	programData, _ := GetProgramDataAddress(param.Program)
+	accounts := make([]types.AccountMeta, 0, 4)
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: programData, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: param.Program, IsSigner: false, IsWritable: true},
+	)
+	if param.Payer != nil {
+		accounts = append(accounts,
+			types.AccountMeta{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+			types.AccountMeta{PubKey: *param.Payer, IsSigner: true, IsWritable: true},
+		)
+	}
+
+	return types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
@@ -0,0 +1,389 @@
+package bpf_loader_upgradeable
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/pointer"
+	"github.com/EntySquare/solana-go-sdk/types"
+)
+
+func TestInitializeBuffer(t *testing.T) {
+	type args struct {
+		param InitializeBufferParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "with authority",
+			args: args{
+				param: InitializeBufferParam{
+					Buffer:    common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+					Authority: pointer.Get(common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.BPFLoaderUpgradeableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{0, 0, 0, 0},
+			},
+		},
+		{
+			name: "immutable",
+			args: args{
+				param: InitializeBufferParam{
+					Buffer: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.BPFLoaderUpgradeableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+				},
+				Data: []byte{0, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InitializeBuffer(tt.args.param); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InitializeBuffer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	type args struct {
+		param WriteParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: WriteParam{
+					Buffer:    common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+					Authority: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+					Offset:    1000,
+					Bytes:     []byte{1, 2, 3},
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.BPFLoaderUpgradeableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{1, 0, 0, 0, 232, 3, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 1, 2, 3},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Write(tt.args.param); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Write() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeployWithMaxDataLen(t *testing.T) {
+	type args struct {
+		param DeployWithMaxDataLenParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: DeployWithMaxDataLenParam{
+					Payer:      common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+					Program:    common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+					Buffer:     common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					Authority:  common.PublicKeyFromString("DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi"),
+					MaxDataLen: 200000,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.BPFLoaderUpgradeableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: true, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("9KPkw58x6ANtgGqMSv7Kvrk7LqVzvkcenQYWXFfnReje"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: false, IsWritable: true},
+					{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi"), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{2, 0, 0, 0, 64, 13, 3, 0, 0, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DeployWithMaxDataLen(tt.args.param); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DeployWithMaxDataLen() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	type args struct {
+		param UpgradeParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: UpgradeParam{
+					Program:   common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+					Buffer:    common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					Authority: common.PublicKeyFromString("DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi"),
+					Spill:     common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.BPFLoaderUpgradeableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("9KPkw58x6ANtgGqMSv7Kvrk7LqVzvkcenQYWXFfnReje"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: false, IsWritable: true},
+					{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi"), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{3, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Upgrade(tt.args.param); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Upgrade() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetAuthority(t *testing.T) {
+	type args struct {
+		param SetAuthorityParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "new authority",
+			args: args{
+				param: SetAuthorityParam{
+					Account:      common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+					Authority:    common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+					NewAuthority: pointer.Get(common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.BPFLoaderUpgradeableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{4, 0, 0, 0},
+			},
+		},
+		{
+			name: "immutable",
+			args: args{
+				param: SetAuthorityParam{
+					Account:   common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+					Authority: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.BPFLoaderUpgradeableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{4, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SetAuthority(tt.args.param); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SetAuthority() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetAuthorityChecked(t *testing.T) {
+	type args struct {
+		param SetAuthorityCheckedParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: SetAuthorityCheckedParam{
+					Account:      common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+					Authority:    common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+					NewAuthority: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.BPFLoaderUpgradeableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{7, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SetAuthorityChecked(tt.args.param); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SetAuthorityChecked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClose(t *testing.T) {
+	type args struct {
+		param CloseParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "program data",
+			args: args{
+				param: CloseParam{
+					Account:   common.PublicKeyFromString("9KPkw58x6ANtgGqMSv7Kvrk7LqVzvkcenQYWXFfnReje"),
+					Recipient: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+					Authority: pointer.Get(common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")),
+					Program:   pointer.Get(common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.BPFLoaderUpgradeableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("9KPkw58x6ANtgGqMSv7Kvrk7LqVzvkcenQYWXFfnReje"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+				},
+				Data: []byte{5, 0, 0, 0},
+			},
+		},
+		{
+			name: "uninitialized",
+			args: args{
+				param: CloseParam{
+					Account:   common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					Recipient: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.BPFLoaderUpgradeableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: false, IsWritable: true},
+				},
+				Data: []byte{5, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Close(tt.args.param); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Close() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtendProgram(t *testing.T) {
+	type args struct {
+		param ExtendProgramParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "with payer",
+			args: args{
+				param: ExtendProgramParam{
+					Program:         common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+					Payer:           pointer.Get(common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")),
+					AdditionalBytes: 10240,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.BPFLoaderUpgradeableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("9KPkw58x6ANtgGqMSv7Kvrk7LqVzvkcenQYWXFfnReje"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: true, IsWritable: true},
+				},
+				Data: []byte{6, 0, 0, 0, 0, 40, 0, 0},
+			},
+		},
+		{
+			name: "without payer",
+			args: args{
+				param: ExtendProgramParam{
+					Program:         common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+					AdditionalBytes: 10240,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.BPFLoaderUpgradeableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("9KPkw58x6ANtgGqMSv7Kvrk7LqVzvkcenQYWXFfnReje"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+				},
+				Data: []byte{6, 0, 0, 0, 0, 40, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtendProgram(tt.args.param); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtendProgram() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
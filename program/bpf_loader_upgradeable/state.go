@@ -0,0 +1,135 @@
+package bpf_loader_upgradeable
+
+import (
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/bytes_decoder"
+)
+
+const (
+	// BufferMetadataSize is the size of a buffer account without the program data
+	BufferMetadataSize uint64 = 37
+	// ProgramAccountSize is the size of a program account
+	ProgramAccountSize uint64 = 36
+	// ProgramDataMetadataSize is the size of a program data account without the program data
+	ProgramDataMetadataSize uint64 = 45
+)
+
+// GetProgramDataAddress derives the program data account of a program
+func GetProgramDataAddress(program common.PublicKey) (common.PublicKey, uint8) {
+	pubkey, bump, _ := common.FindProgramAddress([][]byte{program.Bytes()}, common.BPFLoaderUpgradeableProgramID)
+	return pubkey, bump
+}
+
+type AccountType uint32
+
+const (
+	AccountTypeUninitialized AccountType = iota
+	AccountTypeBuffer
+	AccountTypeProgram
+	AccountTypeProgramData
+)
+
+type BufferAccount struct {
+	Authority *common.PublicKey
+	Data      []byte
+}
+
+type ProgramAccount struct {
+	ProgramData common.PublicKey
+}
+
+type ProgramDataAccount struct {
+	// Slot is the slot the program was last deployed at
+	Slot             uint64
+	UpgradeAuthority *common.PublicKey
+	Data             []byte
+}
+
+// GetAccountType returns the state type of a loader account
+func GetAccountType(data []byte) (AccountType, error) {
+	current := 0
+	accountType, err := bytes_decoder.GetUint32(&current, data)
+	if err != nil {
+		return 0, ErrInvalidAccountDataSize
+	}
+	if AccountType(accountType) > AccountTypeProgramData {
+		return 0, ErrInvalidAccountData
+	}
+	return AccountType(accountType), nil
+}
+
+func DeserializeBufferAccount(data []byte, accountOwner common.PublicKey) (BufferAccount, error) {
+	if accountOwner != common.BPFLoaderUpgradeableProgramID {
+		return BufferAccount{}, ErrInvalidAccountOwner
+	}
+	return BufferAccountFromData(data)
+}
+
+func BufferAccountFromData(data []byte) (BufferAccount, error) {
+	if err := checkAccountType(data, AccountTypeBuffer, BufferMetadataSize); err != nil {
+		return BufferAccount{}, err
+	}
+	return BufferAccount{
+		Authority: optionPublicKey(data[4:37]),
+		Data:      data[BufferMetadataSize:],
+	}, nil
+}
+
+func DeserializeProgramAccount(data []byte, accountOwner common.PublicKey) (ProgramAccount, error) {
+	if accountOwner != common.BPFLoaderUpgradeableProgramID {
+		return ProgramAccount{}, ErrInvalidAccountOwner
+	}
+	return ProgramAccountFromData(data)
+}
+
+func ProgramAccountFromData(data []byte) (ProgramAccount, error) {
+	if err := checkAccountType(data, AccountTypeProgram, ProgramAccountSize); err != nil {
+		return ProgramAccount{}, err
+	}
+	return ProgramAccount{
+		ProgramData: common.PublicKeyFromBytes(data[4:36]),
+	}, nil
+}
+
+func DeserializeProgramDataAccount(data []byte, accountOwner common.PublicKey) (ProgramDataAccount, error) {
+	if accountOwner != common.BPFLoaderUpgradeableProgramID {
+		return ProgramDataAccount{}, ErrInvalidAccountOwner
+	}
+	return ProgramDataAccountFromData(data)
+}
+
+func ProgramDataAccountFromData(data []byte) (ProgramDataAccount, error) {
+	if err := checkAccountType(data, AccountTypeProgramData, ProgramDataMetadataSize); err != nil {
+		return ProgramDataAccount{}, err
+	}
+	current := 4
+	slot, _ := bytes_decoder.GetUint64(&current, data)
+	return ProgramDataAccount{
+		Slot:             slot,
+		UpgradeAuthority: optionPublicKey(data[12:45]),
+		Data:             data[ProgramDataMetadataSize:],
+	}, nil
+}
+
+func checkAccountType(data []byte, expected AccountType, metadataSize uint64) error {
+	accountType, err := GetAccountType(data)
+	if err != nil {
+		return err
+	}
+	if accountType != expected {
+		return ErrInvalidAccountData
+	}
+	if uint64(len(data)) < metadataSize {
+		return ErrInvalidAccountDataSize
+	}
+	return nil
+}
+
+// optionPublicKey decodes an Option<Pubkey> which always reserves the space of the pubkey
+func optionPublicKey(b []byte) *common.PublicKey {
+	if b[0] == 0 {
+		return nil
+	}
+	pubkey := common.PublicKeyFromBytes(b[1:33])
+	return &pubkey
+}
@@ -0,0 +1,174 @@
+package bpf_loader_upgradeable
+
+import (
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/pointer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProgramDataAddress(t *testing.T) {
+	programData, bump := GetProgramDataAddress(common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"))
+	assert.Equal(t, common.PublicKeyFromString("9KPkw58x6ANtgGqMSv7Kvrk7LqVzvkcenQYWXFfnReje"), programData)
+	assert.Equal(t, uint8(253), bump)
+}
+
+func TestDeserializeBufferAccount(t *testing.T) {
+	type args struct {
+		data  []byte
+		owner common.PublicKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want BufferAccount
+		err  error
+	}{
+		{
+			name: "with authority",
+			args: args{
+				data:  []byte{1, 0, 0, 0, 1, 159, 186, 247, 199, 172, 215, 195, 31, 127, 42, 207, 18, 192, 64, 156, 59, 98, 1, 180, 8, 69, 70, 199, 127, 220, 159, 6, 40, 64, 117, 246, 19, 127, 69, 76, 70},
+				owner: common.BPFLoaderUpgradeableProgramID,
+			},
+			want: BufferAccount{
+				Authority: pointer.Get(common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")),
+				Data:      []byte{127, 69, 76, 70},
+			},
+			err: nil,
+		},
+		{
+			name: "immutable",
+			args: args{
+				data:  []byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+				owner: common.BPFLoaderUpgradeableProgramID,
+			},
+			want: BufferAccount{
+				Data: []byte{},
+			},
+			err: nil,
+		},
+		{
+			name: "invalid owner",
+			args: args{
+				data:  []byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+				owner: common.SystemProgramID,
+			},
+			want: BufferAccount{},
+			err:  ErrInvalidAccountOwner,
+		},
+		{
+			name: "program account",
+			args: args{
+				data:  []byte{2, 0, 0, 0, 159, 186, 247, 199, 172, 215, 195, 31, 127, 42, 207, 18, 192, 64, 156, 59, 98, 1, 180, 8, 69, 70, 199, 127, 220, 159, 6, 40, 64, 117, 246, 19},
+				owner: common.BPFLoaderUpgradeableProgramID,
+			},
+			want: BufferAccount{},
+			err:  ErrInvalidAccountData,
+		},
+		{
+			name: "too short",
+			args: args{
+				data:  []byte{1, 0, 0, 0, 1},
+				owner: common.BPFLoaderUpgradeableProgramID,
+			},
+			want: BufferAccount{},
+			err:  ErrInvalidAccountDataSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeserializeBufferAccount(tt.args.data, tt.args.owner)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestDeserializeProgramAccount(t *testing.T) {
+	type args struct {
+		data  []byte
+		owner common.PublicKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want ProgramAccount
+		err  error
+	}{
+		{
+			args: args{
+				data:  []byte{2, 0, 0, 0, 159, 186, 247, 199, 172, 215, 195, 31, 127, 42, 207, 18, 192, 64, 156, 59, 98, 1, 180, 8, 69, 70, 199, 127, 220, 159, 6, 40, 64, 117, 246, 19},
+				owner: common.BPFLoaderUpgradeableProgramID,
+			},
+			want: ProgramAccount{
+				ProgramData: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+			},
+			err: nil,
+		},
+		{
+			args: args{
+				data:  []byte{9, 0, 0, 0},
+				owner: common.BPFLoaderUpgradeableProgramID,
+			},
+			want: ProgramAccount{},
+			err:  ErrInvalidAccountData,
+		},
+		{
+			args: args{
+				data:  []byte{2, 0},
+				owner: common.BPFLoaderUpgradeableProgramID,
+			},
+			want: ProgramAccount{},
+			err:  ErrInvalidAccountDataSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeserializeProgramAccount(tt.args.data, tt.args.owner)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestDeserializeProgramDataAccount(t *testing.T) {
+	type args struct {
+		data  []byte
+		owner common.PublicKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want ProgramDataAccount
+		err  error
+	}{
+		{
+			args: args{
+				data:  []byte{3, 0, 0, 0, 0, 15, 223, 12, 0, 0, 0, 0, 1, 159, 186, 247, 199, 172, 215, 195, 31, 127, 42, 207, 18, 192, 64, 156, 59, 98, 1, 180, 8, 69, 70, 199, 127, 220, 159, 6, 40, 64, 117, 246, 19, 127, 69, 76, 70, 0, 0},
+				owner: common.BPFLoaderUpgradeableProgramID,
+			},
+			want: ProgramDataAccount{
+				Slot:             215944960,
+				UpgradeAuthority: pointer.Get(common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")),
+				Data:             []byte{127, 69, 76, 70, 0, 0},
+			},
+			err: nil,
+		},
+		{
+			args: args{
+				data:  []byte{3, 0, 0, 0, 0, 15, 223, 12, 0, 0, 0, 0, 1},
+				owner: common.BPFLoaderUpgradeableProgramID,
+			},
+			want: ProgramDataAccount{},
+			err:  ErrInvalidAccountDataSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeserializeProgramDataAccount(tt.args.data, tt.args.owner)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package ed25519
+
+import "errors"
+
+var (
+	ErrInvalidInstructionDataSize = errors.New("invalid instruction data size")
+	ErrInvalidDataOffsets         = errors.New("invalid data offsets")
+	ErrInvalidSignature           = errors.New("invalid signature")
+	ErrInvalidPublicKey           = errors.New("invalid public key")
+)
@@ -0,0 +1,217 @@
+package ed25519
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"filippo.io/edwards25519"
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/bincode"
+	"github.com/EntySquare/solana-go-sdk/types"
+)
+
+const (
+	PublicKeySize                  = 32
+	SignatureSize                  = 64
+	SignatureOffsetsSerializedSize = 14
+	SignatureOffsetsStart          = 2
+)
+
+// CurrentInstructionIndex is the instruction index which points at the ed25519 instruction itself
+const CurrentInstructionIndex uint16 = math.MaxUint16
+
+type SignatureOffsets struct {
+	SignatureOffset           uint16
+	SignatureInstructionIndex uint16
+	PublicKeyOffset           uint16
+	PublicKeyInstructionIndex uint16
+	MessageDataOffset         uint16
+	MessageDataSize           uint16
+	MessageInstructionIndex   uint16
+}
+
+// DataStart returns where the inline data starts in an instruction with n signatures
+func DataStart(n int) int {
+	return SignatureOffsetsStart + n*SignatureOffsetsSerializedSize
+}
+
+type SignedMessage struct {
+	PublicKey common.PublicKey
+	Signature []byte
+	Message   []byte
+}
+
+type NewVerifyInstructionParam struct {
+	SignedMessages []SignedMessage
+}
+
+// NewVerifyInstruction builds an instruction which carries all public keys, signatures and messages inline
+func NewVerifyInstruction(param NewVerifyInstructionParam) (types.Instruction, error) {
+	n := len(param.SignedMessages)
+	if n > math.MaxUint8 {
+		return types.Instruction{}, fmt.Errorf("%w, too many signatures: %v", ErrInvalidInstructionDataSize, n)
+	}
+
+	offsets := make([]SignatureOffsets, 0, n)
+	inlineData := []byte{}
+	for i, signedMessage := range param.SignedMessages {
+		if len(signedMessage.Signature) != SignatureSize {
+			return types.Instruction{}, fmt.Errorf("%w, index: %v, signature size: %v", ErrInvalidSignature, i, len(signedMessage.Signature))
+		}
+		publicKeyOffset := DataStart(n) + len(inlineData)
+		inlineData = append(inlineData, signedMessage.PublicKey.Bytes()...)
+		signatureOffset := DataStart(n) + len(inlineData)
+		inlineData = append(inlineData, signedMessage.Signature...)
+		messageDataOffset := DataStart(n) + len(inlineData)
+		inlineData = append(inlineData, signedMessage.Message...)
+		if DataStart(n)+len(inlineData) > math.MaxUint16 {
+			return types.Instruction{}, fmt.Errorf("%w, data is too large", ErrInvalidInstructionDataSize)
+		}
+
+		offsets = append(offsets, SignatureOffsets{
+			SignatureOffset:           uint16(signatureOffset),
+			SignatureInstructionIndex: CurrentInstructionIndex,
+			PublicKeyOffset:           uint16(publicKeyOffset),
+			PublicKeyInstructionIndex: CurrentInstructionIndex,
+			MessageDataOffset:         uint16(messageDataOffset),
+			MessageDataSize:           uint16(len(signedMessage.Message)),
+			MessageInstructionIndex:   CurrentInstructionIndex,
+		})
+	}
+
+	return NewVerifyInstructionWithOffsets(NewVerifyInstructionWithOffsetsParam{
+		Offsets:    offsets,
+		InlineData: inlineData,
+	}), nil
+}
+
+type NewVerifyInstructionWithOffsetsParam struct {
+	// Offsets can point into other instructions of the transaction
+	// or into InlineData with CurrentInstructionIndex
+	Offsets []SignatureOffsets
+	// InlineData starts at DataStart(len(Offsets)) of the instruction data
+	InlineData []byte
+}
+
+// NewVerifyInstructionWithOffsets builds an instruction from raw offsets
+func NewVerifyInstructionWithOffsets(param NewVerifyInstructionWithOffsetsParam) types.Instruction {
+	data := make([]byte, 0, DataStart(len(param.Offsets))+len(param.InlineData))
+	data = append(data, uint8(len(param.Offsets)), 0)
+	for _, offsets := range param.Offsets {
+		b, err := bincode.SerializeData(offsets)
+		if err != nil {
+			panic(err)
+		}
+		data = append(data, b...)
+	}
+	data = append(data, param.InlineData...)
+
+	return types.Instruction{
+		ProgramID: common.Ed25519ProgramID,
+		Accounts:  []types.AccountMeta{},
+		Data:      data,
+	}
+}
+
+// ParseSignatureOffsets decodes the offsets of ed25519 instruction data
+func ParseSignatureOffsets(data []byte) ([]SignatureOffsets, error) {
+	if len(data) < SignatureOffsetsStart {
+		return nil, ErrInvalidInstructionDataSize
+	}
+	n := int(data[0])
+	if n == 0 && len(data) > SignatureOffsetsStart {
+		return nil, ErrInvalidInstructionDataSize
+	}
+	if len(data) < DataStart(n) {
+		return nil, ErrInvalidInstructionDataSize
+	}
+
+	offsets := make([]SignatureOffsets, 0, n)
+	for i := 0; i < n; i++ {
+		b := data[SignatureOffsetsStart+i*SignatureOffsetsSerializedSize:]
+		offsets = append(offsets, SignatureOffsets{
+			SignatureOffset:           binary.LittleEndian.Uint16(b[0:2]),
+			SignatureInstructionIndex: binary.LittleEndian.Uint16(b[2:4]),
+			PublicKeyOffset:           binary.LittleEndian.Uint16(b[4:6]),
+			PublicKeyInstructionIndex: binary.LittleEndian.Uint16(b[6:8]),
+			MessageDataOffset:         binary.LittleEndian.Uint16(b[8:10]),
+			MessageDataSize:           binary.LittleEndian.Uint16(b[10:12]),
+			MessageInstructionIndex:   binary.LittleEndian.Uint16(b[12:14]),
+		})
+	}
+	return offsets, nil
+}
+
+// Verify checks ed25519 instruction data the same way the runtime does.
+// instructionDatas are the data of all instructions in the transaction.
+func Verify(data []byte, instructionDatas [][]byte) error {
+	offsets, err := ParseSignatureOffsets(data)
+	if err != nil {
+		return err
+	}
+	for i, o := range offsets {
+		signature, err := getDataSlice(data, instructionDatas, o.SignatureInstructionIndex, o.SignatureOffset, SignatureSize)
+		if err != nil {
+			return fmt.Errorf("%w, index: %v, signature", err, i)
+		}
+		publicKey, err := getDataSlice(data, instructionDatas, o.PublicKeyInstructionIndex, o.PublicKeyOffset, PublicKeySize)
+		if err != nil {
+			return fmt.Errorf("%w, index: %v, public key", err, i)
+		}
+		message, err := getDataSlice(data, instructionDatas, o.MessageInstructionIndex, o.MessageDataOffset, int(o.MessageDataSize))
+		if err != nil {
+			return fmt.Errorf("%w, index: %v, message", err, i)
+		}
+
+		if isSmallOrder(publicKey) {
+			return fmt.Errorf("%w, index: %v", ErrInvalidPublicKey, i)
+		}
+		if isSmallOrder(signature[:32]) || !ed25519.Verify(publicKey, message, signature) {
+			return fmt.Errorf("%w, index: %v", ErrInvalidSignature, i)
+		}
+	}
+	return nil
+}
+
+// VerifyMessage runs Verify on every ed25519 instruction of the message
+func VerifyMessage(message types.Message) error {
+	instructionDatas := make([][]byte, 0, len(message.Instructions))
+	for _, instruction := range message.Instructions {
+		instructionDatas = append(instructionDatas, instruction.Data)
+	}
+	for i, instruction := range message.Instructions {
+		if instruction.ProgramIDIndex >= len(message.Accounts) || message.Accounts[instruction.ProgramIDIndex] != common.Ed25519ProgramID {
+			continue
+		}
+		if err := Verify(instruction.Data, instructionDatas); err != nil {
+			return fmt.Errorf("instruction %v: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func getDataSlice(data []byte, instructionDatas [][]byte, instructionIndex uint16, offset uint16, size int) ([]byte, error) {
+	instruction := data
+	if instructionIndex != CurrentInstructionIndex {
+		if int(instructionIndex) >= len(instructionDatas) {
+			return nil, ErrInvalidDataOffsets
+		}
+		instruction = instructionDatas[instructionIndex]
+	}
+	start := int(offset)
+	if start+size > len(instruction) {
+		return nil, ErrInvalidDataOffsets
+	}
+	return instruction[start : start+size], nil
+}
+
+// isSmallOrder reports whether the encoded point is invalid or has a small order, which strict verification rejects
+func isSmallOrder(b []byte) bool {
+	p, err := new(edwards25519.Point).SetBytes(b)
+	if err != nil {
+		return true
+	}
+	return new(edwards25519.Point).MultByCofactor(p).Equal(edwards25519.NewIdentityPoint()) == 1
+}
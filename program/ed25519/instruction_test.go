@@ -0,0 +1,236 @@
+package ed25519
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testAccount, _ = types.AccountFromSeed(bytes.Repeat([]byte{1}, 32))
+
+func TestNewVerifyInstruction(t *testing.T) {
+	signature := testAccount.Sign([]byte("hello"))
+
+	got, err := NewVerifyInstruction(NewVerifyInstructionParam{
+		SignedMessages: []SignedMessage{
+			{PublicKey: testAccount.PublicKey, Signature: signature, Message: []byte("hello")},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, types.Instruction{
+		ProgramID: common.Ed25519ProgramID,
+		Accounts:  []types.AccountMeta{},
+		Data: bytes.Join([][]byte{
+			{1, 0},
+			{48, 0, 255, 255, 16, 0, 255, 255, 112, 0, 5, 0, 255, 255},
+			testAccount.PublicKey.Bytes(),
+			signature,
+			[]byte("hello"),
+		}, nil),
+	}, got)
+
+	_, err = NewVerifyInstruction(NewVerifyInstructionParam{
+		SignedMessages: []SignedMessage{
+			{PublicKey: testAccount.PublicKey, Signature: signature[:63], Message: []byte("hello")},
+		},
+	})
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestParseSignatureOffsets(t *testing.T) {
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		want []SignatureOffsets
+		err  error
+	}{
+		{
+			name: "empty",
+			args: args{
+				data: []byte{0},
+			},
+			want: nil,
+			err:  ErrInvalidInstructionDataSize,
+		},
+		{
+			name: "no signature",
+			args: args{
+				data: []byte{0, 0},
+			},
+			want: []SignatureOffsets{},
+			err:  nil,
+		},
+		{
+			name: "no signature with data",
+			args: args{
+				data: []byte{0, 0, 1},
+			},
+			want: nil,
+			err:  ErrInvalidInstructionDataSize,
+		},
+		{
+			name: "truncated offsets",
+			args: args{
+				data: []byte{1, 0, 48, 0, 255, 255, 16, 0, 255, 255, 112, 0, 5, 0, 255},
+			},
+			want: nil,
+			err:  ErrInvalidInstructionDataSize,
+		},
+		{
+			name: "one signature",
+			args: args{
+				data: []byte{1, 0, 48, 0, 255, 255, 16, 0, 1, 0, 112, 0, 5, 0, 2, 0},
+			},
+			want: []SignatureOffsets{
+				{
+					SignatureOffset:           48,
+					SignatureInstructionIndex: CurrentInstructionIndex,
+					PublicKeyOffset:           16,
+					PublicKeyInstructionIndex: 1,
+					MessageDataOffset:         112,
+					MessageDataSize:           5,
+					MessageInstructionIndex:   2,
+				},
+			},
+			err: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSignatureOffsets(tt.args.data)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	signature := testAccount.Sign([]byte("hello"))
+	inline, err := NewVerifyInstruction(NewVerifyInstructionParam{
+		SignedMessages: []SignedMessage{
+			{PublicKey: testAccount.PublicKey, Signature: signature, Message: []byte("hello")},
+			{PublicKey: testAccount.PublicKey, Signature: testAccount.Sign([]byte("world")), Message: []byte("world")},
+		},
+	})
+	assert.Nil(t, err)
+
+	tampered, _ := NewVerifyInstruction(NewVerifyInstructionParam{
+		SignedMessages: []SignedMessage{
+			{PublicKey: testAccount.PublicKey, Signature: signature, Message: []byte("hellO")},
+		},
+	})
+
+	smallOrderPublicKey := common.PublicKeyFromBytes(append([]byte{1}, make([]byte, 31)...))
+	smallOrder, _ := NewVerifyInstruction(NewVerifyInstructionParam{
+		SignedMessages: []SignedMessage{
+			{PublicKey: smallOrderPublicKey, Signature: signature, Message: []byte("hello")},
+		},
+	})
+
+	// the message lives in instruction 0, public key and signature are inline
+	crossInstruction := func(messageInstructionIndex, messageDataSize uint16) []byte {
+		return NewVerifyInstructionWithOffsets(NewVerifyInstructionWithOffsetsParam{
+			Offsets: []SignatureOffsets{
+				{
+					SignatureOffset:           uint16(DataStart(1) + PublicKeySize),
+					SignatureInstructionIndex: CurrentInstructionIndex,
+					PublicKeyOffset:           uint16(DataStart(1)),
+					PublicKeyInstructionIndex: CurrentInstructionIndex,
+					MessageDataOffset:         2,
+					MessageDataSize:           messageDataSize,
+					MessageInstructionIndex:   messageInstructionIndex,
+				},
+			},
+			InlineData: append(testAccount.PublicKey.Bytes(), signature...),
+		}).Data
+	}
+
+	type args struct {
+		data             []byte
+		instructionDatas [][]byte
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "inline",
+			args: args{data: inline.Data},
+			err:  nil,
+		},
+		{
+			name: "tampered message",
+			args: args{data: tampered.Data},
+			err:  ErrInvalidSignature,
+		},
+		{
+			name: "small order public key",
+			args: args{data: smallOrder.Data},
+			err:  ErrInvalidPublicKey,
+		},
+		{
+			name: "cross instruction",
+			args: args{
+				data:             crossInstruction(0, 5),
+				instructionDatas: [][]byte{[]byte("..hello"), nil},
+			},
+			err: nil,
+		},
+		{
+			name: "message out of range",
+			args: args{
+				data:             crossInstruction(0, 6),
+				instructionDatas: [][]byte{[]byte("..hello"), nil},
+			},
+			err: ErrInvalidDataOffsets,
+		},
+		{
+			name: "instruction index out of range",
+			args: args{
+				data:             crossInstruction(2, 5),
+				instructionDatas: [][]byte{[]byte("..hello"), nil},
+			},
+			err: ErrInvalidDataOffsets,
+		},
+		{
+			name: "truncated",
+			args: args{data: inline.Data[:len(inline.Data)-1]},
+			err:  ErrInvalidDataOffsets,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.args.data, tt.args.instructionDatas)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestVerifyMessage(t *testing.T) {
+	newMessage := func(message []byte) types.Message {
+		instruction, err := NewVerifyInstruction(NewVerifyInstructionParam{
+			SignedMessages: []SignedMessage{
+				{PublicKey: testAccount.PublicKey, Signature: testAccount.Sign([]byte("hello")), Message: message},
+			},
+		})
+		assert.Nil(t, err)
+		return types.NewMessage(types.NewMessageParam{
+			FeePayer: testAccount.PublicKey,
+			Instructions: []types.Instruction{
+				{ProgramID: common.MemoProgramID, Accounts: []types.AccountMeta{}, Data: []byte("memo")},
+				instruction,
+			},
+			RecentBlockhash: common.PublicKey{}.ToBase58(),
+		})
+	}
+
+	assert.Nil(t, VerifyMessage(newMessage([]byte("hello"))))
+	assert.ErrorIs(t, VerifyMessage(newMessage([]byte("world"))), ErrInvalidSignature)
+}
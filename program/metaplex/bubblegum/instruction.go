@@ -0,0 +1,579 @@
+package bubblegum
+
+import (
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/near/borsh-go"
+)
+
+// Instruction is the anchor discriminator, the first 8 bytes of sha256("global:<instruction name>")
+type Instruction [8]uint8
+
+var (
+	InstructionCreateTree         = Instruction{165, 83, 136, 142, 89, 202, 47, 220}
+	InstructionMintV1             = Instruction{145, 98, 192, 118, 184, 147, 118, 104}
+	InstructionMintToCollectionV1 = Instruction{153, 18, 178, 47, 197, 158, 86, 15}
+	InstructionTransfer           = Instruction{163, 52, 200, 231, 140, 3, 69, 186}
+	InstructionBurn               = Instruction{116, 110, 29, 56, 107, 219, 42, 93}
+	InstructionDelegate           = Instruction{90, 147, 75, 178, 85, 88, 4, 137}
+	InstructionRedeem             = Instruction{184, 12, 86, 149, 70, 196, 97, 225}
+)
+
+type CreateTreeParam struct {
+	// MerkleTree has to be allocated and owned by the account compression program,
+	// see account_compression.GetConcurrentMerkleTreeAccountSize
+	MerkleTree    common.PublicKey
+	Payer         common.PublicKey
+	TreeCreator   common.PublicKey
+	MaxDepth      uint32
+	MaxBufferSize uint32
+	// Public allows anyone to mint into the tree
+	Public *bool
+}
+
+// CreateTree initializes a merkle tree and its tree config
+func CreateTree(param CreateTreeParam) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction   Instruction
+		MaxDepth      uint32
+		MaxBufferSize uint32
+		Public        *bool
+	}{
+		Instruction:   InstructionCreateTree,
+		MaxDepth:      param.MaxDepth,
+		MaxBufferSize: param.MaxBufferSize,
+		Public:        param.Public,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.MetaplexBubblegumProgramID,
+		Accounts: []types.AccountMeta{
+			{
+				PubKey:     treeAuthority(param.MerkleTree),
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.MerkleTree,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.Payer,
+				IsSigner:   true,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.TreeCreator,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SPLNoopProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SPLAccountCompressionProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		},
+		Data: data,
+	}
+}
+
+type MintV1Param struct {
+	MerkleTree   common.PublicKey
+	LeafOwner    common.PublicKey
+	LeafDelegate common.PublicKey
+	Payer        common.PublicKey
+	// TreeDelegate is the tree creator, the tree delegate or anyone if the tree is public
+	TreeDelegate common.PublicKey
+	Metadata     MetadataArgs
+}
+
+// MintV1 appends a compressed nft to the tree
+func MintV1(param MintV1Param) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction Instruction
+		Metadata    MetadataArgs
+	}{
+		Instruction: InstructionMintV1,
+		Metadata:    param.Metadata,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.MetaplexBubblegumProgramID,
+		Accounts: []types.AccountMeta{
+			{
+				PubKey:     treeAuthority(param.MerkleTree),
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.LeafOwner,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.LeafDelegate,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.MerkleTree,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.Payer,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.TreeDelegate,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SPLNoopProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SPLAccountCompressionProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		},
+		Data: data,
+	}
+}
+
+type MintToCollectionV1Param struct {
+	MerkleTree          common.PublicKey
+	LeafOwner           common.PublicKey
+	LeafDelegate        common.PublicKey
+	Payer               common.PublicKey
+	TreeDelegate        common.PublicKey
+	CollectionAuthority common.PublicKey
+	// CollectionAuthorityRecord is required if the collection authority is a delegated one
+	CollectionAuthorityRecord *common.PublicKey
+	CollectionMint            common.PublicKey
+	CollectionMetadata        common.PublicKey
+	CollectionMasterEdition   common.PublicKey
+	// Metadata.Collection has to be the collection mint, it is verified by the instruction
+	Metadata MetadataArgs
+}
+
+// MintToCollectionV1 appends a compressed nft to the tree and verifies its collection
+func MintToCollectionV1(param MintToCollectionV1Param) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction Instruction
+		Metadata    MetadataArgs
+	}{
+		Instruction: InstructionMintToCollectionV1,
+		Metadata:    param.Metadata,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	collectionAuthorityRecord := common.MetaplexBubblegumProgramID
+	if param.CollectionAuthorityRecord != nil {
+		collectionAuthorityRecord = *param.CollectionAuthorityRecord
+	}
+	bubblegumSigner, err := GetBubblegumSigner()
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.MetaplexBubblegumProgramID,
+		Accounts: []types.AccountMeta{
+			{
+				PubKey:     treeAuthority(param.MerkleTree),
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.LeafOwner,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.LeafDelegate,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.MerkleTree,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.Payer,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.TreeDelegate,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.CollectionAuthority,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			{
+				PubKey:     collectionAuthorityRecord,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.CollectionMint,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.CollectionMetadata,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.CollectionMasterEdition,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     bubblegumSigner,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SPLNoopProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SPLAccountCompressionProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.MetaplexTokenMetaProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		},
+		Data: data,
+	}
+}
+
+// LeafArgs locates a leaf and proves its content, the tree replaces the leaf with a new one
+type LeafArgs struct {
+	// Root is the root the proof is built against, it can be a recent one in the change log
+	Root        [32]byte
+	DataHash    [32]byte
+	CreatorHash [32]byte
+	Nonce       uint64
+	Index       uint32
+	// Proof is the proof of the leaf without the nodes the canopy caches,
+	// see account_compression.ConcurrentMerkleTreeAccount.TruncateProof
+	Proof [][32]byte
+}
+
+type TransferParam struct {
+	MerkleTree   common.PublicKey
+	LeafOwner    common.PublicKey
+	LeafDelegate common.PublicKey
+	NewLeafOwner common.PublicKey
+	// Authority is the leaf owner or the leaf delegate
+	Authority common.PublicKey
+	LeafArgs
+}
+
+// Transfer changes the owner of a compressed nft, the delegate is cleared
+func Transfer(param TransferParam) types.Instruction {
+	return types.Instruction{
+		ProgramID: common.MetaplexBubblegumProgramID,
+		Accounts: append([]types.AccountMeta{
+			{
+				PubKey:     treeAuthority(param.MerkleTree),
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.LeafOwner,
+				IsSigner:   param.LeafOwner == param.Authority,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.LeafDelegate,
+				IsSigner:   param.LeafDelegate == param.Authority,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.NewLeafOwner,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.MerkleTree,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     common.SPLNoopProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SPLAccountCompressionProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		}, proofAccountMetas(param.Proof)...),
+		Data: leafInstructionData(InstructionTransfer, param.LeafArgs),
+	}
+}
+
+type BurnParam struct {
+	MerkleTree   common.PublicKey
+	LeafOwner    common.PublicKey
+	LeafDelegate common.PublicKey
+	// Authority is the leaf owner or the leaf delegate
+	Authority common.PublicKey
+	LeafArgs
+}
+
+// Burn replaces the leaf with an empty node
+func Burn(param BurnParam) types.Instruction {
+	return types.Instruction{
+		ProgramID: common.MetaplexBubblegumProgramID,
+		Accounts: append([]types.AccountMeta{
+			{
+				PubKey:     treeAuthority(param.MerkleTree),
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.LeafOwner,
+				IsSigner:   param.LeafOwner == param.Authority,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.LeafDelegate,
+				IsSigner:   param.LeafDelegate == param.Authority,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.MerkleTree,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     common.SPLNoopProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SPLAccountCompressionProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		}, proofAccountMetas(param.Proof)...),
+		Data: leafInstructionData(InstructionBurn, param.LeafArgs),
+	}
+}
+
+type DelegateParam struct {
+	MerkleTree           common.PublicKey
+	LeafOwner            common.PublicKey
+	PreviousLeafDelegate common.PublicKey
+	NewLeafDelegate      common.PublicKey
+	LeafArgs
+}
+
+// Delegate sets the delegate of a compressed nft, only the owner can do it
+func Delegate(param DelegateParam) types.Instruction {
+	return types.Instruction{
+		ProgramID: common.MetaplexBubblegumProgramID,
+		Accounts: append([]types.AccountMeta{
+			{
+				PubKey:     treeAuthority(param.MerkleTree),
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.LeafOwner,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.PreviousLeafDelegate,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.NewLeafDelegate,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.MerkleTree,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     common.SPLNoopProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SPLAccountCompressionProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		}, proofAccountMetas(param.Proof)...),
+		Data: leafInstructionData(InstructionDelegate, param.LeafArgs),
+	}
+}
+
+type RedeemParam struct {
+	MerkleTree   common.PublicKey
+	LeafOwner    common.PublicKey
+	LeafDelegate common.PublicKey
+	LeafArgs
+}
+
+// Redeem removes the leaf from the tree and stores it in a voucher, the voucher can be decompressed to a token
+func Redeem(param RedeemParam) types.Instruction {
+	voucher, err := GetVoucher(param.MerkleTree, param.Nonce)
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.MetaplexBubblegumProgramID,
+		Accounts: append([]types.AccountMeta{
+			{
+				PubKey:     treeAuthority(param.MerkleTree),
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.LeafOwner,
+				IsSigner:   true,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.LeafDelegate,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.MerkleTree,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     voucher,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     common.SPLNoopProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SPLAccountCompressionProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		}, proofAccountMetas(param.Proof)...),
+		Data: leafInstructionData(InstructionRedeem, param.LeafArgs),
+	}
+}
+
+func leafInstructionData(instruction Instruction, args LeafArgs) []byte {
+	data, err := borsh.Serialize(struct {
+		Instruction Instruction
+		Root        [32]uint8
+		DataHash    [32]uint8
+		CreatorHash [32]uint8
+		Nonce       uint64
+		Index       uint32
+	}{
+		Instruction: instruction,
+		Root:        args.Root,
+		DataHash:    args.DataHash,
+		CreatorHash: args.CreatorHash,
+		Nonce:       args.Nonce,
+		Index:       args.Index,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func proofAccountMetas(proof [][32]byte) []types.AccountMeta {
+	accounts := make([]types.AccountMeta, 0, len(proof))
+	for _, node := range proof {
+		accounts = append(accounts, types.AccountMeta{PubKey: common.PublicKey(node), IsSigner: false, IsWritable: false})
+	}
+	return accounts
+}
+
+func treeAuthority(merkleTree common.PublicKey) common.PublicKey {
+	pubkey, err := GetTreeAuthority(merkleTree)
+	if err != nil {
+		panic(err)
+	}
+	return pubkey
+}
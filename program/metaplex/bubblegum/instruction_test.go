@@ -0,0 +1,416 @@
+package bubblegum
+
+import (
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/pointer"
+	"github.com/EntySquare/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTree(t *testing.T) {
+	type args struct {
+		param CreateTreeParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "",
+			args: args{
+				param: CreateTreeParam{
+					MerkleTree:    common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"),
+					Payer:         common.PublicKeyFromString("payer11111111111111111111111111111111111111"),
+					TreeCreator:   common.PublicKeyFromString("treeCreator11111111111111111111111111111111"),
+					MaxDepth:      14,
+					MaxBufferSize: 64,
+					Public:        pointer.Get(false),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexBubblegumProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("J2FfeU3aoJXPupYDoSF2oa88nHYTNZBKapo93T6smSbY"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("payer11111111111111111111111111111111111111"), IsSigner: true, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("treeCreator11111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.SPLNoopProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SPLAccountCompressionProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{165, 83, 136, 142, 89, 202, 47, 220, 14, 0, 0, 0, 64, 0, 0, 0, 1, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CreateTree(tt.args.param))
+		})
+	}
+}
+
+func TestMintV1(t *testing.T) {
+	type args struct {
+		param MintV1Param
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "",
+			args: args{
+				param: MintV1Param{
+					MerkleTree:   common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"),
+					LeafOwner:    common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					LeafDelegate: common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					Payer:        common.PublicKeyFromString("payer11111111111111111111111111111111111111"),
+					TreeDelegate: common.PublicKeyFromString("treeDe1egate1111111111111111111111111111111"),
+					Metadata: MetadataArgs{
+						Name:                 "cNFT",
+						Symbol:               "C",
+						Uri:                  "https://example.com/c.json",
+						SellerFeeBasisPoints: 500,
+						IsMutable:            true,
+						TokenStandard:        pointer.Get(token_metadata.NonFungible),
+						Collection:           &token_metadata.Collection{Key: common.PublicKeyFromString("co11ection111111111111111111111111111111111")},
+						Creators:             []token_metadata.Creator{{Address: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), Verified: true, Share: 100}},
+					},
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexBubblegumProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("J2FfeU3aoJXPupYDoSF2oa88nHYTNZBKapo93T6smSbY"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("payer11111111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("treeDe1egate1111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.SPLNoopProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SPLAccountCompressionProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{145, 98, 192, 118, 184, 147, 118, 104, 4, 0, 0, 0, 99, 78, 70, 84, 1, 0, 0, 0, 67, 26, 0, 0, 0, 104, 116, 116, 112, 115, 58, 47, 47, 101, 120, 97, 109, 112, 108, 101, 46, 99, 111, 109, 47, 99, 46, 106, 115, 111, 110, 244, 1, 0, 1, 0, 1, 0, 1, 0, 9, 43, 87, 69, 81, 72, 180, 164, 241, 142, 203, 246, 1, 57, 233, 100, 231, 234, 41, 227, 97, 230, 238, 246, 238, 142, 58, 98, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 206, 211, 135, 230, 195, 111, 87, 254, 147, 239, 143, 81, 110, 159, 49, 140, 109, 137, 224, 197, 24, 49, 223, 61, 123, 8, 78, 109, 110, 136, 228, 240, 1, 100},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MintV1(tt.args.param))
+		})
+	}
+}
+
+func TestMintToCollectionV1(t *testing.T) {
+	type args struct {
+		param MintToCollectionV1Param
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "",
+			args: args{
+				param: MintToCollectionV1Param{
+					MerkleTree:              common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"),
+					LeafOwner:               common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					LeafDelegate:            common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					Payer:                   common.PublicKeyFromString("payer11111111111111111111111111111111111111"),
+					TreeDelegate:            common.PublicKeyFromString("treeDe1egate1111111111111111111111111111111"),
+					CollectionAuthority:     common.PublicKeyFromString("co11ectionAuthority111111111111111111111111"),
+					CollectionMint:          common.PublicKeyFromString("co11ection111111111111111111111111111111111"),
+					CollectionMetadata:      common.PublicKeyFromString("co11ectionMetadata1111111111111111111111111"),
+					CollectionMasterEdition: common.PublicKeyFromString("co11ectionEdition11111111111111111111111111"),
+					Metadata: MetadataArgs{
+						Name:                 "cNFT",
+						Symbol:               "C",
+						Uri:                  "https://example.com/c.json",
+						SellerFeeBasisPoints: 500,
+						IsMutable:            true,
+						TokenStandard:        pointer.Get(token_metadata.NonFungible),
+						Collection:           &token_metadata.Collection{Key: common.PublicKeyFromString("co11ection111111111111111111111111111111111")},
+						Creators:             []token_metadata.Creator{{Address: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), Verified: true, Share: 100}},
+					},
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexBubblegumProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("J2FfeU3aoJXPupYDoSF2oa88nHYTNZBKapo93T6smSbY"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("payer11111111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("treeDe1egate1111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("co11ectionAuthority111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.MetaplexBubblegumProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("co11ection111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("co11ectionMetadata1111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("co11ectionEdition11111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("4ewWZC5gT6TGpm5LZNDs9wVonfUT2q5PP5sc9kVbwMAK"), IsSigner: false, IsWritable: false},
+					{PubKey: common.SPLNoopProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SPLAccountCompressionProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{153, 18, 178, 47, 197, 158, 86, 15, 4, 0, 0, 0, 99, 78, 70, 84, 1, 0, 0, 0, 67, 26, 0, 0, 0, 104, 116, 116, 112, 115, 58, 47, 47, 101, 120, 97, 109, 112, 108, 101, 46, 99, 111, 109, 47, 99, 46, 106, 115, 111, 110, 244, 1, 0, 1, 0, 1, 0, 1, 0, 9, 43, 87, 69, 81, 72, 180, 164, 241, 142, 203, 246, 1, 57, 233, 100, 231, 234, 41, 227, 97, 230, 238, 246, 238, 142, 58, 98, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 206, 211, 135, 230, 195, 111, 87, 254, 147, 239, 143, 81, 110, 159, 49, 140, 109, 137, 224, 197, 24, 49, 223, 61, 123, 8, 78, 109, 110, 136, 228, 240, 1, 100},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MintToCollectionV1(tt.args.param))
+		})
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	type args struct {
+		param TransferParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "owner signs",
+			args: args{
+				param: TransferParam{
+					MerkleTree:   common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"),
+					LeafOwner:    common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					LeafDelegate: common.PublicKeyFromString("de1egate11111111111111111111111111111111111"),
+					NewLeafOwner: common.PublicKeyFromString("newowner11111111111111111111111111111111111"),
+					Authority:    common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					LeafArgs: LeafArgs{
+						Root:        [32]byte{1},
+						DataHash:    [32]byte{2},
+						CreatorHash: [32]byte{3},
+						Nonce:       3,
+						Index:       3,
+						Proof:       [][32]byte{common.PublicKeyFromString("proof11111111111111111111111111111111111111"), common.PublicKeyFromString("proof21111111111111111111111111111111111111")},
+					},
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexBubblegumProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("J2FfeU3aoJXPupYDoSF2oa88nHYTNZBKapo93T6smSbY"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("de1egate11111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("newowner11111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.SPLNoopProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SPLAccountCompressionProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("proof11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("proof21111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{163, 52, 200, 231, 140, 3, 69, 186, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0},
+			},
+		},
+		{
+			name: "delegate signs",
+			args: args{
+				param: TransferParam{
+					MerkleTree:   common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"),
+					LeafOwner:    common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					LeafDelegate: common.PublicKeyFromString("de1egate11111111111111111111111111111111111"),
+					NewLeafOwner: common.PublicKeyFromString("newowner11111111111111111111111111111111111"),
+					Authority:    common.PublicKeyFromString("de1egate11111111111111111111111111111111111"),
+					LeafArgs: LeafArgs{
+						Root:        [32]byte{1},
+						DataHash:    [32]byte{2},
+						CreatorHash: [32]byte{3},
+						Nonce:       3,
+						Index:       3,
+						Proof:       [][32]byte{common.PublicKeyFromString("proof11111111111111111111111111111111111111"), common.PublicKeyFromString("proof21111111111111111111111111111111111111")},
+					},
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexBubblegumProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("J2FfeU3aoJXPupYDoSF2oa88nHYTNZBKapo93T6smSbY"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("de1egate11111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("newowner11111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.SPLNoopProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SPLAccountCompressionProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("proof11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("proof21111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{163, 52, 200, 231, 140, 3, 69, 186, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Transfer(tt.args.param))
+		})
+	}
+}
+
+func TestBurn(t *testing.T) {
+	type args struct {
+		param BurnParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "",
+			args: args{
+				param: BurnParam{
+					MerkleTree:   common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"),
+					LeafOwner:    common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					LeafDelegate: common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					Authority:    common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					LeafArgs: LeafArgs{
+						Root:        [32]byte{1},
+						DataHash:    [32]byte{2},
+						CreatorHash: [32]byte{3},
+						Nonce:       3,
+						Index:       3,
+						Proof:       [][32]byte{common.PublicKeyFromString("proof11111111111111111111111111111111111111"), common.PublicKeyFromString("proof21111111111111111111111111111111111111")},
+					},
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexBubblegumProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("J2FfeU3aoJXPupYDoSF2oa88nHYTNZBKapo93T6smSbY"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.SPLNoopProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SPLAccountCompressionProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("proof11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("proof21111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{116, 110, 29, 56, 107, 219, 42, 93, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Burn(tt.args.param))
+		})
+	}
+}
+
+func TestDelegate(t *testing.T) {
+	type args struct {
+		param DelegateParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "",
+			args: args{
+				param: DelegateParam{
+					MerkleTree:           common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"),
+					LeafOwner:            common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					PreviousLeafDelegate: common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					NewLeafDelegate:      common.PublicKeyFromString("de1egate11111111111111111111111111111111111"),
+					LeafArgs: LeafArgs{
+						Root:        [32]byte{1},
+						DataHash:    [32]byte{2},
+						CreatorHash: [32]byte{3},
+						Nonce:       3,
+						Index:       3,
+						Proof:       [][32]byte{common.PublicKeyFromString("proof11111111111111111111111111111111111111"), common.PublicKeyFromString("proof21111111111111111111111111111111111111")},
+					},
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexBubblegumProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("J2FfeU3aoJXPupYDoSF2oa88nHYTNZBKapo93T6smSbY"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("de1egate11111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.SPLNoopProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SPLAccountCompressionProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("proof11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("proof21111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{90, 147, 75, 178, 85, 88, 4, 137, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Delegate(tt.args.param))
+		})
+	}
+}
+
+func TestRedeem(t *testing.T) {
+	type args struct {
+		param RedeemParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "",
+			args: args{
+				param: RedeemParam{
+					MerkleTree:   common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"),
+					LeafOwner:    common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					LeafDelegate: common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					LeafArgs: LeafArgs{
+						Root:        [32]byte{1},
+						DataHash:    [32]byte{2},
+						CreatorHash: [32]byte{3},
+						Nonce:       3,
+						Index:       3,
+						Proof:       [][32]byte{common.PublicKeyFromString("proof11111111111111111111111111111111111111"), common.PublicKeyFromString("proof21111111111111111111111111111111111111")},
+					},
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexBubblegumProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("J2FfeU3aoJXPupYDoSF2oa88nHYTNZBKapo93T6smSbY"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: true, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("9yDrdNc9aWv2WbTqn8xUm61BVv4V77xB6E6RWhddwHRs"), IsSigner: false, IsWritable: true},
+					{PubKey: common.SPLNoopProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SPLAccountCompressionProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("proof11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("proof21111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{184, 12, 86, 149, 70, 196, 97, 225, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Redeem(tt.args.param))
+		})
+	}
+}
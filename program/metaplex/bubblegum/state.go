@@ -0,0 +1,100 @@
+package bubblegum
+
+import (
+	"encoding/binary"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/near/borsh-go"
+	"golang.org/x/crypto/sha3"
+)
+
+type TokenProgramVersion borsh.Enum
+
+const (
+	TokenProgramVersionOriginal TokenProgramVersion = iota
+	TokenProgramVersionToken2022
+)
+
+// MetadataArgs is the metadata of a compressed nft, only its hash is stored in the leaf
+type MetadataArgs struct {
+	Name                 string
+	Symbol               string
+	Uri                  string
+	SellerFeeBasisPoints uint16
+	PrimarySaleHappened  bool
+	IsMutable            bool
+	EditionNonce         *uint8
+	TokenStandard        *token_metadata.TokenStandard
+	Collection           *token_metadata.Collection
+	Uses                 *token_metadata.Uses
+	TokenProgramVersion  TokenProgramVersion
+	Creators             []token_metadata.Creator
+}
+
+type LeafSchemaVersion uint8
+
+const (
+	LeafSchemaVersionV1 LeafSchemaVersion = 1
+)
+
+// LeafSchema is the content of a leaf, its hash is what the tree stores
+type LeafSchema struct {
+	// ID is the asset id, see GetAssetId
+	ID          common.PublicKey
+	Owner       common.PublicKey
+	Delegate    common.PublicKey
+	Nonce       uint64
+	DataHash    [32]byte
+	CreatorHash [32]byte
+}
+
+// Hash returns the leaf node of a v1 leaf schema
+func (l LeafSchema) Hash() [32]byte {
+	nonce := make([]byte, 8)
+	binary.LittleEndian.PutUint64(nonce, l.Nonce)
+	return keccak256(
+		[]byte{uint8(LeafSchemaVersionV1)},
+		l.ID.Bytes(),
+		l.Owner.Bytes(),
+		l.Delegate.Bytes(),
+		nonce,
+		l.DataHash[:],
+		l.CreatorHash[:],
+	)
+}
+
+// HashMetadata returns the data hash of a leaf
+func HashMetadata(metadata MetadataArgs) ([32]byte, error) {
+	b, err := borsh.Serialize(metadata)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	metadataHash := keccak256(b)
+	sellerFeeBasisPoints := make([]byte, 2)
+	binary.LittleEndian.PutUint16(sellerFeeBasisPoints, metadata.SellerFeeBasisPoints)
+	return keccak256(metadataHash[:], sellerFeeBasisPoints), nil
+}
+
+// HashCreators returns the creator hash of a leaf
+func HashCreators(creators []token_metadata.Creator) [32]byte {
+	data := make([][]byte, 0, len(creators))
+	for _, creator := range creators {
+		verified := uint8(0)
+		if creator.Verified {
+			verified = 1
+		}
+		data = append(data, append(creator.Address.Bytes(), verified, creator.Share))
+	}
+	return keccak256(data...)
+}
+
+func keccak256(data ...[]byte) [32]byte {
+	var hash [32]byte
+	h := sha3.NewLegacyKeccak256()
+	for _, b := range data {
+		h.Write(b)
+	}
+	h.Sum(hash[:0])
+	return hash
+}
@@ -0,0 +1,81 @@
+package bubblegum
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/pointer"
+	"github.com/EntySquare/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func hexToBytes32(s string) [32]byte {
+	var b [32]byte
+	n, err := hex.Decode(b[:], []byte(s))
+	if err != nil || n != 32 {
+		panic("invalid hex")
+	}
+	return b
+}
+
+var testMetadataArgs = MetadataArgs{
+	Name:                 "cNFT",
+	Symbol:               "C",
+	Uri:                  "https://example.com/c.json",
+	SellerFeeBasisPoints: 500,
+	IsMutable:            true,
+	TokenStandard:        pointer.Get(token_metadata.NonFungible),
+	Collection:           &token_metadata.Collection{Key: common.PublicKeyFromString("co11ection111111111111111111111111111111111")},
+	Creators:             []token_metadata.Creator{{Address: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), Verified: true, Share: 100}},
+}
+
+func TestHashMetadata(t *testing.T) {
+	got, err := HashMetadata(testMetadataArgs)
+	assert.Nil(t, err)
+	assert.Equal(t, hexToBytes32("f05d3c023d07e42c35f9462f4ca46846b5a6500025f7494fc6bb33440c925f4b"), got)
+}
+
+func TestHashCreators(t *testing.T) {
+	type args struct {
+		creators []token_metadata.Creator
+	}
+	tests := []struct {
+		name string
+		args args
+		want [32]byte
+	}{
+		{
+			name: "no creator",
+			args: args{
+				creators: nil,
+			},
+			// keccak256 of nothing
+			want: hexToBytes32("c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"),
+		},
+		{
+			name: "one creator",
+			args: args{
+				creators: testMetadataArgs.Creators,
+			},
+			want: hexToBytes32("0920c99b65f4ace67400228a546c4f10593cd854ba99f6916f191f9ef2e8e5e8"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, HashCreators(tt.args.creators))
+		})
+	}
+}
+
+func TestLeafSchema_Hash(t *testing.T) {
+	leaf := LeafSchema{
+		ID:          common.PublicKeyFromString("3oHnkBR6qcTqhjGUFtsCGzU9tfqhcN81QcDs5pNfozV6"),
+		Owner:       common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+		Delegate:    common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+		Nonce:       0,
+		DataHash:    hexToBytes32("f05d3c023d07e42c35f9462f4ca46846b5a6500025f7494fc6bb33440c925f4b"),
+		CreatorHash: hexToBytes32("0920c99b65f4ace67400228a546c4f10593cd854ba99f6916f191f9ef2e8e5e8"),
+	}
+	assert.Equal(t, hexToBytes32("93e26f53a8237eaf8e4efaa75665a7413a2b72ccee32cca741217b2fb715b263"), leaf.Hash())
+}
@@ -0,0 +1,73 @@
+package bubblegum
+
+import (
+	"encoding/binary"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+)
+
+// GetTreeAuthority returns the tree config pda, it is the authority of the merkle tree
+func GetTreeAuthority(merkleTree common.PublicKey) (common.PublicKey, error) {
+	treeAuthority, _, err := common.FindProgramAddress(
+		[][]byte{
+			merkleTree.Bytes(),
+		},
+		common.MetaplexBubblegumProgramID,
+	)
+	if err != nil {
+		return common.PublicKey{}, err
+	}
+	return treeAuthority, nil
+}
+
+// GetAssetId returns the id of the compressed nft minted with the nonce
+func GetAssetId(merkleTree common.PublicKey, nonce uint64) (common.PublicKey, error) {
+	assetId, _, err := common.FindProgramAddress(
+		[][]byte{
+			[]byte("asset"),
+			merkleTree.Bytes(),
+			uint64ToBytes(nonce),
+		},
+		common.MetaplexBubblegumProgramID,
+	)
+	if err != nil {
+		return common.PublicKey{}, err
+	}
+	return assetId, nil
+}
+
+// GetVoucher returns the voucher pda a redeemed leaf is stored in
+func GetVoucher(merkleTree common.PublicKey, nonce uint64) (common.PublicKey, error) {
+	voucher, _, err := common.FindProgramAddress(
+		[][]byte{
+			[]byte("voucher"),
+			merkleTree.Bytes(),
+			uint64ToBytes(nonce),
+		},
+		common.MetaplexBubblegumProgramID,
+	)
+	if err != nil {
+		return common.PublicKey{}, err
+	}
+	return voucher, nil
+}
+
+// GetBubblegumSigner returns the pda bubblegum signs token metadata cpis with
+func GetBubblegumSigner() (common.PublicKey, error) {
+	signer, _, err := common.FindProgramAddress(
+		[][]byte{
+			[]byte("collection_cpi"),
+		},
+		common.MetaplexBubblegumProgramID,
+	)
+	if err != nil {
+		return common.PublicKey{}, err
+	}
+	return signer, nil
+}
+
+func uint64ToBytes(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, v)
+	return b
+}
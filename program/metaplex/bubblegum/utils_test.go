@@ -0,0 +1,96 @@
+package bubblegum
+
+import (
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTreeAuthority(t *testing.T) {
+	type args struct {
+		merkleTree common.PublicKey
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    common.PublicKey
+		wantErr error
+	}{
+		{
+			args: args{
+				merkleTree: common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"),
+			},
+			want: common.PublicKeyFromString("J2FfeU3aoJXPupYDoSF2oa88nHYTNZBKapo93T6smSbY"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetTreeAuthority(tt.args.merkleTree)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetAssetId(t *testing.T) {
+	type args struct {
+		merkleTree common.PublicKey
+		nonce      uint64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    common.PublicKey
+		wantErr error
+	}{
+		{
+			args: args{
+				merkleTree: common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"),
+				nonce:      0,
+			},
+			want: common.PublicKeyFromString("3oHnkBR6qcTqhjGUFtsCGzU9tfqhcN81QcDs5pNfozV6"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetAssetId(tt.args.merkleTree, tt.args.nonce)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetVoucher(t *testing.T) {
+	type args struct {
+		merkleTree common.PublicKey
+		nonce      uint64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    common.PublicKey
+		wantErr error
+	}{
+		{
+			args: args{
+				merkleTree: common.PublicKeyFromString("merkLeTree111111111111111111111111111111111"),
+				nonce:      3,
+			},
+			want: common.PublicKeyFromString("9yDrdNc9aWv2WbTqn8xUm61BVv4V77xB6E6RWhddwHRs"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetVoucher(tt.args.merkleTree, tt.args.nonce)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetBubblegumSigner(t *testing.T) {
+	got, err := GetBubblegumSigner()
+	assert.Nil(t, err)
+	assert.Equal(t, common.PublicKeyFromString("4ewWZC5gT6TGpm5LZNDs9wVonfUT2q5PP5sc9kVbwMAK"), got)
+}
@@ -0,0 +1,12 @@
+package offchain
+
+import "errors"
+
+var (
+	ErrUnsupportedURIScheme = errors.New("unsupported uri scheme")
+	ErrUnexpectedStatusCode = errors.New("unexpected status code")
+	ErrResponseTooLarge     = errors.New("response too large")
+	ErrNameMismatch         = errors.New("name mismatch")
+	ErrSymbolMismatch       = errors.New("symbol mismatch")
+	ErrCreatorsMismatch     = errors.New("creators mismatch")
+)
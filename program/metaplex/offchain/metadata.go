@@ -0,0 +1,85 @@
+package offchain
+
+import (
+	"fmt"
+
+	"github.com/EntySquare/solana-go-sdk/program/metaplex/token_metadata"
+)
+
+// Metadata is the off-chain json which Metadata.Data.Uri points at, it follows the metaplex token standard
+type Metadata struct {
+	Name                 string      `json:"name"`
+	Symbol               string      `json:"symbol"`
+	Description          string      `json:"description,omitempty"`
+	SellerFeeBasisPoints uint16      `json:"seller_fee_basis_points,omitempty"`
+	Image                string      `json:"image,omitempty"`
+	AnimationUrl         string      `json:"animation_url,omitempty"`
+	ExternalUrl          string      `json:"external_url,omitempty"`
+	Attributes           []Attribute `json:"attributes,omitempty"`
+	Properties           Properties  `json:"properties,omitempty"`
+	// Creators is the legacy place of the creators, new json puts them in Properties.Creators
+	Creators []Creator `json:"creators,omitempty"`
+}
+
+type Attribute struct {
+	TraitType string `json:"trait_type"`
+	// Value is a string or a number
+	Value any `json:"value"`
+}
+
+type Properties struct {
+	Category string    `json:"category,omitempty"`
+	Files    []File    `json:"files,omitempty"`
+	Creators []Creator `json:"creators,omitempty"`
+}
+
+type File struct {
+	Uri  string `json:"uri"`
+	Type string `json:"type,omitempty"`
+	Cdn  bool   `json:"cdn,omitempty"`
+}
+
+type Creator struct {
+	Address string `json:"address"`
+	Share   uint8  `json:"share"`
+}
+
+// AllCreators returns Properties.Creators, or the legacy Creators if the former is empty
+func (m Metadata) AllCreators() []Creator {
+	if len(m.Properties.Creators) > 0 {
+		return m.Properties.Creators
+	}
+	return m.Creators
+}
+
+// Verify cross-checks the name, symbol and creators against the on-chain metadata.
+// creators are only checked when the json lists them.
+func (m Metadata) Verify(metadata token_metadata.Metadata) error {
+	if m.Name != metadata.Data.Name {
+		return fmt.Errorf("%w, on-chain: %v, off-chain: %v", ErrNameMismatch, metadata.Data.Name, m.Name)
+	}
+	if m.Symbol != metadata.Data.Symbol {
+		return fmt.Errorf("%w, on-chain: %v, off-chain: %v", ErrSymbolMismatch, metadata.Data.Symbol, m.Symbol)
+	}
+
+	creators := m.AllCreators()
+	if len(creators) == 0 {
+		return nil
+	}
+	var onchainCreators []token_metadata.Creator
+	if metadata.Data.Creators != nil {
+		onchainCreators = *metadata.Data.Creators
+	}
+	if len(creators) != len(onchainCreators) {
+		return fmt.Errorf("%w, on-chain has %v creators, off-chain has %v", ErrCreatorsMismatch, len(onchainCreators), len(creators))
+	}
+	for i, creator := range creators {
+		if creator.Address != onchainCreators[i].Address.ToBase58() || creator.Share != onchainCreators[i].Share {
+			return fmt.Errorf(
+				"%w, index: %v, on-chain: %v(%v), off-chain: %v(%v)",
+				ErrCreatorsMismatch, i, onchainCreators[i].Address.ToBase58(), onchainCreators[i].Share, creator.Address, creator.Share,
+			)
+		}
+	}
+	return nil
+}
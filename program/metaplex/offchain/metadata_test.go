@@ -0,0 +1,115 @@
+package offchain
+
+import (
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadata_Verify(t *testing.T) {
+	onchain := token_metadata.Metadata{
+		Data: token_metadata.Data{
+			Name:   "Fox #1",
+			Symbol: "FOX",
+			Uri:    "https://example.com/1.json",
+			Creators: &[]token_metadata.Creator{
+				{
+					Address:  common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					Verified: true,
+					Share:    100,
+				},
+			},
+		},
+	}
+	type args struct {
+		metadata token_metadata.Metadata
+	}
+	tests := []struct {
+		name     string
+		offchain Metadata
+		args     args
+		err      error
+	}{
+		{
+			name: "match",
+			offchain: Metadata{
+				Name:   "Fox #1",
+				Symbol: "FOX",
+				Properties: Properties{
+					Creators: []Creator{{Address: "EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7", Share: 100}},
+				},
+			},
+			args: args{metadata: onchain},
+			err:  nil,
+		},
+		{
+			name: "legacy creators",
+			offchain: Metadata{
+				Name:     "Fox #1",
+				Symbol:   "FOX",
+				Creators: []Creator{{Address: "EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7", Share: 100}},
+			},
+			args: args{metadata: onchain},
+			err:  nil,
+		},
+		{
+			name: "no creators in json",
+			offchain: Metadata{
+				Name:   "Fox #1",
+				Symbol: "FOX",
+			},
+			args: args{metadata: onchain},
+			err:  nil,
+		},
+		{
+			name: "name mismatch",
+			offchain: Metadata{
+				Name:   "Fox #2",
+				Symbol: "FOX",
+			},
+			args: args{metadata: onchain},
+			err:  ErrNameMismatch,
+		},
+		{
+			name: "symbol mismatch",
+			offchain: Metadata{
+				Name:   "Fox #1",
+				Symbol: "DOG",
+			},
+			args: args{metadata: onchain},
+			err:  ErrSymbolMismatch,
+		},
+		{
+			name: "creator share mismatch",
+			offchain: Metadata{
+				Name:   "Fox #1",
+				Symbol: "FOX",
+				Properties: Properties{
+					Creators: []Creator{{Address: "EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7", Share: 50}},
+				},
+			},
+			args: args{metadata: onchain},
+			err:  ErrCreatorsMismatch,
+		},
+		{
+			name: "creators count mismatch",
+			offchain: Metadata{
+				Name:   "Fox #1",
+				Symbol: "FOX",
+				Properties: Properties{
+					Creators: []Creator{{Address: "EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7", Share: 100}},
+				},
+			},
+			args: args{metadata: token_metadata.Metadata{Data: token_metadata.Data{Name: "Fox #1", Symbol: "FOX"}}},
+			err:  ErrCreatorsMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.offchain.Verify(tt.args.metadata)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
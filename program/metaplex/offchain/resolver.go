@@ -0,0 +1,166 @@
+package offchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/EntySquare/solana-go-sdk/program/metaplex/token_metadata"
+)
+
+const (
+	DefaultIPFSGateway    = "https://ipfs.io/ipfs/"
+	DefaultArweaveGateway = "https://arweave.net/"
+	DefaultMaxSize        = 1 << 20
+	DefaultTimeout        = 10 * time.Second
+)
+
+// Resolver fetches the off-chain json of a metadata
+type Resolver struct {
+	httpClient     *http.Client
+	ipfsGateway    string
+	arweaveGateway string
+	maxSize        int64
+	timeout        time.Duration
+}
+
+// Option is a configuration type for the Resolver
+type Option func(*Resolver)
+
+// WithHTTPClient is an Option that allows you provide your own HTTP client
+func WithHTTPClient(h *http.Client) Option {
+	return func(r *Resolver) {
+		r.httpClient = h
+	}
+}
+
+// WithIPFSGateway is an Option that sets the gateway ipfs:// uris are fetched through
+func WithIPFSGateway(gateway string) Option {
+	return func(r *Resolver) {
+		r.ipfsGateway = gateway
+	}
+}
+
+// WithArweaveGateway is an Option that sets the gateway ar:// uris are fetched through
+func WithArweaveGateway(gateway string) Option {
+	return func(r *Resolver) {
+		r.arweaveGateway = gateway
+	}
+}
+
+// WithMaxSize is an Option that limits the size of the response body in bytes
+func WithMaxSize(maxSize int64) Option {
+	return func(r *Resolver) {
+		r.maxSize = maxSize
+	}
+}
+
+// WithTimeout is an Option that limits the time of a fetch
+func WithTimeout(timeout time.Duration) Option {
+	return func(r *Resolver) {
+		r.timeout = timeout
+	}
+}
+
+// New applies the given options to the resolver being created. if no options
+// is passed, it defaults to a bare bone http client and the public gateways
+func New(opts ...Option) Resolver {
+	r := &Resolver{
+		httpClient:     &http.Client{},
+		ipfsGateway:    DefaultIPFSGateway,
+		arweaveGateway: DefaultArweaveGateway,
+		maxSize:        DefaultMaxSize,
+		timeout:        DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return *r
+}
+
+// ResolveURL converts ipfs:// and ar:// uris to gateway urls. http(s) urls are returned as is.
+func (r Resolver) ResolveURL(uri string) (string, error) {
+	switch {
+	case strings.HasPrefix(uri, "https://"), strings.HasPrefix(uri, "http://"):
+		return uri, nil
+	case strings.HasPrefix(uri, "ipfs://"):
+		path := strings.TrimPrefix(uri, "ipfs://")
+		path = strings.TrimPrefix(path, "ipfs/")
+		return joinGateway(r.ipfsGateway, path), nil
+	case strings.HasPrefix(uri, "ar://"):
+		return joinGateway(r.arweaveGateway, strings.TrimPrefix(uri, "ar://")), nil
+	}
+	return "", fmt.Errorf("%w, uri: %v", ErrUnsupportedURIScheme, uri)
+}
+
+// Fetch downloads and parses the json the uri points at
+func (r Resolver) Fetch(ctx context.Context, uri string) (Metadata, error) {
+	url, err := r.ResolveURL(uri)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to do http.NewRequestWithContext, err: %v", err)
+	}
+	req.Header.Add("Accept", "application/json")
+
+	res, err := r.httpClient.Do(req)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to do request, err: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return Metadata{}, fmt.Errorf("%w, status code: %v", ErrUnexpectedStatusCode, res.StatusCode)
+	}
+	if r.maxSize > 0 && res.ContentLength > r.maxSize {
+		return Metadata{}, fmt.Errorf("%w, content length: %v, limit: %v", ErrResponseTooLarge, res.ContentLength, r.maxSize)
+	}
+
+	var body io.Reader = res.Body
+	if r.maxSize > 0 {
+		// read one more byte to know if the body exceeds the limit
+		body = io.LimitReader(res.Body, r.maxSize+1)
+	}
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to read body, err: %w", err)
+	}
+	if r.maxSize > 0 && int64(len(b)) > r.maxSize {
+		return Metadata{}, fmt.Errorf("%w, limit: %v", ErrResponseTooLarge, r.maxSize)
+	}
+
+	var metadata Metadata
+	if err := json.Unmarshal(b, &metadata); err != nil {
+		return Metadata{}, fmt.Errorf("failed to unmarshal json, err: %w", err)
+	}
+	return metadata, nil
+}
+
+// FetchAndVerify fetches the json of the on-chain metadata and cross-checks them
+func (r Resolver) FetchAndVerify(ctx context.Context, metadata token_metadata.Metadata) (Metadata, error) {
+	offchainMetadata, err := r.Fetch(ctx, metadata.Data.Uri)
+	if err != nil {
+		return Metadata{}, err
+	}
+	if err := offchainMetadata.Verify(metadata); err != nil {
+		return offchainMetadata, err
+	}
+	return offchainMetadata, nil
+}
+
+func joinGateway(gateway, path string) string {
+	return strings.TrimSuffix(gateway, "/") + "/" + path
+}
@@ -0,0 +1,158 @@
+package offchain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolver_ResolveURL(t *testing.T) {
+	type args struct {
+		uri string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+		err  error
+	}{
+		{
+			args: args{uri: "https://example.com/1.json"},
+			want: "https://example.com/1.json",
+		},
+		{
+			args: args{uri: "ipfs://bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi/1.json"},
+			want: "https://ipfs.io/ipfs/bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi/1.json",
+		},
+		{
+			args: args{uri: "ipfs://ipfs/bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"},
+			want: "https://ipfs.io/ipfs/bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi",
+		},
+		{
+			args: args{uri: "ar://bWxQ7fiTkwiQt4vH4FfKUZrxDGx7X8fOQqHGBiyG-iA"},
+			want: "https://arweave.net/bWxQ7fiTkwiQt4vH4FfKUZrxDGx7X8fOQqHGBiyG-iA",
+		},
+		{
+			args: args{uri: "ftp://example.com/1.json"},
+			err:  ErrUnsupportedURIScheme,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.args.uri, func(t *testing.T) {
+			got, err := New().ResolveURL(tt.args.uri)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolver_Fetch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ipfs/cid/1.json", func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"name":"Fox #1","symbol":"FOX","description":"a fox","seller_fee_basis_points":500,"image":"https://example.com/1.png","animation_url":"https://example.com/1.mp4","attributes":[{"trait_type":"color","value":"red"},{"trait_type":"level","value":3}],"properties":{"category":"image","files":[{"uri":"https://example.com/1.png","type":"image/png"}],"creators":[{"address":"EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7","share":100}]}}`))
+	})
+	mux.HandleFunc("/ar/tx", func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"name":"Fox #2","symbol":"FOX"}`))
+	})
+	mux.HandleFunc("/large.json", func(rw http.ResponseWriter, req *http.Request) {
+		// chunked, so the limit can only be found by reading
+		rw.(http.Flusher).Flush()
+		rw.Write([]byte(`{"name":"` + strings.Repeat("a", 1024) + `"}`))
+	})
+	mux.HandleFunc("/slow.json", func(rw http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+	mux.HandleFunc("/invalid.json", func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`<html></html>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	resolver := New(
+		WithHTTPClient(server.Client()),
+		WithIPFSGateway(server.URL+"/ipfs"),
+		WithArweaveGateway(server.URL+"/ar/"),
+		WithMaxSize(512),
+		WithTimeout(50*time.Millisecond),
+	)
+
+	t.Run("ipfs", func(t *testing.T) {
+		got, err := resolver.Fetch(context.Background(), "ipfs://cid/1.json")
+		assert.Nil(t, err)
+		assert.Equal(t, Metadata{
+			Name:                 "Fox #1",
+			Symbol:               "FOX",
+			Description:          "a fox",
+			SellerFeeBasisPoints: 500,
+			Image:                "https://example.com/1.png",
+			AnimationUrl:         "https://example.com/1.mp4",
+			Attributes: []Attribute{
+				{TraitType: "color", Value: "red"},
+				{TraitType: "level", Value: float64(3)},
+			},
+			Properties: Properties{
+				Category: "image",
+				Files:    []File{{Uri: "https://example.com/1.png", Type: "image/png"}},
+				Creators: []Creator{{Address: "EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7", Share: 100}},
+			},
+		}, got)
+	})
+	t.Run("arweave", func(t *testing.T) {
+		got, err := resolver.Fetch(context.Background(), "ar://tx")
+		assert.Nil(t, err)
+		assert.Equal(t, Metadata{Name: "Fox #2", Symbol: "FOX"}, got)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := resolver.Fetch(context.Background(), server.URL+"/404.json")
+		assert.ErrorIs(t, err, ErrUnexpectedStatusCode)
+	})
+	t.Run("too large", func(t *testing.T) {
+		_, err := resolver.Fetch(context.Background(), server.URL+"/large.json")
+		assert.ErrorIs(t, err, ErrResponseTooLarge)
+	})
+	t.Run("timeout", func(t *testing.T) {
+		_, err := resolver.Fetch(context.Background(), server.URL+"/slow.json")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := resolver.Fetch(context.Background(), server.URL+"/invalid.json")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestResolver_FetchAndVerify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"name":"Fox #1","symbol":"FOX","properties":{"creators":[{"address":"EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7","share":100}]}}`))
+	}))
+	defer server.Close()
+
+	resolver := New(WithHTTPClient(server.Client()))
+	metadata := token_metadata.Metadata{
+		Data: token_metadata.Data{
+			Name:   "Fox #1",
+			Symbol: "FOX",
+			Uri:    server.URL + "/1.json",
+			Creators: &[]token_metadata.Creator{
+				{Address: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), Verified: true, Share: 100},
+			},
+		},
+	}
+
+	got, err := resolver.FetchAndVerify(context.Background(), metadata)
+	assert.Nil(t, err)
+	assert.Equal(t, "Fox #1", got.Name)
+
+	metadata.Data.Symbol = "DOG"
+	_, err = resolver.FetchAndVerify(context.Background(), metadata)
+	assert.ErrorIs(t, err, ErrSymbolMismatch)
+}
@@ -0,0 +1,10 @@
+package token_metadata
+
+import "errors"
+
+var (
+	ErrInvalidAccountDataSize = errors.New("invalid account data size")
+	ErrInvalidAccountData     = errors.New("invalid account data")
+	ErrUnexpectedAccountKey   = errors.New("unexpected account key")
+	ErrUnsupportedAccountKey  = errors.New("unsupported account key")
+)
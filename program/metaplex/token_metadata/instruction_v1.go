@@ -0,0 +1,1290 @@
+package token_metadata
+
+import (
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/near/borsh-go"
+)
+
+// unified instructions which also handle programmable NFTs
+// https://github.com/metaplex-foundation/mpl-token-metadata/blob/main/programs/token-metadata/program/src/instruction/mod.rs
+const (
+	InstructionCreateEscrowAccount Instruction = iota + InstructionBurnEditionNft + 1
+	InstructionCloseEscrowAccount
+	InstructionTransferOutOfEscrow
+	InstructionBurn
+	InstructionCreate
+	InstructionMint
+	InstructionDelegate
+	InstructionRevoke
+	InstructionLock
+	InstructionUnlock
+	InstructionMigrate
+	InstructionTransfer
+	InstructionUpdate
+	InstructionUse
+	InstructionVerify
+	InstructionUnverify
+	InstructionCollect
+	InstructionPrint
+)
+
+// AuthorizationData is passed to the rule set of a programmable NFT
+type AuthorizationData struct {
+	Payload Payload
+}
+
+type Payload struct {
+	Map map[string]PayloadType
+}
+
+type PayloadType struct {
+	Enum        borsh.Enum `borsh_enum:"true"`
+	Pubkey      PayloadPubkey
+	Seeds       SeedsVec
+	MerkleProof ProofInfo
+	Number      PayloadNumber
+}
+
+const (
+	PayloadTypePubkey borsh.Enum = iota
+	PayloadTypeSeeds
+	PayloadTypeMerkleProof
+	PayloadTypeNumber
+)
+
+// borsh-go only serializes struct variants of complex enums,
+// so single value variants are wrapped in a struct which has the same layout
+
+type PayloadPubkey struct {
+	Pubkey common.PublicKey
+}
+
+type PayloadNumber struct {
+	Number uint64
+}
+
+type SeedsVec struct {
+	Seeds [][]byte
+}
+
+type ProofInfo struct {
+	Proof [][32]byte
+}
+
+type PrintSupply struct {
+	Enum      borsh.Enum `borsh_enum:"true"`
+	Zero      struct{}
+	Limited   LimitedPrintSupply
+	Unlimited struct{}
+}
+
+type LimitedPrintSupply struct {
+	MaxSupply uint64
+}
+
+const (
+	PrintSupplyZero borsh.Enum = iota
+	PrintSupplyLimited
+	PrintSupplyUnlimited
+)
+
+type AssetData struct {
+	Name                 string
+	Symbol               string
+	Uri                  string
+	SellerFeeBasisPoints uint16
+	Creators             *[]Creator
+	PrimarySaleHappened  bool
+	IsMutable            bool
+	TokenStandard        TokenStandard
+	Collection           *Collection
+	Uses                 *Uses
+	CollectionDetails    *CollectionDetails
+	RuleSet              *common.PublicKey
+}
+
+type DelegateArgs struct {
+	Enum                     borsh.Enum `borsh_enum:"true"`
+	CollectionV1             DelegateAuthorizationArgs
+	SaleV1                   DelegateAmountArgs
+	TransferV1               DelegateAmountArgs
+	DataV1                   DelegateAuthorizationArgs
+	UtilityV1                DelegateAmountArgs
+	StakingV1                DelegateAmountArgs
+	StandardV1               DelegateStandardArgs
+	LockedTransferV1         DelegateLockedTransferArgs
+	ProgrammableConfigV1     DelegateAuthorizationArgs
+	AuthorityItemV1          DelegateAuthorizationArgs
+	DataItemV1               DelegateAuthorizationArgs
+	CollectionItemV1         DelegateAuthorizationArgs
+	ProgrammableConfigItemV1 DelegateAuthorizationArgs
+	PrintDelegateV1          DelegateAuthorizationArgs
+}
+
+const (
+	DelegateArgsCollectionV1 borsh.Enum = iota
+	DelegateArgsSaleV1
+	DelegateArgsTransferV1
+	DelegateArgsDataV1
+	DelegateArgsUtilityV1
+	DelegateArgsStakingV1
+	DelegateArgsStandardV1
+	DelegateArgsLockedTransferV1
+	DelegateArgsProgrammableConfigV1
+	DelegateArgsAuthorityItemV1
+	DelegateArgsDataItemV1
+	DelegateArgsCollectionItemV1
+	DelegateArgsProgrammableConfigItemV1
+	DelegateArgsPrintDelegateV1
+)
+
+type DelegateAuthorizationArgs struct {
+	AuthorizationData *AuthorizationData
+}
+
+type DelegateAmountArgs struct {
+	Amount            uint64
+	AuthorizationData *AuthorizationData
+}
+
+type DelegateStandardArgs struct {
+	Amount uint64
+}
+
+type DelegateLockedTransferArgs struct {
+	Amount            uint64
+	LockedAddress     common.PublicKey
+	AuthorizationData *AuthorizationData
+}
+
+type RevokeArgs borsh.Enum
+
+const (
+	RevokeArgsCollectionV1 RevokeArgs = iota
+	RevokeArgsSaleV1
+	RevokeArgsTransferV1
+	RevokeArgsDataV1
+	RevokeArgsUtilityV1
+	RevokeArgsStakingV1
+	RevokeArgsStandardV1
+	RevokeArgsLockedTransferV1
+	RevokeArgsProgrammableConfigV1
+	RevokeArgsMigrationV1
+	RevokeArgsAuthorityItemV1
+	RevokeArgsDataItemV1
+	RevokeArgsCollectionItemV1
+	RevokeArgsProgrammableConfigItemV1
+	RevokeArgsPrintDelegateV1
+)
+
+type VerificationArgs borsh.Enum
+
+const (
+	VerificationArgsCreatorV1 VerificationArgs = iota
+	VerificationArgsCollectionV1
+)
+
+type CollectionToggle struct {
+	Enum  borsh.Enum `borsh_enum:"true"`
+	None  struct{}
+	Clear struct{}
+	Set   Collection
+}
+
+type CollectionDetailsToggle struct {
+	Enum  borsh.Enum `borsh_enum:"true"`
+	None  struct{}
+	Clear struct{}
+	Set   CollectionDetails
+}
+
+type UsesToggle struct {
+	Enum  borsh.Enum `borsh_enum:"true"`
+	None  struct{}
+	Clear struct{}
+	Set   Uses
+}
+
+type RuleSetToggle struct {
+	Enum  borsh.Enum `borsh_enum:"true"`
+	None  struct{}
+	Clear struct{}
+	Set   RuleSet
+}
+
+type RuleSet struct {
+	RuleSet common.PublicKey
+}
+
+// the variants of every toggle
+const (
+	ToggleNone borsh.Enum = iota
+	ToggleClear
+	ToggleSet
+)
+
+type BurnV1Param struct {
+	Authority          common.PublicKey
+	CollectionMetadata *common.PublicKey
+	Metadata           common.PublicKey
+	// Edition defaults to the master edition of the mint for non-fungible token standards
+	Edition            *common.PublicKey
+	Mint               common.PublicKey
+	Token              common.PublicKey
+	MasterEdition      *common.PublicKey // Master edition of a print edition
+	MasterEditionMint  *common.PublicKey // Mint of the master edition of a print edition
+	MasterEditionToken *common.PublicKey // Token account of the master edition of a print edition
+	EditionMarker      *common.PublicKey // Edition marker of a print edition
+	// TokenRecord defaults to the token record of Token for programmable NFTs
+	TokenRecord   *common.PublicKey
+	TokenStandard TokenStandard
+	Amount        uint64
+}
+
+// BurnV1 burns an asset and closes its accounts, programmable NFTs included
+func BurnV1(param BurnV1Param) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction Instruction
+		Enum        borsh.Enum
+		Amount      uint64
+	}{
+		Instruction: InstructionBurn,
+		Enum:        0,
+		Amount:      param.Amount,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	edition := defaultEdition(param.Edition, param.Mint, param.TokenStandard)
+	tokenRecord := defaultTokenRecord(param.TokenRecord, param.Mint, param.Token, param.TokenStandard)
+
+	return types.Instruction{
+		ProgramID: common.MetaplexTokenMetaProgramID,
+		Accounts: []types.AccountMeta{
+			{
+				PubKey:     param.Authority,
+				IsSigner:   true,
+				IsWritable: true,
+			},
+			optionalAccountMeta(param.CollectionMetadata, true),
+			{
+				PubKey:     param.Metadata,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			optionalAccountMeta(edition, true),
+			{
+				PubKey:     param.Mint,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.Token,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			optionalAccountMeta(param.MasterEdition, true),
+			optionalAccountMeta(param.MasterEditionMint, false),
+			optionalAccountMeta(param.MasterEditionToken, false),
+			optionalAccountMeta(param.EditionMarker, true),
+			optionalAccountMeta(tokenRecord, true),
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SysVarInstructionsPubkey,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.TokenProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		},
+		Data: data,
+	}
+}
+
+type CreateV1Param struct {
+	Metadata common.PublicKey
+	// MasterEdition is required for non-fungible token standards
+	MasterEdition           *common.PublicKey
+	Mint                    common.PublicKey
+	MintIsSigner            bool // the mint is created by the instruction when it signs
+	Authority               common.PublicKey
+	Payer                   common.PublicKey
+	UpdateAuthority         common.PublicKey
+	UpdateAuthorityIsSigner bool
+	AssetData               AssetData
+	Decimals                *uint8
+	PrintSupply             *PrintSupply
+}
+
+// CreateV1 creates the metadata and master edition of an asset of any token standard
+func CreateV1(param CreateV1Param) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction Instruction
+		Enum        borsh.Enum
+		AssetData   AssetData
+		Decimals    *uint8
+		PrintSupply *PrintSupply
+	}{
+		Instruction: InstructionCreate,
+		Enum:        0,
+		AssetData:   param.AssetData,
+		Decimals:    param.Decimals,
+		PrintSupply: param.PrintSupply,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.MetaplexTokenMetaProgramID,
+		Accounts: []types.AccountMeta{
+			{
+				PubKey:     param.Metadata,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			optionalAccountMeta(param.MasterEdition, true),
+			{
+				PubKey:     param.Mint,
+				IsSigner:   param.MintIsSigner,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.Authority,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.Payer,
+				IsSigner:   true,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.UpdateAuthority,
+				IsSigner:   param.UpdateAuthorityIsSigner,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SysVarInstructionsPubkey,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.TokenProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		},
+		Data: data,
+	}
+}
+
+type MintV1Param struct {
+	Token      common.PublicKey
+	TokenOwner *common.PublicKey // required when the token account does not exist yet
+	Metadata   common.PublicKey
+	// MasterEdition defaults to the master edition of the mint for non-fungible token standards
+	MasterEdition *common.PublicKey
+	// TokenRecord defaults to the token record of Token for programmable NFTs
+	TokenRecord        *common.PublicKey
+	Mint               common.PublicKey
+	Authority          common.PublicKey
+	DelegateRecord     *common.PublicKey
+	Payer              common.PublicKey
+	AuthorizationRules *common.PublicKey
+	TokenStandard      TokenStandard
+	Amount             uint64
+	AuthorizationData  *AuthorizationData
+}
+
+// MintV1 mints tokens of an asset, the token account is created if needed
+func MintV1(param MintV1Param) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction       Instruction
+		Enum              borsh.Enum
+		Amount            uint64
+		AuthorizationData *AuthorizationData
+	}{
+		Instruction:       InstructionMint,
+		Enum:              0,
+		Amount:            param.Amount,
+		AuthorizationData: param.AuthorizationData,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	masterEdition := defaultEdition(param.MasterEdition, param.Mint, param.TokenStandard)
+	tokenRecord := defaultTokenRecord(param.TokenRecord, param.Mint, param.Token, param.TokenStandard)
+
+	return types.Instruction{
+		ProgramID: common.MetaplexTokenMetaProgramID,
+		Accounts: append([]types.AccountMeta{
+			{
+				PubKey:     param.Token,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			optionalAccountMeta(param.TokenOwner, false),
+			{
+				PubKey:     param.Metadata,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			optionalAccountMeta(masterEdition, false),
+			optionalAccountMeta(tokenRecord, true),
+			{
+				PubKey:     param.Mint,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.Authority,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			optionalAccountMeta(param.DelegateRecord, false),
+			{
+				PubKey:     param.Payer,
+				IsSigner:   true,
+				IsWritable: true,
+			},
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SysVarInstructionsPubkey,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.TokenProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SPLAssociatedTokenAccountProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		}, authorizationRulesAccountMetas(param.AuthorizationRules)...),
+		Data: data,
+	}
+}
+
+type TransferV1Param struct {
+	Token            common.PublicKey
+	TokenOwner       common.PublicKey
+	Destination      common.PublicKey
+	DestinationOwner common.PublicKey
+	Mint             common.PublicKey
+	Metadata         common.PublicKey
+	// Edition defaults to the master edition of the mint for non-fungible token standards
+	Edition *common.PublicKey
+	// OwnerTokenRecord and DestinationTokenRecord default to the token records of Token and Destination for programmable NFTs
+	OwnerTokenRecord       *common.PublicKey
+	DestinationTokenRecord *common.PublicKey
+	Authority              common.PublicKey // the owner or a delegate
+	Payer                  common.PublicKey
+	AuthorizationRules     *common.PublicKey
+	TokenStandard          TokenStandard
+	Amount                 uint64
+	AuthorizationData      *AuthorizationData
+}
+
+// TransferV1 transfers an asset, the destination token account is created if needed.
+// Programmable NFTs can only be moved by this instruction.
+func TransferV1(param TransferV1Param) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction       Instruction
+		Enum              borsh.Enum
+		Amount            uint64
+		AuthorizationData *AuthorizationData
+	}{
+		Instruction:       InstructionTransfer,
+		Enum:              0,
+		Amount:            param.Amount,
+		AuthorizationData: param.AuthorizationData,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	edition := defaultEdition(param.Edition, param.Mint, param.TokenStandard)
+	ownerTokenRecord := defaultTokenRecord(param.OwnerTokenRecord, param.Mint, param.Token, param.TokenStandard)
+	destinationTokenRecord := defaultTokenRecord(param.DestinationTokenRecord, param.Mint, param.Destination, param.TokenStandard)
+
+	return types.Instruction{
+		ProgramID: common.MetaplexTokenMetaProgramID,
+		Accounts: append([]types.AccountMeta{
+			{
+				PubKey:     param.Token,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.TokenOwner,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.Destination,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.DestinationOwner,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.Mint,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.Metadata,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			optionalAccountMeta(edition, false),
+			optionalAccountMeta(ownerTokenRecord, true),
+			optionalAccountMeta(destinationTokenRecord, true),
+			{
+				PubKey:     param.Authority,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.Payer,
+				IsSigner:   true,
+				IsWritable: true,
+			},
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SysVarInstructionsPubkey,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.TokenProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SPLAssociatedTokenAccountProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		}, authorizationRulesAccountMetas(param.AuthorizationRules)...),
+		Data: data,
+	}
+}
+
+type UpdateV1Param struct {
+	Authority           common.PublicKey
+	DelegateRecord      *common.PublicKey
+	Token               *common.PublicKey // required when the authority is a holder
+	Mint                common.PublicKey
+	Metadata            common.PublicKey
+	Edition             *common.PublicKey
+	Payer               common.PublicKey
+	AuthorizationRules  *common.PublicKey
+	NewUpdateAuthority  *common.PublicKey
+	Data                *Data
+	PrimarySaleHappened *bool
+	IsMutable           *bool
+	Collection          CollectionToggle
+	CollectionDetails   CollectionDetailsToggle
+	Uses                UsesToggle
+	RuleSet             RuleSetToggle
+	AuthorizationData   *AuthorizationData
+}
+
+// UpdateV1 updates the metadata of an asset, nil fields and ToggleNone are left untouched
+func UpdateV1(param UpdateV1Param) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction         Instruction
+		Enum                borsh.Enum
+		NewUpdateAuthority  *common.PublicKey
+		Data                *Data
+		PrimarySaleHappened *bool
+		IsMutable           *bool
+		Collection          CollectionToggle
+		CollectionDetails   CollectionDetailsToggle
+		Uses                UsesToggle
+		RuleSet             RuleSetToggle
+		AuthorizationData   *AuthorizationData
+	}{
+		Instruction:         InstructionUpdate,
+		Enum:                0,
+		NewUpdateAuthority:  param.NewUpdateAuthority,
+		Data:                param.Data,
+		PrimarySaleHappened: param.PrimarySaleHappened,
+		IsMutable:           param.IsMutable,
+		Collection:          param.Collection,
+		CollectionDetails:   param.CollectionDetails,
+		Uses:                param.Uses,
+		RuleSet:             param.RuleSet,
+		AuthorizationData:   param.AuthorizationData,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.MetaplexTokenMetaProgramID,
+		Accounts: append([]types.AccountMeta{
+			{
+				PubKey:     param.Authority,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			optionalAccountMeta(param.DelegateRecord, false),
+			optionalAccountMeta(param.Token, false),
+			{
+				PubKey:     param.Mint,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.Metadata,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			optionalAccountMeta(param.Edition, false),
+			{
+				PubKey:     param.Payer,
+				IsSigner:   true,
+				IsWritable: true,
+			},
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SysVarInstructionsPubkey,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		}, authorizationRulesAccountMetas(param.AuthorizationRules)...),
+		Data: data,
+	}
+}
+
+type DelegateV1Param struct {
+	// DelegateRecord is the metadata delegate record, token delegates have none
+	DelegateRecord *common.PublicKey
+	Delegate       common.PublicKey
+	Metadata       common.PublicKey
+	// MasterEdition defaults to the master edition of the mint for non-fungible token standards
+	MasterEdition *common.PublicKey
+	// TokenRecord defaults to the token record of Token for programmable NFTs
+	TokenRecord        *common.PublicKey
+	Mint               common.PublicKey
+	Token              *common.PublicKey // required by token delegates
+	Authority          common.PublicKey
+	Payer              common.PublicKey
+	AuthorizationRules *common.PublicKey
+	TokenStandard      TokenStandard
+	Args               DelegateArgs
+}
+
+// DelegateV1 approves a metadata or token delegate of an asset
+func DelegateV1(param DelegateV1Param) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction Instruction
+		Args        DelegateArgs
+	}{
+		Instruction: InstructionDelegate,
+		Args:        param.Args,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.MetaplexTokenMetaProgramID,
+		Accounts:  delegateAccountMetas(param.DelegateRecord, param.Delegate, param.Metadata, param.MasterEdition, param.TokenRecord, param.Mint, param.Token, param.Authority, param.Payer, param.AuthorizationRules, param.TokenStandard),
+		Data:      data,
+	}
+}
+
+type RevokeV1Param struct {
+	// DelegateRecord is the metadata delegate record, token delegates have none
+	DelegateRecord *common.PublicKey
+	Delegate       common.PublicKey
+	Metadata       common.PublicKey
+	// MasterEdition defaults to the master edition of the mint for non-fungible token standards
+	MasterEdition *common.PublicKey
+	// TokenRecord defaults to the token record of Token for programmable NFTs
+	TokenRecord        *common.PublicKey
+	Mint               common.PublicKey
+	Token              *common.PublicKey // required by token delegates
+	Authority          common.PublicKey
+	Payer              common.PublicKey
+	AuthorizationRules *common.PublicKey
+	TokenStandard      TokenStandard
+	Args               RevokeArgs
+}
+
+// RevokeV1 revokes a metadata or token delegate of an asset
+func RevokeV1(param RevokeV1Param) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction Instruction
+		Args        RevokeArgs
+	}{
+		Instruction: InstructionRevoke,
+		Args:        param.Args,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.MetaplexTokenMetaProgramID,
+		Accounts:  delegateAccountMetas(param.DelegateRecord, param.Delegate, param.Metadata, param.MasterEdition, param.TokenRecord, param.Mint, param.Token, param.Authority, param.Payer, param.AuthorizationRules, param.TokenStandard),
+		Data:      data,
+	}
+}
+
+type LockV1Param struct {
+	Authority  common.PublicKey // a utility delegate, or the freeze authority for non-programmable assets
+	TokenOwner *common.PublicKey
+	Token      common.PublicKey
+	Mint       common.PublicKey
+	Metadata   common.PublicKey
+	// Edition defaults to the master edition of the mint for non-fungible token standards
+	Edition *common.PublicKey
+	// TokenRecord defaults to the token record of Token for programmable NFTs
+	TokenRecord        *common.PublicKey
+	Payer              common.PublicKey
+	AuthorizationRules *common.PublicKey
+	TokenStandard      TokenStandard
+	AuthorizationData  *AuthorizationData
+}
+
+// LockV1 locks an asset so it can not be transferred or burned
+func LockV1(param LockV1Param) types.Instruction {
+	return lockInstruction(InstructionLock, param)
+}
+
+type UnlockV1Param = LockV1Param
+
+// UnlockV1 unlocks an asset which was locked by LockV1
+func UnlockV1(param UnlockV1Param) types.Instruction {
+	return lockInstruction(InstructionUnlock, param)
+}
+
+func lockInstruction(instruction Instruction, param LockV1Param) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction       Instruction
+		Enum              borsh.Enum
+		AuthorizationData *AuthorizationData
+	}{
+		Instruction:       instruction,
+		Enum:              0,
+		AuthorizationData: param.AuthorizationData,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	edition := defaultEdition(param.Edition, param.Mint, param.TokenStandard)
+	tokenRecord := defaultTokenRecord(param.TokenRecord, param.Mint, param.Token, param.TokenStandard)
+
+	return types.Instruction{
+		ProgramID: common.MetaplexTokenMetaProgramID,
+		Accounts: append([]types.AccountMeta{
+			{
+				PubKey:     param.Authority,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			optionalAccountMeta(param.TokenOwner, false),
+			{
+				PubKey:     param.Token,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.Mint,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.Metadata,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			optionalAccountMeta(edition, false),
+			optionalAccountMeta(tokenRecord, true),
+			{
+				PubKey:     param.Payer,
+				IsSigner:   true,
+				IsWritable: true,
+			},
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SysVarInstructionsPubkey,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.TokenProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		}, authorizationRulesAccountMetas(param.AuthorizationRules)...),
+		Data: data,
+	}
+}
+
+type UseV1Param struct {
+	Authority          common.PublicKey
+	DelegateRecord     *common.PublicKey
+	Token              *common.PublicKey
+	Mint               common.PublicKey
+	Metadata           common.PublicKey
+	Edition            *common.PublicKey
+	Payer              common.PublicKey
+	AuthorizationRules *common.PublicKey
+	AuthorizationData  *AuthorizationData
+}
+
+// UseV1 reduces the remaining uses of an asset
+func UseV1(param UseV1Param) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction       Instruction
+		Enum              borsh.Enum
+		AuthorizationData *AuthorizationData
+	}{
+		Instruction:       InstructionUse,
+		Enum:              0,
+		AuthorizationData: param.AuthorizationData,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.MetaplexTokenMetaProgramID,
+		Accounts: append([]types.AccountMeta{
+			{
+				PubKey:     param.Authority,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			optionalAccountMeta(param.DelegateRecord, true),
+			optionalAccountMeta(param.Token, true),
+			{
+				PubKey:     param.Mint,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.Metadata,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			optionalAccountMeta(param.Edition, false),
+			{
+				PubKey:     param.Payer,
+				IsSigner:   true,
+				IsWritable: true,
+			},
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SysVarInstructionsPubkey,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.TokenProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		}, authorizationRulesAccountMetas(param.AuthorizationRules)...),
+		Data: data,
+	}
+}
+
+type VerifyV1Param struct {
+	Authority      common.PublicKey
+	DelegateRecord *common.PublicKey
+	Metadata       common.PublicKey
+	// collection accounts are only used by VerificationArgsCollectionV1
+	CollectionMint          *common.PublicKey
+	CollectionMetadata      *common.PublicKey
+	CollectionMasterEdition *common.PublicKey
+	Args                    VerificationArgs
+}
+
+// VerifyV1 verifies a creator or the collection of an asset
+func VerifyV1(param VerifyV1Param) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction Instruction
+		Args        VerificationArgs
+	}{
+		Instruction: InstructionVerify,
+		Args:        param.Args,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.MetaplexTokenMetaProgramID,
+		Accounts: []types.AccountMeta{
+			{
+				PubKey:     param.Authority,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			optionalAccountMeta(param.DelegateRecord, false),
+			{
+				PubKey:     param.Metadata,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			optionalAccountMeta(param.CollectionMint, false),
+			optionalAccountMeta(param.CollectionMetadata, true),
+			optionalAccountMeta(param.CollectionMasterEdition, false),
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SysVarInstructionsPubkey,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		},
+		Data: data,
+	}
+}
+
+type UnverifyV1Param struct {
+	Authority      common.PublicKey
+	DelegateRecord *common.PublicKey
+	Metadata       common.PublicKey
+	// collection accounts are only used by VerificationArgsCollectionV1
+	CollectionMint     *common.PublicKey
+	CollectionMetadata *common.PublicKey
+	Args               VerificationArgs
+}
+
+// UnverifyV1 unverifies a creator or the collection of an asset
+func UnverifyV1(param UnverifyV1Param) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction Instruction
+		Args        VerificationArgs
+	}{
+		Instruction: InstructionUnverify,
+		Args:        param.Args,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.MetaplexTokenMetaProgramID,
+		Accounts: []types.AccountMeta{
+			{
+				PubKey:     param.Authority,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			optionalAccountMeta(param.DelegateRecord, false),
+			{
+				PubKey:     param.Metadata,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			optionalAccountMeta(param.CollectionMint, false),
+			optionalAccountMeta(param.CollectionMetadata, true),
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SysVarInstructionsPubkey,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		},
+		Data: data,
+	}
+}
+
+type PrintV1Param struct {
+	EditionMetadata          common.PublicKey
+	Edition                  common.PublicKey
+	EditionMint              common.PublicKey
+	EditionMintIsSigner      bool // the mint is created by the instruction when it signs
+	EditionTokenAccountOwner common.PublicKey
+	EditionTokenAccount      common.PublicKey
+	EditionMintAuthority     common.PublicKey
+	// EditionTokenRecord defaults to the token record of EditionTokenAccount for programmable NFTs
+	EditionTokenRecord      *common.PublicKey
+	MasterEdition           common.PublicKey
+	EditionMarker           common.PublicKey
+	Payer                   common.PublicKey
+	MasterTokenAccountOwner common.PublicKey
+	MasterTokenAccount      common.PublicKey
+	MasterMetadata          common.PublicKey
+	UpdateAuthority         common.PublicKey
+	TokenStandard           TokenStandard // token standard of the master edition
+	EditionNumber           uint64
+}
+
+// PrintV1 prints a new edition from a master edition
+func PrintV1(param PrintV1Param) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction Instruction
+		Enum        borsh.Enum
+		Edition     uint64
+	}{
+		Instruction: InstructionPrint,
+		Enum:        0,
+		Edition:     param.EditionNumber,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	editionTokenRecord := defaultTokenRecord(param.EditionTokenRecord, param.EditionMint, param.EditionTokenAccount, param.TokenStandard)
+
+	return types.Instruction{
+		ProgramID: common.MetaplexTokenMetaProgramID,
+		Accounts: []types.AccountMeta{
+			{
+				PubKey:     param.EditionMetadata,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.Edition,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.EditionMint,
+				IsSigner:   param.EditionMintIsSigner,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.EditionTokenAccountOwner,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.EditionTokenAccount,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.EditionMintAuthority,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			optionalAccountMeta(editionTokenRecord, true),
+			{
+				PubKey:     param.MasterEdition,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.EditionMarker,
+				IsSigner:   false,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.Payer,
+				IsSigner:   true,
+				IsWritable: true,
+			},
+			{
+				PubKey:     param.MasterTokenAccountOwner,
+				IsSigner:   true,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.MasterTokenAccount,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.MasterMetadata,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     param.UpdateAuthority,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.TokenProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SPLAssociatedTokenAccountProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SysVarInstructionsPubkey,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+			{
+				PubKey:     common.SystemProgramID,
+				IsSigner:   false,
+				IsWritable: false,
+			},
+		},
+		Data: data,
+	}
+}
+
+func delegateAccountMetas(delegateRecord *common.PublicKey, delegate, metadata common.PublicKey, masterEdition, tokenRecord *common.PublicKey, mint common.PublicKey, token *common.PublicKey, authority, payer common.PublicKey, authorizationRules *common.PublicKey, tokenStandard TokenStandard) []types.AccountMeta {
+	masterEdition = defaultEdition(masterEdition, mint, tokenStandard)
+	if token != nil {
+		tokenRecord = defaultTokenRecord(tokenRecord, mint, *token, tokenStandard)
+	}
+	// the spl token program is only needed to approve or revoke on the token account
+	var splTokenProgram *common.PublicKey
+	if token != nil {
+		splTokenProgram = &common.TokenProgramID
+	}
+
+	return append([]types.AccountMeta{
+		optionalAccountMeta(delegateRecord, true),
+		{
+			PubKey:     delegate,
+			IsSigner:   false,
+			IsWritable: false,
+		},
+		{
+			PubKey:     metadata,
+			IsSigner:   false,
+			IsWritable: true,
+		},
+		optionalAccountMeta(masterEdition, false),
+		optionalAccountMeta(tokenRecord, true),
+		{
+			PubKey:     mint,
+			IsSigner:   false,
+			IsWritable: false,
+		},
+		optionalAccountMeta(token, true),
+		{
+			PubKey:     authority,
+			IsSigner:   true,
+			IsWritable: false,
+		},
+		{
+			PubKey:     payer,
+			IsSigner:   true,
+			IsWritable: true,
+		},
+		{
+			PubKey:     common.SystemProgramID,
+			IsSigner:   false,
+			IsWritable: false,
+		},
+		{
+			PubKey:     common.SysVarInstructionsPubkey,
+			IsSigner:   false,
+			IsWritable: false,
+		},
+		optionalAccountMeta(splTokenProgram, false),
+	}, authorizationRulesAccountMetas(authorizationRules)...)
+}
+
+// optionalAccountMeta passes the program id in place of an account which is not provided
+func optionalAccountMeta(pubkey *common.PublicKey, isWritable bool) types.AccountMeta {
+	if pubkey == nil {
+		return types.AccountMeta{
+			PubKey:     common.MetaplexTokenMetaProgramID,
+			IsSigner:   false,
+			IsWritable: false,
+		}
+	}
+	return types.AccountMeta{
+		PubKey:     *pubkey,
+		IsSigner:   false,
+		IsWritable: isWritable,
+	}
+}
+
+// authorizationRulesAccountMetas returns the rule set and its program which come last in every instruction that checks rules
+func authorizationRulesAccountMetas(authorizationRules *common.PublicKey) []types.AccountMeta {
+	var authorizationRulesProgram *common.PublicKey
+	if authorizationRules != nil {
+		authorizationRulesProgram = &common.MetaplexTokenAuthRulesProgramID
+	}
+	return []types.AccountMeta{
+		optionalAccountMeta(authorizationRulesProgram, false),
+		optionalAccountMeta(authorizationRules, false),
+	}
+}
+
+func defaultEdition(edition *common.PublicKey, mint common.PublicKey, tokenStandard TokenStandard) *common.PublicKey {
+	if edition != nil || !isNonFungible(tokenStandard) {
+		return edition
+	}
+	masterEdition, err := GetMasterEdition(mint)
+	if err != nil {
+		panic(err)
+	}
+	return &masterEdition
+}
+
+func defaultTokenRecord(tokenRecord *common.PublicKey, mint, token common.PublicKey, tokenStandard TokenStandard) *common.PublicKey {
+	if tokenRecord != nil || tokenStandard != ProgrammableNonFungible {
+		return tokenRecord
+	}
+	pubkey, err := GetTokenRecord(mint, token)
+	if err != nil {
+		panic(err)
+	}
+	return &pubkey
+}
+
+func isNonFungible(tokenStandard TokenStandard) bool {
+	return tokenStandard == NonFungible || tokenStandard == NonFungibleEdition || tokenStandard == ProgrammableNonFungible
+}
@@ -0,0 +1,755 @@
+package token_metadata
+
+import (
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/pointer"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBurnV1(t *testing.T) {
+	type args struct {
+		param BurnV1Param
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "programmable non fungible",
+			args: args{
+				param: BurnV1Param{
+					Authority:     common.PublicKeyFromString("authority111111111111111111111111111111111"),
+					Metadata:      common.PublicKeyFromString("metadata11111111111111111111111111111111111"),
+					Mint:          common.PublicKeyFromString("mint111111111111111111111111111111111111111"),
+					Token:         common.PublicKeyFromString("token11111111111111111111111111111111111111"),
+					TokenStandard: ProgrammableNonFungible,
+					Amount:        1,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexTokenMetaProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("authority111111111111111111111111111111111"), IsSigner: true, IsWritable: true},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("metadata11111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("GuawUt2ngqmrkeUtFAVCnvA7f1U69LKmfTL3iJyBAPNJ"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("mint111111111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("token11111111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("EZPjXjnbMmxx7vVQo5Ypn3EzxxC75rCg1oRHEUiFtB1a"), IsSigner: false, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarInstructionsPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.TokenProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{41, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, BurnV1(tt.args.param))
+		})
+	}
+}
+
+func TestCreateV1(t *testing.T) {
+	type args struct {
+		param CreateV1Param
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "programmable non fungible",
+			args: args{
+				param: CreateV1Param{
+					Metadata:                common.PublicKeyFromString("metadata11111111111111111111111111111111111"),
+					MasterEdition:           pointer.Get(common.PublicKeyFromString("masterEdition111111111111111111111111111111")),
+					Mint:                    common.PublicKeyFromString("mint111111111111111111111111111111111111111"),
+					MintIsSigner:            true,
+					Authority:               common.PublicKeyFromString("authority111111111111111111111111111111111"),
+					Payer:                   common.PublicKeyFromString("payer11111111111111111111111111111111111111"),
+					UpdateAuthority:         common.PublicKeyFromString("authority111111111111111111111111111111111"),
+					UpdateAuthorityIsSigner: true,
+					AssetData: AssetData{
+						Name:                 "pNFT",
+						Symbol:               "P",
+						Uri:                  "u",
+						SellerFeeBasisPoints: 500,
+						IsMutable:            true,
+						TokenStandard:        ProgrammableNonFungible,
+						RuleSet:              pointer.Get(common.PublicKeyFromString("11111111111111111111111111111111")),
+					},
+					Decimals:    pointer.Get[uint8](0),
+					PrintSupply: &PrintSupply{Enum: PrintSupplyLimited, Limited: LimitedPrintSupply{MaxSupply: 2}},
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexTokenMetaProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("metadata11111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("masterEdition111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("mint111111111111111111111111111111111111111"), IsSigner: true, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("authority111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("payer11111111111111111111111111111111111111"), IsSigner: true, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("authority111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarInstructionsPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.TokenProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{
+					42, 0,
+					4, 0, 0, 0, 'p', 'N', 'F', 'T',
+					1, 0, 0, 0, 'P',
+					1, 0, 0, 0, 'u',
+					244, 1,
+					0,
+					0, 1,
+					4,
+					0, 0, 0,
+					1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+					1, 0,
+					1, 1, 2, 0, 0, 0, 0, 0, 0, 0,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CreateV1(tt.args.param))
+		})
+	}
+}
+
+func TestMintV1(t *testing.T) {
+	type args struct {
+		param MintV1Param
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "programmable non fungible",
+			args: args{
+				param: MintV1Param{
+					Token:              common.PublicKeyFromString("token11111111111111111111111111111111111111"),
+					TokenOwner:         pointer.Get(common.PublicKeyFromString("owner11111111111111111111111111111111111111")),
+					Metadata:           common.PublicKeyFromString("metadata11111111111111111111111111111111111"),
+					Mint:               common.PublicKeyFromString("mint111111111111111111111111111111111111111"),
+					Authority:          common.PublicKeyFromString("authority111111111111111111111111111111111"),
+					Payer:              common.PublicKeyFromString("payer11111111111111111111111111111111111111"),
+					AuthorizationRules: pointer.Get(common.PublicKeyFromString("ruLeSet111111111111111111111111111111111111")),
+					TokenStandard:      ProgrammableNonFungible,
+					Amount:             1,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexTokenMetaProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("token11111111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("metadata11111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("GuawUt2ngqmrkeUtFAVCnvA7f1U69LKmfTL3iJyBAPNJ"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("EZPjXjnbMmxx7vVQo5Ypn3EzxxC75rCg1oRHEUiFtB1a"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("mint111111111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("authority111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("payer11111111111111111111111111111111111111"), IsSigner: true, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarInstructionsPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.TokenProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SPLAssociatedTokenAccountProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenAuthRulesProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("ruLeSet111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{43, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MintV1(tt.args.param))
+		})
+	}
+}
+
+func TestTransferV1(t *testing.T) {
+	type args struct {
+		param TransferV1Param
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "programmable non fungible",
+			args: args{
+				param: TransferV1Param{
+					Token:              common.PublicKeyFromString("token11111111111111111111111111111111111111"),
+					TokenOwner:         common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					Destination:        common.PublicKeyFromString("destination1111111111111111111111111111111"),
+					DestinationOwner:   common.PublicKeyFromString("destinationowner11111111111111111111111111"),
+					Mint:               common.PublicKeyFromString("mint111111111111111111111111111111111111111"),
+					Metadata:           common.PublicKeyFromString("metadata11111111111111111111111111111111111"),
+					Authority:          common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					Payer:              common.PublicKeyFromString("payer11111111111111111111111111111111111111"),
+					AuthorizationRules: pointer.Get(common.PublicKeyFromString("ruLeSet111111111111111111111111111111111111")),
+					TokenStandard:      ProgrammableNonFungible,
+					Amount:             1,
+					AuthorizationData: &AuthorizationData{
+						Payload: Payload{
+							Map: map[string]PayloadType{
+								"Amount": {Enum: PayloadTypeNumber, Number: PayloadNumber{Number: 1}},
+							},
+						},
+					},
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexTokenMetaProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("token11111111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("destination1111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("destinationowner11111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("mint111111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("metadata11111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("GuawUt2ngqmrkeUtFAVCnvA7f1U69LKmfTL3iJyBAPNJ"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("EZPjXjnbMmxx7vVQo5Ypn3EzxxC75rCg1oRHEUiFtB1a"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("6ngVh9mt3XsKiHwnhWnEqcpf9uLsUUCQFd8aXKoyYX1B"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("payer11111111111111111111111111111111111111"), IsSigner: true, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarInstructionsPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.TokenProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SPLAssociatedTokenAccountProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenAuthRulesProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("ruLeSet111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{49, 0, 1, 0, 0, 0, 0, 0, 0, 0, 1, 1, 0, 0, 0, 6, 0, 0, 0, 'A', 'm', 'o', 'u', 'n', 't', 3, 1, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+		{
+			name: "fungible",
+			args: args{
+				param: TransferV1Param{
+					Token:            common.PublicKeyFromString("token11111111111111111111111111111111111111"),
+					TokenOwner:       common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					Destination:      common.PublicKeyFromString("destination1111111111111111111111111111111"),
+					DestinationOwner: common.PublicKeyFromString("destinationowner11111111111111111111111111"),
+					Mint:             common.PublicKeyFromString("mint111111111111111111111111111111111111111"),
+					Metadata:         common.PublicKeyFromString("metadata11111111111111111111111111111111111"),
+					Authority:        common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					Payer:            common.PublicKeyFromString("payer11111111111111111111111111111111111111"),
+					TokenStandard:    Fungible,
+					Amount:           258,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexTokenMetaProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("token11111111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("destination1111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("destinationowner11111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("mint111111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("metadata11111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("payer11111111111111111111111111111111111111"), IsSigner: true, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarInstructionsPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.TokenProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SPLAssociatedTokenAccountProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{49, 0, 2, 1, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TransferV1(tt.args.param))
+		})
+	}
+}
+
+func TestUpdateV1(t *testing.T) {
+	type args struct {
+		param UpdateV1Param
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: UpdateV1Param{
+					Authority:           common.PublicKeyFromString("authority111111111111111111111111111111111"),
+					Mint:                common.PublicKeyFromString("mint111111111111111111111111111111111111111"),
+					Metadata:            common.PublicKeyFromString("metadata11111111111111111111111111111111111"),
+					Payer:               common.PublicKeyFromString("payer11111111111111111111111111111111111111"),
+					PrimarySaleHappened: pointer.Get(true),
+					Collection:          CollectionToggle{Enum: ToggleClear},
+					RuleSet:             RuleSetToggle{Enum: ToggleSet, Set: RuleSet{RuleSet: common.PublicKeyFromString("11111111111111111111111111111111")}},
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexTokenMetaProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("authority111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("mint111111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("metadata11111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("payer11111111111111111111111111111111111111"), IsSigner: true, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarInstructionsPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{
+					50, 0,
+					0, 0, 1, 1, 0,
+					1, 0, 0,
+					2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+					0,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, UpdateV1(tt.args.param))
+		})
+	}
+}
+
+func TestDelegateV1(t *testing.T) {
+	type args struct {
+		param DelegateV1Param
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "token delegate",
+			args: args{
+				param: DelegateV1Param{
+					Delegate:      common.PublicKeyFromString("delegate11111111111111111111111111111111111"),
+					Metadata:      common.PublicKeyFromString("metadata11111111111111111111111111111111111"),
+					Mint:          common.PublicKeyFromString("mint111111111111111111111111111111111111111"),
+					Token:         pointer.Get(common.PublicKeyFromString("token11111111111111111111111111111111111111")),
+					Authority:     common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					Payer:         common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					TokenStandard: ProgrammableNonFungible,
+					Args: DelegateArgs{
+						Enum:   DelegateArgsSaleV1,
+						SaleV1: DelegateAmountArgs{Amount: 1},
+					},
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexTokenMetaProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("delegate11111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("metadata11111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("GuawUt2ngqmrkeUtFAVCnvA7f1U69LKmfTL3iJyBAPNJ"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("EZPjXjnbMmxx7vVQo5Ypn3EzxxC75rCg1oRHEUiFtB1a"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("mint111111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("token11111111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: true, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarInstructionsPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.TokenProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{44, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+		{
+			name: "metadata delegate",
+			args: args{
+				param: DelegateV1Param{
+					DelegateRecord: pointer.Get(common.PublicKeyFromString("5EjPCwMpnU8LLVAj36hH1KAUvvjWSkyfUBTtkTjs2AV3")),
+					Delegate:       common.PublicKeyFromString("delegate11111111111111111111111111111111111"),
+					Metadata:       common.PublicKeyFromString("metadata11111111111111111111111111111111111"),
+					Mint:           common.PublicKeyFromString("mint111111111111111111111111111111111111111"),
+					Authority:      common.PublicKeyFromString("updateAuthority1111111111111111111111111111"),
+					Payer:          common.PublicKeyFromString("updateAuthority1111111111111111111111111111"),
+					TokenStandard:  ProgrammableNonFungible,
+					Args: DelegateArgs{
+						Enum: DelegateArgsCollectionV1,
+					},
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexTokenMetaProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("5EjPCwMpnU8LLVAj36hH1KAUvvjWSkyfUBTtkTjs2AV3"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("delegate11111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("metadata11111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("GuawUt2ngqmrkeUtFAVCnvA7f1U69LKmfTL3iJyBAPNJ"), IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("mint111111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("updateAuthority1111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("updateAuthority1111111111111111111111111111"), IsSigner: true, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarInstructionsPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{44, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DelegateV1(tt.args.param))
+		})
+	}
+}
+
+func TestRevokeV1(t *testing.T) {
+	type args struct {
+		param RevokeV1Param
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: RevokeV1Param{
+					Delegate:      common.PublicKeyFromString("delegate11111111111111111111111111111111111"),
+					Metadata:      common.PublicKeyFromString("metadata11111111111111111111111111111111111"),
+					Mint:          common.PublicKeyFromString("mint111111111111111111111111111111111111111"),
+					Token:         pointer.Get(common.PublicKeyFromString("token11111111111111111111111111111111111111")),
+					Authority:     common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					Payer:         common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					TokenStandard: ProgrammableNonFungible,
+					Args:          RevokeArgsSaleV1,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexTokenMetaProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("delegate11111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("metadata11111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("GuawUt2ngqmrkeUtFAVCnvA7f1U69LKmfTL3iJyBAPNJ"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("EZPjXjnbMmxx7vVQo5Ypn3EzxxC75rCg1oRHEUiFtB1a"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("mint111111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("token11111111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: true, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarInstructionsPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.TokenProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{45, 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RevokeV1(tt.args.param))
+		})
+	}
+}
+
+func TestLockV1(t *testing.T) {
+	type args struct {
+		param LockV1Param
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: LockV1Param{
+					Authority:     common.PublicKeyFromString("delegate11111111111111111111111111111111111"),
+					TokenOwner:    pointer.Get(common.PublicKeyFromString("owner11111111111111111111111111111111111111")),
+					Token:         common.PublicKeyFromString("token11111111111111111111111111111111111111"),
+					Mint:          common.PublicKeyFromString("mint111111111111111111111111111111111111111"),
+					Metadata:      common.PublicKeyFromString("metadata11111111111111111111111111111111111"),
+					Payer:         common.PublicKeyFromString("delegate11111111111111111111111111111111111"),
+					TokenStandard: ProgrammableNonFungible,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexTokenMetaProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("delegate11111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("token11111111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("mint111111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("metadata11111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("GuawUt2ngqmrkeUtFAVCnvA7f1U69LKmfTL3iJyBAPNJ"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("EZPjXjnbMmxx7vVQo5Ypn3EzxxC75rCg1oRHEUiFtB1a"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("delegate11111111111111111111111111111111111"), IsSigner: true, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarInstructionsPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.TokenProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{46, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, LockV1(tt.args.param))
+			tt.want.Data[0] = byte(InstructionUnlock)
+			assert.Equal(t, tt.want, UnlockV1(tt.args.param))
+		})
+	}
+}
+
+func TestUseV1(t *testing.T) {
+	type args struct {
+		param UseV1Param
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: UseV1Param{
+					Authority: common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					Token:     pointer.Get(common.PublicKeyFromString("token11111111111111111111111111111111111111")),
+					Mint:      common.PublicKeyFromString("mint111111111111111111111111111111111111111"),
+					Metadata:  common.PublicKeyFromString("metadata11111111111111111111111111111111111"),
+					Payer:     common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexTokenMetaProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("token11111111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("mint111111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("metadata11111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: true, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarInstructionsPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.TokenProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{51, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, UseV1(tt.args.param))
+		})
+	}
+}
+
+func TestVerifyV1(t *testing.T) {
+	type args struct {
+		param VerifyV1Param
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "creator",
+			args: args{
+				param: VerifyV1Param{
+					Authority: common.PublicKeyFromString("authority111111111111111111111111111111111"),
+					Metadata:  common.PublicKeyFromString("metadata11111111111111111111111111111111111"),
+					Args:      VerificationArgsCreatorV1,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexTokenMetaProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("authority111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("metadata11111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarInstructionsPubkey, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{52, 0},
+			},
+		},
+		{
+			name: "collection",
+			args: args{
+				param: VerifyV1Param{
+					Authority:               common.PublicKeyFromString("authority111111111111111111111111111111111"),
+					Metadata:                common.PublicKeyFromString("metadata11111111111111111111111111111111111"),
+					CollectionMint:          pointer.Get(common.PublicKeyFromString("cxLLectionMint1111111111111111111111111111")),
+					CollectionMetadata:      pointer.Get(common.PublicKeyFromString("cxLLectionMetadata111111111111111111111111")),
+					CollectionMasterEdition: pointer.Get(common.PublicKeyFromString("cxLLectionMasterEdition1111111111111111111")),
+					Args:                    VerificationArgsCollectionV1,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexTokenMetaProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("authority111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("metadata11111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("cxLLectionMint1111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("cxLLectionMetadata111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("cxLLectionMasterEdition1111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarInstructionsPubkey, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{52, 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, VerifyV1(tt.args.param))
+		})
+	}
+}
+
+func TestUnverifyV1(t *testing.T) {
+	type args struct {
+		param UnverifyV1Param
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: UnverifyV1Param{
+					Authority:          common.PublicKeyFromString("authority111111111111111111111111111111111"),
+					Metadata:           common.PublicKeyFromString("metadata11111111111111111111111111111111111"),
+					CollectionMint:     pointer.Get(common.PublicKeyFromString("cxLLectionMint1111111111111111111111111111")),
+					CollectionMetadata: pointer.Get(common.PublicKeyFromString("cxLLectionMetadata111111111111111111111111")),
+					Args:               VerificationArgsCollectionV1,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexTokenMetaProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("authority111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.MetaplexTokenMetaProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("metadata11111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("cxLLectionMint1111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("cxLLectionMetadata111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarInstructionsPubkey, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{53, 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, UnverifyV1(tt.args.param))
+		})
+	}
+}
+
+func TestPrintV1(t *testing.T) {
+	type args struct {
+		param PrintV1Param
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: PrintV1Param{
+					EditionMetadata:          common.PublicKeyFromString("editionMetadata1111111111111111111111111111"),
+					Edition:                  common.PublicKeyFromString("edition1111111111111111111111111111111111111"),
+					EditionMint:              common.PublicKeyFromString("editionMint1111111111111111111111111111111"),
+					EditionMintIsSigner:      true,
+					EditionTokenAccountOwner: common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					EditionTokenAccount:      common.PublicKeyFromString("editionTokenAccount111111111111111111111111"),
+					EditionMintAuthority:     common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					MasterEdition:            common.PublicKeyFromString("masterEdition111111111111111111111111111111"),
+					EditionMarker:            common.PublicKeyFromString("editionMark11111111111111111111111111111111"),
+					Payer:                    common.PublicKeyFromString("payer11111111111111111111111111111111111111"),
+					MasterTokenAccountOwner:  common.PublicKeyFromString("owner11111111111111111111111111111111111111"),
+					MasterTokenAccount:       common.PublicKeyFromString("token11111111111111111111111111111111111111"),
+					MasterMetadata:           common.PublicKeyFromString("metadata11111111111111111111111111111111111"),
+					UpdateAuthority:          common.PublicKeyFromString("updateAuthority1111111111111111111111111111"),
+					TokenStandard:            ProgrammableNonFungible,
+					EditionNumber:            1,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.MetaplexTokenMetaProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("editionMetadata1111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("edition1111111111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("editionMint1111111111111111111111111111111"), IsSigner: true, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("editionTokenAccount111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("ERfXGH2WxMMyR2GhnCQu73Ww11F6t2JYbeUCjbM1ZGPs"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("masterEdition111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("editionMark11111111111111111111111111111111"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("payer11111111111111111111111111111111111111"), IsSigner: true, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("owner11111111111111111111111111111111111111"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("token11111111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("metadata11111111111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("updateAuthority1111111111111111111111111111"), IsSigner: false, IsWritable: false},
+					{PubKey: common.TokenProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SPLAssociatedTokenAccountProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarInstructionsPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{55, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PrintV1(tt.args.param))
+		})
+	}
+}
@@ -23,11 +23,15 @@ const (
 	KeyEditionMarker
 	KeyUseAuthorityRecord
 	KeyCollectionAuthorityRecord
-	KeyUnknown          // FIXME: could not find this in the metaplex docs
+	KeyTokenOwnedEscrow
 	KeyTokenRecord      // 11
 	KeyMetadataDelegate // 12
+	KeyEditionMarkerV2  // 13
 )
 
+// Deprecated: the key is KeyTokenOwnedEscrow
+const KeyUnknown = KeyTokenOwnedEscrow
+
 type Creator struct {
 	Address  common.PublicKey
 	Verified bool
@@ -142,75 +146,165 @@ func MetadataDeserialize(data []byte) (Metadata, error) {
 	return metadata, nil
 }
 
+type MasterEditionV1 struct {
+	Key                              Key
+	Supply                           uint64
+	MaxSupply                        *uint64
+	PrintingMint                     common.PublicKey
+	OneTimePrintingAuthorizationMint common.PublicKey
+}
+
 type MasterEditionV2 struct {
 	Key       Key
 	Supply    uint64
 	MaxSupply *uint64
 }
 
+// Edition is a print of a master edition
+type Edition struct {
+	Key     Key
+	Parent  common.PublicKey // the master edition account
+	Edition uint64
+}
+
+// EditionMarker keeps track of which editions of a range of EDITION_MARKER_BIT_SIZE were printed
+type EditionMarker struct {
+	Key    Key
+	Ledger [31]uint8
+}
+
+// EditionTaken reports whether the edition was printed, the edition must belong to the marker
+func (m EditionMarker) EditionTaken(edition uint64) bool {
+	offset := edition % EDITION_MARKER_BIT_SIZE
+	return m.Ledger[offset/8]&(1<<(7-offset%8)) != 0
+}
+
+// EditionMarkerV2 is the edition marker of programmable NFTs, its ledger grows as editions are printed
+type EditionMarkerV2 struct {
+	Key    Key
+	Ledger []uint8
+}
+
+// EditionTaken reports whether the edition was printed
+func (m EditionMarkerV2) EditionTaken(edition uint64) bool {
+	index := edition / 8
+	if index >= uint64(len(m.Ledger)) {
+		return false
+	}
+	return m.Ledger[index]&(1<<(7-edition%8)) != 0
+}
+
 type UseAuthorityRecord struct {
 	Key         Key
 	AllowedUses uint64
-	Bump        *uint8
+	Bump        uint8
 }
 
 type CollectionAuthorityRecord struct {
-	Key  Key
-	Bump *uint8
+	Key             Key
+	Bump            uint8
+	UpdateAuthority *common.PublicKey
+}
+
+type EscrowAuthority struct {
+	Enum       borsh.Enum `borsh_enum:"true"`
+	TokenOwner struct{}
+	Creator    EscrowCreator
 }
 
+const (
+	EscrowAuthorityTokenOwner borsh.Enum = iota
+	EscrowAuthorityCreator
+)
+
+type EscrowCreator struct {
+	Creator common.PublicKey
+}
+
+type TokenOwnedEscrow struct {
+	Key       Key
+	BaseToken common.PublicKey
+	Authority EscrowAuthority
+	Bump      uint8
+}
+
+// TokenState is the state of a token record, the values follow the program.
+// the former TokenStateUninitialized ... TokenStateMint never matched the program and have no counterpart.
 type TokenState borsh.Enum
 
 const (
-	TokenStateUninitialized TokenState = iota
-	TokenStateOwnerTransfer
-	TokenStateDelegateTransfer
-	TokenStateOwnerBurn
-	TokenStateDelegateBurn
-	TokenStateOwnerRevoke
-	TokenStateOwnerApprove
-	TokenStateOwnerUnlock
-	TokenStateDelegateUnlock
-	TokenStateOwnerLock
-	TokenStateDelegateLock
-	TokenStateMint
+	TokenStateUnlocked TokenState = iota
+	TokenStateLocked
+	TokenStateListed
 )
 
-// TokenDelegateRole represents the different delegates types. There are six different values and instrution are restricted depending on the token delegate role and token state values.
+// TokenDelegateRole represents the different delegates types. There are seven different values and instruction are restricted depending on the token delegate role and token state values.
+// the values follow the program. there is no none role, a token record without a delegate has a nil DelegateRole.
 type TokenDelegateRole borsh.Enum
 
 const (
-	TokenDelegateRoleNone TokenDelegateRole = iota
-	TokenDelegateRoleSale
+	TokenDelegateRoleSale TokenDelegateRole = iota
 	TokenDelegateRoleTransfer
-	TokenDelegateRoleLockedTransfer
 	TokenDelegateRoleUtility
 	TokenDelegateRoleStaking
-	TokenDelegateRoleMigration
 	TokenDelegateRoleStandard
+	TokenDelegateRoleLockedTransfer
+	TokenDelegateRoleMigration
 )
 
 // https://github.com/metaplex-foundation/metaplex-program-library/blob/master/token-metadata/program/ProgrammableNFTGuide.md#token-delegate
 type TokenRecord struct {
-	Key               Key
-	Bump              uint8
-	State             TokenState
-	rule_set_revision *uint8
-	delegate          *common.PublicKey
-	delegate_role     *TokenDelegateRole
-	locked_transfer   *common.PublicKey
+	Key             Key
+	Bump            uint8
+	State           TokenState
+	RuleSetRevision *uint64
+	Delegate        *common.PublicKey
+	DelegateRole    *TokenDelegateRole
+	LockedTransfer  *common.PublicKey
 }
 
 // MetadataDelegates are delegates that operate at the metadata level.
+// the values follow MetadataDelegateRole of the program, AuthorityItem is 0 so Collection is 1 and Use is 2.
 // https://github.com/metaplex-foundation/metaplex-program-library/blob/master/token-metadata/program/ProgrammableNFTGuide.md#metadata-delegates
 type MetadataDelegate borsh.Enum
 
 const (
-	MetadataDelegateCollection MetadataDelegate = iota
+	MetadataDelegateAuthorityItem MetadataDelegate = iota
+	MetadataDelegateCollection
 	MetadataDelegateUse
-	MetadataDelegateUpdate
+	MetadataDelegateData
+	MetadataDelegateProgrammableConfig
+	MetadataDelegateDataItem
+	MetadataDelegateCollectionItem
+	MetadataDelegateProgrammableConfigItem
 )
 
+// Deprecated: the update delegate was renamed to the data delegate
+const MetadataDelegateUpdate = MetadataDelegateData
+
+// Seed returns the seed of the delegate record pda
+func (d MetadataDelegate) Seed() string {
+	switch d {
+	case MetadataDelegateAuthorityItem:
+		return "authority_item_delegate"
+	case MetadataDelegateCollection:
+		return "collection_delegate"
+	case MetadataDelegateUse:
+		return "use_delegate"
+	case MetadataDelegateData:
+		return "data_delegate"
+	case MetadataDelegateProgrammableConfig:
+		return "programmable_config_delegate"
+	case MetadataDelegateDataItem:
+		return "data_item_delegate"
+	case MetadataDelegateCollectionItem:
+		return "collection_item_delegate"
+	case MetadataDelegateProgrammableConfigItem:
+		return "prog_config_item_delegate"
+	}
+	return ""
+}
+
 type MetadataDelegateRecord struct {
 	Key             Key
 	Bump            uint8
@@ -218,3 +312,121 @@ type MetadataDelegateRecord struct {
 	Delegate        common.PublicKey
 	UpdateAuthority common.PublicKey
 }
+
+// GetAccountKey returns the key which leads every account of the program
+func GetAccountKey(data []byte) (Key, error) {
+	if len(data) == 0 {
+		return 0, ErrInvalidAccountDataSize
+	}
+	return Key(data[0]), nil
+}
+
+// AccountDeserialize decodes an account of the program by its key. It returns one of
+// Metadata, MasterEditionV1, MasterEditionV2, Edition, EditionMarker, EditionMarkerV2,
+// UseAuthorityRecord, CollectionAuthorityRecord, TokenOwnedEscrow, TokenRecord and MetadataDelegateRecord.
+func AccountDeserialize(data []byte) (any, error) {
+	key, err := GetAccountKey(data)
+	if err != nil {
+		return nil, err
+	}
+	switch key {
+	case KeyMetadataV1:
+		return MetadataDeserialize(data)
+	case KeyMasterEditionV1:
+		return MasterEditionV1Deserialize(data)
+	case KeyMasterEditionV2:
+		return MasterEditionV2Deserialize(data)
+	case KeyEditionV1:
+		return EditionDeserialize(data)
+	case KeyEditionMarker:
+		return EditionMarkerDeserialize(data)
+	case KeyEditionMarkerV2:
+		return EditionMarkerV2Deserialize(data)
+	case KeyUseAuthorityRecord:
+		return UseAuthorityRecordDeserialize(data)
+	case KeyCollectionAuthorityRecord:
+		return CollectionAuthorityRecordDeserialize(data)
+	case KeyTokenOwnedEscrow:
+		return TokenOwnedEscrowDeserialize(data)
+	case KeyTokenRecord:
+		return TokenRecordDeserialize(data)
+	case KeyMetadataDelegate:
+		return MetadataDelegateRecordDeserialize(data)
+	}
+	return nil, fmt.Errorf("%w, key: %v", ErrUnsupportedAccountKey, key)
+}
+
+func MasterEditionV1Deserialize(data []byte) (MasterEditionV1, error) {
+	var masterEdition MasterEditionV1
+	err := deserializeAccount(&masterEdition, KeyMasterEditionV1, data)
+	return masterEdition, err
+}
+
+func MasterEditionV2Deserialize(data []byte) (MasterEditionV2, error) {
+	var masterEdition MasterEditionV2
+	err := deserializeAccount(&masterEdition, KeyMasterEditionV2, data)
+	return masterEdition, err
+}
+
+func EditionDeserialize(data []byte) (Edition, error) {
+	var edition Edition
+	err := deserializeAccount(&edition, KeyEditionV1, data)
+	return edition, err
+}
+
+func EditionMarkerDeserialize(data []byte) (EditionMarker, error) {
+	var editionMarker EditionMarker
+	err := deserializeAccount(&editionMarker, KeyEditionMarker, data)
+	return editionMarker, err
+}
+
+func EditionMarkerV2Deserialize(data []byte) (EditionMarkerV2, error) {
+	var editionMarker EditionMarkerV2
+	err := deserializeAccount(&editionMarker, KeyEditionMarkerV2, data)
+	return editionMarker, err
+}
+
+func UseAuthorityRecordDeserialize(data []byte) (UseAuthorityRecord, error) {
+	var record UseAuthorityRecord
+	err := deserializeAccount(&record, KeyUseAuthorityRecord, data)
+	return record, err
+}
+
+func CollectionAuthorityRecordDeserialize(data []byte) (CollectionAuthorityRecord, error) {
+	var record CollectionAuthorityRecord
+	err := deserializeAccount(&record, KeyCollectionAuthorityRecord, data)
+	return record, err
+}
+
+func TokenOwnedEscrowDeserialize(data []byte) (TokenOwnedEscrow, error) {
+	var escrow TokenOwnedEscrow
+	err := deserializeAccount(&escrow, KeyTokenOwnedEscrow, data)
+	return escrow, err
+}
+
+func TokenRecordDeserialize(data []byte) (TokenRecord, error) {
+	var record TokenRecord
+	err := deserializeAccount(&record, KeyTokenRecord, data)
+	return record, err
+}
+
+func MetadataDelegateRecordDeserialize(data []byte) (MetadataDelegateRecord, error) {
+	var record MetadataDelegateRecord
+	err := deserializeAccount(&record, KeyMetadataDelegate, data)
+	return record, err
+}
+
+// deserializeAccount checks the key and decodes the account, accounts are usually larger than their content
+func deserializeAccount(v any, key Key, data []byte) error {
+	got, err := GetAccountKey(data)
+	if err != nil {
+		return err
+	}
+	if got != key {
+		return fmt.Errorf("%w, expected: %v, got: %v", ErrUnexpectedAccountKey, key, got)
+	}
+	if err := borsh.Deserialize(v, data); err != nil {
+		return fmt.Errorf("%w, err: %v", ErrInvalidAccountData, err)
+	}
+	return nil
+}
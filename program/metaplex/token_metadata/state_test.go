@@ -5,6 +5,7 @@ import (
 
 	"github.com/EntySquare/solana-go-sdk/common"
 	"github.com/EntySquare/solana-go-sdk/pkg/pointer"
+	"github.com/near/borsh-go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -161,3 +162,227 @@ func TestMetadataDeserialize(t *testing.T) {
 		})
 	}
 }
+
+func TestMasterEditionV2Deserialize(t *testing.T) {
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		want MasterEditionV2
+		err  error
+	}{
+		{
+			args: args{
+				data: append([]byte{6, 2, 0, 0, 0, 0, 0, 0, 0, 1, 10, 0, 0, 0, 0, 0, 0, 0}, make([]byte, 264)...),
+			},
+			want: MasterEditionV2{
+				Key:       KeyMasterEditionV2,
+				Supply:    2,
+				MaxSupply: pointer.Get[uint64](10),
+			},
+			err: nil,
+		},
+		{
+			name: "unexpected key",
+			args: args{
+				data: append([]byte{1}, make([]byte, 40)...),
+			},
+			want: MasterEditionV2{},
+			err:  ErrUnexpectedAccountKey,
+		},
+		{
+			name: "empty",
+			args: args{
+				data: []byte{},
+			},
+			want: MasterEditionV2{},
+			err:  ErrInvalidAccountDataSize,
+		},
+		{
+			name: "truncated",
+			args: args{
+				data: []byte{6, 2, 0, 0, 0, 0, 0, 0, 0, 1},
+			},
+			want: MasterEditionV2{},
+			err:  ErrInvalidAccountData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MasterEditionV2Deserialize(tt.args.data)
+			assert.ErrorIs(t, err, tt.err)
+			if tt.err == nil {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEditionMarker_EditionTaken(t *testing.T) {
+	marker, err := EditionMarkerDeserialize(append([]byte{7, 0b01000000, 0b00000001}, make([]byte, 29)...))
+	assert.NoError(t, err)
+	assert.False(t, marker.EditionTaken(0))
+	assert.True(t, marker.EditionTaken(1))
+	assert.True(t, marker.EditionTaken(15))
+	assert.True(t, marker.EditionTaken(249))
+	assert.False(t, marker.EditionTaken(2))
+
+	markerV2, err := EditionMarkerV2Deserialize([]byte{13, 1, 0, 0, 0, 0b10000000})
+	assert.NoError(t, err)
+	assert.True(t, markerV2.EditionTaken(0))
+	assert.False(t, markerV2.EditionTaken(1))
+	assert.False(t, markerV2.EditionTaken(8))
+}
+
+func TestAccountDeserialize(t *testing.T) {
+	pubkey := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		want any
+		err  error
+	}{
+		{
+			name: "master edition v1",
+			args: args{
+				data: append(append(append([]byte{2, 1, 0, 0, 0, 0, 0, 0, 0, 0}, pubkey.Bytes()...), pubkey.Bytes()...), 0),
+			},
+			want: MasterEditionV1{
+				Key:                              KeyMasterEditionV1,
+				Supply:                           1,
+				PrintingMint:                     pubkey,
+				OneTimePrintingAuthorizationMint: pubkey,
+			},
+		},
+		{
+			name: "edition",
+			args: args{
+				data: append(append([]byte{1}, pubkey.Bytes()...), 3, 0, 0, 0, 0, 0, 0, 0, 0, 0),
+			},
+			want: Edition{
+				Key:     KeyEditionV1,
+				Parent:  pubkey,
+				Edition: 3,
+			},
+		},
+		{
+			name: "token record",
+			args: args{
+				data: append(append(append([]byte{11, 255, 1, 0, 1}, pubkey.Bytes()...), 1, 0, 0), make([]byte, 41)...),
+			},
+			want: TokenRecord{
+				Key:          KeyTokenRecord,
+				Bump:         255,
+				State:        TokenStateLocked,
+				Delegate:     pointer.Get(pubkey),
+				DelegateRole: pointer.Get(TokenDelegateRoleSale),
+			},
+		},
+		{
+			name: "metadata delegate record",
+			args: args{
+				data: append(append(append(append([]byte{12, 254}, pubkey.Bytes()...), pubkey.Bytes()...), pubkey.Bytes()...), 0),
+			},
+			want: MetadataDelegateRecord{
+				Key:             KeyMetadataDelegate,
+				Bump:            254,
+				Mint:            pubkey,
+				Delegate:        pubkey,
+				UpdateAuthority: pubkey,
+			},
+		},
+		{
+			name: "collection authority record",
+			args: args{
+				data: append([]byte{9, 253, 1}, pubkey.Bytes()...),
+			},
+			want: CollectionAuthorityRecord{
+				Key:             KeyCollectionAuthorityRecord,
+				Bump:            253,
+				UpdateAuthority: pointer.Get(pubkey),
+			},
+		},
+		{
+			name: "token owned escrow",
+			args: args{
+				data: append(append(append([]byte{10}, pubkey.Bytes()...), 1), append(pubkey.Bytes(), 252)...),
+			},
+			want: TokenOwnedEscrow{
+				Key:       KeyTokenOwnedEscrow,
+				BaseToken: pubkey,
+				Authority: EscrowAuthority{
+					Enum:    EscrowAuthorityCreator,
+					Creator: EscrowCreator{Creator: pubkey},
+				},
+				Bump: 252,
+			},
+		},
+		{
+			name: "reservation list",
+			args: args{
+				data: []byte{5, 0, 0},
+			},
+			want: nil,
+			err:  ErrUnsupportedAccountKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AccountDeserialize(tt.args.data)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// the values are what the program writes, they must not move
+func TestTokenRecordEnums(t *testing.T) {
+	assert.Equal(t, []TokenState{0, 1, 2}, []TokenState{TokenStateUnlocked, TokenStateLocked, TokenStateListed})
+	assert.Equal(t,
+		[]TokenDelegateRole{0, 1, 2, 3, 4, 5, 6},
+		[]TokenDelegateRole{
+			TokenDelegateRoleSale,
+			TokenDelegateRoleTransfer,
+			TokenDelegateRoleUtility,
+			TokenDelegateRoleStaking,
+			TokenDelegateRoleStandard,
+			TokenDelegateRoleLockedTransfer,
+			TokenDelegateRoleMigration,
+		},
+	)
+	assert.Equal(t, Key(10), KeyTokenOwnedEscrow)
+	assert.Equal(t, KeyTokenOwnedEscrow, KeyUnknown)
+}
+
+// the borsh value is the MetadataDelegateRole of the program and the seed is its delegate record seed
+func TestMetadataDelegate(t *testing.T) {
+	tests := []struct {
+		role  MetadataDelegate
+		value []byte
+		seed  string
+	}{
+		{role: MetadataDelegateAuthorityItem, value: []byte{0}, seed: "authority_item_delegate"},
+		{role: MetadataDelegateCollection, value: []byte{1}, seed: "collection_delegate"},
+		{role: MetadataDelegateUse, value: []byte{2}, seed: "use_delegate"},
+		{role: MetadataDelegateData, value: []byte{3}, seed: "data_delegate"},
+		{role: MetadataDelegateProgrammableConfig, value: []byte{4}, seed: "programmable_config_delegate"},
+		{role: MetadataDelegateDataItem, value: []byte{5}, seed: "data_item_delegate"},
+		{role: MetadataDelegateCollectionItem, value: []byte{6}, seed: "collection_item_delegate"},
+		{role: MetadataDelegateProgrammableConfigItem, value: []byte{7}, seed: "prog_config_item_delegate"},
+		{role: MetadataDelegateUpdate, value: []byte{3}, seed: "data_delegate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.seed, func(t *testing.T) {
+			value, err := borsh.Serialize(tt.role)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.value, value)
+			assert.Equal(t, tt.seed, tt.role.Seed())
+		})
+	}
+	assert.Equal(t, "", MetadataDelegate(8).Seed())
+}
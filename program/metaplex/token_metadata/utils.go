@@ -88,13 +88,14 @@ func GetTokenRecord(mint, tokenAccount common.PublicKey) (common.PublicKey, erro
 }
 
 // Metadata Delegate Record accounts are used to store multiple delegate authorities for a given Metadata account.
+// the role is seeded by its name, e.g. "collection_delegate", as the program does. the former 8 byte role index never matched a record.
 func GetMetadataDelegateRecord(mint, updateAuthority, delegate common.PublicKey, delegateRole MetadataDelegate) (common.PublicKey, error) {
 	pubkey, _, err := common.FindProgramAddress(
 		[][]byte{
 			[]byte("metadata"),
 			common.MetaplexTokenMetaProgramID.Bytes(),
 			mint.Bytes(),
-			uint8ToBytes(uint8(delegateRole)),
+			[]byte(delegateRole.Seed()),
 			updateAuthority.Bytes(),
 			delegate.Bytes(),
 		},
@@ -132,10 +133,3 @@ func GetUseAuthorityRecord(mint, authotity common.PublicKey) (common.PublicKey,
 	)
 	return pubkey, err
 }
-
-// Convert uint8 to bytes
-func uint8ToBytes(u uint8) []byte {
-	b := make([]byte, 8)
-	b[0] = byte(u)
-	return b
-}
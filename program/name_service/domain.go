@@ -0,0 +1,97 @@
+package name_service
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+)
+
+// ReverseLookupClass is the class of the reverse records which map a name account to its name
+var ReverseLookupClass = common.PublicKeyFromString("33m47vH6Eav6jr5Ry86XjhRft2jRBLDnDgPSHoquXi2Z")
+
+// NameOffersProgramID keeps the primary (favourite) domain of a wallet
+var NameOffersProgramID = common.PublicKeyFromString("85iDfUvr3HJyLM2zcq5BXSiDvUWfw6cSE1FfNBo8Ap29")
+
+// DomainKey is the name account of a .sol domain
+type DomainKey struct {
+	Pubkey common.PublicKey
+	// Parent is SolTldAuthority for a domain and the domain for a subdomain
+	Parent common.PublicKey
+	IsSub  bool
+}
+
+// GetDomainKey derives the name account of a domain, e.g. "alice.sol", "alice" or "pay.alice.sol"
+func GetDomainKey(domain string) (DomainKey, error) {
+	domain = strings.TrimSuffix(domain, ".sol")
+	labels := strings.Split(domain, ".")
+	for _, label := range labels {
+		if label == "" {
+			return DomainKey{}, fmt.Errorf("%w, %v", ErrInvalidDomain, domain)
+		}
+	}
+
+	switch len(labels) {
+	case 1:
+		return DomainKey{
+			Pubkey: GetNameAccountKey(GetHashName(labels[0]), common.PublicKey{}, SolTldAuthority),
+			Parent: SolTldAuthority,
+		}, nil
+	case 2:
+		parent := GetNameAccountKey(GetHashName(labels[1]), common.PublicKey{}, SolTldAuthority)
+		return DomainKey{
+			Pubkey: GetNameAccountKey(GetHashName("\x00"+labels[0]), common.PublicKey{}, parent),
+			Parent: parent,
+			IsSub:  true,
+		}, nil
+	}
+	return DomainKey{}, fmt.Errorf("%w, too many labels: %v", ErrInvalidDomain, domain)
+}
+
+// GetReverseLookupKey derives the reverse record of a name account.
+// The parent is only used for subdomains, pass nil for a domain.
+func GetReverseLookupKey(nameAccount common.PublicKey, parent *common.PublicKey) common.PublicKey {
+	nameParent := common.PublicKey{}
+	if parent != nil {
+		nameParent = *parent
+	}
+	return GetNameAccountKey(GetHashName(nameAccount.ToBase58()), ReverseLookupClass, nameParent)
+}
+
+// GetFavouriteDomainKey derives the account which keeps the primary domain of the owner
+func GetFavouriteDomainKey(owner common.PublicKey) common.PublicKey {
+	pubkey, _, _ := common.FindProgramAddress([][]byte{[]byte("favourite_domain"), owner.Bytes()}, NameOffersProgramID)
+	return pubkey
+}
+
+// ReverseLookupFromData decodes the name kept in the data of a reverse record, without the .sol suffix
+func ReverseLookupFromData(data []byte) (string, error) {
+	header, err := NameRecordHeaderFromData(data)
+	if err != nil {
+		return "", ErrInvalidAccountDataSize
+	}
+	if len(header.Data) < 4 {
+		return "", ErrInvalidAccountDataSize
+	}
+	n := binary.LittleEndian.Uint32(header.Data)
+	if uint64(len(header.Data)-4) < uint64(n) {
+		return "", ErrInvalidAccountDataSize
+	}
+	// subdomains are stored with a leading zero byte
+	return strings.TrimPrefix(string(header.Data[4:4+n]), "\x00"), nil
+}
+
+// FavouriteDomain is the primary domain a wallet has chosen
+type FavouriteDomain struct {
+	NameAccount common.PublicKey
+}
+
+func FavouriteDomainFromData(data []byte) (FavouriteDomain, error) {
+	if len(data) < 33 {
+		return FavouriteDomain{}, ErrInvalidAccountDataSize
+	}
+	return FavouriteDomain{
+		NameAccount: common.PublicKeyFromBytes(data[1:33]),
+	}, nil
+}
@@ -0,0 +1,137 @@
+package name_service
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDomainKey(t *testing.T) {
+	type args struct {
+		domain string
+	}
+	tests := []struct {
+		name string
+		args args
+		want DomainKey
+		err  error
+	}{
+		{
+			args: args{
+				domain: "blocto.sol",
+			},
+			want: DomainKey{
+				Pubkey: common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"),
+				Parent: SolTldAuthority,
+			},
+		},
+		{
+			args: args{
+				domain: "blocto",
+			},
+			want: DomainKey{
+				Pubkey: common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"),
+				Parent: SolTldAuthority,
+			},
+		},
+		{
+			args: args{
+				domain: "yihau.blocto.sol",
+			},
+			want: DomainKey{
+				Pubkey: common.PublicKeyFromString("5Cjg2Xah4Cc24yM7zsfbyBuXKZ6Wm9ZJqHa5n47vnvNz"),
+				Parent: common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"),
+				IsSub:  true,
+			},
+		},
+		{
+			args: args{
+				domain: ".sol",
+			},
+			err: ErrInvalidDomain,
+		},
+		{
+			args: args{
+				domain: "a.b.c.sol",
+			},
+			err: ErrInvalidDomain,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetDomainKey(tt.args.domain)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got err: %v, want: %v", err, tt.err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetReverseLookupKey(t *testing.T) {
+	domain := common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS")
+	assert.Equal(t, common.PublicKeyFromString("9AXAoKtvZ6kMKZX1qT8gyjdrbknukyaUXz51NGrBN6Q8"), GetReverseLookupKey(domain, nil))
+	assert.Equal(t, common.PublicKeyFromString("2G8CP2oQJfJAqJcRrvmN4wTBqEvspX9FsqcrZ6CW1Sjb"), GetReverseLookupKey(common.PublicKeyFromString("5Cjg2Xah4Cc24yM7zsfbyBuXKZ6Wm9ZJqHa5n47vnvNz"), &domain))
+}
+
+func TestGetFavouriteDomainKey(t *testing.T) {
+	assert.Equal(t, common.PublicKeyFromString("DQTj7xCr1TW4AfgiKhMYSsZyhsXHVz8ZnbEurn8nqcqH"), GetFavouriteDomainKey(common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")))
+}
+
+func TestReverseLookupFromData(t *testing.T) {
+	decode := func(s string) []byte {
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+		err  error
+	}{
+		{
+			name: "domain",
+			args: args{
+				data: decode("AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAebFjksbVKKFvPFD7mp4g1bvqG4lnz2KsWavQO9itJjh5sWOSxtUooW88UPuaniDVu+obiWfPYqxZq9A72K0mOBgAAAGJsb2N0bw=="),
+			},
+			want: "blocto",
+		},
+		{
+			name: "subdomain",
+			args: args{
+				data: decode("WK2Q1w6fmJhsGBoA3UR0iNnNGRFnfcF4Sxm+/tzw9V0ebFjksbVKKFvPFD7mp4g1bvqG4lnz2KsWavQO9itJjh5sWOSxtUooW88UPuaniDVu+obiWfPYqxZq9A72K0mOBgAAAAB5aWhhdQ=="),
+			},
+			want: "yihau",
+		},
+		{
+			name: "truncated name",
+			args: args{
+				data: append(make([]byte, 96), 7, 0, 0, 0, 'b'),
+			},
+			err: ErrInvalidAccountDataSize,
+		},
+		{
+			name: "without name",
+			args: args{
+				data: make([]byte, 96),
+			},
+			err: ErrInvalidAccountDataSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReverseLookupFromData(tt.args.data)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package name_service
+
+import "errors"
+
+var (
+	ErrInvalidAccountDataSize = errors.New("invalid account data size")
+	ErrInvalidAccountData     = errors.New("invalid account data")
+	ErrInvalidDomain          = errors.New("invalid domain")
+)
@@ -0,0 +1,222 @@
+package name_service
+
+import (
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/near/borsh-go"
+)
+
+type Instruction uint8
+
+const (
+	InstructionCreate Instruction = iota
+	InstructionUpdate
+	InstructionTransfer
+	InstructionDelete
+	InstructionRealloc
+)
+
+type CreateNameRegistryParam struct {
+	Payer     common.PublicKey
+	Name      common.PublicKey
+	NameOwner common.PublicKey
+	// HashedName is GetHashName of the name
+	HashedName []byte
+	Lamports   uint64
+	// Space is the size of the data after the header
+	Space     uint32
+	NameClass *common.PublicKey
+	// NameParent and NameParentOwner are required to create a name under a parent, e.g. a domain under .sol
+	NameParent      *common.PublicKey
+	NameParentOwner *common.PublicKey
+}
+
+// CreateNameRegistry creates a name record, the class has to sign if it is set
+func CreateNameRegistry(param CreateNameRegistryParam) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction Instruction
+		HashedName  []byte
+		Lamports    uint64
+		Space       uint32
+	}{
+		Instruction: InstructionCreate,
+		HashedName:  param.HashedName,
+		Lamports:    param.Lamports,
+		Space:       param.Space,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := []types.AccountMeta{
+		{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+		{PubKey: param.Payer, IsSigner: true, IsWritable: true},
+		{PubKey: param.Name, IsSigner: false, IsWritable: true},
+		{PubKey: param.NameOwner, IsSigner: false, IsWritable: false},
+	}
+	if param.NameClass != nil {
+		accounts = append(accounts, types.AccountMeta{PubKey: *param.NameClass, IsSigner: true, IsWritable: false})
+	} else {
+		accounts = append(accounts, types.AccountMeta{PubKey: common.PublicKey{}, IsSigner: false, IsWritable: false})
+	}
+	if param.NameParent != nil {
+		accounts = append(accounts, types.AccountMeta{PubKey: *param.NameParent, IsSigner: false, IsWritable: false})
+	} else {
+		accounts = append(accounts, types.AccountMeta{PubKey: common.PublicKey{}, IsSigner: false, IsWritable: false})
+	}
+	if param.NameParentOwner != nil {
+		accounts = append(accounts, types.AccountMeta{PubKey: *param.NameParentOwner, IsSigner: true, IsWritable: false})
+	}
+
+	return types.Instruction{
+		ProgramID: common.SPLNameServiceProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type UpdateNameRegistryParam struct {
+	Name common.PublicKey
+	// UpdateSigner is the owner, the class if it is set, or the owner of the parent
+	UpdateSigner common.PublicKey
+	// Offset is the offset in the data after the header
+	Offset uint32
+	Data   []byte
+	// NameParent is required if the owner of the parent signs
+	NameParent *common.PublicKey
+}
+
+// UpdateNameRegistry writes data into a name record
+func UpdateNameRegistry(param UpdateNameRegistryParam) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction Instruction
+		Offset      uint32
+		Data        []byte
+	}{
+		Instruction: InstructionUpdate,
+		Offset:      param.Offset,
+		Data:        param.Data,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := []types.AccountMeta{
+		{PubKey: param.Name, IsSigner: false, IsWritable: true},
+		{PubKey: param.UpdateSigner, IsSigner: true, IsWritable: false},
+	}
+	if param.NameParent != nil {
+		accounts = append(accounts, types.AccountMeta{PubKey: *param.NameParent, IsSigner: false, IsWritable: false})
+	}
+
+	return types.Instruction{
+		ProgramID: common.SPLNameServiceProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type TransferNameRegistryParam struct {
+	Name     common.PublicKey
+	Owner    common.PublicKey
+	NewOwner common.PublicKey
+	// NameClass has to sign if the record has a class
+	NameClass *common.PublicKey
+	// NameParent is required if the owner of the parent signs as Owner
+	NameParent *common.PublicKey
+}
+
+// TransferNameRegistry changes the owner of a name record
+func TransferNameRegistry(param TransferNameRegistryParam) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction Instruction
+		NewOwner    common.PublicKey
+	}{
+		Instruction: InstructionTransfer,
+		NewOwner:    param.NewOwner,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := []types.AccountMeta{
+		{PubKey: param.Name, IsSigner: false, IsWritable: true},
+		{PubKey: param.Owner, IsSigner: true, IsWritable: false},
+	}
+	if param.NameClass != nil {
+		accounts = append(accounts, types.AccountMeta{PubKey: *param.NameClass, IsSigner: true, IsWritable: false})
+	}
+	if param.NameParent != nil {
+		if param.NameClass == nil {
+			accounts = append(accounts, types.AccountMeta{PubKey: common.PublicKey{}, IsSigner: false, IsWritable: false})
+		}
+		accounts = append(accounts, types.AccountMeta{PubKey: *param.NameParent, IsSigner: false, IsWritable: false})
+	}
+
+	return types.Instruction{
+		ProgramID: common.SPLNameServiceProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type DeleteNameRegistryParam struct {
+	Name           common.PublicKey
+	Owner          common.PublicKey
+	RefundReceiver common.PublicKey
+}
+
+// DeleteNameRegistry closes a name record and refunds its lamports
+func DeleteNameRegistry(param DeleteNameRegistryParam) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionDelete,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.SPLNameServiceProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Name, IsSigner: false, IsWritable: true},
+			{PubKey: param.Owner, IsSigner: true, IsWritable: false},
+			{PubKey: param.RefundReceiver, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+type ReallocNameRegistryParam struct {
+	Payer common.PublicKey
+	Name  common.PublicKey
+	Owner common.PublicKey
+	// Space is the new size of the data after the header
+	Space uint32
+}
+
+// ReallocNameRegistry resizes the data of a name record, the payer funds or receives the rent difference
+func ReallocNameRegistry(param ReallocNameRegistryParam) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction Instruction
+		Space       uint32
+	}{
+		Instruction: InstructionRealloc,
+		Space:       param.Space,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.SPLNameServiceProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+			{PubKey: param.Payer, IsSigner: true, IsWritable: true},
+			{PubKey: param.Name, IsSigner: false, IsWritable: true},
+			{PubKey: param.Owner, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
@@ -0,0 +1,228 @@
+package name_service
+
+import (
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/pointer"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateNameRegistry(t *testing.T) {
+	type args struct {
+		param CreateNameRegistryParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "domain",
+			args: args{
+				param: CreateNameRegistryParam{
+					Payer:           common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+					Name:            common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"),
+					NameOwner:       common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					HashedName:      GetHashName("blocto"),
+					Lamports:        1000000,
+					Space:           8,
+					NameParent:      pointer.Get[common.PublicKey](SolTldAuthority),
+					NameParentOwner: pointer.Get[common.PublicKey](common.PublicKeyFromString("DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi")),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.SPLNameServiceProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: true, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKey{}, IsSigner: false, IsWritable: false},
+					{PubKey: SolTldAuthority, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi"), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{0, 32, 0, 0, 0, 98, 148, 58, 194, 158, 123, 157, 78, 56, 83, 178, 132, 221, 127, 1, 102, 235, 95, 0, 227, 31, 37, 83, 81, 131, 97, 56, 51, 205, 197, 249, 3, 64, 66, 15, 0, 0, 0, 0, 0, 8, 0, 0, 0},
+			},
+		},
+		{
+			name: "with class, without parent",
+			args: args{
+				param: CreateNameRegistryParam{
+					Payer:      common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+					Name:       common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"),
+					NameOwner:  common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					HashedName: GetHashName("blocto"),
+					Lamports:   1000000,
+					Space:      8,
+					NameClass:  pointer.Get[common.PublicKey](common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.SPLNameServiceProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: true, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKey{}, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{0, 32, 0, 0, 0, 98, 148, 58, 194, 158, 123, 157, 78, 56, 83, 178, 132, 221, 127, 1, 102, 235, 95, 0, 227, 31, 37, 83, 81, 131, 97, 56, 51, 205, 197, 249, 3, 64, 66, 15, 0, 0, 0, 0, 0, 8, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CreateNameRegistry(tt.args.param))
+		})
+	}
+}
+
+func TestUpdateNameRegistry(t *testing.T) {
+	type args struct {
+		param UpdateNameRegistryParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: UpdateNameRegistryParam{
+					Name:         common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"),
+					UpdateSigner: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					Offset:       2,
+					Data:         []byte{1, 2, 3},
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.SPLNameServiceProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{1, 2, 0, 0, 0, 3, 0, 0, 0, 1, 2, 3},
+			},
+		},
+		{
+			name: "parent owner signs",
+			args: args{
+				param: UpdateNameRegistryParam{
+					Name:         common.PublicKeyFromString("5Cjg2Xah4Cc24yM7zsfbyBuXKZ6Wm9ZJqHa5n47vnvNz"),
+					UpdateSigner: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					Offset:       2,
+					Data:         []byte{1, 2, 3},
+					NameParent:   pointer.Get[common.PublicKey](common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS")),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.SPLNameServiceProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("5Cjg2Xah4Cc24yM7zsfbyBuXKZ6Wm9ZJqHa5n47vnvNz"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"), IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{1, 2, 0, 0, 0, 3, 0, 0, 0, 1, 2, 3},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, UpdateNameRegistry(tt.args.param))
+		})
+	}
+}
+
+func TestTransferNameRegistry(t *testing.T) {
+	type args struct {
+		param TransferNameRegistryParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: TransferNameRegistryParam{
+					Name:     common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"),
+					Owner:    common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+					NewOwner: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.SPLNameServiceProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{2, 206, 211, 135, 230, 195, 111, 87, 254, 147, 239, 143, 81, 110, 159, 49, 140, 109, 137, 224, 197, 24, 49, 223, 61, 123, 8, 78, 109, 110, 136, 228, 240},
+			},
+		},
+		{
+			name: "parent owner signs",
+			args: args{
+				param: TransferNameRegistryParam{
+					Name:       common.PublicKeyFromString("5Cjg2Xah4Cc24yM7zsfbyBuXKZ6Wm9ZJqHa5n47vnvNz"),
+					Owner:      common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+					NewOwner:   common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					NameParent: pointer.Get[common.PublicKey](common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS")),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.SPLNameServiceProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("5Cjg2Xah4Cc24yM7zsfbyBuXKZ6Wm9ZJqHa5n47vnvNz"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKey{}, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"), IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{2, 206, 211, 135, 230, 195, 111, 87, 254, 147, 239, 143, 81, 110, 159, 49, 140, 109, 137, 224, 197, 24, 49, 223, 61, 123, 8, 78, 109, 110, 136, 228, 240},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TransferNameRegistry(tt.args.param))
+		})
+	}
+}
+
+func TestDeleteNameRegistry(t *testing.T) {
+	got := DeleteNameRegistry(DeleteNameRegistryParam{
+		Name:           common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"),
+		Owner:          common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+		RefundReceiver: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+	})
+	assert.Equal(t, types.Instruction{
+		ProgramID: common.SPLNameServiceProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"), IsSigner: false, IsWritable: true},
+			{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+			{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+		},
+		Data: []byte{3},
+	}, got)
+}
+
+func TestReallocNameRegistry(t *testing.T) {
+	got := ReallocNameRegistry(ReallocNameRegistryParam{
+		Payer: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+		Name:  common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"),
+		Owner: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+		Space: 100,
+	})
+	assert.Equal(t, types.Instruction{
+		ProgramID: common.SPLNameServiceProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+			{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: true, IsWritable: true},
+			{PubKey: common.PublicKeyFromString("6yAP2rFW7wQiqVmySE4DTfQSWmp6fR1geGyWx6SQMAhS"), IsSigner: false, IsWritable: true},
+			{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+		},
+		Data: []byte{4, 100, 0, 0, 0},
+	}, got)
+}
@@ -0,0 +1,12 @@
+package secp256k1
+
+import "errors"
+
+var (
+	ErrInvalidPrivateKey          = errors.New("invalid private key")
+	ErrInvalidPublicKey           = errors.New("invalid public key")
+	ErrInvalidInstructionDataSize = errors.New("invalid instruction data size")
+	ErrInvalidDataOffsets         = errors.New("invalid data offsets")
+	ErrInvalidSignature           = errors.New("invalid signature")
+	ErrInvalidRecoveryId          = errors.New("invalid recovery id")
+)
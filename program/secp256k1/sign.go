@@ -0,0 +1,116 @@
+package secp256k1
+
+import (
+	"fmt"
+	"math"
+
+	secp "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/EntySquare/solana-go-sdk/types"
+)
+
+const (
+	PrivateKeySize = 32
+	EthAddressSize = 20
+	// SignatureSize is the size of a signature with its recovery id
+	SignatureSize = 65
+)
+
+// Keccak256 hashes data with the legacy keccak-256 used by ethereum
+func Keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, b := range data {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+// EthAddressFromPublicKey returns the ethereum address of a public key, which can be compressed or uncompressed
+func EthAddressFromPublicKey(publicKey []byte) ([EthAddressSize]byte, error) {
+	pubkey, err := secp.ParsePubKey(publicKey)
+	if err != nil {
+		return [EthAddressSize]byte{}, fmt.Errorf("%w, %v", ErrInvalidPublicKey, err)
+	}
+	return ethAddress(pubkey), nil
+}
+
+// EthAddressFromPrivateKey returns the ethereum address of a private key
+func EthAddressFromPrivateKey(privateKey []byte) ([EthAddressSize]byte, error) {
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return [EthAddressSize]byte{}, err
+	}
+	return ethAddress(key.PubKey()), nil
+}
+
+// Sign signs the keccak-256 hash of the message and returns r || s || recovery id
+func Sign(privateKey []byte, message []byte) ([]byte, error) {
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	compact := ecdsa.SignCompact(key, Keccak256(message), false)
+	// compact signature is recovery code || r || s, where recovery code is 27 + recovery id
+	sig := make([]byte, 0, SignatureSize)
+	sig = append(sig, compact[1:]...)
+	return append(sig, compact[0]-27), nil
+}
+
+type SignParam struct {
+	PrivateKey []byte
+	Message    []byte
+}
+
+type NewSignedInstructionParam struct {
+	Signs []SignParam
+	// ThisInstructionIndex is the index of this instruction in the transaction
+	ThisInstructionIndex uint8
+}
+
+// NewSignedInstruction signs every message with its private key and builds the instruction
+func NewSignedInstruction(param NewSignedInstructionParam) (types.Instruction, error) {
+	if len(param.Signs) > math.MaxUint8 {
+		return types.Instruction{}, fmt.Errorf("%w, too many signatures: %v", ErrInvalidInstructionDataSize, len(param.Signs))
+	}
+	msgs := make([][]byte, 0, len(param.Signs))
+	sigs := make([][]byte, 0, len(param.Signs))
+	addrs := make([][]byte, 0, len(param.Signs))
+	size := 1
+	for i, s := range param.Signs {
+		addr, err := EthAddressFromPrivateKey(s.PrivateKey)
+		if err != nil {
+			return types.Instruction{}, fmt.Errorf("%w, index: %v", err, i)
+		}
+		sig, err := Sign(s.PrivateKey, s.Message)
+		if err != nil {
+			return types.Instruction{}, fmt.Errorf("%w, index: %v", err, i)
+		}
+		size += OffsetsSerializedSize + EthAddressSize + SignatureSize + len(s.Message)
+		if size > math.MaxUint16 {
+			return types.Instruction{}, fmt.Errorf("%w, data is too large", ErrInvalidInstructionDataSize)
+		}
+		msgs = append(msgs, s.Message)
+		sigs = append(sigs, sig)
+		addrs = append(addrs, addr[:])
+	}
+	return NewSecp256k1Instruction(msgs, sigs, addrs, param.ThisInstructionIndex)
+}
+
+func parsePrivateKey(privateKey []byte) (*secp.PrivateKey, error) {
+	if len(privateKey) != PrivateKeySize {
+		return nil, fmt.Errorf("%w, size: %v", ErrInvalidPrivateKey, len(privateKey))
+	}
+	var k secp.ModNScalar
+	if overflow := k.SetByteSlice(privateKey); overflow || k.IsZero() {
+		return nil, ErrInvalidPrivateKey
+	}
+	return secp.NewPrivateKey(&k), nil
+}
+
+func ethAddress(pubkey *secp.PublicKey) [EthAddressSize]byte {
+	var addr [EthAddressSize]byte
+	copy(addr[:], Keccak256(pubkey.SerializeUncompressed()[1:])[12:])
+	return addr
+}
@@ -0,0 +1,90 @@
+package secp256k1
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testPrivateKey(t *testing.T) []byte {
+	sk, err := base64.StdEncoding.DecodeString("bNyQVhCtQ86p9CCtzVkrg3Fm6WJqiYb+dMO4HDtbl6o=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sk
+}
+
+func TestEthAddressFromPrivateKey(t *testing.T) {
+	addr, err := EthAddressFromPrivateKey(testPrivateKey(t))
+	assert.NoError(t, err)
+	assert.Equal(t, "rx8O5L8N25rze03Dr4YXi9E+/Ys=", base64.StdEncoding.EncodeToString(addr[:]))
+
+	_, err = EthAddressFromPrivateKey(make([]byte, 32))
+	assert.ErrorIs(t, err, ErrInvalidPrivateKey)
+	_, err = EthAddressFromPrivateKey(make([]byte, 31))
+	assert.ErrorIs(t, err, ErrInvalidPrivateKey)
+}
+
+func TestEthAddressFromPublicKey(t *testing.T) {
+	_, err := EthAddressFromPublicKey([]byte{1, 2, 3})
+	assert.ErrorIs(t, err, ErrInvalidPublicKey)
+}
+
+func TestSign(t *testing.T) {
+	sig, err := Sign(testPrivateKey(t), []byte("message"))
+	assert.NoError(t, err)
+	assert.Equal(t, "K2mYts9f1v1hJc2kp2nCTZ6hZ9dhoHfADHW9zUCBftFTeN1lYUZEgoUZrklfifnZeWUJUujShZKgYtzoKMaRCgE=", base64.StdEncoding.EncodeToString(sig))
+}
+
+func TestNewSignedInstruction(t *testing.T) {
+	type args struct {
+		param NewSignedInstructionParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+		err  error
+	}{
+		{
+			args: args{
+				param: NewSignedInstructionParam{
+					Signs: []SignParam{
+						{
+							PrivateKey: testPrivateKey(t),
+							Message:    []byte("message"),
+						},
+					},
+				},
+			},
+			want: "ASAAAAwAAGEABwAArx8O5L8N25rze03Dr4YXi9E+/YsraZi2z1/W/WElzaSnacJNnqFn12Ggd8AMdb3NQIF+0VN43WVhRkSChRmuSV+J+dl5ZQlS6NKFkqBi3OgoxpEKAW1lc3NhZ2U=",
+		},
+		{
+			args: args{
+				param: NewSignedInstructionParam{
+					Signs: []SignParam{
+						{
+							PrivateKey: []byte{1},
+							Message:    []byte("message"),
+						},
+					},
+				},
+			},
+			err: ErrInvalidPrivateKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSignedInstruction(tt.args.param)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got err: %v, want: %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, base64.StdEncoding.EncodeToString(got.Data))
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package secp256k1
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/types"
+)
+
+// ParseSignatureOffsets decodes the offsets of secp256k1 instruction data
+func ParseSignatureOffsets(data []byte) ([]SecpSignatureOffsets, error) {
+	if len(data) < 1 {
+		return nil, ErrInvalidInstructionDataSize
+	}
+	n := int(data[0])
+	if n == 0 && len(data) > 1 {
+		return nil, ErrInvalidInstructionDataSize
+	}
+	if len(data) < 1+n*OffsetsSerializedSize {
+		return nil, ErrInvalidInstructionDataSize
+	}
+
+	offsets := make([]SecpSignatureOffsets, 0, n)
+	for i := 0; i < n; i++ {
+		b := data[1+i*OffsetsSerializedSize:]
+		offsets = append(offsets, SecpSignatureOffsets{
+			SignatureOffsets:           binary.LittleEndian.Uint16(b[0:2]),
+			SignatureInstructionIndex:  b[2],
+			EthAddressOffset:           binary.LittleEndian.Uint16(b[3:5]),
+			EthAddressInstructionIndex: b[5],
+			MessageDataOffset:          binary.LittleEndian.Uint16(b[6:8]),
+			MessageDataSize:            binary.LittleEndian.Uint16(b[8:10]),
+			MessageInstructionIndex:    b[10],
+		})
+	}
+	return offsets, nil
+}
+
+// RecoveredSigner is a signer recovered from a secp256k1 instruction
+type RecoveredSigner struct {
+	// EthAddress is the address recovered from the signature
+	EthAddress [EthAddressSize]byte
+	// ExpectedEthAddress is the address the instruction claims
+	ExpectedEthAddress [EthAddressSize]byte
+	Message            []byte
+}
+
+// RecoverSigners recovers the signer of every signature of secp256k1 instruction data.
+// instructionDatas are the data of all instructions in the transaction.
+func RecoverSigners(data []byte, instructionDatas [][]byte) ([]RecoveredSigner, error) {
+	offsets, err := ParseSignatureOffsets(data)
+	if err != nil {
+		return nil, err
+	}
+	signers := make([]RecoveredSigner, 0, len(offsets))
+	for i, o := range offsets {
+		if int(o.SignatureInstructionIndex) >= len(instructionDatas) {
+			return nil, fmt.Errorf("%w, index: %v, signature", ErrInvalidInstructionDataSize, i)
+		}
+		signatureInstruction := instructionDatas[o.SignatureInstructionIndex]
+		sigEnd := int(o.SignatureOffsets) + SignatureSize - 1
+		if sigEnd >= len(signatureInstruction) {
+			return nil, fmt.Errorf("%w, index: %v", ErrInvalidSignature, i)
+		}
+		recoveryId := signatureInstruction[sigEnd]
+		if recoveryId >= 4 {
+			return nil, fmt.Errorf("%w, index: %v", ErrInvalidRecoveryId, i)
+		}
+		expectedEthAddress, err := getDataSlice(instructionDatas, o.EthAddressInstructionIndex, o.EthAddressOffset, EthAddressSize)
+		if err != nil {
+			return nil, fmt.Errorf("%w, index: %v, eth address", err, i)
+		}
+		message, err := getDataSlice(instructionDatas, o.MessageInstructionIndex, o.MessageDataOffset, int(o.MessageDataSize))
+		if err != nil {
+			return nil, fmt.Errorf("%w, index: %v, message", err, i)
+		}
+
+		compact := make([]byte, 0, SignatureSize)
+		compact = append(compact, 27+recoveryId)
+		compact = append(compact, signatureInstruction[o.SignatureOffsets:sigEnd]...)
+		pubkey, _, err := ecdsa.RecoverCompact(compact, Keccak256(message))
+		if err != nil {
+			return nil, fmt.Errorf("%w, index: %v, %v", ErrInvalidSignature, i, err)
+		}
+
+		signer := RecoveredSigner{
+			EthAddress: ethAddress(pubkey),
+			Message:    message,
+		}
+		copy(signer.ExpectedEthAddress[:], expectedEthAddress)
+		signers = append(signers, signer)
+	}
+	return signers, nil
+}
+
+// Verify checks secp256k1 instruction data the same way the runtime does.
+// instructionDatas are the data of all instructions in the transaction.
+func Verify(data []byte, instructionDatas [][]byte) error {
+	signers, err := RecoverSigners(data, instructionDatas)
+	if err != nil {
+		return err
+	}
+	for i, signer := range signers {
+		if !bytes.Equal(signer.EthAddress[:], signer.ExpectedEthAddress[:]) {
+			return fmt.Errorf("%w, index: %v", ErrInvalidSignature, i)
+		}
+	}
+	return nil
+}
+
+// VerifyMessage runs Verify on every secp256k1 instruction of the message
+func VerifyMessage(message types.Message) error {
+	instructionDatas := make([][]byte, 0, len(message.Instructions))
+	for _, instruction := range message.Instructions {
+		instructionDatas = append(instructionDatas, instruction.Data)
+	}
+	for i, instruction := range message.Instructions {
+		if instruction.ProgramIDIndex >= len(message.Accounts) || message.Accounts[instruction.ProgramIDIndex] != common.Secp256k1ProgramID {
+			continue
+		}
+		if err := Verify(instruction.Data, instructionDatas); err != nil {
+			return fmt.Errorf("instruction %v: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func getDataSlice(instructionDatas [][]byte, instructionIndex uint8, offset uint16, size int) ([]byte, error) {
+	if int(instructionIndex) >= len(instructionDatas) {
+		return nil, ErrInvalidDataOffsets
+	}
+	instruction := instructionDatas[instructionIndex]
+	start := int(offset)
+	if start+size > len(instruction) {
+		return nil, ErrInvalidSignature
+	}
+	return instruction[start : start+size], nil
+}
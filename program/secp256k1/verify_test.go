@@ -0,0 +1,179 @@
+package secp256k1
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/types"
+)
+
+func testInstructionData(t *testing.T) []byte {
+	data, err := base64.StdEncoding.DecodeString("ASAAAAwAAGEABwAArx8O5L8N25rze03Dr4YXi9E+/YsraZi2z1/W/WElzaSnacJNnqFn12Ggd8AMdb3NQIF+0VN43WVhRkSChRmuSV+J+dl5ZQlS6NKFkqBi3OgoxpEKAW1lc3NhZ2U=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestParseSignatureOffsets(t *testing.T) {
+	got, err := ParseSignatureOffsets(testInstructionData(t))
+	assert.NoError(t, err)
+	assert.Equal(t, []SecpSignatureOffsets{
+		{
+			SignatureOffsets:           32,
+			SignatureInstructionIndex:  0,
+			EthAddressOffset:           12,
+			EthAddressInstructionIndex: 0,
+			MessageDataOffset:          97,
+			MessageDataSize:            7,
+			MessageInstructionIndex:    0,
+		},
+	}, got)
+
+	_, err = ParseSignatureOffsets([]byte{})
+	assert.ErrorIs(t, err, ErrInvalidInstructionDataSize)
+	_, err = ParseSignatureOffsets([]byte{0, 1})
+	assert.ErrorIs(t, err, ErrInvalidInstructionDataSize)
+	_, err = ParseSignatureOffsets([]byte{1, 0, 0})
+	assert.ErrorIs(t, err, ErrInvalidInstructionDataSize)
+}
+
+func TestRecoverSigners(t *testing.T) {
+	addr, _ := base64.StdEncoding.DecodeString("rx8O5L8N25rze03Dr4YXi9E+/Ys=")
+	data := testInstructionData(t)
+	got, err := RecoverSigners(data, [][]byte{data})
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, addr, got[0].EthAddress[:])
+	assert.Equal(t, addr, got[0].ExpectedEthAddress[:])
+	assert.Equal(t, []byte("message"), got[0].Message)
+}
+
+func TestVerify(t *testing.T) {
+	tamper := func(f func(data []byte)) []byte {
+		data := testInstructionData(t)
+		f(data)
+		return data
+	}
+	type args struct {
+		data             []byte
+		instructionDatas [][]byte
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "valid",
+			args: args{
+				data: testInstructionData(t),
+			},
+		},
+		{
+			name: "no signatures",
+			args: args{
+				data: []byte{0},
+			},
+		},
+		{
+			name: "wrong message",
+			args: args{
+				data: tamper(func(data []byte) { data[len(data)-1] = 'f' }),
+			},
+			err: ErrInvalidSignature,
+		},
+		{
+			name: "wrong eth address",
+			args: args{
+				data: tamper(func(data []byte) { data[12] ^= 1 }),
+			},
+			err: ErrInvalidSignature,
+		},
+		{
+			name: "invalid recovery id",
+			args: args{
+				data: tamper(func(data []byte) { data[96] = 4 }),
+			},
+			err: ErrInvalidRecoveryId,
+		},
+		{
+			name: "signature instruction out of range",
+			args: args{
+				data: tamper(func(data []byte) { data[3] = 1 }),
+			},
+			err: ErrInvalidInstructionDataSize,
+		},
+		{
+			name: "eth address instruction out of range",
+			args: args{
+				data: tamper(func(data []byte) { data[6] = 1 }),
+			},
+			err: ErrInvalidDataOffsets,
+		},
+		{
+			name: "message out of range",
+			args: args{
+				data: tamper(func(data []byte) { data[9] = 8 }),
+			},
+			err: ErrInvalidSignature,
+		},
+		{
+			name: "signature out of range",
+			args: args{
+				data: tamper(func(data []byte) { data[1] = 60 }),
+			},
+			err: ErrInvalidSignature,
+		},
+		{
+			name: "message in another instruction",
+			args: args{
+				data: tamper(func(data []byte) {
+					// message of the instruction at index 1 at offset 0
+					data[7], data[8], data[11] = 0, 0, 1
+				}),
+				instructionDatas: [][]byte{nil, []byte("message")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instructionDatas := tt.args.instructionDatas
+			if instructionDatas == nil {
+				instructionDatas = [][]byte{tt.args.data}
+			} else {
+				instructionDatas[0] = tt.args.data
+			}
+			err := Verify(tt.args.data, instructionDatas)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got err: %v, want: %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestVerifyMessage(t *testing.T) {
+	feePayer := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	instruction, err := NewSignedInstruction(NewSignedInstructionParam{
+		Signs: []SignParam{
+			{PrivateKey: testPrivateKey(t), Message: []byte("hello")},
+			{PrivateKey: testPrivateKey(t), Message: []byte("world")},
+		},
+		ThisInstructionIndex: 0,
+	})
+	assert.NoError(t, err)
+
+	message := types.NewMessage(types.NewMessageParam{
+		FeePayer:        feePayer,
+		RecentBlockhash: "9rAtxuhtKn8qagc3UtZFyhLrw5zgh6ha6Tg5RDaGx5Fx",
+		Instructions:    []types.Instruction{instruction},
+	})
+	assert.NoError(t, VerifyMessage(message))
+
+	message.Instructions[0].Data[len(message.Instructions[0].Data)-1] ^= 1
+	assert.ErrorIs(t, VerifyMessage(message), ErrInvalidSignature)
+}
@@ -0,0 +1,11 @@
+package stake
+
+import "errors"
+
+var (
+	ErrInvalidAccountOwner    = errors.New("invalid account owner")
+	ErrInvalidAccountDataSize = errors.New("invalid account data size")
+	ErrInvalidAccountData     = errors.New("invalid account data")
+	ErrMergeTransientStake    = errors.New("stake account with transient stake cannot be merged")
+	ErrMergeMismatch          = errors.New("stake account merge mismatch")
+)
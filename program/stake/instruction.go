@@ -20,6 +20,13 @@ const (
 	InstructionSetLockup
 	InstructionMerge
 	InstructionAuthorizeWithSeed
+	InstructionInitializeChecked
+	InstructionAuthorizeChecked
+	InstructionAuthorizeCheckedWithSeed
+	InstructionSetLockupChecked
+	InstructionGetMinimumDelegation
+	InstructionDeactivateDelinquent
+	InstructionRedelegate
 )
 
 type StakeAuthorizationType uint32
@@ -346,3 +353,232 @@ func AuthorizeWithSeed(param AuthorizeWithSeedParam) types.Instruction {
 		Data:      data,
 	}
 }
+
+type InitializeCheckedParam struct {
+	Stake      common.PublicKey
+	Staker     common.PublicKey
+	Withdrawer common.PublicKey
+}
+
+// InitializeChecked is the same as Initialize but the withdrawer has to sign and the lockup is default.
+func InitializeChecked(param InitializeCheckedParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionInitializeChecked,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Stake, IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: param.Staker, IsSigner: false, IsWritable: false},
+			{PubKey: param.Withdrawer, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type AuthorizeCheckedParam struct {
+	Stake     common.PublicKey
+	Auth      common.PublicKey
+	NewAuth   common.PublicKey
+	AuthType  StakeAuthorizationType
+	Custodian *common.PublicKey
+}
+
+// AuthorizeChecked is the same as Authorize but the new authority has to sign.
+func AuthorizeChecked(param AuthorizeCheckedParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction            Instruction
+		StakeAuthorizationType StakeAuthorizationType
+	}{
+		Instruction:            InstructionAuthorizeChecked,
+		StakeAuthorizationType: param.AuthType,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 5)
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: param.Stake, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+		types.AccountMeta{PubKey: param.Auth, IsSigner: true, IsWritable: false},
+		types.AccountMeta{PubKey: param.NewAuth, IsSigner: true, IsWritable: false},
+	)
+	if param.Custodian != nil {
+		accounts = append(accounts, types.AccountMeta{PubKey: *param.Custodian, IsSigner: true, IsWritable: false})
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type AuthorizeCheckedWithSeedParam struct {
+	Stake     common.PublicKey
+	AuthBase  common.PublicKey
+	AuthSeed  string
+	AuthOwner common.PublicKey
+	NewAuth   common.PublicKey
+	AuthType  StakeAuthorizationType
+	Custodian *common.PublicKey
+}
+
+// AuthorizeCheckedWithSeed is the same as AuthorizeWithSeed but the new authority has to sign.
+func AuthorizeCheckedWithSeed(param AuthorizeCheckedWithSeedParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction            Instruction
+		StakeAuthorizationType StakeAuthorizationType
+		AuthSeed               string
+		AuthOwner              common.PublicKey
+	}{
+		Instruction:            InstructionAuthorizeCheckedWithSeed,
+		StakeAuthorizationType: param.AuthType,
+		AuthSeed:               param.AuthSeed,
+		AuthOwner:              param.AuthOwner,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 5)
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: param.Stake, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: param.AuthBase, IsSigner: true, IsWritable: false},
+		types.AccountMeta{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+		types.AccountMeta{PubKey: param.NewAuth, IsSigner: true, IsWritable: false},
+	)
+	if param.Custodian != nil {
+		accounts = append(accounts, types.AccountMeta{PubKey: *param.Custodian, IsSigner: true, IsWritable: false})
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type SetLockupCheckedParam struct {
+	Stake         common.PublicKey
+	Auth          common.PublicKey
+	UnixTimestamp *int64
+	Epoch         *uint64
+	NewCustodian  *common.PublicKey
+}
+
+// SetLockupChecked is the same as SetLockup but the new custodian has to sign.
+func SetLockupChecked(param SetLockupCheckedParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction   Instruction
+		UnixTimestamp *int64
+		Epoch         *uint64
+	}{
+		Instruction:   InstructionSetLockupChecked,
+		UnixTimestamp: param.UnixTimestamp,
+		Epoch:         param.Epoch,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 3)
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: param.Stake, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: param.Auth, IsSigner: true, IsWritable: false},
+	)
+	if param.NewCustodian != nil {
+		accounts = append(accounts, types.AccountMeta{PubKey: *param.NewCustodian, IsSigner: true, IsWritable: false})
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// GetMinimumDelegation returns the minimum delegation as a little endian u64 in the return data.
+func GetMinimumDelegation() types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionGetMinimumDelegation,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts:  []types.AccountMeta{},
+		Data:      data,
+	}
+}
+
+type DeactivateDelinquentParam struct {
+	Stake          common.PublicKey
+	DelinquentVote common.PublicKey
+	ReferenceVote  common.PublicKey
+}
+
+// DeactivateDelinquent deactivates a stake which is delegated to a vote account that has been delinquent for at least 5 epochs.
+func DeactivateDelinquent(param DeactivateDelinquentParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionDeactivateDelinquent,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Stake, IsSigner: false, IsWritable: true},
+			{PubKey: param.DelinquentVote, IsSigner: false, IsWritable: false},
+			{PubKey: param.ReferenceVote, IsSigner: false, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type RedelegateParam struct {
+	Stake    common.PublicKey
+	NewStake common.PublicKey
+	Vote     common.PublicKey
+	Auth     common.PublicKey
+}
+
+// Redelegate moves a delegated stake to another vote account. NewStake must be an uninitialized stake account.
+func Redelegate(param RedelegateParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionRedelegate,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Stake, IsSigner: false, IsWritable: true},
+			{PubKey: param.NewStake, IsSigner: false, IsWritable: true},
+			{PubKey: param.Vote, IsSigner: false, IsWritable: false},
+			{PubKey: common.StakeConfigPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: param.Auth, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
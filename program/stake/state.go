@@ -0,0 +1,324 @@
+package stake
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/sysvar"
+)
+
+type StakeState uint32
+
+const (
+	StakeStateUninitialized StakeState = iota
+	StakeStateInitialized
+	StakeStateStake
+	StakeStateRewardsPool
+)
+
+type Meta struct {
+	RentExemptReserve uint64
+	Authorized        Authorized
+	Lockup            Lockup
+}
+
+type Delegation struct {
+	Voter              common.PublicKey
+	Stake              uint64
+	ActivationEpoch    uint64
+	DeactivationEpoch  uint64
+	WarmupCooldownRate float64
+}
+
+type Stake struct {
+	Delegation      Delegation
+	CreditsObserved uint64
+}
+
+// StakeAccount is a stake program account. Meta is valid from StakeStateInitialized, Stake is only valid in StakeStateStake.
+type StakeAccount struct {
+	State      StakeState
+	Meta       Meta
+	Stake      Stake
+	StakeFlags uint8
+}
+
+func StakeAccountFromData(data []byte) (StakeAccount, error) {
+	if uint64(len(data)) != AccountSize {
+		return StakeAccount{}, ErrInvalidAccountDataSize
+	}
+
+	state := StakeState(binary.LittleEndian.Uint32(data[:4]))
+	switch state {
+	case StakeStateUninitialized, StakeStateRewardsPool:
+		return StakeAccount{State: state}, nil
+	case StakeStateInitialized, StakeStateStake:
+	default:
+		return StakeAccount{}, ErrInvalidAccountData
+	}
+
+	meta := Meta{
+		RentExemptReserve: binary.LittleEndian.Uint64(data[4:12]),
+		Authorized: Authorized{
+			Staker:     common.PublicKeyFromBytes(data[12:44]),
+			Withdrawer: common.PublicKeyFromBytes(data[44:76]),
+		},
+		Lockup: Lockup{
+			UnixTimestamp: int64(binary.LittleEndian.Uint64(data[76:84])),
+			Epoch:         binary.LittleEndian.Uint64(data[84:92]),
+			Cusodian:      common.PublicKeyFromBytes(data[92:124]),
+		},
+	}
+	if state == StakeStateInitialized {
+		return StakeAccount{State: state, Meta: meta}, nil
+	}
+
+	return StakeAccount{
+		State: state,
+		Meta:  meta,
+		Stake: Stake{
+			Delegation: Delegation{
+				Voter:              common.PublicKeyFromBytes(data[124:156]),
+				Stake:              binary.LittleEndian.Uint64(data[156:164]),
+				ActivationEpoch:    binary.LittleEndian.Uint64(data[164:172]),
+				DeactivationEpoch:  binary.LittleEndian.Uint64(data[172:180]),
+				WarmupCooldownRate: math.Float64frombits(binary.LittleEndian.Uint64(data[180:188])),
+			},
+			CreditsObserved: binary.LittleEndian.Uint64(data[188:196]),
+		},
+		StakeFlags: data[196],
+	}, nil
+}
+
+func DeserializeStakeAccount(data []byte, accountOwner common.PublicKey) (StakeAccount, error) {
+	if accountOwner != common.StakeProgramID {
+		return StakeAccount{}, ErrInvalidAccountOwner
+	}
+	return StakeAccountFromData(data)
+}
+
+// IsInForce reports whether the lockup still restricts withdrawals. A signature of the lockup custodian lifts it.
+func (l Lockup) IsInForce(epoch uint64, unixTimestamp int64, custodian *common.PublicKey) bool {
+	if custodian != nil && *custodian == l.Cusodian {
+		return false
+	}
+	return l.UnixTimestamp > unixTimestamp || l.Epoch > epoch
+}
+
+// IsDeactivated reports whether the delegation has been deactivated.
+func (d Delegation) IsDeactivated() bool {
+	return d.DeactivationEpoch != math.MaxUint64
+}
+
+// WarmupCooldownRate is the part of the cluster effective stake which can warm up or cool down in an epoch.
+// it is the rate since the reduce stake warmup cooldown feature, which is active on every cluster.
+const WarmupCooldownRate = 0.09
+
+// StakeActivation is how the delegated lamports are bound to the vote account at an epoch
+type StakeActivation struct {
+	Effective    uint64
+	Activating   uint64
+	Deactivating uint64
+}
+
+// Activation returns the effective, activating and deactivating lamports at the epoch the same way the runtime does.
+// history is the stake history sysvar, without an entry for the epoch the delegation changed
+// the change is seen as done.
+func (d Delegation) Activation(epoch uint64, history sysvar.StakeHistory) StakeActivation {
+	effective, activating := d.effectiveAndActivating(epoch, history)
+	switch {
+	case epoch < d.DeactivationEpoch:
+		return StakeActivation{Effective: effective, Activating: activating}
+	case epoch == d.DeactivationEpoch:
+		return StakeActivation{Effective: effective, Deactivating: effective}
+	}
+
+	prev, ok := history.Get(d.DeactivationEpoch)
+	if !ok {
+		return StakeActivation{}
+	}
+	for current := d.DeactivationEpoch + 1; prev.Deactivating != 0; current++ {
+		weight := float64(effective) / float64(prev.Deactivating)
+		cooledDown := uint64(weight * float64(prev.Effective) * WarmupCooldownRate)
+		if cooledDown == 0 {
+			cooledDown = 1
+		}
+		if cooledDown >= effective {
+			effective = 0
+			break
+		}
+		effective -= cooledDown
+		if current >= epoch {
+			break
+		}
+		if prev, ok = history.Get(current); !ok {
+			break
+		}
+	}
+	return StakeActivation{Effective: effective, Deactivating: effective}
+}
+
+func (d Delegation) effectiveAndActivating(epoch uint64, history sysvar.StakeHistory) (uint64, uint64) {
+	switch {
+	// a bootstrap delegation is active from the genesis
+	case d.ActivationEpoch == math.MaxUint64:
+		return d.Stake, 0
+	case d.ActivationEpoch == d.DeactivationEpoch:
+		return 0, 0
+	case epoch == d.ActivationEpoch:
+		return 0, d.Stake
+	case epoch < d.ActivationEpoch:
+		return 0, 0
+	}
+
+	prev, ok := history.Get(d.ActivationEpoch)
+	if !ok {
+		return d.Stake, 0
+	}
+	var effective uint64
+	for current := d.ActivationEpoch + 1; prev.Activating != 0; current++ {
+		weight := float64(d.Stake-effective) / float64(prev.Activating)
+		warmedUp := uint64(weight * float64(prev.Effective) * WarmupCooldownRate)
+		if warmedUp == 0 {
+			warmedUp = 1
+		}
+		effective += warmedUp
+		if effective >= d.Stake {
+			effective = d.Stake
+			break
+		}
+		if current >= epoch || current >= d.DeactivationEpoch {
+			break
+		}
+		if prev, ok = history.Get(current); !ok {
+			break
+		}
+	}
+	return effective, d.Stake - effective
+}
+
+// EffectiveStake returns the delegated lamports which are effective at the epoch, see Activation
+func (d Delegation) EffectiveStake(epoch uint64, history sysvar.StakeHistory) uint64 {
+	return d.Activation(epoch, history).Effective
+}
+
+// Withdrawable returns how many of the lamports held by the stake account can be withdrawn at the given clock
+// and stake history. custodian is the lockup custodian which will sign the withdraw instruction, if any.
+// it is the whole balance if the account can be closed, see WithdrawLimits for what a partial withdrawal can take.
+func (s StakeAccount) Withdrawable(lamports uint64, epoch uint64, unixTimestamp int64, history sysvar.StakeHistory, custodian *common.PublicKey) uint64 {
+	partial, full := s.WithdrawLimits(lamports, epoch, unixTimestamp, history, custodian)
+	if full {
+		return lamports
+	}
+	return partial
+}
+
+// WithdrawLimits returns the largest partial withdrawal and whether the whole balance can be withdrawn.
+// the stake program takes either the whole balance, which closes an account without stake,
+// or a partial withdrawal which leaves the stake and the rent exempt reserve behind.
+// the whole delegation is staked until it is deactivated, then the stake still cooling down.
+func (s StakeAccount) WithdrawLimits(lamports uint64, epoch uint64, unixTimestamp int64, history sysvar.StakeHistory, custodian *common.PublicKey) (partial uint64, full bool) {
+	var reserve uint64
+	switch s.State {
+	case StakeStateUninitialized:
+		return lamports, true
+	case StakeStateInitialized:
+		if s.Meta.Lockup.IsInForce(epoch, unixTimestamp, custodian) {
+			return 0, false
+		}
+		reserve, full = s.Meta.RentExemptReserve, true
+	case StakeStateStake:
+		if s.Meta.Lockup.IsInForce(epoch, unixTimestamp, custodian) {
+			return 0, false
+		}
+		staked := s.Stake.Delegation.Stake
+		if epoch >= s.Stake.Delegation.DeactivationEpoch {
+			staked = s.Stake.Delegation.EffectiveStake(epoch, history)
+		}
+		reserve, full = staked+s.Meta.RentExemptReserve, staked == 0
+	default:
+		return 0, false
+	}
+	if lamports <= reserve {
+		return 0, full
+	}
+	return lamports - reserve, full
+}
+
+// CanWithdraw reports whether the stake program takes a withdrawal of amount at the given clock and stake history
+func (s StakeAccount) CanWithdraw(amount, lamports uint64, epoch uint64, unixTimestamp int64, history sysvar.StakeHistory, custodian *common.PublicKey) bool {
+	partial, full := s.WithdrawLimits(lamports, epoch, unixTimestamp, history, custodian)
+	if amount == lamports {
+		return full || amount <= partial
+	}
+	return amount <= partial
+}
+
+type MergeKind uint8
+
+const (
+	MergeKindInactive MergeKind = iota
+	MergeKindActivationEpoch
+	MergeKindFullyActive
+	MergeKindTransient
+)
+
+// MergeKind classifies the account the same way the stake program does before merging.
+// a delegation which is warming up or cooling down is transient.
+func (s StakeAccount) MergeKind(epoch uint64, history sysvar.StakeHistory) (MergeKind, error) {
+	switch s.State {
+	case StakeStateInitialized:
+		return MergeKindInactive, nil
+	case StakeStateStake:
+		status := s.Stake.Delegation.Activation(epoch, history)
+		switch {
+		case status == StakeActivation{}:
+			return MergeKindInactive, nil
+		case status.Effective == 0:
+			return MergeKindActivationEpoch, nil
+		case status.Activating == 0 && status.Deactivating == 0:
+			return MergeKindFullyActive, nil
+		default:
+			return MergeKindTransient, nil
+		}
+	}
+	return MergeKindTransient, ErrInvalidAccountData
+}
+
+// CheckMerge returns nil if source can be merged into destination at the given clock and stake history.
+func CheckMerge(destination, source StakeAccount, epoch uint64, unixTimestamp int64, history sysvar.StakeHistory) error {
+	if destination.Meta.Authorized != source.Meta.Authorized {
+		return ErrMergeMismatch
+	}
+	if destination.Meta.Lockup != source.Meta.Lockup &&
+		(destination.Meta.Lockup.IsInForce(epoch, unixTimestamp, nil) || source.Meta.Lockup.IsInForce(epoch, unixTimestamp, nil)) {
+		return ErrMergeMismatch
+	}
+
+	destinationKind, err := destination.MergeKind(epoch, history)
+	if err != nil {
+		return err
+	}
+	sourceKind, err := source.MergeKind(epoch, history)
+	if err != nil {
+		return err
+	}
+	if destinationKind == MergeKindTransient || sourceKind == MergeKindTransient {
+		return ErrMergeTransientStake
+	}
+
+	switch {
+	case destinationKind == MergeKindInactive && sourceKind == MergeKindInactive,
+		destinationKind == MergeKindInactive && sourceKind == MergeKindActivationEpoch:
+		return nil
+	case destinationKind == MergeKindActivationEpoch && sourceKind == MergeKindInactive:
+		return nil
+	case destinationKind == sourceKind:
+		if destination.Stake.Delegation.Voter != source.Stake.Delegation.Voter {
+			return ErrMergeMismatch
+		}
+		return nil
+	}
+	return ErrMergeMismatch
+}
@@ -0,0 +1,428 @@
+package stake
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/pointer"
+	"github.com/EntySquare/solana-go-sdk/program/sysvar"
+	"github.com/stretchr/testify/assert"
+)
+
+var testStakeAccountData = []byte{2, 0, 0, 0, 128, 213, 34, 0, 0, 0, 0, 0, 159, 186, 247, 199, 172, 215, 195, 31, 127, 42, 207, 18, 192, 64, 156, 59, 98, 1, 180, 8, 69, 70, 199, 127, 220, 159, 6, 40, 64, 117, 246, 19, 206, 211, 135, 230, 195, 111, 87, 254, 147, 239, 143, 81, 110, 159, 49, 140, 109, 137, 224, 197, 24, 49, 223, 61, 123, 8, 78, 109, 110, 136, 228, 240, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 221, 80, 102, 110, 178, 69, 222, 85, 116, 74, 249, 178, 121, 37, 13, 77, 55, 98, 68, 253, 225, 50, 140, 189, 234, 125, 163, 76, 23, 20, 245, 176, 0, 202, 154, 59, 0, 0, 0, 0, 244, 1, 0, 0, 0, 0, 0, 0, 255, 255, 255, 255, 255, 255, 255, 255, 0, 0, 0, 0, 0, 0, 208, 63, 57, 48, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+
+var testStakeAccount = StakeAccount{
+	State: StakeStateStake,
+	Meta: Meta{
+		RentExemptReserve: 2282880,
+		Authorized: Authorized{
+			Staker:     common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+			Withdrawer: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+		},
+	},
+	Stake: Stake{
+		Delegation: Delegation{
+			Voter:              common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+			Stake:              1000000000,
+			ActivationEpoch:    500,
+			DeactivationEpoch:  math.MaxUint64,
+			WarmupCooldownRate: 0.25,
+		},
+		CreditsObserved: 12345,
+	},
+}
+
+func TestDeserializeStakeAccount(t *testing.T) {
+	type args struct {
+		data         []byte
+		accountOwner common.PublicKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want StakeAccount
+		err  error
+	}{
+		{
+			args: args{
+				data:         testStakeAccountData,
+				accountOwner: common.SystemProgramID,
+			},
+			want: StakeAccount{},
+			err:  ErrInvalidAccountOwner,
+		},
+		{
+			args: args{
+				data:         testStakeAccountData[:199],
+				accountOwner: common.StakeProgramID,
+			},
+			want: StakeAccount{},
+			err:  ErrInvalidAccountDataSize,
+		},
+		{
+			args: args{
+				data:         make([]byte, 200),
+				accountOwner: common.StakeProgramID,
+			},
+			want: StakeAccount{State: StakeStateUninitialized},
+			err:  nil,
+		},
+		{
+			args: args{
+				data:         append([]byte{1}, testStakeAccountData[1:]...),
+				accountOwner: common.StakeProgramID,
+			},
+			want: StakeAccount{
+				State: StakeStateInitialized,
+				Meta:  testStakeAccount.Meta,
+			},
+			err: nil,
+		},
+		{
+			args: args{
+				data:         testStakeAccountData,
+				accountOwner: common.StakeProgramID,
+			},
+			want: testStakeAccount,
+			err:  nil,
+		},
+		{
+			args: args{
+				data:         append([]byte{4}, testStakeAccountData[1:]...),
+				accountOwner: common.StakeProgramID,
+			},
+			want: StakeAccount{},
+			err:  ErrInvalidAccountData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeserializeStakeAccount(tt.args.data, tt.args.accountOwner)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+// testStakeHistory has the cluster warm up and cool down 10% of its stake in epoch 509
+var testStakeHistory = sysvar.StakeHistory{
+	{Epoch: 509, Effective: 100_000_000_000, Activating: 10_000_000_000, Deactivating: 10_000_000_000},
+}
+
+func TestDelegation_Activation(t *testing.T) {
+	delegation := Delegation{Stake: 500, ActivationEpoch: 500, DeactivationEpoch: math.MaxUint64}
+	deactivating := Delegation{Stake: 500, ActivationEpoch: 400, DeactivationEpoch: 505}
+	history := sysvar.StakeHistory{
+		{Epoch: 506, Effective: 910, Deactivating: 410},
+		{Epoch: 505, Effective: 1000, Deactivating: 500},
+		{Epoch: 501, Effective: 1090, Activating: 410},
+		{Epoch: 500, Effective: 1000, Activating: 500},
+	}
+
+	tests := []struct {
+		name       string
+		delegation Delegation
+		epoch      uint64
+		history    sysvar.StakeHistory
+		want       StakeActivation
+	}{
+		{
+			name:       "before activation",
+			delegation: delegation,
+			epoch:      499,
+			history:    history,
+			want:       StakeActivation{},
+		},
+		{
+			name:       "activation epoch",
+			delegation: delegation,
+			epoch:      500,
+			history:    history,
+			want:       StakeActivation{Activating: 500},
+		},
+		{
+			name:       "warming up",
+			delegation: delegation,
+			epoch:      501,
+			history:    history,
+			want:       StakeActivation{Effective: 90, Activating: 410},
+		},
+		{
+			name:       "warming up over epochs",
+			delegation: delegation,
+			epoch:      502,
+			history:    history,
+			want:       StakeActivation{Effective: 188, Activating: 312},
+		},
+		{
+			name:       "without history",
+			delegation: delegation,
+			epoch:      501,
+			want:       StakeActivation{Effective: 500},
+		},
+		{
+			name:       "bootstrap",
+			delegation: Delegation{Stake: 500, ActivationEpoch: math.MaxUint64, DeactivationEpoch: math.MaxUint64},
+			epoch:      0,
+			want:       StakeActivation{Effective: 500},
+		},
+		{
+			name:       "deactivated in the activation epoch",
+			delegation: Delegation{Stake: 500, ActivationEpoch: 500, DeactivationEpoch: 500},
+			epoch:      500,
+			history:    history,
+			want:       StakeActivation{},
+		},
+		{
+			name:       "deactivation epoch",
+			delegation: deactivating,
+			epoch:      505,
+			history:    history,
+			want:       StakeActivation{Effective: 500, Deactivating: 500},
+		},
+		{
+			name:       "cooling down",
+			delegation: deactivating,
+			epoch:      506,
+			history:    history,
+			want:       StakeActivation{Effective: 410, Deactivating: 410},
+		},
+		{
+			name:       "cooling down until the history ends",
+			delegation: deactivating,
+			epoch:      520,
+			history:    history,
+			want:       StakeActivation{Effective: 329, Deactivating: 329},
+		},
+		{
+			name:       "deactivated without history",
+			delegation: deactivating,
+			epoch:      506,
+			want:       StakeActivation{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.delegation.Activation(tt.epoch, tt.history))
+			assert.Equal(t, tt.want.Effective, tt.delegation.EffectiveStake(tt.epoch, tt.history))
+		})
+	}
+}
+
+func TestStakeAccount_Withdrawable(t *testing.T) {
+	deactivated := testStakeAccount
+	deactivated.Stake.Delegation.DeactivationEpoch = 510
+
+	coolingDown := testStakeAccount
+	coolingDown.Stake.Delegation.DeactivationEpoch = 509
+
+	lockedUp := testStakeAccount
+	lockedUp.Meta.Lockup = Lockup{
+		Epoch:    600,
+		Cusodian: common.PublicKeyFromString("DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi"),
+	}
+
+	type args struct {
+		lamports      uint64
+		epoch         uint64
+		unixTimestamp int64
+		custodian     *common.PublicKey
+	}
+	tests := []struct {
+		name    string
+		account StakeAccount
+		args    args
+		want    uint64
+	}{
+		{
+			name:    "uninitialized",
+			account: StakeAccount{State: StakeStateUninitialized},
+			args:    args{lamports: 100, epoch: 510},
+			want:    100,
+		},
+		{
+			name:    "active",
+			account: testStakeAccount,
+			args:    args{lamports: 1002282880 + 50, epoch: 510},
+			want:    50,
+		},
+		{
+			name:    "deactivating",
+			account: deactivated,
+			args:    args{lamports: 1002282880 + 50, epoch: 510},
+			want:    50,
+		},
+		{
+			name:    "deactivated",
+			account: deactivated,
+			args:    args{lamports: 1002282880 + 50, epoch: 511},
+			want:    1002282880 + 50,
+		},
+		{
+			name:    "cooling down",
+			account: coolingDown,
+			args:    args{lamports: 1002282880 + 50, epoch: 510},
+			want:    900_000_000 + 50,
+		},
+		{
+			name:    "lockup in force",
+			account: lockedUp,
+			args:    args{lamports: 1002282880 + 50, epoch: 510},
+			want:    0,
+		},
+		{
+			name:    "lockup with custodian",
+			account: lockedUp,
+			args: args{
+				lamports:  1002282880 + 50,
+				epoch:     510,
+				custodian: pointer.Get(common.PublicKeyFromString("DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi")),
+			},
+			want: 50,
+		},
+		{
+			name:    "below reserve",
+			account: testStakeAccount,
+			args:    args{lamports: 1000000000, epoch: 510},
+			want:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.account.Withdrawable(tt.args.lamports, tt.args.epoch, tt.args.unixTimestamp, testStakeHistory, tt.args.custodian))
+		})
+	}
+}
+
+func TestStakeAccount_WithdrawLimits(t *testing.T) {
+	initialized := StakeAccount{State: StakeStateInitialized, Meta: testStakeAccount.Meta}
+	deactivated := testStakeAccount
+	deactivated.Stake.Delegation.DeactivationEpoch = 510
+	coolingDown := testStakeAccount
+	coolingDown.Stake.Delegation.DeactivationEpoch = 509
+
+	const reserve = 2282880
+	const balance = reserve + 1000
+	tests := []struct {
+		name        string
+		account     StakeAccount
+		lamports    uint64
+		epoch       uint64
+		wantPartial uint64
+		wantFull    bool
+		withdraw    map[uint64]bool
+	}{
+		{
+			name:        "initialized",
+			account:     initialized,
+			lamports:    balance,
+			epoch:       510,
+			wantPartial: 1000,
+			wantFull:    true,
+			withdraw:    map[uint64]bool{balance: true, 1000: true, 1001: false, balance - 1: false},
+		},
+		{
+			name:        "fully deactivated",
+			account:     deactivated,
+			lamports:    balance,
+			epoch:       511,
+			wantPartial: 1000,
+			wantFull:    true,
+			withdraw:    map[uint64]bool{balance: true, 1000: true, 1001: false, balance - 1: false},
+		},
+		{
+			name:        "cooling down",
+			account:     coolingDown,
+			lamports:    1002282880 + 50,
+			epoch:       510,
+			wantPartial: 900_000_000 + 50,
+			wantFull:    false,
+			withdraw:    map[uint64]bool{1002282880 + 50: false, 900_000_050: true, 900_000_051: false},
+		},
+		{
+			name:        "active",
+			account:     testStakeAccount,
+			lamports:    1002282880 + 50,
+			epoch:       510,
+			wantPartial: 50,
+			wantFull:    false,
+			withdraw:    map[uint64]bool{1002282880 + 50: false, 50: true, 51: false},
+		},
+		{
+			name:        "initialized below reserve",
+			account:     initialized,
+			lamports:    reserve - 1,
+			epoch:       510,
+			wantPartial: 0,
+			wantFull:    true,
+			withdraw:    map[uint64]bool{reserve - 1: true, 1: false},
+		},
+		{
+			name:        "uninitialized",
+			account:     StakeAccount{State: StakeStateUninitialized},
+			lamports:    100,
+			wantPartial: 100,
+			wantFull:    true,
+			withdraw:    map[uint64]bool{100: true, 99: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partial, full := tt.account.WithdrawLimits(tt.lamports, tt.epoch, 0, testStakeHistory, nil)
+			assert.Equal(t, tt.wantPartial, partial)
+			assert.Equal(t, tt.wantFull, full)
+			for amount, want := range tt.withdraw {
+				assert.Equal(t, want, tt.account.CanWithdraw(amount, tt.lamports, tt.epoch, 0, testStakeHistory, nil), amount)
+			}
+		})
+	}
+}
+
+func TestCheckMerge(t *testing.T) {
+	otherVoter := testStakeAccount
+	otherVoter.Stake.Delegation.Voter = common.PublicKeyFromString("RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+
+	activating := testStakeAccount
+	activating.Stake.Delegation.ActivationEpoch = 510
+
+	deactivating := testStakeAccount
+	deactivating.Stake.Delegation.DeactivationEpoch = 510
+
+	warmingUp := testStakeAccount
+	warmingUp.Stake.Delegation.ActivationEpoch = 509
+
+	otherStaker := testStakeAccount
+	otherStaker.Meta.Authorized.Staker = common.PublicKeyFromString("RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+
+	lockedUp := testStakeAccount
+	lockedUp.Meta.Lockup.Epoch = 600
+
+	initialized := StakeAccount{State: StakeStateInitialized, Meta: testStakeAccount.Meta}
+
+	type args struct {
+		destination StakeAccount
+		source      StakeAccount
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{name: "fully active", args: args{testStakeAccount, testStakeAccount}, err: nil},
+		{name: "different voter", args: args{testStakeAccount, otherVoter}, err: ErrMergeMismatch},
+		{name: "different staker", args: args{testStakeAccount, otherStaker}, err: ErrMergeMismatch},
+		{name: "lockup in force", args: args{testStakeAccount, lockedUp}, err: ErrMergeMismatch},
+		{name: "deactivating", args: args{testStakeAccount, deactivating}, err: ErrMergeTransientStake},
+		{name: "warming up", args: args{testStakeAccount, warmingUp}, err: ErrMergeTransientStake},
+		{name: "inactive into activating", args: args{activating, initialized}, err: nil},
+		{name: "activating into inactive", args: args{initialized, activating}, err: nil},
+		{name: "active into inactive", args: args{initialized, testStakeAccount}, err: ErrMergeMismatch},
+		{name: "activating into active", args: args{testStakeAccount, activating}, err: ErrMergeMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.err, CheckMerge(tt.args.destination, tt.args.source, 510, 0, testStakeHistory))
+		})
+	}
+}
@@ -15,7 +15,7 @@ type FeeCalculator struct {
 
 func FeeCalculatorDeserialize(data []byte) (FeeCalculator, error) {
 	if len(data) < FeeCalculatorSize {
-		return FeeCalculator{}, fmt.Errorf("fee calculator data size is not enough")
+		return FeeCalculator{}, fmt.Errorf("%w, fee calculator data size is not enough", ErrInvalidAccountDataSize)
 	}
 	lamportsPerSignature := binary.LittleEndian.Uint64(data[:8])
 	return FeeCalculator{
@@ -25,6 +25,16 @@ func FeeCalculatorDeserialize(data []byte) (FeeCalculator, error) {
 
 const NonceAccountSize = 80
 
+const (
+	NonceVersionLegacy uint32 = iota
+	NonceVersionCurrent
+)
+
+const (
+	NonceStateUninitialized uint32 = iota
+	NonceStateInitialized
+)
+
 type NonceAccount struct {
 	Version          uint32
 	State            uint32
@@ -34,11 +44,17 @@ type NonceAccount struct {
 }
 
 func NonceAccountDeserialize(data []byte) (NonceAccount, error) {
-	if len(data) < NonceAccountSize {
-		return NonceAccount{}, fmt.Errorf("nonce account data size is not enough")
+	if len(data) != NonceAccountSize {
+		return NonceAccount{}, fmt.Errorf("%w, nonce account data size should be %v", ErrInvalidAccountDataSize, NonceAccountSize)
 	}
 	version := binary.LittleEndian.Uint32(data[:4])
+	if version > NonceVersionCurrent {
+		return NonceAccount{}, fmt.Errorf("%w, unknown nonce version: %v", ErrInvalidAccountData, version)
+	}
 	state := binary.LittleEndian.Uint32(data[4:8])
+	if state > NonceStateInitialized {
+		return NonceAccount{}, fmt.Errorf("%w, unknown nonce state: %v", ErrInvalidAccountData, state)
+	}
 	authorizedPubkey := common.PublicKeyFromBytes(data[8:40])
 	nonce := common.PublicKeyFromBytes(data[40:72])
 	feeCalculator, err := FeeCalculatorDeserialize(data[72:])
@@ -32,6 +32,38 @@ func TestNonceAccountDeserialize(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "trailing data",
+			args: args{
+				data: append(nonceAccountData(), 0),
+			},
+			want:    NonceAccount{},
+			wantErr: true,
+		},
+		{
+			name: "truncated",
+			args: args{
+				data: nonceAccountData()[:79],
+			},
+			want:    NonceAccount{},
+			wantErr: true,
+		},
+		{
+			name: "unknown version",
+			args: args{
+				data: func() []byte { b := nonceAccountData(); b[0] = 2; return b }(),
+			},
+			want:    NonceAccount{},
+			wantErr: true,
+		},
+		{
+			name: "unknown state",
+			args: args{
+				data: func() []byte { b := nonceAccountData(); b[4] = 2; return b }(),
+			},
+			want:    NonceAccount{},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -46,3 +78,20 @@ func TestNonceAccountDeserialize(t *testing.T) {
 		})
 	}
 }
+
+func nonceAccountData() []byte {
+	return []byte{0, 0, 0, 0, 1, 0, 0, 0, 170, 118, 78, 20, 110, 21, 146, 201, 207, 34, 55, 190, 100, 27, 130, 117, 252, 159, 223, 230, 13, 166, 95, 130, 155, 86, 34, 134, 87, 106, 160, 233, 118, 21, 129, 71, 191, 98, 171, 247, 177, 47, 125, 104, 215, 37, 254, 44, 68, 82, 208, 182, 201, 123, 37, 207, 233, 116, 103, 34, 74, 217, 164, 8, 136, 19, 0, 0, 0, 0, 0, 0}
+}
+
+func FuzzNonceAccountDeserialize(f *testing.F) {
+	f.Add(nonceAccountData())
+	f.Fuzz(func(t *testing.T, data []byte) {
+		got, err := NonceAccountDeserialize(data)
+		if err != nil {
+			return
+		}
+		if got.Version > NonceVersionCurrent || got.State > NonceStateInitialized {
+			t.Fatalf("unexpected nonce account: %+v", got)
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package sysvar
+
+import (
+	"encoding/binary"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+)
+
+const ClockSize = 40
+
+type Clock struct {
+	Slot                uint64
+	EpochStartTimestamp int64
+	Epoch               uint64
+	LeaderScheduleEpoch uint64
+	UnixTimestamp       int64
+}
+
+func DeserializeClock(data []byte, owner common.PublicKey) (Clock, error) {
+	if owner != common.SysVarPubkey {
+		return Clock{}, ErrInvalidAccountOwner
+	}
+	if len(data) < ClockSize {
+		return Clock{}, ErrInvalidAccountDataSize
+	}
+
+	return Clock{
+		Slot:                binary.LittleEndian.Uint64(data[0:8]),
+		EpochStartTimestamp: int64(binary.LittleEndian.Uint64(data[8:16])),
+		Epoch:               binary.LittleEndian.Uint64(data[16:24]),
+		LeaderScheduleEpoch: binary.LittleEndian.Uint64(data[24:32]),
+		UnixTimestamp:       int64(binary.LittleEndian.Uint64(data[32:40])),
+	}, nil
+}
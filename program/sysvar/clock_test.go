@@ -0,0 +1,65 @@
+package sysvar
+
+import (
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeserializeClock(t *testing.T) {
+	type args struct {
+		data  []byte
+		owner common.PublicKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want Clock
+		err  error
+	}{
+		{
+			args: args{
+				data:  []byte{},
+				owner: common.SystemProgramID,
+			},
+			want: Clock{},
+			err:  ErrInvalidAccountOwner,
+		},
+		{
+			args: args{
+				data:  []byte{0, 230, 223, 12, 0, 0, 0, 0},
+				owner: common.SysVarPubkey,
+			},
+			want: Clock{},
+			err:  ErrInvalidAccountDataSize,
+		},
+		{
+			args: args{
+				data: []byte{
+					0, 230, 223, 12, 0, 0, 0, 0,
+					128, 90, 187, 100, 0, 0, 0, 0,
+					254, 1, 0, 0, 0, 0, 0, 0,
+					255, 1, 0, 0, 0, 0, 0, 0,
+					32, 225, 188, 100, 0, 0, 0, 0,
+				},
+				owner: common.SysVarPubkey,
+			},
+			want: Clock{
+				Slot:                216000000,
+				EpochStartTimestamp: 1690000000,
+				Epoch:               510,
+				LeaderScheduleEpoch: 511,
+				UnixTimestamp:       1690100000,
+			},
+			err: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeserializeClock(tt.args.data, tt.args.owner)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package sysvar
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeserializeEpochRewards(t *testing.T) {
+	type args struct {
+		data  []byte
+		owner common.PublicKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want EpochRewards
+		err  error
+	}{
+		{
+			args: args{
+				data:  []byte{},
+				owner: common.SystemProgramID,
+			},
+			want: EpochRewards{},
+			err:  ErrInvalidAccountOwner,
+		},
+		{
+			args: args{
+				data:  []byte{0, 0, 0, 0, 0, 0, 0, 0},
+				owner: common.SysVarPubkey,
+			},
+			want: EpochRewards{},
+			err:  ErrInvalidAccountDataSize,
+		},
+		{
+			args: args{
+				data: []byte{
+					64, 66, 15, 0, 0, 0, 0, 0,
+					10, 0, 0, 0, 0, 0, 0, 0,
+					1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+					0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0,
+					0, 232, 118, 72, 23, 0, 0, 0,
+					0, 116, 59, 164, 11, 0, 0, 0,
+					1,
+				},
+				owner: common.SysVarPubkey,
+			},
+			want: EpochRewards{
+				DistributionStartingBlockHeight: 1000000,
+				NumPartitions:                   10,
+				ParentBlockhash:                 [32]byte{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+				TotalPoints:                     new(big.Int).Lsh(big.NewInt(1), 64),
+				TotalRewards:                    100000000000,
+				DistributedRewards:              50000000000,
+				Active:                          true,
+			},
+			err: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeserializeEpochRewards(tt.args.data, tt.args.owner)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
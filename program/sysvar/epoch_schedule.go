@@ -0,0 +1,59 @@
+package sysvar
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+)
+
+const EpochScheduleSize = 33
+
+// MinimumSlotsPerEpoch is the length of the first epoch when warmup is enabled
+const MinimumSlotsPerEpoch uint64 = 32
+
+type EpochSchedule struct {
+	SlotsPerEpoch            uint64
+	LeaderScheduleSlotOffset uint64
+	// Warmup means epochs start short and double in length until SlotsPerEpoch
+	Warmup           bool
+	FirstNormalEpoch uint64
+	FirstNormalSlot  uint64
+}
+
+// GetEpochAndSlotIndex returns the epoch of the slot and the index of the slot in the epoch
+func (s EpochSchedule) GetEpochAndSlotIndex(slot uint64) (uint64, uint64) {
+	if slot < s.FirstNormalSlot {
+		minimumTrailingZeros := uint64(bits.TrailingZeros64(MinimumSlotsPerEpoch))
+		epoch := uint64(bits.Len64(slot+MinimumSlotsPerEpoch)) - minimumTrailingZeros - 1
+		epochLen := uint64(1) << (epoch + minimumTrailingZeros)
+		return epoch, slot - (epochLen - MinimumSlotsPerEpoch)
+	}
+	normalSlotIndex := slot - s.FirstNormalSlot
+	return s.FirstNormalEpoch + normalSlotIndex/s.SlotsPerEpoch, normalSlotIndex % s.SlotsPerEpoch
+}
+
+// GetFirstSlotInEpoch returns the first slot of the epoch
+func (s EpochSchedule) GetFirstSlotInEpoch(epoch uint64) uint64 {
+	if epoch <= s.FirstNormalEpoch {
+		return ((uint64(1) << epoch) - 1) * MinimumSlotsPerEpoch
+	}
+	return (epoch-s.FirstNormalEpoch)*s.SlotsPerEpoch + s.FirstNormalSlot
+}
+
+func DeserializeEpochSchedule(data []byte, owner common.PublicKey) (EpochSchedule, error) {
+	if owner != common.SysVarPubkey {
+		return EpochSchedule{}, ErrInvalidAccountOwner
+	}
+	if len(data) < EpochScheduleSize {
+		return EpochSchedule{}, ErrInvalidAccountDataSize
+	}
+
+	return EpochSchedule{
+		SlotsPerEpoch:            binary.LittleEndian.Uint64(data[0:8]),
+		LeaderScheduleSlotOffset: binary.LittleEndian.Uint64(data[8:16]),
+		Warmup:                   data[16] == 1,
+		FirstNormalEpoch:         binary.LittleEndian.Uint64(data[17:25]),
+		FirstNormalSlot:          binary.LittleEndian.Uint64(data[25:33]),
+	}, nil
+}
@@ -0,0 +1,95 @@
+package sysvar
+
+import (
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeserializeEpochSchedule(t *testing.T) {
+	type args struct {
+		data  []byte
+		owner common.PublicKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want EpochSchedule
+		err  error
+	}{
+		{
+			args: args{
+				data:  []byte{},
+				owner: common.SystemProgramID,
+			},
+			want: EpochSchedule{},
+			err:  ErrInvalidAccountOwner,
+		},
+		{
+			args: args{
+				data:  []byte{128, 151, 6, 0, 0, 0, 0, 0},
+				owner: common.SysVarPubkey,
+			},
+			want: EpochSchedule{},
+			err:  ErrInvalidAccountDataSize,
+		},
+		{
+			args: args{
+				data: []byte{
+					128, 151, 6, 0, 0, 0, 0, 0,
+					128, 151, 6, 0, 0, 0, 0, 0,
+					1,
+					14, 0, 0, 0, 0, 0, 0, 0,
+					224, 255, 7, 0, 0, 0, 0, 0,
+				},
+				owner: common.SysVarPubkey,
+			},
+			want: EpochSchedule{
+				SlotsPerEpoch:            432000,
+				LeaderScheduleSlotOffset: 432000,
+				Warmup:                   true,
+				FirstNormalEpoch:         14,
+				FirstNormalSlot:          524256,
+			},
+			err: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeserializeEpochSchedule(tt.args.data, tt.args.owner)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestEpochSchedule_GetEpochAndSlotIndex(t *testing.T) {
+	schedule := EpochSchedule{
+		SlotsPerEpoch:            432000,
+		LeaderScheduleSlotOffset: 432000,
+		Warmup:                   true,
+		FirstNormalEpoch:         14,
+		FirstNormalSlot:          524256,
+	}
+	tests := []struct {
+		slot      uint64
+		epoch     uint64
+		slotIndex uint64
+	}{
+		{slot: 0, epoch: 0, slotIndex: 0},
+		{slot: 31, epoch: 0, slotIndex: 31},
+		{slot: 32, epoch: 1, slotIndex: 0},
+		{slot: 95, epoch: 1, slotIndex: 63},
+		{slot: 96, epoch: 2, slotIndex: 0},
+		{slot: 524255, epoch: 13, slotIndex: 262143},
+		{slot: 524256, epoch: 14, slotIndex: 0},
+		{slot: 216000000, epoch: 512, slotIndex: 339744},
+	}
+	for _, tt := range tests {
+		epoch, slotIndex := schedule.GetEpochAndSlotIndex(tt.slot)
+		assert.Equal(t, tt.epoch, epoch, "slot %v", tt.slot)
+		assert.Equal(t, tt.slotIndex, slotIndex, "slot %v", tt.slot)
+		assert.Equal(t, tt.slot-tt.slotIndex, schedule.GetFirstSlotInEpoch(tt.epoch), "epoch %v", tt.epoch)
+	}
+}
@@ -3,6 +3,9 @@ package sysvar
 import "errors"
 
 var (
-	ErrInvalidAccountOwner    = errors.New("invalid account owner")
-	ErrInvalidAccountDataSize = errors.New("invalid account data size")
+	ErrInvalidAccountOwner        = errors.New("invalid account owner")
+	ErrInvalidAccountDataSize     = errors.New("invalid account data size")
+	ErrInvalidAccountIndex        = errors.New("invalid account index")
+	ErrInvalidInstructionIndex    = errors.New("invalid instruction index")
+	ErrAddressLookupTableNotFound = errors.New("address lookup table not found")
 )
@@ -0,0 +1,33 @@
+package sysvar
+
+import (
+	"encoding/binary"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+)
+
+const FeesSize = 8
+
+type FeeCalculator struct {
+	LamportsPerSignature uint64
+}
+
+// Fees is deprecated on chain but still readable
+type Fees struct {
+	FeeCalculator FeeCalculator
+}
+
+func DeserializeFees(data []byte, owner common.PublicKey) (Fees, error) {
+	if owner != common.SysVarPubkey {
+		return Fees{}, ErrInvalidAccountOwner
+	}
+	if len(data) < FeesSize {
+		return Fees{}, ErrInvalidAccountDataSize
+	}
+
+	return Fees{
+		FeeCalculator: FeeCalculator{
+			LamportsPerSignature: binary.LittleEndian.Uint64(data[0:8]),
+		},
+	}, nil
+}
@@ -0,0 +1,55 @@
+package sysvar
+
+import (
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeserializeFees(t *testing.T) {
+	type args struct {
+		data  []byte
+		owner common.PublicKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want Fees
+		err  error
+	}{
+		{
+			args: args{
+				data:  []byte{},
+				owner: common.SystemProgramID,
+			},
+			want: Fees{},
+			err:  ErrInvalidAccountOwner,
+		},
+		{
+			args: args{
+				data:  []byte{136, 19},
+				owner: common.SysVarPubkey,
+			},
+			want: Fees{},
+			err:  ErrInvalidAccountDataSize,
+		},
+		{
+			args: args{
+				data:  []byte{136, 19, 0, 0, 0, 0, 0, 0},
+				owner: common.SysVarPubkey,
+			},
+			want: Fees{
+				FeeCalculator: FeeCalculator{LamportsPerSignature: 5000},
+			},
+			err: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeserializeFees(tt.args.data, tt.args.owner)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package sysvar
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/types"
+)
+
+const (
+	instructionsAccountFlagIsSigner uint8 = 1 << iota
+	instructionsAccountFlagIsWritable
+)
+
+// Instructions is the content of the instructions sysvar
+type Instructions struct {
+	Instructions []types.Instruction
+	// CurrentIndex is the index of the instruction which is being executed
+	CurrentIndex uint16
+}
+
+// SerializeInstructions lays out the instructions sysvar of the message the same way the runtime does.
+// The current index is set to 0, use StoreCurrentIndex to move it.
+// A v0 message needs its address lookup tables to resolve the loaded addresses.
+func SerializeInstructions(message types.Message, addressLookupTableAccounts []types.AddressLookupTableAccount) ([]byte, error) {
+	accounts, isWritable, err := messageAccountKeys(message, addressLookupTableAccounts)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(message.Instructions)
+	data := make([]byte, 2+2*n, 2+2*n+n*(2+32+2))
+	binary.LittleEndian.PutUint16(data, uint16(n))
+	for i, instruction := range message.Instructions {
+		binary.LittleEndian.PutUint16(data[2+2*i:], uint16(len(data)))
+
+		if instruction.ProgramIDIndex < 0 || instruction.ProgramIDIndex >= len(accounts) {
+			return nil, fmt.Errorf("%w, instruction: %v, program id index: %v", ErrInvalidAccountIndex, i, instruction.ProgramIDIndex)
+		}
+		data = binary.LittleEndian.AppendUint16(data, uint16(len(instruction.Accounts)))
+		for _, idx := range instruction.Accounts {
+			if idx < 0 || idx >= len(accounts) {
+				return nil, fmt.Errorf("%w, instruction: %v, account index: %v", ErrInvalidAccountIndex, i, idx)
+			}
+			var flags uint8
+			if idx < int(message.Header.NumRequireSignatures) {
+				flags |= instructionsAccountFlagIsSigner
+			}
+			if isWritable[idx] {
+				flags |= instructionsAccountFlagIsWritable
+			}
+			data = append(data, flags)
+			data = append(data, accounts[idx].Bytes()...)
+		}
+		data = append(data, accounts[instruction.ProgramIDIndex].Bytes()...)
+		data = binary.LittleEndian.AppendUint16(data, uint16(len(instruction.Data)))
+		data = append(data, instruction.Data...)
+	}
+	// room for the current index
+	return append(data, 0, 0), nil
+}
+
+// StoreCurrentIndex sets the current index of serialized instructions sysvar data
+func StoreCurrentIndex(data []byte, index uint16) {
+	binary.LittleEndian.PutUint16(data[len(data)-2:], index)
+}
+
+func DeserializeInstructions(data []byte, owner common.PublicKey) (Instructions, error) {
+	if owner != common.SysVarPubkey {
+		return Instructions{}, ErrInvalidAccountOwner
+	}
+
+	n, err := getUint16(data, 0)
+	if err != nil {
+		return Instructions{}, err
+	}
+	instructions := make([]types.Instruction, 0, n)
+	for i := uint16(0); i < n; i++ {
+		instruction, err := LoadInstructionAt(data, i)
+		if err != nil {
+			return Instructions{}, err
+		}
+		instructions = append(instructions, instruction)
+	}
+	currentIndex, err := LoadCurrentIndex(data)
+	if err != nil {
+		return Instructions{}, err
+	}
+
+	return Instructions{
+		Instructions: instructions,
+		CurrentIndex: currentIndex,
+	}, nil
+}
+
+// LoadCurrentIndex returns the index of the instruction which is being executed
+func LoadCurrentIndex(data []byte) (uint16, error) {
+	if len(data) < 4 {
+		return 0, ErrInvalidAccountDataSize
+	}
+	return binary.LittleEndian.Uint16(data[len(data)-2:]), nil
+}
+
+// LoadInstructionAt decodes the instruction at the index
+func LoadInstructionAt(data []byte, index uint16) (types.Instruction, error) {
+	n, err := getUint16(data, 0)
+	if err != nil {
+		return types.Instruction{}, err
+	}
+	if index >= n {
+		return types.Instruction{}, fmt.Errorf("%w, index: %v, instructions: %v", ErrInvalidInstructionIndex, index, n)
+	}
+	offset, err := getUint16(data, 2+2*int(index))
+	if err != nil {
+		return types.Instruction{}, err
+	}
+
+	current := int(offset)
+	accountCount, err := getUint16(data, current)
+	if err != nil {
+		return types.Instruction{}, err
+	}
+	current += 2
+	if len(data)-current < int(accountCount)*33 {
+		return types.Instruction{}, ErrInvalidAccountDataSize
+	}
+	accounts := make([]types.AccountMeta, 0, accountCount)
+	for i := uint16(0); i < accountCount; i++ {
+		flags := data[current]
+		accounts = append(accounts, types.AccountMeta{
+			PubKey:     common.PublicKeyFromBytes(data[current+1 : current+33]),
+			IsSigner:   flags&instructionsAccountFlagIsSigner != 0,
+			IsWritable: flags&instructionsAccountFlagIsWritable != 0,
+		})
+		current += 33
+	}
+
+	if len(data)-current < 32 {
+		return types.Instruction{}, ErrInvalidAccountDataSize
+	}
+	programID := common.PublicKeyFromBytes(data[current : current+32])
+	current += 32
+
+	dataLen, err := getUint16(data, current)
+	if err != nil {
+		return types.Instruction{}, err
+	}
+	current += 2
+	if len(data)-current < int(dataLen) {
+		return types.Instruction{}, ErrInvalidAccountDataSize
+	}
+
+	return types.Instruction{
+		ProgramID: programID,
+		Accounts:  accounts,
+		Data:      data[current : current+int(dataLen)],
+	}, nil
+}
+
+// GetInstructionRelative decodes the instruction at the current index plus relativeIndex
+func GetInstructionRelative(data []byte, relativeIndex int64) (types.Instruction, error) {
+	currentIndex, err := LoadCurrentIndex(data)
+	if err != nil {
+		return types.Instruction{}, err
+	}
+	index := int64(currentIndex) + relativeIndex
+	if index < 0 || index > 0xffff {
+		return types.Instruction{}, fmt.Errorf("%w, index: %v", ErrInvalidInstructionIndex, index)
+	}
+	return LoadInstructionAt(data, uint16(index))
+}
+
+func getUint16(data []byte, offset int) (uint16, error) {
+	if offset < 0 || len(data)-offset < 2 {
+		return 0, ErrInvalidAccountDataSize
+	}
+	return binary.LittleEndian.Uint16(data[offset:]), nil
+}
+
+// messageAccountKeys returns all account keys of the message, loaded addresses included,
+// and whether each of them is writable at runtime.
+func messageAccountKeys(message types.Message, addressLookupTableAccounts []types.AddressLookupTableAccount) ([]common.PublicKey, []bool, error) {
+	accounts := make([]common.PublicKey, 0, len(message.Accounts))
+	accounts = append(accounts, message.Accounts...)
+	isWritable := make([]bool, 0, len(message.Accounts))
+	for i := range message.Accounts {
+		isWritable = append(isWritable, i < int(message.Header.NumRequireSignatures-message.Header.NumReadonlySignedAccounts) ||
+			(i >= int(message.Header.NumRequireSignatures) && i < len(message.Accounts)-int(message.Header.NumReadonlyUnsignedAccounts)))
+	}
+
+	if len(message.AddressLookupTables) > 0 {
+		tables := map[common.PublicKey][]common.PublicKey{}
+		for _, table := range addressLookupTableAccounts {
+			tables[table.Key] = table.Addresses
+		}
+		var writable, readonly []common.PublicKey
+		for _, lookup := range message.AddressLookupTables {
+			addresses, ok := tables[lookup.AccountKey]
+			if !ok {
+				return nil, nil, fmt.Errorf("%w, table: %v", ErrAddressLookupTableNotFound, lookup.AccountKey)
+			}
+			for _, idx := range lookup.WritableIndexes {
+				if int(idx) >= len(addresses) {
+					return nil, nil, fmt.Errorf("%w, table: %v, index: %v", ErrInvalidAccountIndex, lookup.AccountKey, idx)
+				}
+				writable = append(writable, addresses[idx])
+			}
+			for _, idx := range lookup.ReadonlyIndexes {
+				if int(idx) >= len(addresses) {
+					return nil, nil, fmt.Errorf("%w, table: %v, index: %v", ErrInvalidAccountIndex, lookup.AccountKey, idx)
+				}
+				readonly = append(readonly, addresses[idx])
+			}
+		}
+		for _, pubkey := range writable {
+			accounts = append(accounts, pubkey)
+			isWritable = append(isWritable, true)
+		}
+		for _, pubkey := range readonly {
+			accounts = append(accounts, pubkey)
+			isWritable = append(isWritable, false)
+		}
+	}
+
+	// the runtime demotes invoked programs unless the upgradeable loader is present,
+	// and always demotes sysvars and builtin programs
+	isUpgradeableLoaderPresent := false
+	for _, pubkey := range accounts {
+		if pubkey == common.BPFLoaderUpgradeableProgramID {
+			isUpgradeableLoaderPresent = true
+		}
+	}
+	for _, instruction := range message.Instructions {
+		if !isUpgradeableLoaderPresent && instruction.ProgramIDIndex >= 0 && instruction.ProgramIDIndex < len(isWritable) {
+			isWritable[instruction.ProgramIDIndex] = false
+		}
+	}
+	for i, pubkey := range accounts {
+		if isReservedAccountKey(pubkey) {
+			isWritable[i] = false
+		}
+	}
+
+	return accounts, isWritable, nil
+}
+
+func isReservedAccountKey(pubkey common.PublicKey) bool {
+	switch pubkey {
+	case common.SysVarPubkey,
+		common.SysVarClockPubkey,
+		common.SysVarEpochSchedulePubkey,
+		common.SysVarFeesPubkey,
+		common.SysVarRecentBlockhashsPubkey,
+		common.SysVarRentPubkey,
+		common.SysVarRewardsPubkey,
+		common.SysVarSlotHashesPubkey,
+		common.SysVarStakeHistoryPubkey,
+		common.SysVarInstructionsPubkey,
+		common.SysVarEpochRewardsPubkey,
+		common.SystemProgramID,
+		common.ConfigProgramID,
+		common.StakeProgramID,
+		common.VoteProgramID,
+		common.BPFLoaderProgramID,
+		common.BPFLoaderUpgradeableProgramID,
+		common.Secp256k1ProgramID,
+		common.Ed25519ProgramID,
+		common.ComputeBudgetProgramID,
+		common.AddressLookupTableProgramID:
+		return true
+	}
+	return false
+}
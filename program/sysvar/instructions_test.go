@@ -0,0 +1,255 @@
+package sysvar
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/secp256k1"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testPubkey1 = common.PublicKeyFromBytes(bytes.Repeat([]byte{1}, 32))
+	testPubkey2 = common.PublicKeyFromBytes(bytes.Repeat([]byte{2}, 32))
+	testPubkey3 = common.PublicKeyFromBytes(bytes.Repeat([]byte{3}, 32))
+	testPubkey4 = common.PublicKeyFromBytes(bytes.Repeat([]byte{4}, 32))
+)
+
+func TestSerializeInstructions(t *testing.T) {
+	type args struct {
+		message                    types.Message
+		addressLookupTableAccounts []types.AddressLookupTableAccount
+	}
+	tests := []struct {
+		name string
+		args args
+		want []byte
+		err  error
+	}{
+		{
+			name: "legacy",
+			args: args{
+				message: types.Message{
+					Version: types.MessageVersionLegacy,
+					Header: types.MessageHeader{
+						NumRequireSignatures:        1,
+						NumReadonlySignedAccounts:   0,
+						NumReadonlyUnsignedAccounts: 1,
+					},
+					Accounts: []common.PublicKey{testPubkey1, testPubkey2, common.SystemProgramID},
+					Instructions: []types.CompiledInstruction{
+						{ProgramIDIndex: 2, Accounts: []int{0, 1}, Data: []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}},
+					},
+				},
+			},
+			want: bytes.Join([][]byte{
+				{1, 0},
+				{4, 0},
+				{2, 0},
+				{3}, testPubkey1.Bytes(),
+				{2}, testPubkey2.Bytes(),
+				common.SystemProgramID.Bytes(),
+				{12, 0},
+				{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+				{0, 0},
+			}, nil),
+			err: nil,
+		},
+		{
+			name: "demote writable program",
+			args: args{
+				message: types.Message{
+					Version: types.MessageVersionLegacy,
+					Header: types.MessageHeader{
+						NumRequireSignatures:        1,
+						NumReadonlySignedAccounts:   0,
+						NumReadonlyUnsignedAccounts: 0,
+					},
+					Accounts: []common.PublicKey{testPubkey1, testPubkey2},
+					Instructions: []types.CompiledInstruction{
+						{ProgramIDIndex: 1, Accounts: []int{1}, Data: []byte{}},
+					},
+				},
+			},
+			want: bytes.Join([][]byte{
+				{1, 0},
+				{4, 0},
+				{1, 0},
+				{0}, testPubkey2.Bytes(),
+				testPubkey2.Bytes(),
+				{0, 0},
+				{0, 0},
+			}, nil),
+			err: nil,
+		},
+		{
+			name: "v0",
+			args: args{
+				message: types.Message{
+					Version: types.MessageVersionV0,
+					Header: types.MessageHeader{
+						NumRequireSignatures:        1,
+						NumReadonlySignedAccounts:   0,
+						NumReadonlyUnsignedAccounts: 1,
+					},
+					Accounts: []common.PublicKey{testPubkey1, testPubkey2},
+					Instructions: []types.CompiledInstruction{
+						{ProgramIDIndex: 1, Accounts: []int{3, 2}, Data: []byte{9}},
+					},
+					AddressLookupTables: []types.CompiledAddressLookupTable{
+						{AccountKey: testPubkey4, WritableIndexes: []uint8{1}, ReadonlyIndexes: []uint8{0}},
+					},
+				},
+				addressLookupTableAccounts: []types.AddressLookupTableAccount{
+					{Key: testPubkey4, Addresses: []common.PublicKey{common.SysVarClockPubkey, testPubkey3}},
+				},
+			},
+			want: bytes.Join([][]byte{
+				{1, 0},
+				{4, 0},
+				{2, 0},
+				{0}, common.SysVarClockPubkey.Bytes(),
+				{2}, testPubkey3.Bytes(),
+				testPubkey2.Bytes(),
+				{1, 0},
+				{9},
+				{0, 0},
+			}, nil),
+			err: nil,
+		},
+		{
+			name: "lookup table not found",
+			args: args{
+				message: types.Message{
+					Version:  types.MessageVersionV0,
+					Accounts: []common.PublicKey{testPubkey1},
+					AddressLookupTables: []types.CompiledAddressLookupTable{
+						{AccountKey: testPubkey4, WritableIndexes: []uint8{0}},
+					},
+				},
+			},
+			want: nil,
+			err:  ErrAddressLookupTableNotFound,
+		},
+		{
+			name: "invalid account index",
+			args: args{
+				message: types.Message{
+					Version:  types.MessageVersionLegacy,
+					Accounts: []common.PublicKey{testPubkey1},
+					Instructions: []types.CompiledInstruction{
+						{ProgramIDIndex: 0, Accounts: []int{1}},
+					},
+				},
+			},
+			want: nil,
+			err:  ErrInvalidAccountIndex,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SerializeInstructions(tt.args.message, tt.args.addressLookupTableAccounts)
+			assert.Equal(t, tt.want, got)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestDeserializeInstructions(t *testing.T) {
+	message := types.NewMessage(types.NewMessageParam{
+		FeePayer: testPubkey1,
+		Instructions: []types.Instruction{
+			{
+				ProgramID: testPubkey3,
+				Accounts: []types.AccountMeta{
+					{PubKey: testPubkey1, IsSigner: true, IsWritable: true},
+					{PubKey: testPubkey2, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{1, 2, 3},
+			},
+			{
+				ProgramID: testPubkey4,
+				Accounts:  []types.AccountMeta{},
+				Data:      []byte{},
+			},
+		},
+		RecentBlockhash: common.PublicKey{}.ToBase58(),
+	})
+	data, err := SerializeInstructions(message, nil)
+	assert.Nil(t, err)
+	StoreCurrentIndex(data, 1)
+
+	got, err := DeserializeInstructions(data, common.SysVarPubkey)
+	assert.Nil(t, err)
+	assert.Equal(t, Instructions{
+		Instructions: []types.Instruction{
+			{
+				ProgramID: testPubkey3,
+				Accounts: []types.AccountMeta{
+					{PubKey: testPubkey1, IsSigner: true, IsWritable: true},
+					{PubKey: testPubkey2, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{1, 2, 3},
+			},
+			{
+				ProgramID: testPubkey4,
+				Accounts:  []types.AccountMeta{},
+				Data:      []byte{},
+			},
+		},
+		CurrentIndex: 1,
+	}, got)
+
+	instruction, err := GetInstructionRelative(data, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, testPubkey3, instruction.ProgramID)
+
+	_, err = GetInstructionRelative(data, 1)
+	assert.ErrorIs(t, err, ErrInvalidInstructionIndex)
+
+	_, err = DeserializeInstructions(data, common.SystemProgramID)
+	assert.Equal(t, ErrInvalidAccountOwner, err)
+
+	for i := 0; i < len(data)-2; i++ {
+		_, err = DeserializeInstructions(data[:i], common.SysVarPubkey)
+		assert.Equal(t, ErrInvalidAccountDataSize, err, "length %v", i)
+	}
+}
+
+func TestSerializeInstructions_Secp256k1Offsets(t *testing.T) {
+	pubkey, _ := base64.StdEncoding.DecodeString("rx8O5L8N25rze03Dr4YXi9E+/Ys=")
+	sig, _ := base64.StdEncoding.DecodeString("K2mYts9f1v1hJc2kp2nCTZ6hZ9dhoHfADHW9zUCBftFTeN1lYUZEgoUZrklfifnZeWUJUujShZKgYtzoKMaRCgE=")
+	secpInstruction, err := secp256k1.NewSecp256k1Instruction([][]byte{[]byte("message")}, [][]byte{sig}, [][]byte{pubkey}, 1)
+	assert.Nil(t, err)
+
+	message := types.NewMessage(types.NewMessageParam{
+		FeePayer: testPubkey1,
+		Instructions: []types.Instruction{
+			{ProgramID: testPubkey3, Accounts: []types.AccountMeta{}, Data: []byte{}},
+			secpInstruction,
+		},
+		RecentBlockhash: common.PublicKey{}.ToBase58(),
+	})
+	data, err := SerializeInstructions(message, nil)
+	assert.Nil(t, err)
+	StoreCurrentIndex(data, 1)
+
+	current, err := GetInstructionRelative(data, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, common.Secp256k1ProgramID, current.ProgramID)
+
+	offsets := current.Data[1:secp256k1.DataStart]
+	load := func(offset, instructionIndex int, size int) []byte {
+		instruction, err := LoadInstructionAt(data, uint16(current.Data[1+instructionIndex]))
+		assert.Nil(t, err)
+		start := binary.LittleEndian.Uint16(offsets[offset:])
+		return instruction.Data[start : int(start)+size]
+	}
+	assert.Equal(t, sig, load(0, 2, 65))
+	assert.Equal(t, pubkey, load(3, 5, 20))
+	assert.Equal(t, []byte("message"), load(6, 10, int(binary.LittleEndian.Uint16(offsets[8:]))))
+}
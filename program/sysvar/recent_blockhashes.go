@@ -0,0 +1,37 @@
+package sysvar
+
+import (
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/bytes_decoder"
+)
+
+type RecentBlockhash struct {
+	Blockhash     [32]byte
+	FeeCalculator FeeCalculator
+}
+
+// RecentBlockhashes is deprecated on chain but still readable. The newest one comes first.
+type RecentBlockhashes []RecentBlockhash
+
+func DeserializeRecentBlockhashes(data []byte, owner common.PublicKey) (RecentBlockhashes, error) {
+	if owner != common.SysVarPubkey {
+		return RecentBlockhashes{}, ErrInvalidAccountOwner
+	}
+
+	current := 0
+	len, err := getLength(&current, data, 40)
+	if err != nil {
+		return RecentBlockhashes{}, err
+	}
+
+	v := make([]RecentBlockhash, 0, len)
+	for i := uint64(0); i < len; i++ {
+		blockhash, _ := bytes_decoder.GetBytes32(&current, data)
+		lamportsPerSignature, _ := bytes_decoder.GetUint64(&current, data)
+		v = append(v, RecentBlockhash{
+			Blockhash:     blockhash,
+			FeeCalculator: FeeCalculator{LamportsPerSignature: lamportsPerSignature},
+		})
+	}
+	return v, nil
+}
@@ -0,0 +1,39 @@
+package sysvar
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+)
+
+const RentSize = 17
+
+// AccountStorageOverhead is the bytes counted for rent on top of the account data
+const AccountStorageOverhead uint64 = 128
+
+type Rent struct {
+	LamportsPerByteYear uint64
+	ExemptionThreshold  float64
+	BurnPercent         uint8
+}
+
+// MinimumBalance returns the lamports an account with dataLen bytes needs to be rent exempt
+func (r Rent) MinimumBalance(dataLen uint64) uint64 {
+	return uint64(float64((AccountStorageOverhead+dataLen)*r.LamportsPerByteYear) * r.ExemptionThreshold)
+}
+
+func DeserializeRent(data []byte, owner common.PublicKey) (Rent, error) {
+	if owner != common.SysVarPubkey {
+		return Rent{}, ErrInvalidAccountOwner
+	}
+	if len(data) < RentSize {
+		return Rent{}, ErrInvalidAccountDataSize
+	}
+
+	return Rent{
+		LamportsPerByteYear: binary.LittleEndian.Uint64(data[0:8]),
+		ExemptionThreshold:  math.Float64frombits(binary.LittleEndian.Uint64(data[8:16])),
+		BurnPercent:         data[16],
+	}, nil
+}
@@ -0,0 +1,63 @@
+package sysvar
+
+import (
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeserializeRent(t *testing.T) {
+	type args struct {
+		data  []byte
+		owner common.PublicKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want Rent
+		err  error
+	}{
+		{
+			args: args{
+				data:  []byte{},
+				owner: common.SystemProgramID,
+			},
+			want: Rent{},
+			err:  ErrInvalidAccountOwner,
+		},
+		{
+			args: args{
+				data:  []byte{152, 13, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 64},
+				owner: common.SysVarPubkey,
+			},
+			want: Rent{},
+			err:  ErrInvalidAccountDataSize,
+		},
+		{
+			args: args{
+				data:  []byte{152, 13, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 64, 50},
+				owner: common.SysVarPubkey,
+			},
+			want: Rent{
+				LamportsPerByteYear: 3480,
+				ExemptionThreshold:  2,
+				BurnPercent:         50,
+			},
+			err: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeserializeRent(tt.args.data, tt.args.owner)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestRent_MinimumBalance(t *testing.T) {
+	rent := Rent{LamportsPerByteYear: 3480, ExemptionThreshold: 2, BurnPercent: 50}
+	assert.Equal(t, uint64(890880), rent.MinimumBalance(0))
+	assert.Equal(t, uint64(2039280), rent.MinimumBalance(165))
+}
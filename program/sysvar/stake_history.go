@@ -0,0 +1,53 @@
+package sysvar
+
+import (
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/bytes_decoder"
+)
+
+type StakeHistoryEntry struct {
+	Epoch        uint64
+	Effective    uint64
+	Activating   uint64
+	Deactivating uint64
+}
+
+// StakeHistory is the cluster stake of past epochs. The newest epoch comes first.
+type StakeHistory []StakeHistoryEntry
+
+// Get returns the entry of the epoch
+func (h StakeHistory) Get(epoch uint64) (StakeHistoryEntry, bool) {
+	for _, entry := range h {
+		if entry.Epoch == epoch {
+			return entry, true
+		}
+	}
+	return StakeHistoryEntry{}, false
+}
+
+func DeserializeStakeHistory(data []byte, owner common.PublicKey) (StakeHistory, error) {
+	if owner != common.SysVarPubkey {
+		return StakeHistory{}, ErrInvalidAccountOwner
+	}
+
+	current := 0
+	len, err := getLength(&current, data, 32)
+	if err != nil {
+		return StakeHistory{}, err
+	}
+
+	v := make([]StakeHistoryEntry, 0, len)
+	for i := uint64(0); i < len; i++ {
+		epoch, _ := bytes_decoder.GetUint64(&current, data)
+		effective, _ := bytes_decoder.GetUint64(&current, data)
+		activating, _ := bytes_decoder.GetUint64(&current, data)
+		deactivating, _ := bytes_decoder.GetUint64(&current, data)
+		v = append(v, StakeHistoryEntry{
+			Epoch:        epoch,
+			Effective:    effective,
+			Activating:   activating,
+			Deactivating: deactivating,
+		})
+	}
+	return v, nil
+}
@@ -0,0 +1,74 @@
+package sysvar
+
+import (
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeserializeStakeHistory(t *testing.T) {
+	type args struct {
+		data  []byte
+		owner common.PublicKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want StakeHistory
+		err  error
+	}{
+		{
+			args: args{
+				data:  []byte{},
+				owner: common.SystemProgramID,
+			},
+			want: StakeHistory{},
+			err:  ErrInvalidAccountOwner,
+		},
+		{
+			args: args{
+				data:  []byte{255, 255, 255, 255, 255, 255, 255, 255},
+				owner: common.SysVarPubkey,
+			},
+			want: StakeHistory{},
+			err:  ErrInvalidAccountDataSize,
+		},
+		{
+			args: args{
+				data: []byte{
+					2, 0, 0, 0, 0, 0, 0, 0,
+					254, 1, 0, 0, 0, 0, 0, 0, 100, 0, 0, 0, 0, 0, 0, 0, 10, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0,
+					253, 1, 0, 0, 0, 0, 0, 0, 90, 0, 0, 0, 0, 0, 0, 0, 20, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0,
+				},
+				owner: common.SysVarPubkey,
+			},
+			want: StakeHistory{
+				{Epoch: 510, Effective: 100, Activating: 10, Deactivating: 1},
+				{Epoch: 509, Effective: 90, Activating: 20, Deactivating: 2},
+			},
+			err: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeserializeStakeHistory(tt.args.data, tt.args.owner)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestStakeHistory_Get(t *testing.T) {
+	history := StakeHistory{
+		{Epoch: 510, Effective: 100, Activating: 10, Deactivating: 1},
+		{Epoch: 509, Effective: 90, Activating: 20, Deactivating: 2},
+	}
+
+	entry, ok := history.Get(509)
+	assert.True(t, ok)
+	assert.Equal(t, StakeHistoryEntry{Epoch: 509, Effective: 90, Activating: 20, Deactivating: 2}, entry)
+
+	_, ok = history.Get(511)
+	assert.False(t, ok)
+}
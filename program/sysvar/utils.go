@@ -0,0 +1,15 @@
+package sysvar
+
+import "github.com/EntySquare/solana-go-sdk/pkg/bytes_decoder"
+
+// getLength reads a vec length and makes sure the rest of data can hold all items
+func getLength(current *int, data []byte, itemSize int) (uint64, error) {
+	l, err := bytes_decoder.GetUint64(current, data)
+	if err != nil {
+		return 0, ErrInvalidAccountDataSize
+	}
+	if l > uint64((len(data)-*current)/itemSize) {
+		return 0, ErrInvalidAccountDataSize
+	}
+	return l, nil
+}
@@ -68,10 +68,9 @@ func MintAccountFromData(data []byte) (MintAccount, error) {
 		return MintAccount{}, ErrInvalidAccountDataSize
 	}
 
-	var mint *common.PublicKey
-	if bytes.Equal(data[:4], Some) {
-		key := common.PublicKeyFromBytes(data[4:36])
-		mint = &key
+	mint, err := cOptionPublicKey(data[:36])
+	if err != nil {
+		return MintAccount{}, err
 	}
 
 	supply := binary.LittleEndian.Uint64(data[36:44])
@@ -80,10 +79,9 @@ func MintAccountFromData(data []byte) (MintAccount, error) {
 
 	isInitialized := data[45] == 1
 
-	var freezeAuthority *common.PublicKey
-	if bytes.Equal(data[46:50], Some) {
-		key := common.PublicKeyFromBytes(data[50:82])
-		freezeAuthority = &key
+	freezeAuthority, err := cOptionPublicKey(data[46:82])
+	if err != nil {
+		return MintAccount{}, err
 	}
 
 	return MintAccount{
@@ -129,26 +127,30 @@ func TokenAccountFromData(data []byte) (TokenAccount, error) {
 
 	amount := binary.LittleEndian.Uint64(data[64:72])
 
-	var delegate *common.PublicKey
-	if bytes.Equal(data[72:76], Some) {
-		key := common.PublicKeyFromBytes(data[76:108])
-		delegate = &key
+	delegate, err := cOptionPublicKey(data[72:108])
+	if err != nil {
+		return TokenAccount{}, err
 	}
 
 	state := TokenAccountState(data[108])
+	if state > TokenAccountFrozen {
+		return TokenAccount{}, ErrInvalidAccountData
+	}
 
 	var isNative *uint64
-	if bytes.Equal(data[109:113], Some) {
+	switch {
+	case bytes.Equal(data[109:113], Some):
 		num := binary.LittleEndian.Uint64(data[113:121])
 		isNative = &num
+	case !bytes.Equal(data[109:113], None):
+		return TokenAccount{}, ErrInvalidAccountData
 	}
 
 	delegatedAmount := binary.LittleEndian.Uint64(data[121:129])
 
-	var closeAuthority *common.PublicKey
-	if bytes.Equal(data[129:133], Some) {
-		key := common.PublicKeyFromBytes(data[133:165])
-		closeAuthority = &key
+	closeAuthority, err := cOptionPublicKey(data[129:165])
+	if err != nil {
+		return TokenAccount{}, err
 	}
 
 	return TokenAccount{
@@ -169,3 +171,15 @@ func DeserializeTokenAccount(data []byte, accountOwner common.PublicKey) (TokenA
 	}
 	return TokenAccountFromData(data)
 }
+
+// cOptionPublicKey decodes a COption<Pubkey>, a 4 bytes tag followed by the pubkey
+func cOptionPublicKey(b []byte) (*common.PublicKey, error) {
+	switch {
+	case bytes.Equal(b[:4], Some):
+		key := common.PublicKeyFromBytes(b[4:36])
+		return &key, nil
+	case bytes.Equal(b[:4], None):
+		return nil, nil
+	}
+	return nil, ErrInvalidAccountData
+}
@@ -50,6 +50,46 @@ func TestAccountFromData(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "trailing data",
+			args: args{
+				data: append(tokenAccountData(), 0),
+			},
+			want:    TokenAccount{},
+			wantErr: ErrInvalidAccountDataSize,
+		},
+		{
+			name: "truncated",
+			args: args{
+				data: tokenAccountData()[:164],
+			},
+			want:    TokenAccount{},
+			wantErr: ErrInvalidAccountDataSize,
+		},
+		{
+			name: "invalid delegate option",
+			args: args{
+				data: func() []byte { b := tokenAccountData(); b[72] = 2; return b }(),
+			},
+			want:    TokenAccount{},
+			wantErr: ErrInvalidAccountData,
+		},
+		{
+			name: "invalid is native option",
+			args: args{
+				data: func() []byte { b := tokenAccountData(); b[110] = 1; return b }(),
+			},
+			want:    TokenAccount{},
+			wantErr: ErrInvalidAccountData,
+		},
+		{
+			name: "invalid state",
+			args: args{
+				data: func() []byte { b := tokenAccountData(); b[108] = 3; return b }(),
+			},
+			want:    TokenAccount{},
+			wantErr: ErrInvalidAccountData,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -265,3 +305,19 @@ func TestDeserializeTokenAccount(t *testing.T) {
 		})
 	}
 }
+
+func tokenAccountData() []byte {
+	return []byte{105, 145, 9, 101, 129, 184, 46, 130, 176, 132, 102, 98, 17, 241, 215, 189, 90, 219, 106, 196, 196, 121, 174, 243, 65, 40, 132, 7, 252, 112, 238, 112, 206, 211, 135, 230, 195, 111, 87, 254, 147, 239, 143, 81, 110, 159, 49, 140, 109, 137, 224, 197, 24, 49, 223, 61, 123, 8, 78, 109, 110, 136, 228, 240, 0, 186, 69, 61, 244, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+}
+
+func FuzzTokenAccountFromData(f *testing.F) {
+	f.Add(tokenAccountData())
+	f.Fuzz(func(t *testing.T, data []byte) {
+		got, err := TokenAccountFromData(data)
+		if err != nil {
+			return
+		}
+		assert.Equal(t, common.PublicKeyFromBytes(data[:32]), got.Mint)
+		assert.Equal(t, common.PublicKeyFromBytes(data[32:64]), got.Owner)
+	})
+}
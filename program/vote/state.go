@@ -0,0 +1,285 @@
+package vote
+
+import (
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/bytes_decoder"
+)
+
+// AccountSize is the size of a vote account which holds the current VoteState version
+const AccountSize uint64 = 3762
+
+// MaxPriorVoters is the capacity of the prior voters circular buffer
+const MaxPriorVoters = 32
+
+type VoteStateVersion uint32
+
+const (
+	VoteStateVersionV0_23_5 VoteStateVersion = iota
+	VoteStateVersionV1_14_11
+	VoteStateVersionCurrent
+)
+
+// LandedVote is a vote with its lockout. Latency is always 0 before VoteStateVersionCurrent.
+type LandedVote struct {
+	Latency           uint8
+	Slot              uint64
+	ConfirmationCount uint32
+}
+
+type AuthorizedVoter struct {
+	Epoch  uint64
+	Pubkey common.PublicKey
+}
+
+// PriorVoter is an authorized voter which was replaced. It was in charge during [EpochStart, EpochEnd).
+type PriorVoter struct {
+	Pubkey     common.PublicKey
+	EpochStart uint64
+	EpochEnd   uint64
+}
+
+type EpochCredits struct {
+	Epoch       uint64
+	Credits     uint64
+	PrevCredits uint64
+}
+
+// Earned returns the credits earned in the epoch
+func (e EpochCredits) Earned() uint64 {
+	return e.Credits - e.PrevCredits
+}
+
+type BlockTimestamp struct {
+	Slot      uint64
+	Timestamp int64
+}
+
+// VoteState is the vote account state. All versions are decoded into this struct.
+type VoteState struct {
+	Version              VoteStateVersion
+	NodePubkey           common.PublicKey
+	AuthorizedWithdrawer common.PublicKey
+	Commission           uint8
+	Votes                []LandedVote
+	RootSlot             *uint64
+	AuthorizedVoters     []AuthorizedVoter
+	// PriorVoters is ordered from the oldest to the newest
+	PriorVoters   []PriorVoter
+	EpochCredits  []EpochCredits
+	LastTimestamp BlockTimestamp
+}
+
+// AuthorizedVoter returns the authorized voter of the epoch
+func (s VoteState) AuthorizedVoter(epoch uint64) (common.PublicKey, bool) {
+	var voter *AuthorizedVoter
+	for i := range s.AuthorizedVoters {
+		if s.AuthorizedVoters[i].Epoch <= epoch {
+			voter = &s.AuthorizedVoters[i]
+		}
+	}
+	if voter == nil {
+		return common.PublicKey{}, false
+	}
+	return voter.Pubkey, true
+}
+
+// Credits returns the total credits the vote account has earned
+func (s VoteState) Credits() uint64 {
+	if len(s.EpochCredits) == 0 {
+		return 0
+	}
+	return s.EpochCredits[len(s.EpochCredits)-1].Credits
+}
+
+func DeserializeVoteState(data []byte, accountOwner common.PublicKey) (VoteState, error) {
+	if accountOwner != common.VoteProgramID {
+		return VoteState{}, ErrInvalidAccountOwner
+	}
+	return VoteStateFromData(data)
+}
+
+func VoteStateFromData(data []byte) (VoteState, error) {
+	current := 0
+	version, err := bytes_decoder.GetUint32(&current, data)
+	if err != nil {
+		return VoteState{}, ErrInvalidAccountDataSize
+	}
+
+	d := decoder{current: current, data: data}
+	var state VoteState
+	switch VoteStateVersion(version) {
+	case VoteStateVersionV0_23_5:
+		state = d.voteState0_23_5()
+	case VoteStateVersionV1_14_11, VoteStateVersionCurrent:
+		state = d.voteState(VoteStateVersion(version))
+	default:
+		return VoteState{}, ErrInvalidAccountData
+	}
+	if d.err != nil {
+		return VoteState{}, ErrInvalidAccountDataSize
+	}
+	return state, nil
+}
+
+func (d *decoder) voteState0_23_5() VoteState {
+	state := VoteState{Version: VoteStateVersionV0_23_5}
+	state.NodePubkey = d.pubkey()
+	authorizedVoter := d.pubkey()
+	authorizedVoterEpoch := d.u64()
+	state.AuthorizedVoters = []AuthorizedVoter{{Epoch: authorizedVoterEpoch, Pubkey: authorizedVoter}}
+
+	type priorVoter struct {
+		PriorVoter
+		slot uint64
+	}
+	buf := make([]priorVoter, 0, MaxPriorVoters)
+	for i := 0; i < MaxPriorVoters; i++ {
+		buf = append(buf, priorVoter{
+			PriorVoter: PriorVoter{Pubkey: d.pubkey(), EpochStart: d.u64(), EpochEnd: d.u64()},
+			slot:       d.u64(),
+		})
+	}
+	idx := d.u64()
+	for i := uint64(1); i <= MaxPriorVoters && d.err == nil; i++ {
+		v := buf[(idx+i)%MaxPriorVoters]
+		if v.PriorVoter != (PriorVoter{}) || v.slot != 0 {
+			state.PriorVoters = append(state.PriorVoters, v.PriorVoter)
+		}
+	}
+
+	state.AuthorizedWithdrawer = d.pubkey()
+	state.Commission = d.u8()
+	state.Votes = d.votes(false)
+	state.RootSlot = d.optionU64()
+	state.EpochCredits = d.epochCredits()
+	state.LastTimestamp = d.blockTimestamp()
+	return state
+}
+
+func (d *decoder) voteState(version VoteStateVersion) VoteState {
+	state := VoteState{Version: version}
+	state.NodePubkey = d.pubkey()
+	state.AuthorizedWithdrawer = d.pubkey()
+	state.Commission = d.u8()
+	state.Votes = d.votes(version == VoteStateVersionCurrent)
+	state.RootSlot = d.optionU64()
+
+	authorizedVoterCount := d.length(40)
+	state.AuthorizedVoters = make([]AuthorizedVoter, 0, authorizedVoterCount)
+	for i := 0; i < authorizedVoterCount; i++ {
+		state.AuthorizedVoters = append(state.AuthorizedVoters, AuthorizedVoter{Epoch: d.u64(), Pubkey: d.pubkey()})
+	}
+
+	buf := make([]PriorVoter, 0, MaxPriorVoters)
+	for i := 0; i < MaxPriorVoters; i++ {
+		buf = append(buf, PriorVoter{Pubkey: d.pubkey(), EpochStart: d.u64(), EpochEnd: d.u64()})
+	}
+	idx := d.u64()
+	isEmpty := d.u8() == 1
+	if !isEmpty {
+		for i := uint64(1); i <= MaxPriorVoters && d.err == nil; i++ {
+			if v := buf[(idx+i)%MaxPriorVoters]; v != (PriorVoter{}) {
+				state.PriorVoters = append(state.PriorVoters, v)
+			}
+		}
+	}
+
+	state.EpochCredits = d.epochCredits()
+	state.LastTimestamp = d.blockTimestamp()
+	return state
+}
+
+// decoder keeps the first error so a layout can be read field by field
+type decoder struct {
+	current int
+	data    []byte
+	err     error
+}
+
+func (d *decoder) u8() uint8 {
+	if d.err != nil {
+		return 0
+	}
+	var v uint8
+	v, d.err = bytes_decoder.GetUint8(&d.current, d.data)
+	return v
+}
+
+func (d *decoder) u32() uint32 {
+	if d.err != nil {
+		return 0
+	}
+	var v uint32
+	v, d.err = bytes_decoder.GetUint32(&d.current, d.data)
+	return v
+}
+
+func (d *decoder) u64() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	var v uint64
+	v, d.err = bytes_decoder.GetUint64(&d.current, d.data)
+	return v
+}
+
+func (d *decoder) pubkey() common.PublicKey {
+	if d.err != nil {
+		return common.PublicKey{}
+	}
+	var v [32]byte
+	v, d.err = bytes_decoder.GetBytes32(&d.current, d.data)
+	return common.PublicKey(v)
+}
+
+func (d *decoder) optionU64() *uint64 {
+	if d.u8() != 1 {
+		return nil
+	}
+	v := d.u64()
+	return &v
+}
+
+// length reads a vec length and makes sure the rest of data can hold it
+func (d *decoder) length(itemSize int) int {
+	l := d.u64()
+	if d.err == nil && l > uint64((len(d.data)-d.current)/itemSize) {
+		d.err = ErrInvalidAccountDataSize
+	}
+	if d.err != nil {
+		return 0
+	}
+	return int(l)
+}
+
+func (d *decoder) votes(withLatency bool) []LandedVote {
+	itemSize := 12
+	if withLatency {
+		itemSize = 13
+	}
+	l := d.length(itemSize)
+	votes := make([]LandedVote, 0, l)
+	for i := 0; i < l; i++ {
+		var vote LandedVote
+		if withLatency {
+			vote.Latency = d.u8()
+		}
+		vote.Slot = d.u64()
+		vote.ConfirmationCount = d.u32()
+		votes = append(votes, vote)
+	}
+	return votes
+}
+
+func (d *decoder) epochCredits() []EpochCredits {
+	l := d.length(24)
+	epochCredits := make([]EpochCredits, 0, l)
+	for i := 0; i < l; i++ {
+		epochCredits = append(epochCredits, EpochCredits{Epoch: d.u64(), Credits: d.u64(), PrevCredits: d.u64()})
+	}
+	return epochCredits
+}
+
+func (d *decoder) blockTimestamp() BlockTimestamp {
+	return BlockTimestamp{Slot: d.u64(), Timestamp: int64(d.u64())}
+}
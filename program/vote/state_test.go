@@ -0,0 +1,228 @@
+package vote
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/pointer"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testNode       = common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	testVoter      = common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	testPriorVoter = common.PublicKeyFromString("DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi")
+	testWithdrawer = common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+)
+
+func encode(vs ...any) []byte {
+	b := new(bytes.Buffer)
+	for _, v := range vs {
+		_ = binary.Write(b, binary.LittleEndian, v)
+	}
+	return b.Bytes()
+}
+
+func priorVoters(withSlot bool) []byte {
+	b := new(bytes.Buffer)
+	for i := 0; i < MaxPriorVoters; i++ {
+		switch i {
+		case 3:
+			b.Write(encode(testVoter, uint64(90), uint64(100)))
+		case 4:
+			b.Write(encode(testPriorVoter, uint64(100), uint64(101)))
+		default:
+			b.Write(make([]byte, 48))
+		}
+		if withSlot {
+			b.Write(make([]byte, 8))
+		}
+	}
+	b.Write(encode(uint64(4)))
+	return b.Bytes()
+}
+
+func TestVoteStateFromData(t *testing.T) {
+	tail := encode(
+		uint64(2),
+		uint64(100), uint64(5000), uint64(1000),
+		uint64(101), uint64(9000), uint64(5000),
+		uint64(216000000), int64(1690000000),
+	)
+	want := VoteState{
+		NodePubkey:           testNode,
+		AuthorizedWithdrawer: testWithdrawer,
+		Commission:           10,
+		RootSlot:             pointer.Get[uint64](215999968),
+		PriorVoters: []PriorVoter{
+			{Pubkey: testVoter, EpochStart: 90, EpochEnd: 100},
+			{Pubkey: testPriorVoter, EpochStart: 100, EpochEnd: 101},
+		},
+		EpochCredits: []EpochCredits{
+			{Epoch: 100, Credits: 5000, PrevCredits: 1000},
+			{Epoch: 101, Credits: 9000, PrevCredits: 5000},
+		},
+		LastTimestamp: BlockTimestamp{Slot: 216000000, Timestamp: 1690000000},
+	}
+
+	current := want
+	current.Version = VoteStateVersionCurrent
+	current.Votes = []LandedVote{{Latency: 1, Slot: 215999999, ConfirmationCount: 2}, {Latency: 2, Slot: 216000000, ConfirmationCount: 1}}
+	current.AuthorizedVoters = []AuthorizedVoter{{Epoch: 101, Pubkey: testVoter}}
+
+	v1_14_11 := current
+	v1_14_11.Version = VoteStateVersionV1_14_11
+	v1_14_11.Votes = []LandedVote{{Slot: 215999999, ConfirmationCount: 2}, {Slot: 216000000, ConfirmationCount: 1}}
+
+	v0_23_5 := v1_14_11
+	v0_23_5.Version = VoteStateVersionV0_23_5
+	v0_23_5.AuthorizedVoters = []AuthorizedVoter{{Epoch: 99, Pubkey: testVoter}}
+
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		want VoteState
+		err  error
+	}{
+		{
+			name: "current",
+			args: args{
+				data: bytes.Join([][]byte{
+					encode(uint32(2), testNode, testWithdrawer, uint8(10)),
+					encode(uint64(2), uint8(1), uint64(215999999), uint32(2), uint8(2), uint64(216000000), uint32(1)),
+					encode(uint8(1), uint64(215999968)),
+					encode(uint64(1), uint64(101), testVoter),
+					priorVoters(false), {0},
+					tail,
+					make([]byte, 100),
+				}, nil),
+			},
+			want: current,
+			err:  nil,
+		},
+		{
+			name: "v1.14.11",
+			args: args{
+				data: bytes.Join([][]byte{
+					encode(uint32(1), testNode, testWithdrawer, uint8(10)),
+					encode(uint64(2), uint64(215999999), uint32(2), uint64(216000000), uint32(1)),
+					encode(uint8(1), uint64(215999968)),
+					encode(uint64(1), uint64(101), testVoter),
+					priorVoters(false), {0},
+					tail,
+				}, nil),
+			},
+			want: v1_14_11,
+			err:  nil,
+		},
+		{
+			name: "v0.23.5",
+			args: args{
+				data: bytes.Join([][]byte{
+					encode(uint32(0), testNode, testVoter, uint64(99)),
+					priorVoters(true),
+					encode(testWithdrawer, uint8(10)),
+					encode(uint64(2), uint64(215999999), uint32(2), uint64(216000000), uint32(1)),
+					encode(uint8(1), uint64(215999968)),
+					tail,
+				}, nil),
+			},
+			want: v0_23_5,
+			err:  nil,
+		},
+		{
+			name: "empty prior voters",
+			args: args{
+				data: bytes.Join([][]byte{
+					encode(uint32(2), testNode, testWithdrawer, uint8(10)),
+					encode(uint64(0), uint8(0), uint64(0)),
+					make([]byte, MaxPriorVoters*48), encode(uint64(MaxPriorVoters-1), uint8(1)),
+					encode(uint64(0), uint64(0), int64(0)),
+				}, nil),
+			},
+			want: VoteState{
+				Version:              VoteStateVersionCurrent,
+				NodePubkey:           testNode,
+				AuthorizedWithdrawer: testWithdrawer,
+				Commission:           10,
+				Votes:                []LandedVote{},
+				AuthorizedVoters:     []AuthorizedVoter{},
+				EpochCredits:         []EpochCredits{},
+			},
+			err: nil,
+		},
+		{
+			name: "truncated",
+			args: args{
+				data: encode(uint32(2), testNode, testWithdrawer, uint8(10), uint64(2)),
+			},
+			want: VoteState{},
+			err:  ErrInvalidAccountDataSize,
+		},
+		{
+			name: "huge vec length",
+			args: args{
+				data: encode(uint32(2), testNode, testWithdrawer, uint8(10), uint64(1<<60)),
+			},
+			want: VoteState{},
+			err:  ErrInvalidAccountDataSize,
+		},
+		{
+			name: "unknown version",
+			args: args{
+				data: encode(uint32(3)),
+			},
+			want: VoteState{},
+			err:  ErrInvalidAccountData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VoteStateFromData(tt.args.data)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestDeserializeVoteState(t *testing.T) {
+	_, err := DeserializeVoteState(encode(uint32(2)), common.SystemProgramID)
+	assert.Equal(t, ErrInvalidAccountOwner, err)
+}
+
+func TestVoteState_AuthorizedVoter(t *testing.T) {
+	state := VoteState{
+		AuthorizedVoters: []AuthorizedVoter{
+			{Epoch: 100, Pubkey: testPriorVoter},
+			{Epoch: 102, Pubkey: testVoter},
+		},
+	}
+
+	_, ok := state.AuthorizedVoter(99)
+	assert.False(t, ok)
+
+	voter, ok := state.AuthorizedVoter(101)
+	assert.True(t, ok)
+	assert.Equal(t, testPriorVoter, voter)
+
+	voter, ok = state.AuthorizedVoter(102)
+	assert.True(t, ok)
+	assert.Equal(t, testVoter, voter)
+}
+
+func TestVoteState_Credits(t *testing.T) {
+	state := VoteState{
+		EpochCredits: []EpochCredits{
+			{Epoch: 100, Credits: 5000, PrevCredits: 1000},
+			{Epoch: 101, Credits: 9000, PrevCredits: 5000},
+		},
+	}
+	assert.Equal(t, uint64(9000), state.Credits())
+	assert.Equal(t, uint64(4000), state.EpochCredits[0].Earned())
+	assert.Equal(t, uint64(0), VoteState{}.Credits())
+}
@@ -0,0 +1,237 @@
+package rpc
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// CacheStore keeps the cached responses, a ttl of 0 keeps the response until it is evicted
+type CacheStore interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// CachePolicy is how the responses of a method are cached
+type CachePolicy struct {
+	// TTL is how long a response is kept, 0 is until the store evicts it
+	TTL time.Duration
+	// Cacheable decides by the params of the request whether the response may be cached, nil is always
+	Cacheable func(params []json.RawMessage) bool
+}
+
+// DefaultCachePolicies cache what can't change, blocks and transactions at the finalized commitment,
+// forever and what changes rarely for a while. other methods, e.g. sendTransaction, are never cached.
+var DefaultCachePolicies = map[string]CachePolicy{
+	"getBlock":                          {Cacheable: isFinalized},
+	"getTransaction":                    {Cacheable: isFinalized},
+	"getGenesisHash":                    {},
+	"getEpochSchedule":                  {},
+	"getFirstAvailableBlock":            {},
+	"getMinimumBalanceForRentExemption": {TTL: time.Hour},
+	"getLatestBlockhash":                {TTL: 500 * time.Millisecond},
+}
+
+// uncacheable methods change the state of the cluster, a policy can't make them cached
+var uncacheable = map[string]bool{
+	"sendTransaction": true,
+	"requestAirdrop":  true,
+}
+
+// isFinalized tells whether the config of the request asks for the finalized commitment, the default one
+func isFinalized(params []json.RawMessage) bool {
+	for _, param := range params {
+		var config struct {
+			Commitment *Commitment `json:"commitment"`
+		}
+		if json.Unmarshal(param, &config) == nil && config.Commitment != nil && *config.Commitment != CommitmentFinalized {
+			return false
+		}
+	}
+	return true
+}
+
+// Cache serves the responses of cacheable requests from its store. only responses with a result are cached,
+// an error or a null result, e.g. a transaction which isn't found yet, is not.
+type Cache struct {
+	store    CacheStore
+	policies map[string]CachePolicy
+
+	mu    sync.Mutex
+	stats map[string]CacheMethodStats
+}
+
+type CacheOption func(*Cache)
+
+// WithCacheStore is a CacheOption that replaces the default store, an LRU of 1000 responses
+func WithCacheStore(store CacheStore) CacheOption {
+	return func(c *Cache) {
+		c.store = store
+	}
+}
+
+// WithCachePolicy is a CacheOption that sets the policy of a method
+func WithCachePolicy(method string, policy CachePolicy) CacheOption {
+	return func(c *Cache) {
+		c.policies[method] = policy
+	}
+}
+
+// WithoutCachePolicy is a CacheOption that stops a method from being cached
+func WithoutCachePolicy(method string) CacheOption {
+	return func(c *Cache) {
+		delete(c.policies, method)
+	}
+}
+
+func NewCache(opts ...CacheOption) *Cache {
+	c := &Cache{
+		policies: map[string]CachePolicy{},
+		stats:    map[string]CacheMethodStats{},
+	}
+	for method, policy := range DefaultCachePolicies {
+		c.policies[method] = policy
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.store == nil {
+		c.store = NewLRUCacheStore(1000)
+	}
+	return c
+}
+
+type CacheMethodStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+type CacheStats struct {
+	Hits    uint64
+	Misses  uint64
+	Methods map[string]CacheMethodStats
+}
+
+// Stats returns the hits and the misses of the cacheable requests
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := CacheStats{Methods: map[string]CacheMethodStats{}}
+	for method, s := range c.stats {
+		stats.Hits += s.Hits
+		stats.Misses += s.Misses
+		stats.Methods[method] = s
+	}
+	return stats
+}
+
+// get looks the request up. the key is empty if the request is not cacheable.
+func (c *Cache) get(endpoint, method string, payload []byte) (string, []byte, bool) {
+	policy, ok := c.policies[method]
+	if !ok || uncacheable[method] {
+		return "", nil, false
+	}
+	var request struct {
+		Params []json.RawMessage `json:"params"`
+	}
+	if json.Unmarshal(payload, &request) != nil {
+		return "", nil, false
+	}
+	if policy.Cacheable != nil && !policy.Cacheable(request.Params) {
+		return "", nil, false
+	}
+	normalized, err := normalizeRequest(payload)
+	if err != nil {
+		return "", nil, false
+	}
+
+	// a store may be shared by the clients of several clusters
+	key := endpoint + " " + string(normalized)
+	body, ok := c.store.Get(key)
+	c.mu.Lock()
+	s := c.stats[method]
+	if ok {
+		s.Hits++
+	} else {
+		s.Misses++
+	}
+	c.stats[method] = s
+	c.mu.Unlock()
+	return key, body, ok
+}
+
+func (c *Cache) set(key, method string, body []byte) {
+	if key == "" {
+		return
+	}
+	var res struct {
+		Result json.RawMessage `json:"result"`
+		Error  json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal(body, &res) != nil || len(res.Error) > 0 || len(res.Result) == 0 || string(res.Result) == "null" {
+		return
+	}
+	c.store.Set(key, body, c.policies[method].TTL)
+}
+
+// lruCacheStore evicts the least recently used response once it is full
+type lruCacheStore struct {
+	mu      sync.Mutex
+	size    int
+	items   map[string]*list.Element
+	order   *list.List
+	timeNow func() time.Time
+}
+
+type lruCacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+func NewLRUCacheStore(size int) CacheStore {
+	return &lruCacheStore{
+		size:    size,
+		items:   map[string]*list.Element{},
+		order:   list.New(),
+		timeNow: time.Now,
+	}
+}
+
+func (s *lruCacheStore) Get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	element, ok := s.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*lruCacheEntry)
+	if !entry.expires.IsZero() && !s.timeNow().Before(entry.expires) {
+		s.order.Remove(element)
+		delete(s.items, key)
+		return nil, false
+	}
+	s.order.MoveToFront(element)
+	return entry.value, true
+}
+
+func (s *lruCacheStore) Set(key string, value []byte, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := &lruCacheEntry{key: key, value: value}
+	if ttl > 0 {
+		entry.expires = s.timeNow().Add(ttl)
+	}
+	if element, ok := s.items[key]; ok {
+		element.Value = entry
+		s.order.MoveToFront(element)
+		return
+	}
+	s.items[key] = s.order.PushFront(entry)
+	for s.order.Len() > s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.items, oldest.Value.(*lruCacheEntry).key)
+	}
+}
@@ -0,0 +1,185 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		var request JsonRpcRequest
+		_ = json.Unmarshal(body, &request)
+		mu.Lock()
+		requests[request.Method]++
+		mu.Unlock()
+		switch request.Method {
+		case "getGenesisHash":
+			_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","result":"EtWTRABZaYq6iMfeYKouRu166VU2xqa1wcaWoxPkrZBG","id":1}`))
+		case "getBlock":
+			_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","result":{"blockhash":"3Dpi4W6bNpH4pHSV2tKJq28q9fP9zjaNPKMCAZe9TrmY","blockHeight":33,"parentSlot":32},"id":1}`))
+		case "getTransaction":
+			_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","result":null,"id":1}`))
+		case "getMinimumBalanceForRentExemption":
+			_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params"},"id":1}`))
+		case "getLatestBlockhash":
+			_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":{"blockhash":"3Dpi4W6bNpH4pHSV2tKJq28q9fP9zjaNPKMCAZe9TrmY","lastValidBlockHeight":183}},"id":1}`))
+		case "sendTransaction":
+			_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","result":"5Pzqj1cNTpq5Zpyh8Ycyf5kaV8ZPBBCwJHyaStd6NSZvSvhZQbAtZb2GvfAJjwuVXbaiuBS7tqjD2fAhmbwQ9Hj","id":1}`))
+		default:
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	now := time.Unix(1700000000, 0)
+	store := NewLRUCacheStore(10)
+	store.(*lruCacheStore).timeNow = func() time.Time { return now }
+	cache := NewCache(
+		WithCacheStore(store),
+		WithCachePolicy("sendTransaction", CachePolicy{}),
+	)
+	var cached []bool
+	c := New(WithEndpoint(server.URL), WithCache(cache), WithObserver(ObserverFuncs{
+		End: func(ctx context.Context, info CallInfo) { cached = append(cached, info.Cached) },
+	}))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		res, err := c.GetGenesisHash(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "EtWTRABZaYq6iMfeYKouRu166VU2xqa1wcaWoxPkrZBG", res.Result)
+	}
+	assert.Equal(t, 1, requests["getGenesisHash"])
+	assert.Equal(t, []bool{false, true}, cached)
+
+	// a block is cached at the finalized commitment only
+	for i := 0; i < 2; i++ {
+		res, err := c.GetBlock(ctx, 33)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(33), *res.Result.BlockHeight)
+		_, err = c.GetBlockWithConfig(ctx, 33, GetBlockConfig{Commitment: CommitmentFinalized})
+		assert.NoError(t, err)
+		_, err = c.GetBlockWithConfig(ctx, 33, GetBlockConfig{Commitment: CommitmentConfirmed})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 4, requests["getBlock"])
+
+	// a transaction which isn't found yet and an error are not cached
+	for i := 0; i < 2; i++ {
+		_, err := c.GetTransaction(ctx, "5Pzqj1cNTpq5Zpyh8Ycyf5kaV8ZPBBCwJHyaStd6NSZvSvhZQbAtZb2GvfAJjwuVXbaiuBS7tqjD2fAhmbwQ9Hj")
+		assert.NoError(t, err)
+		res, err := c.GetMinimumBalanceForRentExemption(ctx, 165)
+		assert.NoError(t, err)
+		assert.NotNil(t, res.Error)
+	}
+	assert.Equal(t, 2, requests["getTransaction"])
+	assert.Equal(t, 2, requests["getMinimumBalanceForRentExemption"])
+
+	// a transaction is always sent, even with a policy
+	for i := 0; i < 2; i++ {
+		_, err := c.SendTransaction(ctx, "AQ==")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, requests["sendTransaction"])
+
+	// the blockhash expires
+	for i := 0; i < 3; i++ {
+		res, err := c.GetLatestBlockhash(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(183), res.Result.Value.LatestValidBlockHeight)
+		now = now.Add(300 * time.Millisecond)
+	}
+	assert.Equal(t, 2, requests["getLatestBlockhash"])
+
+	// a failed request is not cached
+	_, err := c.GetEpochSchedule(ctx)
+	assert.ErrorContains(t, err, "get status code: 503")
+	_, err = c.GetEpochSchedule(ctx)
+	assert.ErrorContains(t, err, "get status code: 503")
+
+	assert.Equal(t, CacheStats{
+		Hits:   4,
+		Misses: 11,
+		Methods: map[string]CacheMethodStats{
+			"getGenesisHash":                    {Hits: 1, Misses: 1},
+			"getBlock":                          {Hits: 2, Misses: 2},
+			"getTransaction":                    {Misses: 2},
+			"getMinimumBalanceForRentExemption": {Misses: 2},
+			"getLatestBlockhash":                {Hits: 1, Misses: 2},
+			"getEpochSchedule":                  {Misses: 2},
+		},
+	}, cache.Stats())
+
+	// the store is keyed by the endpoint
+	other := New(WithEndpoint(server.URL+"/other"), WithCache(cache))
+	_, err = other.GetGenesisHash(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests["getGenesisHash"])
+}
+
+func TestCachePolicy(t *testing.T) {
+	cache := NewCache(
+		WithoutCachePolicy("getGenesisHash"),
+		WithCachePolicy("getSlot", CachePolicy{TTL: time.Second}),
+	)
+	key, _, _ := cache.get("", "getGenesisHash", []byte(`{"jsonrpc":"2.0","id":1,"method":"getGenesisHash"}`))
+	assert.Equal(t, "", key)
+	key, _, _ = cache.get("", "getSlot", []byte(`{"jsonrpc":"2.0","id":1,"method":"getSlot"}`))
+	assert.Equal(t, ` {"jsonrpc":"2.0","method":"getSlot"}`, key)
+
+	tests := []struct {
+		params string
+		want   bool
+	}{
+		{params: `[33]`, want: true},
+		{params: `[33,{"encoding":"base64"}]`, want: true},
+		{params: `[33,{"commitment":"finalized"}]`, want: true},
+		{params: `[33,{"commitment":"confirmed"}]`, want: false},
+		{params: `["5Pzqj1cNTpq5Zpyh8Ycyf5kaV8ZPBBCwJHyaStd6NSZvSvhZQbAtZb2GvfAJjwuVXbaiuBS7tqjD2fAhmbwQ9Hj",{"commitment":"processed"}]`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.params, func(t *testing.T) {
+			var params []json.RawMessage
+			assert.NoError(t, json.Unmarshal([]byte(tt.params), &params))
+			assert.Equal(t, tt.want, isFinalized(params))
+		})
+	}
+}
+
+func TestLRUCacheStore(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := NewLRUCacheStore(2).(*lruCacheStore)
+	store.timeNow = func() time.Time { return now }
+
+	store.Set("a", []byte("1"), 0)
+	store.Set("b", []byte("2"), time.Second)
+	_, ok := store.Get("a")
+	assert.True(t, ok)
+	// b is the least recently used
+	store.Set("c", []byte("3"), 0)
+	_, ok = store.Get("b")
+	assert.False(t, ok)
+
+	store.Set("a", []byte("4"), time.Second)
+	value, ok := store.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("4"), value)
+	now = now.Add(time.Second)
+	_, ok = store.Get("a")
+	assert.False(t, ok)
+	value, ok = store.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("3"), value)
+	assert.Equal(t, 1, store.order.Len())
+}
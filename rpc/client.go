@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 )
 
 const (
@@ -65,6 +66,12 @@ type ValueWithContext[T any] struct {
 type RpcClient struct {
 	endpoint   string
 	httpClient *http.Client
+	recorder   *Recorder
+	replayer   *Replayer
+	observers  []Observer
+	cache      *Cache
+	// traceIDHeader is the request header which carries the trace id of the context
+	traceIDHeader string
 }
 
 func NewRpcClient(endpoint string) RpcClient { return New(WithEndpoint(endpoint)) }
@@ -92,32 +99,102 @@ func (c *RpcClient) Call(ctx context.Context, params ...any) ([]byte, error) {
 		return nil, fmt.Errorf("failed to prepare payload, err: %v", err)
 	}
 
+	method := params[0].(string)
+	if len(c.observers) == 0 {
+		_, body, _, err := c.send(ctx, method, j)
+		return body, err
+	}
+
+	info := CallInfo{
+		Method:      method,
+		Endpoint:    c.endpoint,
+		RequestSize: len(j),
+	}
+	info.TraceID, _ = TraceIDFromContext(ctx)
+	// every observer ends with the context it has returned, the request uses the last one
+	contexts := make([]context.Context, len(c.observers))
+	for i, observer := range c.observers {
+		ctx = observer.CallStart(ctx, info)
+		contexts[i] = ctx
+	}
+	start := time.Now()
+	info.StatusCode, info.Response, info.Cached, info.Err = c.send(ctx, method, j)
+	info.Duration = time.Since(start)
+	info.ResponseSize = len(info.Response)
+	info.RpcError = decodeJsonRpcError(info.Response)
+	for i := len(c.observers) - 1; i >= 0; i-- {
+		c.observers[i].CallEnd(contexts[i], info)
+	}
+	return info.Response, info.Err
+}
+
+// send returns the http status code, which is 0 if there is no response, the body and whether it is
+// served from the cache
+func (c *RpcClient) send(ctx context.Context, method string, payload []byte) (int, []byte, bool, error) {
+	if c.cache == nil {
+		statusCode, body, err := c.roundTrip(ctx, payload)
+		return statusCode, body, false, err
+	}
+
+	key, body, ok := c.cache.get(c.endpoint, method, payload)
+	if ok {
+		return http.StatusOK, body, true, nil
+	}
+	statusCode, body, err := c.roundTrip(ctx, payload)
+	if err == nil {
+		c.cache.set(key, method, body)
+	}
+	return statusCode, body, false, err
+}
+
+func (c *RpcClient) roundTrip(ctx context.Context, payload []byte) (int, []byte, error) {
+	if c.replayer != nil {
+		return c.replayer.replay(payload)
+	}
+
+	statusCode, body, err := c.do(ctx, payload)
+	if c.recorder != nil {
+		if err := c.recorder.record(c.endpoint, payload, statusCode, body, err); err != nil {
+			return statusCode, body, fmt.Errorf("failed to record, err: %v", err)
+		}
+	}
+	if err != nil {
+		return statusCode, nil, err
+	}
+	return statusCode, body, checkStatusCode(statusCode)
+}
+
+func (c *RpcClient) do(ctx context.Context, payload []byte) (int, []byte, error) {
 	// prepare request
-	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewBuffer(j))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewBuffer(payload))
 	if err != nil {
-		return nil, fmt.Errorf("failed to do http.NewRequestWithContext, err: %v", err)
+		return 0, nil, fmt.Errorf("failed to do http.NewRequestWithContext, err: %v", err)
 	}
 	req.Header.Add("Content-Type", "application/json")
+	if traceID, ok := TraceIDFromContext(ctx); ok && c.traceIDHeader != "" {
+		req.Header.Add(c.traceIDHeader, traceID)
+	}
 
 	// do request
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to do request, err: %v", err)
+		return 0, nil, fmt.Errorf("failed to do request, err: %v", err)
 	}
 	defer res.Body.Close()
 
 	// parse body
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read body, err: %v", err)
+		return 0, nil, fmt.Errorf("failed to read body, err: %v", err)
 	}
+	return res.StatusCode, body, nil
+}
 
-	// check response code
-	if res.StatusCode < 200 || res.StatusCode > 300 {
-		return body, fmt.Errorf("get status code: %v", res.StatusCode)
+func checkStatusCode(statusCode int) error {
+	if statusCode < 200 || statusCode > 300 {
+		return fmt.Errorf("get status code: %v", statusCode)
 	}
-
-	return body, nil
+	return nil
 }
 
 func preparePayload(params []any) ([]byte, error) {
@@ -0,0 +1,7 @@
+package rpc
+
+import "errors"
+
+var (
+	ErrUnexpectedRequest = errors.New("unexpected request, it has not been recorded")
+)
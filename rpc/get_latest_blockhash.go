@@ -2,6 +2,8 @@ package rpc
 
 import (
 	"context"
+
+	"github.com/EntySquare/solana-go-sdk/common"
 )
 
 type GetLatestBlockhashResponse JsonRpcResponse[GetLatestBlockhash]
@@ -14,6 +16,11 @@ type GetLatestBlockhashValue struct {
 	LatestValidBlockHeight uint64 `json:"lastValidBlockHeight"`
 }
 
+// Hash parses the blockhash, it fails if it is not a base58 hash
+func (v GetLatestBlockhashValue) Hash() (common.Hash, error) {
+	return common.ParseHash(v.Blockhash)
+}
+
 // GetLatestBlockhashConfig is a option config for `getLatestBlockhash`
 type GetLatestBlockhashConfig struct {
 	Commitment Commitment `json:"commitment,omitempty"`
@@ -4,7 +4,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/EntySquare/solana-go-sdk/common"
 	"github.com/EntySquare/solana-go-sdk/internal/client_test"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGetLatestBlockhash(t *testing.T) {
@@ -106,3 +108,12 @@ func TestGetLatestBlockhash(t *testing.T) {
 		},
 	)
 }
+
+func TestGetLatestBlockhashValue_Hash(t *testing.T) {
+	h, err := GetLatestBlockhashValue{Blockhash: "9K9GnvWXn9zYitQdHUSYzvjLjebnviwEFaWgWqHDU3ve"}.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, common.MustParseHash("9K9GnvWXn9zYitQdHUSYzvjLjebnviwEFaWgWqHDU3ve"), h)
+
+	_, err = GetLatestBlockhashValue{}.Hash()
+	assert.ErrorIs(t, err, common.ErrInvalidBase58)
+}
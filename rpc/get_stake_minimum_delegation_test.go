@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EntySquare/solana-go-sdk/internal/client_test"
+)
+
+func TestGetStakeMinimumDelegation(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getStakeMinimumDelegation"}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":214321583},"value":1000000000},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewRpcClient(url)
+					return c.GetStakeMinimumDelegation(
+						context.TODO(),
+					)
+				},
+				ExpectedValue: JsonRpcResponse[ValueWithContext[uint64]]{
+					JsonRpc: "2.0",
+					Id:      1,
+					Error:   nil,
+					Result: ValueWithContext[uint64]{
+						Context: Context{
+							Slot:       214321583,
+							ApiVersion: "1.16.7",
+						},
+						Value: 1000000000,
+					},
+				},
+				ExpectedError: nil,
+			},
+			{
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getStakeMinimumDelegation", "params":[{"commitment": "confirmed"}]}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.16.7","slot":214321583},"value":1000000000},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewRpcClient(url)
+					return c.GetStakeMinimumDelegationWithConfig(
+						context.TODO(),
+						GetStakeMinimumDelegationConfig{
+							Commitment: CommitmentConfirmed,
+						},
+					)
+				},
+				ExpectedValue: JsonRpcResponse[ValueWithContext[uint64]]{
+					JsonRpc: "2.0",
+					Id:      1,
+					Error:   nil,
+					Result: ValueWithContext[uint64]{
+						Context: Context{
+							Slot:       214321583,
+							ApiVersion: "1.16.7",
+						},
+						Value: 1000000000,
+					},
+				},
+				ExpectedError: nil,
+			},
+		},
+	)
+}
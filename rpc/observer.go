@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// CallInfo describes a call of the rpc client. CallStart gets the method, the endpoint, the request size
+// and the trace id, CallEnd gets all of it.
+type CallInfo struct {
+	Method   string
+	Endpoint string
+	// TraceID is the trace id of the context, see ContextWithTraceID
+	TraceID     string
+	RequestSize int
+
+	Duration time.Duration
+	// StatusCode is the http status code, it is 0 if there is no response
+	StatusCode   int
+	ResponseSize int
+	Response     []byte
+	// Cached is true if the response is served from the cache, see WithCache
+	Cached bool
+	// Err is the error Call returns, e.g. a failed request or an unexpected status code
+	Err error
+	// RpcError is the error field of the json rpc response, Call doesn't return it as an error
+	RpcError *JsonRpcError
+}
+
+// Observer is invoked around every call of the rpc client, e.g. to collect latency histograms,
+// error counters, payload sizes and in-flight gauges, or to trace the calls.
+// the observers of a client are invoked in order on start and in reverse order on end.
+type Observer interface {
+	// CallStart is invoked before the request is sent. the context it returns goes to its CallEnd, to the next
+	// observer and to the request, e.g. to carry a span which is a child of the span of the context.
+	CallStart(ctx context.Context, info CallInfo) context.Context
+	CallEnd(ctx context.Context, info CallInfo)
+}
+
+// ObserverFuncs turns functions into an Observer, a nil function is skipped
+type ObserverFuncs struct {
+	Start func(ctx context.Context, info CallInfo) context.Context
+	End   func(ctx context.Context, info CallInfo)
+}
+
+func (o ObserverFuncs) CallStart(ctx context.Context, info CallInfo) context.Context {
+	if o.Start == nil {
+		return ctx
+	}
+	return o.Start(ctx, info)
+}
+
+func (o ObserverFuncs) CallEnd(ctx context.Context, info CallInfo) {
+	if o.End != nil {
+		o.End(ctx, info)
+	}
+}
+
+type traceIDKey struct{}
+
+// ContextWithTraceID attaches a trace id to the context. the observers see it in CallInfo, and the client
+// sends it as a header if WithTraceIDHeader is set. the calls of a client.Client method share the context,
+// so they share the trace id.
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	traceID, ok := ctx.Value(traceIDKey{}).(string)
+	return traceID, ok
+}
+
+func decodeJsonRpcError(body []byte) *JsonRpcError {
+	var res struct {
+		Error *JsonRpcError `json:"error"`
+	}
+	if json.Unmarshal(body, &res) != nil {
+		return nil
+	}
+	return res.Error
+}
@@ -0,0 +1,130 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type spanKey struct{}
+
+// testObserver keeps what a metrics or tracing adapter would
+type testObserver struct {
+	mu       sync.Mutex
+	name     string
+	events   *[]string
+	inFlight int
+	maxFlow  int
+	infos    []CallInfo
+	spans    []string
+}
+
+func (o *testObserver) CallStart(ctx context.Context, info CallInfo) context.Context {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	*o.events = append(*o.events, o.name+" start "+info.Method)
+	o.inFlight++
+	if o.inFlight > o.maxFlow {
+		o.maxFlow = o.inFlight
+	}
+	parent, _ := ctx.Value(spanKey{}).(string)
+	return context.WithValue(ctx, spanKey{}, parent+"/"+info.Method)
+}
+
+func (o *testObserver) CallEnd(ctx context.Context, info CallInfo) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	*o.events = append(*o.events, o.name+" end "+info.Method)
+	o.inFlight--
+	o.infos = append(o.infos, info)
+	o.spans = append(o.spans, ctx.Value(spanKey{}).(string))
+}
+
+func TestObserver(t *testing.T) {
+	var headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		headers = append(headers, req.Header.Get("X-Request-Id"))
+		body, _ := io.ReadAll(req.Body)
+		switch {
+		case strings.Contains(string(body), "getSlot"):
+			_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","result":42,"id":1}`))
+		case strings.Contains(string(body), "getBalance"):
+			_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid param: Invalid"},"id":1}`))
+		default:
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	var events []string
+	first := &testObserver{name: "first", events: &events}
+	second := &testObserver{name: "second", events: &events}
+	c := New(WithEndpoint(server.URL), WithObserver(first), WithObserver(second), WithTraceIDHeader("X-Request-Id"))
+
+	ctx := context.WithValue(ContextWithTraceID(context.Background(), "trace-1"), spanKey{}, "getBalanceAndSlot")
+	slot, err := c.GetSlot(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), slot.Result)
+	_, err = c.GetBalance(ctx, "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+	assert.NoError(t, err)
+	_, err = c.GetVersion(context.Background())
+	assert.ErrorContains(t, err, "get status code: 503")
+
+	assert.Equal(t, []string{
+		"first start getSlot", "second start getSlot", "second end getSlot", "first end getSlot",
+		"first start getBalance", "second start getBalance", "second end getBalance", "first end getBalance",
+		"first start getVersion", "second start getVersion", "second end getVersion", "first end getVersion",
+	}, events)
+	assert.Equal(t, []string{"trace-1", "trace-1", ""}, headers)
+	assert.Equal(t, 0, first.inFlight)
+	assert.Equal(t, 1, first.maxFlow)
+	// the context of a call carries the span of the caller
+	assert.Equal(t, []string{"getBalanceAndSlot/getSlot", "getBalanceAndSlot/getBalance", "/getVersion"}, first.spans)
+	assert.Equal(t, []string{"getBalanceAndSlot/getSlot/getSlot", "getBalanceAndSlot/getBalance/getBalance", "/getVersion/getVersion"}, second.spans)
+
+	infos := first.infos
+	assert.Equal(t, "getSlot", infos[0].Method)
+	assert.Equal(t, server.URL, infos[0].Endpoint)
+	assert.Equal(t, "trace-1", infos[0].TraceID)
+	assert.Equal(t, http.StatusOK, infos[0].StatusCode)
+	assert.Equal(t, len(`{"jsonrpc":"2.0","id":1,"method":"getSlot"}`), infos[0].RequestSize)
+	assert.Equal(t, len(`{"jsonrpc":"2.0","result":42,"id":1}`), infos[0].ResponseSize)
+	assert.Greater(t, int64(infos[0].Duration), int64(0))
+	assert.Nil(t, infos[0].Err)
+	assert.Nil(t, infos[0].RpcError)
+
+	assert.Equal(t, &JsonRpcError{Code: -32602, Message: "Invalid param: Invalid"}, infos[1].RpcError)
+	assert.Nil(t, infos[1].Err)
+
+	assert.Equal(t, http.StatusServiceUnavailable, infos[2].StatusCode)
+	assert.Equal(t, "", infos[2].TraceID)
+	assert.EqualError(t, infos[2].Err, "get status code: 503")
+
+	// a failed request has no status code
+	server.Close()
+	_, err = c.GetSlot(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 0, first.infos[3].StatusCode)
+	assert.ErrorContains(t, first.infos[3].Err, "failed to do request")
+}
+
+func TestObserverFuncs(t *testing.T) {
+	o := ObserverFuncs{}
+	ctx := context.Background()
+	assert.Equal(t, ctx, o.CallStart(ctx, CallInfo{}))
+	o.CallEnd(ctx, CallInfo{})
+
+	var ended CallInfo
+	o = ObserverFuncs{End: func(ctx context.Context, info CallInfo) { ended = info }}
+	c := New(WithReplayer(&Replayer{responses: map[string][]Interaction{}}), WithObserver(o))
+	_, err := c.Call(ctx, "getSlot")
+	assert.ErrorIs(t, err, ErrUnexpectedRequest)
+	assert.Equal(t, "getSlot", ended.Method)
+	assert.ErrorIs(t, ended.Err, ErrUnexpectedRequest)
+}
@@ -22,6 +22,45 @@ func WithEndpoint(endpoint string) Option {
 	}
 }
 
+// WithRecorder is an Option that records every request and response of the client into the
+// fixture file of the recorder
+func WithRecorder(recorder *Recorder) Option {
+	return func(r *RpcClient) {
+		r.recorder = recorder
+	}
+}
+
+// WithReplayer is an Option that serves the recorded responses instead of sending the requests,
+// a request which has not been recorded returns ErrUnexpectedRequest
+func WithReplayer(replayer *Replayer) Option {
+	return func(r *RpcClient) {
+		r.replayer = replayer
+	}
+}
+
+// WithObserver is an Option that adds observers which are invoked around every call
+func WithObserver(observers ...Observer) Option {
+	return func(r *RpcClient) {
+		r.observers = append(r.observers, observers...)
+	}
+}
+
+// WithTraceIDHeader is an Option that sends the trace id of the context, see ContextWithTraceID,
+// in the header, e.g. "X-Request-Id"
+func WithTraceIDHeader(header string) Option {
+	return func(r *RpcClient) {
+		r.traceIDHeader = header
+	}
+}
+
+// WithCache is an Option that serves the responses of cacheable requests from the cache,
+// a cache may be shared by several clients
+func WithCache(cache *Cache) Option {
+	return func(r *RpcClient) {
+		r.cache = cache
+	}
+}
+
 func setDefaultOptions(r *RpcClient) {
 	r.httpClient = &http.Client{}
 	r.endpoint = MainnetRPCEndpoint
@@ -0,0 +1,220 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Recording is the content of a fixture file
+type Recording struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and what it got back. the request is normalized, it has no id.
+type Interaction struct {
+	Endpoint   string          `json:"endpoint"`
+	Request    json.RawMessage `json:"request"`
+	StatusCode int             `json:"statusCode,omitempty"`
+	Response   json.RawMessage `json:"response,omitempty"`
+	// Body is the response if it isn't json
+	Body string `json:"body,omitempty"`
+	// Error is the error of the http request
+	Error string `json:"error,omitempty"`
+}
+
+const redacted = "REDACTED"
+
+// Recorder writes the traffic of the client into a fixture file, the file is rewritten after every request
+type Recorder struct {
+	mu        sync.Mutex
+	path      string
+	secrets   []string
+	recording Recording
+}
+
+type RecorderOption func(*Recorder)
+
+// WithRedactedSecrets is a RecorderOption that replaces the secrets in the endpoint, e.g. an api key in the path.
+// the values of query parameters whose name contains "key" or "token" are always redacted.
+func WithRedactedSecrets(secrets ...string) RecorderOption {
+	return func(r *Recorder) {
+		r.secrets = append(r.secrets, secrets...)
+	}
+}
+
+func NewRecorder(path string, opts ...RecorderOption) *Recorder {
+	r := &Recorder{path: path}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Recording returns what has been recorded so far
+func (r *Recorder) Recording() Recording {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Recording{Interactions: append([]Interaction{}, r.recording.Interactions...)}
+}
+
+func (r *Recorder) record(endpoint string, request []byte, statusCode int, body []byte, err error) error {
+	normalized, nerr := normalizeRequest(request)
+	if nerr != nil {
+		return nerr
+	}
+	interaction := Interaction{
+		Endpoint:   r.redact(endpoint),
+		Request:    normalized,
+		StatusCode: statusCode,
+	}
+	switch {
+	case err != nil:
+		interaction.Error = r.redactText(endpoint, err.Error())
+	case json.Valid(body):
+		interaction.Response = compact(body)
+	default:
+		interaction.Body = r.redactText(endpoint, string(body))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recording.Interactions = append(r.recording.Interactions, interaction)
+	b, err := json.MarshalIndent(r.recording, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, b, 0o644)
+}
+
+func (r *Recorder) redact(endpoint string) string {
+	for _, secret := range r.secrets {
+		if secret != "" {
+			endpoint = strings.ReplaceAll(endpoint, secret, redacted)
+		}
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.RawQuery == "" {
+		return endpoint
+	}
+	query := u.Query()
+	for name := range query {
+		lower := strings.ToLower(name)
+		if strings.Contains(lower, "key") || strings.Contains(lower, "token") {
+			query.Set(name, redacted)
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// redactText redacts a text which may quote the endpoint, e.g. the error of a failed request is a *url.Error
+func (r *Recorder) redactText(endpoint, text string) string {
+	if endpoint != "" {
+		text = strings.ReplaceAll(text, endpoint, r.redact(endpoint))
+	}
+	for _, secret := range r.secrets {
+		if secret != "" {
+			text = strings.ReplaceAll(text, secret, redacted)
+		}
+	}
+	return text
+}
+
+// Replayer serves the responses of a fixture file. identical requests get the recorded responses in order,
+// e.g. polling getSignatureStatuses sees the status change as it did while recording.
+type Replayer struct {
+	mu        sync.Mutex
+	responses map[string][]Interaction
+}
+
+func NewReplayer(recording Recording) (*Replayer, error) {
+	r := &Replayer{responses: map[string][]Interaction{}}
+	for i, interaction := range recording.Interactions {
+		key, err := normalizeRequest(interaction.Request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to normalize request of interaction %d, err: %v", i, err)
+		}
+		r.responses[string(key)] = append(r.responses[string(key)], interaction)
+	}
+	return r, nil
+}
+
+// LoadReplayer reads a fixture file written by a Recorder
+func LoadReplayer(path string) (*Replayer, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var recording Recording
+	if err := json.Unmarshal(b, &recording); err != nil {
+		return nil, fmt.Errorf("failed to decode recording, err: %v", err)
+	}
+	return NewReplayer(recording)
+}
+
+// Remaining returns the recorded interactions which haven't been replayed
+func (r *Replayer) Remaining() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var remaining []Interaction
+	for _, interactions := range r.responses {
+		remaining = append(remaining, interactions...)
+	}
+	return remaining
+}
+
+func (r *Replayer) replay(request []byte) (int, []byte, error) {
+	key, err := normalizeRequest(request)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	r.mu.Lock()
+	interactions := r.responses[string(key)]
+	if len(interactions) == 0 {
+		r.mu.Unlock()
+		return 0, nil, fmt.Errorf("%w, request: %s", ErrUnexpectedRequest, key)
+	}
+	interaction := interactions[0]
+	if len(interactions) == 1 {
+		delete(r.responses, string(key))
+	} else {
+		r.responses[string(key)] = interactions[1:]
+	}
+	r.mu.Unlock()
+
+	if interaction.Error != "" {
+		return interaction.StatusCode, nil, errors.New(interaction.Error)
+	}
+	body := []byte(interaction.Body)
+	if len(interaction.Response) > 0 {
+		body = interaction.Response
+	}
+	return interaction.StatusCode, body, checkStatusCode(interaction.StatusCode)
+}
+
+// normalizeRequest drops the id and sorts the keys so the same call always looks the same
+func normalizeRequest(request []byte) ([]byte, error) {
+	d := json.NewDecoder(bytes.NewReader(request))
+	d.UseNumber()
+	var m map[string]any
+	if err := d.Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to decode request, err: %v", err)
+	}
+	delete(m, "id")
+	return json.Marshal(m)
+}
+
+func compact(body []byte) []byte {
+	var b bytes.Buffer
+	if err := json.Compact(&b, body); err != nil {
+		return body
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,150 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorderReplayer(t *testing.T) {
+	// the status of the signature changes while polling
+	statuses := []string{
+		`{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":[null]},"id":1}`,
+		`{"jsonrpc":"2.0","result":{"context":{"slot":2},"value":[{"slot":2,"confirmations":0,"err":null,"confirmationStatus":"confirmed"}]},"id":1}`,
+	}
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		switch {
+		case strings.Contains(string(body), "getSignatureStatuses"):
+			_, _ = rw.Write([]byte(statuses[polls]))
+			polls++
+		case strings.Contains(string(body), "getSlot"):
+			rw.WriteHeader(http.StatusTooManyRequests)
+			_, _ = rw.Write([]byte("slow down"))
+		default:
+			_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":42},"id":1}`))
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	endpoint := server.URL + "/secret-token/?api-key=123&commitment=confirmed"
+	calls := func(c RpcClient) []string {
+		var results []string
+		for i := 0; i < 2; i++ {
+			res, err := c.GetSignatureStatuses(context.Background(), []string{"sig"})
+			b, _ := json.Marshal(res)
+			results = append(results, fmt.Sprintf("%s %v", b, err))
+		}
+		res, err := c.GetBalance(context.Background(), "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+		b, _ := json.Marshal(res)
+		results = append(results, fmt.Sprintf("%s %v", b, err))
+		_, err = c.GetSlot(context.Background())
+		results = append(results, fmt.Sprintf("%v", err))
+		return results
+	}
+
+	recorder := NewRecorder(path, WithRedactedSecrets("secret-token"))
+	recorded := calls(New(WithEndpoint(endpoint), WithRecorder(recorder)))
+	assert.Contains(t, recorded[3], "get status code: 429")
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	want, err := json.MarshalIndent(recorder.Recording(), "", "  ")
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(b))
+	var recording Recording
+	require.NoError(t, json.Unmarshal(b, &recording))
+	require.Len(t, recording.Interactions, 4)
+	assert.Equal(t, server.URL+"/REDACTED/?api-key=REDACTED&commitment=confirmed", recording.Interactions[0].Endpoint)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","method":"getSignatureStatuses","params":[["sig"]]}`, string(recording.Interactions[0].Request))
+	assert.Equal(t, "slow down", recording.Interactions[3].Body)
+	assert.NotContains(t, string(b), "secret-token")
+	assert.NotContains(t, string(b), "123")
+
+	// the replay needs no server
+	server.Close()
+	replayer, err := LoadReplayer(path)
+	require.NoError(t, err)
+	assert.Equal(t, recorded, calls(New(WithEndpoint(endpoint), WithReplayer(replayer))))
+	assert.Empty(t, replayer.Remaining())
+
+	c := New(WithReplayer(replayer))
+	_, err = c.Call(context.Background(), "getSignatureStatuses", []string{"sig"})
+	assert.ErrorIs(t, err, ErrUnexpectedRequest)
+	_, err = c.Call(context.Background(), "getBalance", "9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g")
+	assert.ErrorIs(t, err, ErrUnexpectedRequest)
+}
+
+func TestRecorder_redact(t *testing.T) {
+	tests := []struct {
+		name     string
+		secrets  []string
+		endpoint string
+		want     string
+	}{
+		{
+			endpoint: MainnetRPCEndpoint,
+			want:     MainnetRPCEndpoint,
+		},
+		{
+			endpoint: "https://mainnet.helius-rpc.com/?api-key=abc",
+			want:     "https://mainnet.helius-rpc.com/?api-key=REDACTED",
+		},
+		{
+			endpoint: "https://rpc.example.com/?apiKey=abc&token=def&cluster=devnet",
+			want:     "https://rpc.example.com/?apiKey=REDACTED&cluster=devnet&token=REDACTED",
+		},
+		{
+			secrets:  []string{"abcdef"},
+			endpoint: "https://solana-mainnet.g.alchemy.com/v2/abcdef",
+			want:     "https://solana-mainnet.g.alchemy.com/v2/REDACTED",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRecorder("", WithRedactedSecrets(tt.secrets...))
+			assert.Equal(t, tt.want, r.redact(tt.endpoint))
+		})
+	}
+}
+
+func TestRecorder_redactError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	endpoint := "http://127.0.0.1:1/SECRET123?api-key=SECRET123"
+	recorder := NewRecorder(path, WithRedactedSecrets("SECRET123"))
+	c := New(WithEndpoint(endpoint), WithRecorder(recorder))
+	_, err := c.GetSlot(context.Background())
+	require.Error(t, err)
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "SECRET123")
+	interactions := recorder.Recording().Interactions
+	require.Len(t, interactions, 1)
+	assert.Contains(t, interactions[0].Error, "http://127.0.0.1:1/REDACTED?api-key=REDACTED")
+
+	// a proxy may echo the url in a non json body
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadGateway)
+		_, _ = rw.Write([]byte("bad gateway: " + req.URL.String()))
+	}))
+	defer server.Close()
+	c = New(WithEndpoint(server.URL+"/SECRET123?api-key=SECRET123"), WithRecorder(recorder))
+	_, err = c.GetSlot(context.Background())
+	require.Error(t, err)
+	interactions = recorder.Recording().Interactions
+	require.Len(t, interactions, 2)
+	assert.Equal(t, "bad gateway: /REDACTED?api-key=REDACTED", interactions[1].Body)
+}
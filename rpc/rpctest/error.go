@@ -0,0 +1,78 @@
+package rpctest
+
+import (
+	"fmt"
+
+	"github.com/EntySquare/solana-go-sdk/rpc"
+)
+
+// json rpc error codes of the solana rpc
+const (
+	ErrCodeParse                         = -32700
+	ErrCodeMethodNotFound                = -32601
+	ErrCodeInvalidParams                 = -32602
+	ErrCodeSendTransactionPreflight      = -32002
+	ErrCodeSignatureVerificationFailure  = -32003
+	ErrCodeBlockNotAvailable             = -32004
+	ErrCodeSlotSkipped                   = -32007
+	ErrCodeUnsupportedTransactionVersion = -32015
+)
+
+// transaction errors as the rpc shows them in "err"
+const (
+	TransactionErrBlockhashNotFound       = "BlockhashNotFound"
+	TransactionErrAlreadyProcessed        = "AlreadyProcessed"
+	TransactionErrAccountNotFound         = "AccountNotFound"
+	TransactionErrInsufficientFundsForFee = "InsufficientFundsForFee"
+)
+
+var transactionErrMessages = map[string]string{
+	TransactionErrBlockhashNotFound:       "Blockhash not found",
+	TransactionErrAlreadyProcessed:        "This transaction has already been processed",
+	TransactionErrAccountNotFound:         "Attempt to debit an account but found no record of a prior credit.",
+	TransactionErrInsufficientFundsForFee: "Insufficient funds for fee",
+}
+
+func errParse() *rpc.JsonRpcError {
+	return &rpc.JsonRpcError{Code: ErrCodeParse, Message: "Parse error"}
+}
+
+func errMethodNotFound(method string) *rpc.JsonRpcError {
+	return &rpc.JsonRpcError{Code: ErrCodeMethodNotFound, Message: "Method not found", Data: method}
+}
+
+func errInvalidParams(format string, a ...any) *rpc.JsonRpcError {
+	return &rpc.JsonRpcError{Code: ErrCodeInvalidParams, Message: fmt.Sprintf(format, a...)}
+}
+
+func errSignatureVerification() *rpc.JsonRpcError {
+	return &rpc.JsonRpcError{Code: ErrCodeSignatureVerificationFailure, Message: "Transaction signature verification failure"}
+}
+
+// errPreflight is the error of sendTransaction when the simulation before sending fails
+func errPreflight(value simulateTransactionValue) *rpc.JsonRpcError {
+	message := fmt.Sprintf("%v", value.Err)
+	if s, ok := value.Err.(string); ok && transactionErrMessages[s] != "" {
+		message = transactionErrMessages[s]
+	}
+	return &rpc.JsonRpcError{
+		Code:    ErrCodeSendTransactionPreflight,
+		Message: "Transaction simulation failed: " + message,
+		Data:    value,
+	}
+}
+
+func errBlockNotAvailable(slot uint64) *rpc.JsonRpcError {
+	return &rpc.JsonRpcError{Code: ErrCodeBlockNotAvailable, Message: fmt.Sprintf("Block not available for slot %v", slot)}
+}
+
+func errSlotSkipped(slot uint64) *rpc.JsonRpcError {
+	return &rpc.JsonRpcError{Code: ErrCodeSlotSkipped, Message: fmt.Sprintf("Slot %v was skipped, or missing due to ledger jump to recent snapshot", slot)}
+}
+
+func errUnsupportedTransactionVersion() *rpc.JsonRpcError {
+	return &rpc.JsonRpcError{
+		Code:    ErrCodeUnsupportedTransactionVersion,
+		Message: "Transaction version (0) is not supported by the requesting client. Please try the request again with the following configuration parameter: \"maxSupportedTransactionVersion\": 0",
+	}
+}
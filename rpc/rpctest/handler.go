@@ -0,0 +1,1152 @@
+package rpctest
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/system"
+	"github.com/EntySquare/solana-go-sdk/program/token"
+	"github.com/EntySquare/solana-go-sdk/rpc"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/mr-tron/base58"
+)
+
+const (
+	version    = "1.18.0"
+	featureSet = 3469865029
+)
+
+type handler func(s *Server, params []json.RawMessage) (any, *rpc.JsonRpcError)
+
+var handlers map[string]handler
+
+func init() {
+	handlers = map[string]handler{
+		"getAccountInfo":                    (*Server).getAccountInfo,
+		"getBalance":                        (*Server).getBalance,
+		"getBlock":                          (*Server).getBlock,
+		"getBlockCommitment":                (*Server).getBlockCommitment,
+		"getBlockHeight":                    (*Server).getBlockHeight,
+		"getBlockProduction":                (*Server).getBlockProduction,
+		"getBlockTime":                      (*Server).getBlockTime,
+		"getBlocks":                         (*Server).getBlocks,
+		"getBlocksWithLimit":                (*Server).getBlocksWithLimit,
+		"getClusterNodes":                   (*Server).getClusterNodes,
+		"getEpochInfo":                      (*Server).getEpochInfo,
+		"getEpochSchedule":                  (*Server).getEpochSchedule,
+		"getFeeForMessage":                  (*Server).getFeeForMessage,
+		"getFirstAvailableBlock":            (*Server).getFirstAvailableBlock,
+		"getGenesisHash":                    (*Server).getGenesisHash,
+		"getIdentity":                       (*Server).getIdentity,
+		"getInflationGovernor":              (*Server).getInflationGovernor,
+		"getInflationRate":                  (*Server).getInflationRate,
+		"getInflationReward":                (*Server).getInflationReward,
+		"getLatestBlockhash":                (*Server).getLatestBlockhash,
+		"getMinimumBalanceForRentExemption": (*Server).getMinimumBalanceForRentExemption,
+		"getMultipleAccounts":               (*Server).getMultipleAccounts,
+		"getProgramAccounts":                (*Server).getProgramAccounts,
+		"getSignatureStatuses":              (*Server).getSignatureStatuses,
+		"getSignaturesForAddress":           (*Server).getSignaturesForAddress,
+		"getSlot":                           (*Server).getSlot,
+		"getStakeMinimumDelegation":         (*Server).getStakeMinimumDelegation,
+		"getTokenAccountBalance":            (*Server).getTokenAccountBalance,
+		"getTokenAccountsByOwner":           (*Server).getTokenAccountsByOwner,
+		"getTokenSupply":                    (*Server).getTokenSupply,
+		"getTransaction":                    (*Server).getTransaction,
+		"getTransactionCount":               (*Server).getTransactionCount,
+		"getVersion":                        (*Server).getVersion,
+		"getVoteAccounts":                   (*Server).getVoteAccounts,
+		"isBlockhashValid":                  (*Server).isBlockhashValidHandler,
+		"minimumLedgerSlot":                 (*Server).getFirstAvailableBlock,
+		"requestAirdrop":                    (*Server).requestAirdrop,
+		"sendTransaction":                   (*Server).sendTransaction,
+		"simulateTransaction":               (*Server).simulateTransaction,
+	}
+}
+
+// param decodes the i-th param, a missing optional param leaves v as it is
+func param(params []json.RawMessage, i int, v any, required bool) *rpc.JsonRpcError {
+	if i >= len(params) || string(params[i]) == "null" {
+		if required {
+			return errInvalidParams("missing param #%d", i)
+		}
+		return nil
+	}
+	if err := json.Unmarshal(params[i], v); err != nil {
+		return errInvalidParams("invalid param #%d: %v", i, err)
+	}
+	return nil
+}
+
+func parsePubkey(s string) (common.PublicKey, *rpc.JsonRpcError) {
+	b, err := base58.Decode(s)
+	if err != nil || len(b) != common.PublicKeyLength {
+		return common.PublicKey{}, errInvalidParams("Invalid param: WrongSize")
+	}
+	return common.PublicKeyFromBytes(b), nil
+}
+
+func withContext(ctx rpc.Context, v any) rpc.ValueWithContext[any] {
+	return rpc.ValueWithContext[any]{Context: ctx, Value: v}
+}
+
+/* accounts */
+
+// accountInfo is rpc.AccountInfo with the space field
+type accountInfo struct {
+	Lamports   uint64 `json:"lamports"`
+	Owner      string `json:"owner"`
+	Data       any    `json:"data"`
+	Executable bool   `json:"executable"`
+	RentEpoch  uint64 `json:"rentEpoch"`
+	Space      uint64 `json:"space"`
+}
+
+type accountConfig struct {
+	Encoding  rpc.AccountEncoding `json:"encoding"`
+	DataSlice *rpc.DataSlice      `json:"dataSlice"`
+}
+
+func encodeAccount(account Account, cfg accountConfig) (accountInfo, *rpc.JsonRpcError) {
+	data := account.Data
+	if cfg.DataSlice != nil {
+		start := cfg.DataSlice.Offset
+		if start > uint64(len(data)) {
+			start = uint64(len(data))
+		}
+		end := start + cfg.DataSlice.Length
+		if end > uint64(len(data)) {
+			end = uint64(len(data))
+		}
+		data = data[start:end]
+	}
+
+	var encoded any
+	switch cfg.Encoding {
+	case "":
+		// the legacy binary encoding
+		encoded = base58.Encode(data)
+	case rpc.AccountEncodingBase58:
+		encoded = []string{base58.Encode(data), string(rpc.AccountEncodingBase58)}
+	case rpc.AccountEncodingBase64, rpc.AccountEncodingJsonParsed:
+		// jsonParsed falls back to base64 for accounts without a parser
+		encoded = []string{base64.StdEncoding.EncodeToString(data), string(rpc.AccountEncodingBase64)}
+	default:
+		return accountInfo{}, errInvalidParams("unsupported encoding: %v", cfg.Encoding)
+	}
+
+	return accountInfo{
+		Lamports:   account.Lamports,
+		Owner:      account.Owner.ToBase58(),
+		Data:       encoded,
+		Executable: account.Executable,
+		RentEpoch:  account.RentEpoch,
+		Space:      uint64(len(account.Data)),
+	}, nil
+}
+
+func (s *Server) getAccountInfo(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var address string
+	var cfg accountConfig
+	if err := param(params, 0, &address, true); err != nil {
+		return nil, err
+	}
+	if err := param(params, 1, &cfg, false); err != nil {
+		return nil, err
+	}
+	pubkey, err := parsePubkey(address)
+	if err != nil {
+		return nil, err
+	}
+
+	account, ok := s.accounts[pubkey]
+	if !ok {
+		return withContext(s.context(), nil), nil
+	}
+	info, err := encodeAccount(account, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return withContext(s.context(), info), nil
+}
+
+func (s *Server) getMultipleAccounts(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var addresses []string
+	var cfg accountConfig
+	if err := param(params, 0, &addresses, true); err != nil {
+		return nil, err
+	}
+	if err := param(params, 1, &cfg, false); err != nil {
+		return nil, err
+	}
+
+	infos := make([]*accountInfo, 0, len(addresses))
+	for _, address := range addresses {
+		pubkey, err := parsePubkey(address)
+		if err != nil {
+			return nil, err
+		}
+		account, ok := s.accounts[pubkey]
+		if !ok {
+			infos = append(infos, nil)
+			continue
+		}
+		info, err := encodeAccount(account, cfg)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, &info)
+	}
+	return withContext(s.context(), infos), nil
+}
+
+func (s *Server) getBalance(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var address string
+	if err := param(params, 0, &address, true); err != nil {
+		return nil, err
+	}
+	pubkey, err := parsePubkey(address)
+	if err != nil {
+		return nil, err
+	}
+	return withContext(s.context(), s.accounts[pubkey].Lamports), nil
+}
+
+type programAccount struct {
+	Pubkey  string      `json:"pubkey"`
+	Account accountInfo `json:"account"`
+}
+
+func (s *Server) programAccounts(pubkeys []common.PublicKey, cfg accountConfig) ([]programAccount, *rpc.JsonRpcError) {
+	accounts := make([]programAccount, 0, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		info, err := encodeAccount(s.accounts[pubkey], cfg)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, programAccount{Pubkey: pubkey.ToBase58(), Account: info})
+	}
+	return accounts, nil
+}
+
+func (s *Server) getProgramAccounts(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var address string
+	var cfg struct {
+		accountConfig
+		Filters     []rpc.GetProgramAccountsConfigFilter `json:"filters"`
+		WithContext bool                                 `json:"withContext"`
+	}
+	if err := param(params, 0, &address, true); err != nil {
+		return nil, err
+	}
+	if err := param(params, 1, &cfg, false); err != nil {
+		return nil, err
+	}
+	programID, err := parsePubkey(address)
+	if err != nil {
+		return nil, err
+	}
+
+	var filterErr *rpc.JsonRpcError
+	pubkeys := s.accountsOf(func(_ common.PublicKey, account Account) bool {
+		if account.Owner != programID {
+			return false
+		}
+		for _, filter := range cfg.Filters {
+			if filter.DataSize != 0 && uint64(len(account.Data)) != filter.DataSize {
+				return false
+			}
+			if filter.MemCmp != nil {
+				b, err := base58.Decode(filter.MemCmp.Bytes)
+				if err != nil {
+					filterErr = errInvalidParams("invalid memcmp bytes: %v", err)
+					return false
+				}
+				offset := filter.MemCmp.Offset
+				if offset+uint64(len(b)) > uint64(len(account.Data)) || !bytes.Equal(account.Data[offset:offset+uint64(len(b))], b) {
+					return false
+				}
+			}
+		}
+		return true
+	})
+	if filterErr != nil {
+		return nil, filterErr
+	}
+
+	accounts, err := s.programAccounts(pubkeys, cfg.accountConfig)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.WithContext {
+		return withContext(s.context(), accounts), nil
+	}
+	return accounts, nil
+}
+
+/* tokens */
+
+type tokenAmount struct {
+	Amount         string   `json:"amount"`
+	Decimals       uint8    `json:"decimals"`
+	UIAmount       *float64 `json:"uiAmount"`
+	UIAmountString string   `json:"uiAmountString"`
+}
+
+func newTokenAmount(amount uint64, decimals uint8) tokenAmount {
+	s := uiAmountString(amount, decimals)
+	f, _ := strconv.ParseFloat(s, 64)
+	return tokenAmount{
+		Amount:         strconv.FormatUint(amount, 10),
+		Decimals:       decimals,
+		UIAmount:       &f,
+		UIAmountString: s,
+	}
+}
+
+// uiAmountString formats the amount with the decimals, 1500 with 2 decimals is "15"
+func uiAmountString(amount uint64, decimals uint8) string {
+	s := strconv.FormatUint(amount, 10)
+	if decimals == 0 {
+		return s
+	}
+	if len(s) <= int(decimals) {
+		s = strings.Repeat("0", int(decimals)-len(s)+1) + s
+	}
+	integer, fraction := s[:len(s)-int(decimals)], strings.TrimRight(s[len(s)-int(decimals):], "0")
+	if fraction == "" {
+		return integer
+	}
+	return integer + "." + fraction
+}
+
+func (s *Server) tokenAccount(pubkey common.PublicKey) (token.TokenAccount, *rpc.JsonRpcError) {
+	account, ok := s.accounts[pubkey]
+	if !ok || account.Owner != common.TokenProgramID {
+		return token.TokenAccount{}, errInvalidParams("Invalid param: could not find account")
+	}
+	tokenAccount, err := token.TokenAccountFromData(account.Data)
+	if err != nil {
+		return token.TokenAccount{}, errInvalidParams("Invalid param: not a Token account")
+	}
+	return tokenAccount, nil
+}
+
+func (s *Server) mint(pubkey common.PublicKey) (token.MintAccount, *rpc.JsonRpcError) {
+	account, ok := s.accounts[pubkey]
+	if !ok || account.Owner != common.TokenProgramID {
+		return token.MintAccount{}, errInvalidParams("Invalid param: could not find mint")
+	}
+	mint, err := token.MintAccountFromData(account.Data)
+	if err != nil {
+		return token.MintAccount{}, errInvalidParams("Invalid param: not a Token mint")
+	}
+	return mint, nil
+}
+
+func (s *Server) getTokenAccountBalance(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var address string
+	if err := param(params, 0, &address, true); err != nil {
+		return nil, err
+	}
+	pubkey, err := parsePubkey(address)
+	if err != nil {
+		return nil, err
+	}
+	tokenAccount, err := s.tokenAccount(pubkey)
+	if err != nil {
+		return nil, err
+	}
+	mint, err := s.mint(tokenAccount.Mint)
+	if err != nil {
+		return nil, err
+	}
+	return withContext(s.context(), newTokenAmount(tokenAccount.Amount, mint.Decimals)), nil
+}
+
+func (s *Server) getTokenSupply(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var address string
+	if err := param(params, 0, &address, true); err != nil {
+		return nil, err
+	}
+	pubkey, err := parsePubkey(address)
+	if err != nil {
+		return nil, err
+	}
+	mint, err := s.mint(pubkey)
+	if err != nil {
+		return nil, err
+	}
+	return withContext(s.context(), newTokenAmount(mint.Supply, mint.Decimals)), nil
+}
+
+func (s *Server) getTokenAccountsByOwner(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var address string
+	var filter rpc.GetTokenAccountsByOwnerConfigFilter
+	var cfg accountConfig
+	if err := param(params, 0, &address, true); err != nil {
+		return nil, err
+	}
+	if err := param(params, 1, &filter, true); err != nil {
+		return nil, err
+	}
+	if err := param(params, 2, &cfg, false); err != nil {
+		return nil, err
+	}
+	owner, err := parsePubkey(address)
+	if err != nil {
+		return nil, err
+	}
+
+	var mint *common.PublicKey
+	switch {
+	case filter.Mint != "":
+		pubkey, err := parsePubkey(filter.Mint)
+		if err != nil {
+			return nil, err
+		}
+		mint = &pubkey
+	case filter.ProgramId != "":
+		programID, err := parsePubkey(filter.ProgramId)
+		if err != nil {
+			return nil, err
+		}
+		if programID != common.TokenProgramID {
+			return nil, errInvalidParams("Invalid param: unrecognized Token program id")
+		}
+	default:
+		return nil, errInvalidParams("Invalid param: either mint or programId is required")
+	}
+
+	pubkeys := s.accountsOf(func(_ common.PublicKey, account Account) bool {
+		if account.Owner != common.TokenProgramID {
+			return false
+		}
+		tokenAccount, err := token.TokenAccountFromData(account.Data)
+		if err != nil {
+			return false
+		}
+		return tokenAccount.Owner == owner && (mint == nil || tokenAccount.Mint == *mint)
+	})
+	accounts, rpcErr := s.programAccounts(pubkeys, cfg)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return withContext(s.context(), accounts), nil
+}
+
+/* blocks */
+
+func (s *Server) getSlot(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	return s.slot, nil
+}
+
+func (s *Server) getBlockHeight(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	return s.slot, nil
+}
+
+func (s *Server) getTransactionCount(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	return len(s.transactions), nil
+}
+
+func (s *Server) getFirstAvailableBlock(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	return s.blockSlots()[0], nil
+}
+
+func (s *Server) blockSlots() []uint64 {
+	slots := make([]uint64, 0, len(s.blocks))
+	for slot := range s.blocks {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+	return slots
+}
+
+func (s *Server) getLatestBlockhash(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	return withContext(s.context(), rpc.GetLatestBlockhashValue{
+		Blockhash:              s.blocks[s.slot].blockhash,
+		LatestValidBlockHeight: s.slot + MaxProcessingAge,
+	}), nil
+}
+
+func (s *Server) isBlockhashValidHandler(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var blockhash string
+	if err := param(params, 0, &blockhash, true); err != nil {
+		return nil, err
+	}
+	return withContext(s.context(), s.isBlockhashValid(blockhash)), nil
+}
+
+func (s *Server) getBlockTime(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var slot uint64
+	if err := param(params, 0, &slot, true); err != nil {
+		return nil, err
+	}
+	b, ok := s.blocks[slot]
+	if !ok {
+		return nil, errBlockNotAvailable(slot)
+	}
+	return b.time, nil
+}
+
+func (s *Server) getBlocks(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var start uint64
+	end := s.slot
+	if err := param(params, 0, &start, true); err != nil {
+		return nil, err
+	}
+	if err := param(params, 1, &end, false); err != nil {
+		return nil, err
+	}
+	slots := []uint64{}
+	for _, slot := range s.blockSlots() {
+		if slot >= start && slot <= end {
+			slots = append(slots, slot)
+		}
+	}
+	return slots, nil
+}
+
+func (s *Server) getBlocksWithLimit(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var start, limit uint64
+	if err := param(params, 0, &start, true); err != nil {
+		return nil, err
+	}
+	if err := param(params, 1, &limit, true); err != nil {
+		return nil, err
+	}
+	slots := []uint64{}
+	for _, slot := range s.blockSlots() {
+		if slot >= start && uint64(len(slots)) < limit {
+			slots = append(slots, slot)
+		}
+	}
+	return slots, nil
+}
+
+func (s *Server) getBlock(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var slot uint64
+	var cfg rpc.GetBlockConfig
+	if err := param(params, 0, &slot, true); err != nil {
+		return nil, err
+	}
+	if err := param(params, 1, &cfg, false); err != nil {
+		return nil, err
+	}
+	b, ok := s.blocks[slot]
+	if !ok {
+		return nil, errSlotSkipped(slot)
+	}
+
+	previousBlockhash := "11111111111111111111111111111111"
+	if parent, ok := s.blocks[slot-1]; ok {
+		previousBlockhash = parent.blockhash
+	}
+	blockHeight := int64(slot)
+	result := map[string]any{
+		"blockhash":         b.blockhash,
+		"blockTime":         b.time,
+		"blockHeight":       blockHeight,
+		"previousBlockhash": previousBlockhash,
+		"parentSlot":        slot - 1,
+	}
+	if cfg.Rewards == nil || *cfg.Rewards {
+		result["rewards"] = []any{}
+	}
+
+	var records []*TransactionRecord
+	for _, record := range s.transactions {
+		if record.Slot == slot {
+			records = append(records, record)
+		}
+	}
+	switch cfg.TransactionDetails {
+	case rpc.GetBlockConfigTransactionDetailsNone:
+	case rpc.GetBlockConfigTransactionDetailsSignatures:
+		signatures := []string{}
+		for _, record := range records {
+			signatures = append(signatures, record.Signature)
+		}
+		result["signatures"] = signatures
+	default:
+		transactions := []any{}
+		for _, record := range records {
+			if record.Transaction.Message.Version == types.MessageVersionV0 && cfg.MaxSupportedTransactionVersion == nil {
+				return nil, errUnsupportedTransactionVersion()
+			}
+			tx, err := encodeTransaction(record.Transaction, rpc.TransactionEncoding(cfg.Encoding))
+			if err != nil {
+				return nil, err
+			}
+			transactions = append(transactions, map[string]any{
+				"transaction": tx,
+				"meta":        transactionMeta(record),
+				"version":     transactionVersion(record.Transaction),
+			})
+		}
+		result["transactions"] = transactions
+	}
+	return result, nil
+}
+
+func (s *Server) getBlockCommitment(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	return rpc.GetBlockCommitment{TotalStake: 0}, nil
+}
+
+func (s *Server) getBlockProduction(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	slots := s.blockSlots()
+	n := uint64(len(slots))
+	return withContext(s.context(), rpc.GetBlockProductionResponseResultValue{
+		ByIdentity: map[string][]uint64{s.identity.ToBase58(): {n, n}},
+		Range:      rpc.GetBlockProductionRange{FirstSlot: slots[0], LastSlot: s.slot},
+	}), nil
+}
+
+/* cluster */
+
+func (s *Server) getGenesisHash(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	return base58.Encode(seed("genesis")), nil
+}
+
+func (s *Server) getIdentity(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	return rpc.GetIdentity{Identity: s.identity.ToBase58()}, nil
+}
+
+func (s *Server) getVersion(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	fs := uint32(featureSet)
+	return rpc.GetVersion{SolanaCore: version, FeatureSet: &fs}, nil
+}
+
+func (s *Server) getClusterNodes(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	rpcAddr := strings.TrimPrefix(s.URL, "http://")
+	return []map[string]any{
+		{
+			"pubkey":       s.identity.ToBase58(),
+			"gossip":       nil,
+			"tpu":          nil,
+			"rpc":          rpcAddr,
+			"version":      version,
+			"featureSet":   featureSet,
+			"shredVersion": 0,
+		},
+	}, nil
+}
+
+func (s *Server) getVoteAccounts(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	return rpc.GetVoteAccounts{Current: rpc.VoteAccounts{}, Deliquent: rpc.VoteAccounts{}}, nil
+}
+
+func (s *Server) getEpochInfo(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	count := uint64(len(s.transactions))
+	return rpc.GetEpochInfo{
+		AbsoluteSlot:     s.slot,
+		BlockHeight:      s.slot,
+		Epoch:            s.slot / SlotsPerEpoch,
+		SlotIndex:        s.slot % SlotsPerEpoch,
+		SlotsInEpoch:     SlotsPerEpoch,
+		TransactionCount: &count,
+	}, nil
+}
+
+func (s *Server) getEpochSchedule(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	return rpc.GetEpochSchedule{
+		LeaderScheduleSlotOffset: SlotsPerEpoch,
+		SlotsPerEpoch:            SlotsPerEpoch,
+	}, nil
+}
+
+func (s *Server) getInflationGovernor(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	return rpc.GetInflationGovernor{
+		Foundation:     0.05,
+		FoundationTerm: 7,
+		Initial:        0.08,
+		Taper:          0.15,
+		Terminal:       0.015,
+	}, nil
+}
+
+func (s *Server) getInflationRate(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	return rpc.GetInflationRate{
+		Epoch:     s.slot / SlotsPerEpoch,
+		Total:     0.05,
+		Validator: 0.05,
+	}, nil
+}
+
+func (s *Server) getInflationReward(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var addresses []string
+	if err := param(params, 0, &addresses, true); err != nil {
+		return nil, err
+	}
+	// there are no stakes in the ledger
+	return make([]*rpc.GetInflationReward, len(addresses)), nil
+}
+
+func (s *Server) getStakeMinimumDelegation(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	return withContext(s.context(), uint64(1_000_000_000)), nil
+}
+
+// MinimumBalanceForRentExemption is the rent exempt balance of an account with the data size
+func MinimumBalanceForRentExemption(size uint64) uint64 {
+	// (account storage overhead + size) * lamports per byte year * exemption threshold years
+	return (128 + size) * 3480 * 2
+}
+
+func (s *Server) getMinimumBalanceForRentExemption(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var size uint64
+	if err := param(params, 0, &size, true); err != nil {
+		return nil, err
+	}
+	return MinimumBalanceForRentExemption(size), nil
+}
+
+func (s *Server) getFeeForMessage(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var raw string
+	if err := param(params, 0, &raw, true); err != nil {
+		return nil, err
+	}
+	b, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, errInvalidParams("invalid base64: %v", err)
+	}
+	message, err := types.MessageDeserialize(b)
+	if err != nil {
+		return nil, errInvalidParams("invalid message: %v", err)
+	}
+	if !s.isBlockhashValid(message.RecentBlockHash) {
+		return withContext(s.context(), nil), nil
+	}
+	return withContext(s.context(), uint64(message.Header.NumRequireSignatures)*LamportsPerSignature), nil
+}
+
+/* transactions */
+
+func decodeTransaction(raw string, encoding string) (types.Transaction, *rpc.JsonRpcError) {
+	var b []byte
+	var err error
+	switch encoding {
+	case "", "base58":
+		b, err = base58.Decode(raw)
+	case "base64":
+		b, err = base64.StdEncoding.DecodeString(raw)
+	default:
+		return types.Transaction{}, errInvalidParams("unsupported encoding: %v", encoding)
+	}
+	if err != nil {
+		return types.Transaction{}, errInvalidParams("failed to decode transaction: %v", err)
+	}
+	tx, err := types.TransactionDeserialize(b)
+	if err != nil {
+		return types.Transaction{}, errInvalidParams("failed to deserialize transaction: %v", err)
+	}
+	return tx, nil
+}
+
+func verifySignatures(tx types.Transaction) bool {
+	if len(tx.Signatures) != int(tx.Message.Header.NumRequireSignatures) || len(tx.Message.Accounts) < len(tx.Signatures) {
+		return false
+	}
+	message, err := tx.Message.Serialize()
+	if err != nil {
+		return false
+	}
+	for i, signature := range tx.Signatures {
+		if !ed25519.Verify(tx.Message.Accounts[i].Bytes(), message, signature) {
+			return false
+		}
+	}
+	return true
+}
+
+func encodeTransaction(tx types.Transaction, encoding rpc.TransactionEncoding) (any, *rpc.JsonRpcError) {
+	switch encoding {
+	case rpc.TransactionEncodingBase64, rpc.TransactionEncodingBase58:
+		b, err := tx.Serialize()
+		if err != nil {
+			return nil, errInvalidParams("failed to serialize transaction: %v", err)
+		}
+		if encoding == rpc.TransactionEncodingBase58 {
+			return []string{base58.Encode(b), string(encoding)}, nil
+		}
+		return []string{base64.StdEncoding.EncodeToString(b), string(encoding)}, nil
+	case "", rpc.TransactionEncodingJson, rpc.TransactionEncodingJsonParsed:
+		signatures := make([]string, 0, len(tx.Signatures))
+		for _, signature := range tx.Signatures {
+			signatures = append(signatures, base58.Encode(signature))
+		}
+		accountKeys := make([]string, 0, len(tx.Message.Accounts))
+		for _, account := range tx.Message.Accounts {
+			accountKeys = append(accountKeys, account.ToBase58())
+		}
+		instructions := make([]rpc.Instruction, 0, len(tx.Message.Instructions))
+		for _, instruction := range tx.Message.Instructions {
+			instructions = append(instructions, rpc.Instruction{
+				ProgramIDIndex: instruction.ProgramIDIndex,
+				Accounts:       instruction.Accounts,
+				Data:           base58.Encode(instruction.Data),
+			})
+		}
+		message := map[string]any{
+			"accountKeys": accountKeys,
+			"header": map[string]any{
+				"numRequiredSignatures":       tx.Message.Header.NumRequireSignatures,
+				"numReadonlySignedAccounts":   tx.Message.Header.NumReadonlySignedAccounts,
+				"numReadonlyUnsignedAccounts": tx.Message.Header.NumReadonlyUnsignedAccounts,
+			},
+			"recentBlockhash": tx.Message.RecentBlockHash,
+			"instructions":    instructions,
+		}
+		if tx.Message.Version == types.MessageVersionV0 {
+			lookups := []map[string]any{}
+			for _, lookup := range tx.Message.AddressLookupTables {
+				lookups = append(lookups, map[string]any{
+					"accountKey":      lookup.AccountKey.ToBase58(),
+					"writableIndexes": bytesToInts(lookup.WritableIndexes),
+					"readonlyIndexes": bytesToInts(lookup.ReadonlyIndexes),
+				})
+			}
+			message["addressTableLookups"] = lookups
+		}
+		return map[string]any{"signatures": signatures, "message": message}, nil
+	}
+	return nil, errInvalidParams("unsupported encoding: %v", encoding)
+}
+
+func bytesToInts(b []uint8) []int {
+	ints := make([]int, 0, len(b))
+	for _, v := range b {
+		ints = append(ints, int(v))
+	}
+	return ints
+}
+
+func transactionVersion(tx types.Transaction) any {
+	if tx.Message.Version == types.MessageVersionV0 {
+		return 0
+	}
+	return "legacy"
+}
+
+func transactionMeta(record *TransactionRecord) map[string]any {
+	status := map[string]any{"Ok": nil}
+	if record.Err != nil {
+		status = map[string]any{"Err": record.Err}
+	}
+	logs := record.LogMessages
+	if logs == nil {
+		logs = []string{}
+	}
+	return map[string]any{
+		"err":                  record.Err,
+		"status":               status,
+		"fee":                  record.Fee,
+		"preBalances":          record.PreBalances,
+		"postBalances":         record.PostBalances,
+		"preTokenBalances":     []any{},
+		"postTokenBalances":    []any{},
+		"innerInstructions":    []any{},
+		"logMessages":          logs,
+		"loadedAddresses":      rpc.TransactionLoadedAddresses{Writable: []string{}, Readonly: []string{}},
+		"rewards":              []any{},
+		"computeUnitsConsumed": 0,
+	}
+}
+
+func (s *Server) getTransaction(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var signature string
+	var cfg rpc.GetTransactionConfig
+	if err := param(params, 0, &signature, true); err != nil {
+		return nil, err
+	}
+	if err := param(params, 1, &cfg, false); err != nil {
+		return nil, err
+	}
+	record, ok := s.signatures[signature]
+	if !ok {
+		return nil, nil
+	}
+	if record.Transaction.Message.Version == types.MessageVersionV0 && cfg.MaxSupportedTransactionVersion == nil {
+		return nil, errUnsupportedTransactionVersion()
+	}
+	tx, err := encodeTransaction(record.Transaction, cfg.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"slot":        record.Slot,
+		"blockTime":   record.BlockTime,
+		"transaction": tx,
+		"meta":        transactionMeta(record),
+		"version":     transactionVersion(record.Transaction),
+	}, nil
+}
+
+func (s *Server) getSignatureStatuses(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var signatures []string
+	if err := param(params, 0, &signatures, true); err != nil {
+		return nil, err
+	}
+	finalized := rpc.CommitmentFinalized
+	statuses := make([]*rpc.SignatureStatus, 0, len(signatures))
+	for _, signature := range signatures {
+		record, ok := s.signatures[signature]
+		if !ok {
+			statuses = append(statuses, nil)
+			continue
+		}
+		statuses = append(statuses, &rpc.SignatureStatus{
+			Slot:               record.Slot,
+			ConfirmationStatus: &finalized,
+			Err:                record.Err,
+		})
+	}
+	return withContext(s.context(), statuses), nil
+}
+
+func (s *Server) getSignaturesForAddress(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var address string
+	var cfg rpc.GetSignaturesForAddressConfig
+	if err := param(params, 0, &address, true); err != nil {
+		return nil, err
+	}
+	if err := param(params, 1, &cfg, false); err != nil {
+		return nil, err
+	}
+	pubkey, err := parsePubkey(address)
+	if err != nil {
+		return nil, err
+	}
+	limit := cfg.Limit
+	if limit <= 0 || limit > 1000 {
+		limit = 1000
+	}
+
+	// newest first
+	result := []rpc.SignatureWithStatus{}
+	started := cfg.Before == ""
+	for i := len(s.transactions) - 1; i >= 0 && len(result) < limit; i-- {
+		record := s.transactions[i]
+		if !started {
+			started = record.Signature == cfg.Before
+			continue
+		}
+		if record.Signature == cfg.Until {
+			break
+		}
+		if !containsAccount(record.Transaction, pubkey) {
+			continue
+		}
+		blockTime := record.BlockTime
+		result = append(result, rpc.SignatureWithStatus{
+			Signature: record.Signature,
+			Slot:      record.Slot,
+			BlockTime: &blockTime,
+			Err:       record.Err,
+		})
+	}
+	return result, nil
+}
+
+func containsAccount(tx types.Transaction, pubkey common.PublicKey) bool {
+	for _, account := range tx.Message.Accounts {
+		if account == pubkey {
+			return true
+		}
+	}
+	return false
+}
+
+type simulateTransactionValue struct {
+	Err           any             `json:"err"`
+	Logs          []string        `json:"logs"`
+	Accounts      []*accountInfo  `json:"accounts"`
+	UnitsConsumed uint64          `json:"unitsConsumed"`
+	ReturnData    *rpc.ReturnData `json:"returnData"`
+}
+
+// check runs the checks before a transaction is executed, it returns the transaction error
+func (s *Server) check(tx types.Transaction) any {
+	if _, ok := s.signatures[base58.Encode(tx.Signatures[0])]; ok {
+		return TransactionErrAlreadyProcessed
+	}
+	if !s.isBlockhashValid(tx.Message.RecentBlockHash) {
+		return TransactionErrBlockhashNotFound
+	}
+	payer, ok := s.accounts[tx.Message.Accounts[0]]
+	if !ok {
+		return TransactionErrAccountNotFound
+	}
+	if payer.Lamports < fee(tx) {
+		return TransactionErrInsufficientFundsForFee
+	}
+	return nil
+}
+
+func fee(tx types.Transaction) uint64 {
+	return uint64(len(tx.Signatures)) * LamportsPerSignature
+}
+
+// process charges the fee and records the transaction in a new block
+func (s *Server) process(tx types.Transaction) *TransactionRecord {
+	record := &TransactionRecord{
+		Signature:   base58.Encode(tx.Signatures[0]),
+		Transaction: tx,
+		Fee:         fee(tx),
+	}
+	for _, pubkey := range tx.Message.Accounts {
+		record.PreBalances = append(record.PreBalances, int64(s.accounts[pubkey].Lamports))
+	}
+
+	payer := s.accounts[tx.Message.Accounts[0]]
+	payer.Lamports -= record.Fee
+	s.accounts[tx.Message.Accounts[0]] = payer
+	for _, pubkey := range tx.Message.Accounts {
+		record.PostBalances = append(record.PostBalances, int64(s.accounts[pubkey].Lamports))
+	}
+
+	s.newBlock(s.slot + 1)
+	record.Slot = s.slot
+	record.BlockTime = s.blocks[s.slot].time
+	s.transactions = append(s.transactions, record)
+	s.signatures[record.Signature] = record
+	return record
+}
+
+func (s *Server) sendTransaction(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var raw string
+	var cfg rpc.SendTransactionConfig
+	if err := param(params, 0, &raw, true); err != nil {
+		return nil, err
+	}
+	if err := param(params, 1, &cfg, false); err != nil {
+		return nil, err
+	}
+	tx, err := decodeTransaction(raw, string(cfg.Encoding))
+	if err != nil {
+		return nil, err
+	}
+	if !verifySignatures(tx) {
+		return nil, errSignatureVerification()
+	}
+
+	signature := base58.Encode(tx.Signatures[0])
+	if txErr := s.check(tx); txErr != nil {
+		// without the preflight the cluster accepts the transaction and drops it
+		if cfg.SkipPreflight {
+			return signature, nil
+		}
+		return nil, errPreflight(simulateTransactionValue{Err: txErr, Logs: []string{}})
+	}
+	s.process(tx)
+	return signature, nil
+}
+
+func (s *Server) simulateTransaction(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var raw string
+	var cfg rpc.SimulateTransactionConfig
+	if err := param(params, 0, &raw, true); err != nil {
+		return nil, err
+	}
+	if err := param(params, 1, &cfg, false); err != nil {
+		return nil, err
+	}
+	tx, err := decodeTransaction(raw, string(cfg.Encoding))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.SigVerify && !verifySignatures(tx) {
+		return nil, errSignatureVerification()
+	}
+	if cfg.ReplaceRecentBlockhash {
+		tx.Message.RecentBlockHash = s.blocks[s.slot].blockhash
+	}
+	if len(tx.Signatures) == 0 || len(tx.Message.Accounts) == 0 {
+		return nil, errInvalidParams("invalid transaction")
+	}
+
+	value := simulateTransactionValue{Err: s.check(tx), Logs: []string{}}
+	if !cfg.SigVerify && value.Err == TransactionErrAlreadyProcessed {
+		// unsigned transactions share the empty signature
+		value.Err = nil
+	}
+	if cfg.Accounts != nil {
+		accountCfg := accountConfig{Encoding: cfg.Accounts.Encoding}
+		for _, address := range cfg.Accounts.Addresses {
+			pubkey, err := parsePubkey(address)
+			if err != nil {
+				return nil, err
+			}
+			account, ok := s.accounts[pubkey]
+			if !ok {
+				value.Accounts = append(value.Accounts, nil)
+				continue
+			}
+			info, err := encodeAccount(account, accountCfg)
+			if err != nil {
+				return nil, err
+			}
+			value.Accounts = append(value.Accounts, &info)
+		}
+	}
+	return withContext(s.context(), value), nil
+}
+
+func (s *Server) requestAirdrop(params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	var address string
+	var lamports uint64
+	if err := param(params, 0, &address, true); err != nil {
+		return nil, err
+	}
+	if err := param(params, 1, &lamports, true); err != nil {
+		return nil, err
+	}
+	pubkey, err := parsePubkey(address)
+	if err != nil {
+		return nil, err
+	}
+
+	// the faucet pays the airdrop with a real transfer so the signature can be looked up
+	faucet := s.accounts[s.faucet.PublicKey]
+	faucet.Lamports = lamports + LamportsPerSignature
+	s.accounts[s.faucet.PublicKey] = faucet
+
+	tx, txErr := types.NewTransaction(types.NewTransactionParam{
+		Message: types.NewMessage(types.NewMessageParam{
+			FeePayer:        s.faucet.PublicKey,
+			RecentBlockhash: s.blocks[s.slot].blockhash,
+			Instructions: []types.Instruction{
+				system.Transfer(system.TransferParam{
+					From:   s.faucet.PublicKey,
+					To:     pubkey,
+					Amount: lamports,
+				}),
+			},
+		}),
+		Signers: []types.Account{s.faucet},
+	})
+	if txErr != nil {
+		return nil, errInvalidParams("failed to build the airdrop: %v", txErr)
+	}
+
+	account, ok := s.accounts[pubkey]
+	if !ok {
+		account = Account{Owner: common.SystemProgramID}
+	}
+	account.Lamports += lamports
+	s.accounts[pubkey] = account
+	faucet = s.accounts[s.faucet.PublicKey]
+	faucet.Lamports -= lamports
+	s.accounts[s.faucet.PublicKey] = faucet
+
+	record := s.process(tx)
+	// the balances before the transfer
+	record.PreBalances = []int64{int64(lamports + LamportsPerSignature), int64(account.Lamports - lamports), 0}
+	delete(s.accounts, s.faucet.PublicKey)
+	return record.Signature, nil
+}
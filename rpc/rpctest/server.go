@@ -0,0 +1,327 @@
+package rpctest
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/rpc"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/mr-tron/base58"
+)
+
+const (
+	// MaxProcessingAge is how many slots a blockhash stays valid
+	MaxProcessingAge = 150
+	// LamportsPerSignature is the fee of a signature
+	LamportsPerSignature = 5000
+	SlotsPerEpoch        = 432000
+)
+
+// Account is an account of the ledger
+type Account struct {
+	Lamports   uint64
+	Owner      common.PublicKey
+	Data       []byte
+	Executable bool
+	RentEpoch  uint64
+}
+
+// TransactionRecord is a transaction the server has processed
+type TransactionRecord struct {
+	Signature    string
+	Slot         uint64
+	BlockTime    int64
+	Transaction  types.Transaction
+	Err          any
+	Fee          uint64
+	PreBalances  []int64
+	PostBalances []int64
+	LogMessages  []string
+}
+
+type block struct {
+	blockhash string
+	time      int64
+}
+
+// Server is an in-process json rpc server backed by an in-memory ledger. it answers the methods
+// rpc.RpcClient implements so tests can run against client.Client without a validator.
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	identity     common.PublicKey
+	faucet       types.Account
+	startTime    time.Time
+	slot         uint64
+	blocks       map[uint64]block
+	blockhashes  map[string]uint64
+	accounts     map[common.PublicKey]Account
+	transactions []*TransactionRecord
+	signatures   map[string]*TransactionRecord
+	errs         map[string]*rpc.JsonRpcError
+	latencies    map[string]time.Duration
+}
+
+type Option func(*Server)
+
+// WithSlot sets the slot the ledger starts from
+func WithSlot(slot uint64) Option {
+	return func(s *Server) {
+		s.slot = slot
+	}
+}
+
+// WithStartTime sets the block time of slot 0, slots are 400ms apart
+func WithStartTime(t time.Time) Option {
+	return func(s *Server) {
+		s.startTime = t
+	}
+}
+
+// WithAccount puts an account into the ledger
+func WithAccount(pubkey common.PublicKey, account Account) Option {
+	return func(s *Server) {
+		s.accounts[pubkey] = account
+	}
+}
+
+// NewServer starts a server, callers should Close it when done
+func NewServer(opts ...Option) *Server {
+	faucet, _ := types.AccountFromSeed(seed("faucet"))
+	identity, _ := types.AccountFromSeed(seed("identity"))
+	s := &Server{
+		identity:    identity.PublicKey,
+		faucet:      faucet,
+		startTime:   time.Unix(1700000000, 0),
+		slot:        1,
+		blocks:      map[uint64]block{},
+		blockhashes: map[string]uint64{},
+		accounts:    map[common.PublicKey]Account{},
+		signatures:  map[string]*TransactionRecord{},
+		errs:        map[string]*rpc.JsonRpcError{},
+		latencies:   map[string]time.Duration{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.newBlock(s.slot)
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func seed(s string) []byte {
+	h := sha256.Sum256([]byte("rpctest:" + s))
+	return h[:]
+}
+
+// SetAccount puts an account into the ledger, it replaces the old one
+func (s *Server) SetAccount(pubkey common.PublicKey, account Account) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	account.Data = append([]byte{}, account.Data...)
+	s.accounts[pubkey] = account
+}
+
+// GetAccount returns an account of the ledger
+func (s *Server) GetAccount(pubkey common.PublicKey) (Account, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	account, ok := s.accounts[pubkey]
+	account.Data = append([]byte{}, account.Data...)
+	return account, ok
+}
+
+// DeleteAccount removes an account from the ledger
+func (s *Server) DeleteAccount(pubkey common.PublicKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.accounts, pubkey)
+}
+
+// Slot returns the current slot
+func (s *Server) Slot() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.slot
+}
+
+// AdvanceSlot produces n empty blocks, blockhashes older than MaxProcessingAge slots expire
+func (s *Server) AdvanceSlot(n uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := uint64(0); i < n; i++ {
+		s.newBlock(s.slot + 1)
+	}
+}
+
+// LatestBlockhash returns the blockhash of the current slot
+func (s *Server) LatestBlockhash() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.blocks[s.slot].blockhash
+}
+
+// Transactions returns the processed transactions in order
+func (s *Server) Transactions() []TransactionRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records := make([]TransactionRecord, 0, len(s.transactions))
+	for _, record := range s.transactions {
+		records = append(records, *record)
+	}
+	return records
+}
+
+// SetError makes a method answer with the error, a nil error clears it
+func (s *Server) SetError(method string, err *rpc.JsonRpcError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		delete(s.errs, method)
+		return
+	}
+	s.errs[method] = err
+}
+
+// SetLatency delays the responses of a method, an empty method applies to all methods
+func (s *Server) SetLatency(method string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencies[method] = d
+}
+
+func (s *Server) newBlock(slot uint64) {
+	h := sha256.Sum256(append(seed("blockhash"), uint64Bytes(slot)...))
+	b := block{
+		blockhash: base58.Encode(h[:]),
+		time:      s.startTime.Add(time.Duration(slot) * 400 * time.Millisecond).Unix(),
+	}
+	s.slot = slot
+	s.blocks[slot] = b
+	s.blockhashes[b.blockhash] = slot
+}
+
+func uint64Bytes(n uint64) []byte {
+	b := make([]byte, 8)
+	for i := range b {
+		b[i] = byte(n >> (8 * i))
+	}
+	return b
+}
+
+// isBlockhashValid checks a blockhash against the current slot
+func (s *Server) isBlockhashValid(blockhash string) bool {
+	slot, ok := s.blockhashes[blockhash]
+	return ok && slot+MaxProcessingAge >= s.slot
+}
+
+func (s *Server) context() rpc.Context {
+	return rpc.Context{Slot: s.slot}
+}
+
+// accountsOf returns the sorted pubkeys of the ledger which satisfy f
+func (s *Server) accountsOf(f func(common.PublicKey, Account) bool) []common.PublicKey {
+	var pubkeys []common.PublicKey
+	for pubkey, account := range s.accounts {
+		if f(pubkey, account) {
+			pubkeys = append(pubkeys, pubkey)
+		}
+	}
+	sort.Slice(pubkeys, func(i, j int) bool {
+		return pubkeys[i].ToBase58() < pubkeys[j].ToBase58()
+	})
+	return pubkeys
+}
+
+type request struct {
+	JsonRpc string            `json:"jsonrpc"`
+	Id      json.RawMessage   `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type response struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id"`
+	Result  any             `json:"result"`
+}
+
+type errorResponse struct {
+	JsonRpc string            `json:"jsonrpc"`
+	Id      json.RawMessage   `json:"id"`
+	Error   *rpc.JsonRpcError `json:"error"`
+}
+
+func (s *Server) serveHTTP(rw http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var r request
+	if err := json.Unmarshal(body, &r); err != nil {
+		writeJson(rw, errorResponse{JsonRpc: "2.0", Id: json.RawMessage("null"), Error: errParse()})
+		return
+	}
+
+	if latency := s.latency(r.Method); latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-req.Context().Done():
+			return
+		}
+	}
+
+	result, rpcErr := s.call(r.Method, r.Params)
+	if rpcErr != nil {
+		writeJson(rw, errorResponse{JsonRpc: "2.0", Id: r.Id, Error: rpcErr})
+		return
+	}
+	writeJson(rw, response{JsonRpc: "2.0", Id: r.Id, Result: result})
+}
+
+func (s *Server) latency(method string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if latency, ok := s.latencies[method]; ok {
+		return latency
+	}
+	return s.latencies[""]
+}
+
+// call runs the handler under the lock, a handler which panics doesn't leave the server locked
+func (s *Server) call(method string, params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.handle(method, params)
+}
+
+func writeJson(rw http.ResponseWriter, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	_, _ = rw.Write(b)
+}
+
+func (s *Server) handle(method string, params []json.RawMessage) (any, *rpc.JsonRpcError) {
+	if err, ok := s.errs[method]; ok {
+		return nil, err
+	}
+	handler, ok := handlers[method]
+	if !ok {
+		return nil, errMethodNotFound(method)
+	}
+	return handler(s, params)
+}
@@ -0,0 +1,235 @@
+package rpctest_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/EntySquare/solana-go-sdk/client"
+	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/program/system"
+	"github.com/EntySquare/solana-go-sdk/rpc"
+	"github.com/EntySquare/solana-go-sdk/rpc/rpctest"
+	"github.com/EntySquare/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	alice, _ = types.AccountFromSeed(make([]byte, 32))
+	bob      = common.PublicKeyFromString("9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g")
+	mint     = common.PublicKeyFromString("So11111111111111111111111111111111111111112")
+	ata      = common.PublicKeyFromString("BGEqZEJRPxHKTLbjwYoTnzY4PqmwvHMjZiFVGPDkN7T7")
+)
+
+func mintData(supply uint64, decimals uint8) []byte {
+	b := make([]byte, 82)
+	binary.LittleEndian.PutUint64(b[36:], supply)
+	b[44] = decimals
+	b[45] = 1
+	return b
+}
+
+func tokenAccountData(mint, owner common.PublicKey, amount uint64) []byte {
+	b := make([]byte, 165)
+	copy(b, mint.Bytes())
+	copy(b[32:], owner.Bytes())
+	binary.LittleEndian.PutUint64(b[64:], amount)
+	b[108] = 1
+	return b
+}
+
+func transfer(t *testing.T, s *rpctest.Server, from types.Account, to common.PublicKey, amount uint64) types.Transaction {
+	tx, err := types.NewTransaction(types.NewTransactionParam{
+		Message: types.NewMessage(types.NewMessageParam{
+			FeePayer:        from.PublicKey,
+			RecentBlockhash: s.LatestBlockhash(),
+			Instructions: []types.Instruction{
+				system.Transfer(system.TransferParam{From: from.PublicKey, To: to, Amount: amount}),
+			},
+		}),
+		Signers: []types.Account{from},
+	})
+	assert.NoError(t, err)
+	return tx
+}
+
+func TestServer_Accounts(t *testing.T) {
+	s := rpctest.NewServer(
+		rpctest.WithAccount(alice.PublicKey, rpctest.Account{Lamports: 10, Owner: common.SystemProgramID}),
+		rpctest.WithAccount(mint, rpctest.Account{Lamports: 1, Owner: common.TokenProgramID, Data: mintData(1500, 2)}),
+		rpctest.WithAccount(ata, rpctest.Account{Lamports: 1, Owner: common.TokenProgramID, Data: tokenAccountData(mint, bob, 1234)}),
+	)
+	defer s.Close()
+	c := client.NewClient(s.URL)
+	ctx := context.Background()
+
+	balance, err := c.GetBalance(ctx, alice.PublicKey.ToBase58())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), balance)
+
+	info, err := c.GetAccountInfo(ctx, mint.ToBase58())
+	assert.NoError(t, err)
+	assert.Equal(t, client.AccountInfo{Lamports: 1, Owner: common.TokenProgramID, Data: mintData(1500, 2)}, info)
+
+	info, err = c.GetAccountInfo(ctx, bob.ToBase58())
+	assert.NoError(t, err)
+	assert.Equal(t, client.AccountInfo{}, info)
+
+	infos, err := c.GetMultipleAccounts(ctx, []string{alice.PublicKey.ToBase58(), bob.ToBase58()})
+	assert.NoError(t, err)
+	assert.Equal(t, []client.AccountInfo{{Lamports: 10, Owner: common.SystemProgramID, Data: []byte{}}, {}}, infos)
+
+	amount, err := c.GetTokenAccountBalance(ctx, ata.ToBase58())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1234), amount.Amount)
+	assert.Equal(t, "12.34", amount.UIAmountString)
+
+	supply, err := c.GetTokenSupply(ctx, mint.ToBase58())
+	assert.NoError(t, err)
+	assert.Equal(t, "15", supply.UIAmountString)
+
+	tokenAccounts, err := c.GetTokenAccountsByOwner(ctx, bob.ToBase58())
+	assert.NoError(t, err)
+	assert.Len(t, tokenAccounts, 1)
+	assert.Equal(t, uint64(1234), tokenAccounts[ata].Amount)
+
+	s.DeleteAccount(alice.PublicKey)
+	balance, err = c.GetBalance(ctx, alice.PublicKey.ToBase58())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), balance)
+}
+
+func TestServer_SendTransaction(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+	c := client.NewClient(s.URL)
+	ctx := context.Background()
+
+	airdrop, err := c.RequestAirdrop(ctx, alice.PublicKey.ToBase58(), 1_000_000)
+	assert.NoError(t, err)
+	balance, err := c.GetBalance(ctx, alice.PublicKey.ToBase58())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1_000_000), balance)
+
+	tx := transfer(t, s, alice, bob, 1)
+	sig, err := c.SendTransaction(ctx, tx)
+	assert.NoError(t, err)
+
+	// the server only charges the fee, the instructions are recorded as they are
+	account, ok := s.GetAccount(alice.PublicKey)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1_000_000-rpctest.LamportsPerSignature), account.Lamports)
+
+	status, err := c.GetSignatureStatus(ctx, sig)
+	assert.NoError(t, err)
+	assert.NotNil(t, status)
+	assert.Equal(t, s.Slot(), status.Slot)
+	assert.Nil(t, status.Err)
+
+	got, err := c.GetTransaction(ctx, sig)
+	assert.NoError(t, err)
+	assert.Equal(t, tx, got.Transaction)
+	assert.Equal(t, uint64(rpctest.LamportsPerSignature), got.Meta.Fee)
+
+	signatures, err := c.GetSignaturesForAddress(ctx, alice.PublicKey.ToBase58())
+	assert.NoError(t, err)
+	assert.Len(t, signatures, 2)
+	assert.Equal(t, sig, signatures[0].Signature)
+	assert.Equal(t, airdrop, signatures[1].Signature)
+	assert.Len(t, s.Transactions(), 2)
+
+	// the same transaction again
+	_, err = c.SendTransaction(ctx, tx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "already been processed")
+}
+
+func TestServer_SendTransactionErrors(t *testing.T) {
+	s := rpctest.NewServer(rpctest.WithAccount(alice.PublicKey, rpctest.Account{Lamports: 1_000_000}))
+	defer s.Close()
+	c := client.NewClient(s.URL)
+	ctx := context.Background()
+
+	tx := transfer(t, s, alice, bob, 1)
+	tx.Signatures[0] = make([]byte, 64)
+	_, err := c.SendTransaction(ctx, tx)
+	assert.ErrorContains(t, err, "signature verification failure")
+
+	tx = transfer(t, s, alice, bob, 1)
+	s.AdvanceSlot(rpctest.MaxProcessingAge + 1)
+	_, err = c.SendTransaction(ctx, tx)
+	assert.ErrorContains(t, err, "Blockhash not found")
+
+	valid, err := c.IsBlockhashValid(ctx, tx.Message.RecentBlockHash)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+
+	s.SetAccount(alice.PublicKey, rpctest.Account{Lamports: 1})
+	_, err = c.SendTransaction(ctx, transfer(t, s, alice, bob, 1))
+	assert.ErrorContains(t, err, "Insufficient funds for fee")
+	assert.Len(t, s.Transactions(), 0)
+}
+
+func TestServer_SetError(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+	c := client.NewClient(s.URL)
+	ctx := context.Background()
+
+	s.SetError("getSlot", &rpc.JsonRpcError{Code: -32005, Message: "Node is unhealthy"})
+	_, err := c.GetSlot(ctx)
+	assert.Equal(t, &rpc.JsonRpcError{Code: -32005, Message: "Node is unhealthy"}, err)
+
+	s.SetError("getSlot", nil)
+	slot, err := c.GetSlot(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), slot)
+
+	body, err := c.RpcClient.Call(ctx, "getSomething")
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), "Method not found")
+}
+
+func TestServer_SetLatency(t *testing.T) {
+	s := rpctest.NewServer()
+	defer s.Close()
+	c := client.NewClient(s.URL)
+
+	s.SetLatency("", time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.GetSlot(ctx)
+	assert.ErrorContains(t, err, context.DeadlineExceeded.Error())
+
+	s.SetLatency("", 0)
+	_, err = c.GetSlot(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestServer_Blocks(t *testing.T) {
+	s := rpctest.NewServer(rpctest.WithSlot(100), rpctest.WithStartTime(time.Unix(0, 0)))
+	defer s.Close()
+	c := client.NewClient(s.URL)
+	ctx := context.Background()
+
+	s.AdvanceSlot(10)
+	slot, err := c.GetSlot(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(110), slot)
+
+	blockTime, err := c.GetBlockTime(ctx, 110)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(44), *blockTime)
+
+	block, err := c.GetBlock(ctx, 105)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(104), int64(block.ParentSlot))
+
+	_, err = c.GetBlock(ctx, 50)
+	assert.Error(t, err)
+
+	latest, err := c.GetLatestBlockhash(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, s.LatestBlockhash(), latest.Blockhash)
+}
@@ -19,6 +19,15 @@ type MessageHeader struct {
 	NumReadonlyUnsignedAccounts uint8
 }
 
+var (
+	ErrUnexpectedEndOfData        = errors.New("unexpected end of data")
+	ErrInvalidCompactU16          = errors.New("invalid compact-u16")
+	ErrMessageUnsupportedVersion  = errors.New("unsupported message version")
+	ErrMessageInvalidHeader       = errors.New("invalid message header")
+	ErrMessageInvalidAccountIndex = errors.New("invalid account index")
+	ErrMessageTrailingData        = errors.New("message has trailing data")
+)
+
 type MessageVersion string
 
 const (
@@ -35,6 +44,16 @@ type Message struct {
 	AddressLookupTables []CompiledAddressLookupTable
 }
 
+// RecentBlockhash parses RecentBlockHash, it fails if it is not a base58 hash
+func (m Message) RecentBlockhash() (common.Hash, error) {
+	return common.ParseHash(m.RecentBlockHash)
+}
+
+// SetRecentBlockhash sets RecentBlockHash to the base58 form of the hash
+func (m *Message) SetRecentBlockhash(h common.Hash) {
+	m.RecentBlockHash = h.String()
+}
+
 type CompiledAddressLookupTable struct {
 	AccountKey      common.PublicKey
 	WritableIndexes []uint8
@@ -141,114 +160,132 @@ func (m Message) decompileLegacyMessageInstructions() []Instruction {
 
 func MessageDeserialize(messageData []byte) (Message, error) {
 	if len(messageData) == 0 {
-		return Message{}, errors.New("empty message data")
+		return Message{}, fmt.Errorf("%w, empty message data", ErrUnexpectedEndOfData)
 	}
 
 	var version MessageVersion
 	if v := uint8(messageData[0]); v > 127 {
-		version = MessageVersion(fmt.Sprintf("v%v", v-128))
+		if v-128 != 0 {
+			return Message{}, fmt.Errorf("%w, v%v", ErrMessageUnsupportedVersion, v-128)
+		}
+		version = MessageVersionV0
 		messageData = messageData[1:]
 	} else {
 		version = MessageVersionLegacy
 	}
 
-	var numRequireSignatures, numReadonlySignedAccounts, numReadonlyUnsignedAccounts uint8
-	var t uint64
-	var err error
-	list := []*uint8{&numRequireSignatures, &numReadonlySignedAccounts, &numReadonlyUnsignedAccounts}
-	for i := 0; i < len(list); i++ {
-		t, err = parseUvarint(&messageData)
-		if err != nil || t > 255 {
-			return Message{}, fmt.Errorf("message header #%d parse error: %v", i+1, err)
-		}
-		*list[i] = uint8(t)
+	header, err := readBytes(&messageData, 3)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to parse message header, err: %w", err)
 	}
+	numRequireSignatures, numReadonlySignedAccounts, numReadonlyUnsignedAccounts := header[0], header[1], header[2]
 
 	accountCount, err := parseUvarint(&messageData)
 	if err != nil {
-		return Message{}, fmt.Errorf("falied to parse count of account, err: %v", err)
+		return Message{}, fmt.Errorf("falied to parse count of account, err: %w", err)
 	}
-	if len(messageData) < int(accountCount)*32 {
-		return Message{}, errors.New("parse account error")
+	accountData, err := readBytes(&messageData, int(accountCount)*32)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to parse accounts, err: %w", err)
 	}
 	accounts := make([]common.PublicKey, 0, accountCount)
 	for i := 0; i < int(accountCount); i++ {
-		accounts = append(accounts, common.PublicKeyFromBytes(messageData[:32]))
-		messageData = messageData[32:]
+		accounts = append(accounts, common.PublicKeyFromBytes(accountData[i*32:(i+1)*32]))
+	}
+	if numReadonlySignedAccounts >= numRequireSignatures ||
+		int(numRequireSignatures)+int(numReadonlyUnsignedAccounts) > len(accounts) {
+		return Message{}, fmt.Errorf("%w, header: %v, accounts: %v", ErrMessageInvalidHeader, header, len(accounts))
 	}
 
-	if len(messageData) < 32 {
-		return Message{}, errors.New("parse blockhash error")
+	blockHash, err := readBytes(&messageData, 32)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to parse blockhash, err: %w", err)
 	}
-	blockHash := base58.Encode(messageData[:32])
-	messageData = messageData[32:]
 
 	instructionCount, err := parseUvarint(&messageData)
 	if err != nil {
-		return Message{}, fmt.Errorf("parse instruction count error: %v", err)
+		return Message{}, fmt.Errorf("parse instruction count error: %w", err)
+	}
+	// an instruction takes at least 3 bytes
+	if uint64(len(messageData)) < instructionCount*3 {
+		return Message{}, fmt.Errorf("parse instruction count error: %w", ErrUnexpectedEndOfData)
 	}
 
 	instructions := make([]CompiledInstruction, 0, instructionCount)
 	for i := 0; i < int(instructionCount); i++ {
-		programID, err := parseUvarint(&messageData)
+		programID, err := readBytes(&messageData, 1)
 		if err != nil {
-			return Message{}, fmt.Errorf("parse instruction #%d programID error: %v", i+1, err)
+			return Message{}, fmt.Errorf("parse instruction #%d programID error: %w", i+1, err)
 		}
 		accountCount, err := parseUvarint(&messageData)
 		if err != nil {
-			return Message{}, fmt.Errorf("parse instruction #%d account count error: %v", i+1, err)
+			return Message{}, fmt.Errorf("parse instruction #%d account count error: %w", i+1, err)
+		}
+		accountIdxList, err := readBytes(&messageData, int(accountCount))
+		if err != nil {
+			return Message{}, fmt.Errorf("parse instruction #%d account idx error: %w", i+1, err)
 		}
 		accounts := make([]int, 0, accountCount)
-		for j := 0; j < int(accountCount); j++ {
-			accountIdx, err := parseUvarint(&messageData)
-			if err != nil {
-				return Message{}, fmt.Errorf("parse instruction #%d account #%d idx error: %v", i+1, j+1, err)
-			}
+		for _, accountIdx := range accountIdxList {
 			accounts = append(accounts, int(accountIdx))
 		}
 		dataLen, err := parseUvarint(&messageData)
 		if err != nil {
-			return Message{}, fmt.Errorf("parse instruction #%d data length error: %v", i+1, err)
+			return Message{}, fmt.Errorf("parse instruction #%d data length error: %w", i+1, err)
+		}
+		data, err := readBytes(&messageData, int(dataLen))
+		if err != nil {
+			return Message{}, fmt.Errorf("parse instruction #%d data error: %w", i+1, err)
 		}
-		var data []byte
-		data, messageData = messageData[:dataLen], messageData[dataLen:]
 
 		instructions = append(instructions, CompiledInstruction{
-			ProgramIDIndex: int(programID),
+			ProgramIDIndex: int(programID[0]),
 			Accounts:       accounts,
 			Data:           data,
 		})
 	}
 
 	compiledAddressLookupTables := []CompiledAddressLookupTable{}
+	loadedAddressCount := 0
 	if version == MessageVersionV0 {
 		addressLookupTableCount, err := parseUvarint(&messageData)
 		if err != nil {
-			return Message{}, fmt.Errorf("parse instruction count error: %v", err)
+			return Message{}, fmt.Errorf("failed to parse address lookup table count, err: %w", err)
+		}
+		// an address lookup table takes at least 34 bytes
+		if uint64(len(messageData)) < addressLookupTableCount*34 {
+			return Message{}, fmt.Errorf("failed to parse address lookup table count, err: %w", ErrUnexpectedEndOfData)
 		}
 
 		for i := uint64(0); i < addressLookupTableCount; i++ {
-			addressLookupTablePubkey := common.PublicKeyFromBytes(messageData[:32])
-			messageData = messageData[32:]
+			addressLookupTablePubkey, err := readBytes(&messageData, 32)
+			if err != nil {
+				return Message{}, fmt.Errorf("failed to parse address lookup table pubkey, err: %w", err)
+			}
 
 			writableAccountIdxCount, err := parseUvarint(&messageData)
 			if err != nil {
-				return Message{}, fmt.Errorf("failed to parse address lookup table writable account idx count, err: %v", err)
+				return Message{}, fmt.Errorf("failed to parse address lookup table writable account idx count, err: %w", err)
+			}
+			writableAccountIdxList, err := readBytes(&messageData, int(writableAccountIdxCount))
+			if err != nil {
+				return Message{}, fmt.Errorf("failed to parse address lookup table writable account idx, err: %w", err)
 			}
-			var writableAccountIdxList []uint8
-			writableAccountIdxList, messageData = messageData[:writableAccountIdxCount], messageData[writableAccountIdxCount:]
 
 			readOnlyAccountIdxCount, err := parseUvarint(&messageData)
 			if err != nil {
-				return Message{}, fmt.Errorf("failed to parse address lookup table readOnly account idx count, err: %v", err)
+				return Message{}, fmt.Errorf("failed to parse address lookup table readOnly account idx count, err: %w", err)
+			}
+			readOnlyAccountIdxList, err := readBytes(&messageData, int(readOnlyAccountIdxCount))
+			if err != nil {
+				return Message{}, fmt.Errorf("failed to parse address lookup table readOnly account idx, err: %w", err)
 			}
-			var readOnlyAccountIdxList []uint8
-			readOnlyAccountIdxList, messageData = messageData[:readOnlyAccountIdxCount], messageData[readOnlyAccountIdxCount:]
 
+			loadedAddressCount += len(writableAccountIdxList) + len(readOnlyAccountIdxList)
 			compiledAddressLookupTables = append(
 				compiledAddressLookupTables,
 				CompiledAddressLookupTable{
-					AccountKey:      addressLookupTablePubkey,
+					AccountKey:      common.PublicKeyFromBytes(addressLookupTablePubkey),
 					WritableIndexes: writableAccountIdxList,
 					ReadonlyIndexes: readOnlyAccountIdxList,
 				},
@@ -256,6 +293,21 @@ func MessageDeserialize(messageData []byte) (Message, error) {
 		}
 	}
 
+	if len(messageData) != 0 {
+		return Message{}, fmt.Errorf("%w, %v bytes", ErrMessageTrailingData, len(messageData))
+	}
+
+	for i, instruction := range instructions {
+		if instruction.ProgramIDIndex >= len(accounts) {
+			return Message{}, fmt.Errorf("%w, instruction #%d programID: %v", ErrMessageInvalidAccountIndex, i+1, instruction.ProgramIDIndex)
+		}
+		for _, accountIdx := range instruction.Accounts {
+			if accountIdx >= len(accounts)+loadedAddressCount {
+				return Message{}, fmt.Errorf("%w, instruction #%d account idx: %v", ErrMessageInvalidAccountIndex, i+1, accountIdx)
+			}
+		}
+	}
+
 	return Message{
 		Version: version,
 		Header: MessageHeader{
@@ -264,7 +316,7 @@ func MessageDeserialize(messageData []byte) (Message, error) {
 			NumReadonlyUnsignedAccounts: numReadonlyUnsignedAccounts,
 		},
 		Accounts:            accounts,
-		RecentBlockHash:     blockHash,
+		RecentBlockHash:     base58.Encode(blockHash),
 		Instructions:        instructions,
 		AddressLookupTables: compiledAddressLookupTables,
 	}, nil
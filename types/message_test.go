@@ -1,7 +1,6 @@
 package types
 
 import (
-	"fmt"
 	"testing"
 
 	"github.com/EntySquare/solana-go-sdk/common"
@@ -686,6 +685,7 @@ func TestMessageSerialize(t *testing.T) {
 }
 
 func TestMessageDeserialize(t *testing.T) {
+	legacyMessage := []byte{1, 0, 1, 3, 206, 211, 135, 230, 195, 111, 87, 254, 147, 239, 143, 81, 110, 159, 49, 140, 109, 137, 224, 197, 24, 49, 223, 61, 123, 8, 78, 109, 110, 136, 228, 240, 134, 172, 209, 213, 227, 137, 61, 108, 116, 171, 205, 124, 54, 68, 61, 110, 80, 31, 240, 117, 108, 137, 97, 222, 38, 242, 68, 156, 27, 65, 29, 142, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 221, 244, 189, 59, 8, 252, 7, 91, 129, 169, 22, 151, 32, 104, 208, 131, 64, 75, 232, 201, 77, 13, 187, 220, 103, 232, 190, 100, 35, 210, 17, 42, 1, 2, 2, 0, 1, 12, 2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0}
 	type args struct {
 		messageData []byte
 	}
@@ -842,14 +842,143 @@ func TestMessageDeserialize(t *testing.T) {
 		{
 			args: args{messageData: []byte{128}},
 			want: Message{},
-			err:  fmt.Errorf("message header #1 parse error: data is empty"),
+			err:  ErrUnexpectedEndOfData,
+		},
+		{
+			name: "empty",
+			args: args{messageData: []byte{}},
+			want: Message{},
+			err:  ErrUnexpectedEndOfData,
+		},
+		{
+			name: "unsupported version",
+			args: args{messageData: append([]byte{129}, legacyMessage...)},
+			want: Message{},
+			err:  ErrMessageUnsupportedVersion,
+		},
+		{
+			name: "truncated accounts",
+			args: args{messageData: legacyMessage[:50]},
+			want: Message{},
+			err:  ErrUnexpectedEndOfData,
+		},
+		{
+			name: "truncated instruction data",
+			args: args{messageData: legacyMessage[:len(legacyMessage)-1]},
+			want: Message{},
+			err:  ErrUnexpectedEndOfData,
+		},
+		{
+			name: "trailing data",
+			args: args{messageData: replaceBytes(legacyMessage, len(legacyMessage), 0, 0)},
+			want: Message{},
+			err:  ErrMessageTrailingData,
+		},
+		{
+			name: "readonly fee payer",
+			args: args{messageData: replaceBytes(legacyMessage, 1, 1, 1)},
+			want: Message{},
+			err:  ErrMessageInvalidHeader,
+		},
+		{
+			name: "too many readonly unsigned accounts",
+			args: args{messageData: replaceBytes(legacyMessage, 2, 1, 3)},
+			want: Message{},
+			err:  ErrMessageInvalidHeader,
+		},
+		{
+			name: "program id index out of range",
+			args: args{messageData: replaceBytes(legacyMessage, 133, 1, 3)},
+			want: Message{},
+			err:  ErrMessageInvalidAccountIndex,
+		},
+		{
+			name: "account index out of range",
+			args: args{messageData: replaceBytes(legacyMessage, 135, 1, 3)},
+			want: Message{},
+			err:  ErrMessageInvalidAccountIndex,
+		},
+		{
+			name: "alias account count",
+			args: args{messageData: replaceBytes(legacyMessage, 3, 1, 0x83, 0x00)},
+			want: Message{},
+			err:  ErrInvalidCompactU16,
+		},
+		{
+			name: "instruction count overflow",
+			args: args{messageData: replaceBytes(legacyMessage, 132, 1, 0xff, 0xff, 0x07)},
+			want: Message{},
+			err:  ErrInvalidCompactU16,
+		},
+		{
+			name: "instruction count too long",
+			args: args{messageData: replaceBytes(legacyMessage, 132, 1, 0x80, 0x80, 0x80, 0x01)},
+			want: Message{},
+			err:  ErrInvalidCompactU16,
+		},
+		{
+			name: "instruction count exceeds data",
+			args: args{messageData: replaceBytes(legacyMessage, 132, 1, 0xff, 0xff, 0x03)},
+			want: Message{},
+			err:  ErrUnexpectedEndOfData,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := MessageDeserialize(tt.args.messageData)
 			assert.Equal(t, tt.want, got)
-			assert.Equal(t, tt.err, err)
+			assert.ErrorIs(t, err, tt.err)
 		})
 	}
 }
+
+// replaceBytes returns a copy of b with n bytes at offset replaced by with
+func replaceBytes(b []byte, offset, n int, with ...byte) []byte {
+	out := make([]byte, 0, len(b)-n+len(with))
+	out = append(out, b[:offset]...)
+	out = append(out, with...)
+	return append(out, b[offset+n:]...)
+}
+
+func FuzzMessageDeserialize(f *testing.F) {
+	f.Add([]byte{1, 0, 1, 3, 206, 211, 135, 230, 195, 111, 87, 254, 147, 239, 143, 81, 110, 159, 49, 140, 109, 137, 224, 197, 24, 49, 223, 61, 123, 8, 78, 109, 110, 136, 228, 240, 134, 172, 209, 213, 227, 137, 61, 108, 116, 171, 205, 124, 54, 68, 61, 110, 80, 31, 240, 117, 108, 137, 97, 222, 38, 242, 68, 156, 27, 65, 29, 142, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 221, 244, 189, 59, 8, 252, 7, 91, 129, 169, 22, 151, 32, 104, 208, 131, 64, 75, 232, 201, 77, 13, 187, 220, 103, 232, 190, 100, 35, 210, 17, 42, 1, 2, 2, 0, 1, 12, 2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+	f.Add([]byte{128, 1, 0, 1, 2, 127, 96, 107, 250, 152, 133, 208, 224, 73, 251, 113, 151, 128, 139, 86, 80, 101, 70, 138, 50, 141, 153, 218, 110, 56, 39, 122, 181, 120, 55, 86, 185, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 62, 255, 204, 109, 44, 223, 1, 225, 41, 92, 205, 204, 199, 90, 32, 104, 6, 123, 211, 72, 233, 131, 88, 65, 115, 38, 138, 217, 189, 202, 86, 39, 1, 1, 2, 0, 2, 12, 2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 1, 241, 61, 2, 62, 211, 181, 33, 219, 74, 147, 127, 38, 231, 159, 99, 194, 103, 129, 201, 15, 51, 106, 114, 199, 122, 142, 121, 87, 112, 78, 138, 249, 1, 1, 0})
+	f.Add([]byte{128})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		message, err := MessageDeserialize(data)
+		if err != nil {
+			return
+		}
+		// a decoded message must survive a round trip and decompile without panicking
+		b, err := message.Serialize()
+		if err != nil {
+			t.Fatalf("failed to serialize a decoded message, err: %v", err)
+		}
+		got, err := MessageDeserialize(b)
+		if err != nil {
+			t.Fatalf("failed to deserialize a serialized message, err: %v", err)
+		}
+		if message.Version == MessageVersionLegacy {
+			assert.Equal(t, data, b)
+			message.DecompileInstructions()
+		}
+		assert.Equal(t, message.Header, got.Header)
+		assert.Equal(t, message.Accounts, got.Accounts)
+		assert.Equal(t, message.RecentBlockHash, got.RecentBlockHash)
+		assert.Equal(t, message.Instructions, got.Instructions)
+	})
+}
+
+func TestMessage_RecentBlockhash(t *testing.T) {
+	h := common.MustParseHash("EtWTRABZaYq6iMfeYKouRu166VU2xqa1wcaWoxPkrZBG")
+	var m Message
+	m.SetRecentBlockhash(h)
+	assert.Equal(t, "EtWTRABZaYq6iMfeYKouRu166VU2xqa1wcaWoxPkrZBG", m.RecentBlockHash)
+	got, err := m.RecentBlockhash()
+	assert.NoError(t, err)
+	assert.Equal(t, h, got)
+
+	m.RecentBlockHash = "EtWTRABZaYq6iMfeYKouRu166VU2xqa1wcaWoxPkrZB0"
+	_, err = m.RecentBlockhash()
+	assert.ErrorIs(t, err, common.ErrInvalidBase58)
+}
@@ -2,9 +2,9 @@ package types
 
 import (
 	"crypto/ed25519"
-	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/EntySquare/solana-go-sdk/common"
 	"github.com/EntySquare/solana-go-sdk/pkg/bincode"
@@ -12,10 +12,21 @@ import (
 
 var (
 	ErrTransactionAddNotNecessarySignatures = errors.New("add not necessary signatures")
+	ErrTransactionInvalidSignatureCount     = errors.New("invalid signature count")
 )
 
 type Signature []byte
 
+// SignatureFromCommon copies a common.Signature
+func SignatureFromCommon(sig common.Signature) Signature {
+	return append(Signature{}, sig[:]...)
+}
+
+// ToCommon returns the signature as a common.Signature, it fails if it is not 64 bytes
+func (s Signature) ToCommon() (common.Signature, error) {
+	return common.NewSignature(s)
+}
+
 type Transaction struct {
 	Signatures []Signature
 	Message    Message
@@ -98,27 +109,27 @@ func (tx *Transaction) Serialize() ([]byte, error) {
 func TransactionDeserialize(tx []byte) (Transaction, error) {
 	signatureCount, err := parseUvarint(&tx)
 	if err != nil {
-		return Transaction{}, fmt.Errorf("parse signature count error: %v", err)
+		return Transaction{}, fmt.Errorf("parse signature count error: %w", err)
 	}
 	if signatureCount < 1 {
-		return Transaction{}, errors.New("signature count must be greater than or equal to 1")
+		return Transaction{}, fmt.Errorf("%w, signature count must be greater than or equal to 1", ErrTransactionInvalidSignatureCount)
 	}
-	if len(tx) < int(signatureCount)*64 {
-		return Transaction{}, errors.New("parse signature error")
+	signatureData, err := readBytes(&tx, int(signatureCount)*64)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("parse signature error: %w", err)
 	}
 	signatures := make([]Signature, 0, signatureCount)
 	for i := 0; i < int(signatureCount); i++ {
-		signatures = append(signatures, tx[:64])
-		tx = tx[64:]
+		signatures = append(signatures, signatureData[i*64:(i+1)*64])
 	}
 
 	message, err := MessageDeserialize(tx)
 	if err != nil {
-		return Transaction{}, fmt.Errorf("failed to parse message, err: %v", err)
+		return Transaction{}, fmt.Errorf("failed to parse message, err: %w", err)
 	}
 
 	if uint64(message.Header.NumRequireSignatures) != signatureCount {
-		return Transaction{}, errors.New("numRequireSignatures is not equal to signatureCount")
+		return Transaction{}, fmt.Errorf("%w, numRequireSignatures is not equal to signatureCount", ErrTransactionInvalidSignatureCount)
 	}
 
 	return Transaction{
@@ -136,14 +147,38 @@ func MustTransactionDeserialize(data []byte) Transaction {
 	return tx
 }
 
+// parseUvarint reads a compact-u16, the length prefix of the wire format
 func parseUvarint(tx *[]byte) (uint64, error) {
 	if len(*tx) == 0 {
-		return 0, errors.New("data is empty")
+		return 0, fmt.Errorf("%w, data is empty", ErrUnexpectedEndOfData)
 	}
-	u, n := binary.Uvarint(*tx)
-	if n <= 0 {
-		return 0, errors.New("format error")
+	var u uint64
+	for i := 0; i < 3; i++ {
+		if i >= len(*tx) {
+			return 0, ErrUnexpectedEndOfData
+		}
+		b := (*tx)[i]
+		u |= uint64(b&0x7f) << (7 * i)
+		if b&0x80 == 0 {
+			if i > 0 && b == 0 {
+				return 0, fmt.Errorf("%w, alias encoding", ErrInvalidCompactU16)
+			}
+			if u > math.MaxUint16 {
+				return 0, fmt.Errorf("%w, overflow", ErrInvalidCompactU16)
+			}
+			*tx = (*tx)[i+1:]
+			return u, nil
+		}
+	}
+	return 0, fmt.Errorf("%w, too long", ErrInvalidCompactU16)
+}
+
+// readBytes cuts n bytes off the front of data
+func readBytes(data *[]byte, n int) ([]byte, error) {
+	if n < 0 || len(*data) < n {
+		return nil, ErrUnexpectedEndOfData
 	}
-	*tx = (*tx)[n:]
-	return u, nil
+	b := (*data)[:n:n]
+	*data = (*data)[n:]
+	return b, nil
 }
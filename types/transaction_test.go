@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/EntySquare/solana-go-sdk/common"
+	"github.com/EntySquare/solana-go-sdk/pkg/bincode"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -145,6 +146,30 @@ func TestTransactionDeserialize(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "no signatures",
+			args: args{
+				tx: []byte{0},
+			},
+			want:    Transaction{},
+			wantErr: true,
+		},
+		{
+			name: "truncated signature",
+			args: args{
+				tx: append([]byte{1}, make([]byte, 63)...),
+			},
+			want:    Transaction{},
+			wantErr: true,
+		},
+		{
+			name: "signature count mismatch",
+			args: args{
+				tx: append(append(append([]byte{2}, make([]byte, 128)...), 1, 0, 0, 1), append(make([]byte, 64), 0)...),
+			},
+			want:    Transaction{},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -495,3 +520,49 @@ func TestTransaction_AddSignature(t *testing.T) {
 		})
 	}
 }
+
+func FuzzTransactionDeserialize(f *testing.F) {
+	f.Add([]byte{1, 189, 98, 67, 19, 102, 99, 124, 234, 70, 209, 28, 10, 33, 66, 167, 162, 222, 122, 16, 68, 248, 129, 46, 111, 221, 255, 40, 40, 236, 84, 233, 213, 234, 185, 235, 222, 155, 204, 139, 164, 184, 155, 32, 54, 151, 73, 235, 65, 200, 76, 127, 111, 244, 72, 183, 208, 21, 247, 114, 176, 181, 21, 77, 8, 1, 0, 1, 3, 206, 211, 135, 230, 195, 111, 87, 254, 147, 239, 143, 81, 110, 159, 49, 140, 109, 137, 224, 197, 24, 49, 223, 61, 123, 8, 78, 109, 110, 136, 228, 240, 134, 172, 209, 213, 227, 137, 61, 108, 116, 171, 205, 124, 54, 68, 61, 110, 80, 31, 240, 117, 108, 137, 97, 222, 38, 242, 68, 156, 27, 65, 29, 142, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 221, 244, 189, 59, 8, 252, 7, 91, 129, 169, 22, 151, 32, 104, 208, 131, 64, 75, 232, 201, 77, 13, 187, 220, 103, 232, 190, 100, 35, 210, 17, 42, 1, 2, 2, 0, 1, 12, 2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+	f.Add([]byte{0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		tx, err := TransactionDeserialize(data)
+		if err != nil {
+			return
+		}
+		b, err := tx.Serialize()
+		if err != nil {
+			t.Fatalf("failed to serialize a decoded transaction, err: %v", err)
+		}
+		if tx.Message.Version == MessageVersionLegacy {
+			assert.Equal(t, data, b)
+		}
+	})
+}
+
+func FuzzParseUvarint(f *testing.F) {
+	f.Add([]byte{0})
+	f.Add([]byte{0x80, 0x01})
+	f.Add([]byte{0xff, 0xff, 0x03})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		rest := data
+		u, err := parseUvarint(&rest)
+		if err != nil {
+			return
+		}
+		// only the canonical encoding is accepted
+		n := len(data) - len(rest)
+		assert.Equal(t, bincode.UintToVarLenBytes(u), data[:n])
+	})
+}
+
+func TestSignature_ToCommon(t *testing.T) {
+	sig := common.MustParseSignature("5Pzqj1cNTpq5Zpyh8Ycyf5kaV8ZPBBCwJHyaStd6NSZvSvhZQbAtZb2GvfAJjwuVXbaiuBS7tqjD2fAhmbwQ9Hj")
+	s := SignatureFromCommon(sig)
+	assert.Equal(t, sig.Bytes(), []byte(s))
+	got, err := s.ToCommon()
+	assert.NoError(t, err)
+	assert.Equal(t, sig, got)
+
+	_, err = Signature(make([]byte, 63)).ToCommon()
+	assert.ErrorIs(t, err, common.ErrInvalidLength)
+}